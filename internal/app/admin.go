@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/app/component/page"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// adminHandler serves the admin pages. Access control is left to the archive
+// handler, which only permits admins to manage other users.
+type adminHandler struct {
+	handler eratov1connect.ArchiveServiceHandler
+}
+
+func (h adminHandler) register(e *echo.Echo) {
+	e.GET(component.PathAdmin, h.users)
+
+	user := e.Group(component.PathAdminUsers + "/:user")
+	user.POST("/"+component.AdminOpPassword, h.resetPassword)
+	user.POST("/"+component.AdminOpRename, h.renameUser)
+	user.POST("/"+component.AdminOpDelete, h.deleteUser)
+}
+
+func (h adminHandler) users(c echo.Context) error {
+	return h.renderUsers(c, "")
+}
+
+func (h adminHandler) resetPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("user")
+	_, err := h.handler.UpdateUser(ctx, connect.NewRequest(eratov1.UpdateUserRequest_builder{
+		Path: db.User{Name: name}.Path(),
+		User: eratov1.User_builder{
+			Password: c.FormValue(component.FormFieldPassword),
+		}.Build(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+	}.Build()))
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return h.renderUsers(c, "Reset the password for "+name+".")
+}
+
+func (h adminHandler) renameUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("user")
+	renamed, err := h.handler.RenameUser(ctx, connect.NewRequest(eratov1.RenameUserRequest_builder{
+		Path:  db.User{Name: name}.Path(),
+		NewId: c.FormValue(component.FormFieldUsername),
+	}.Build()))
+	if err != nil {
+		return toHTTPError(err)
+	}
+	newName := renamed.Msg.GetId()
+	if authd := sec.GetAuthenticatedUser(ctx); authd.Name == name {
+		// the rest of the request should see the current user's new name
+		authd.Name = newName
+		c.SetRequest(c.Request().WithContext(sec.SetAuthenticatedUser(ctx, authd)))
+	}
+	return h.renderUsers(c, "Renamed "+name+" to "+newName+".")
+}
+
+func (h adminHandler) deleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("user")
+	if name == sec.GetAuthenticatedUser(ctx).Name {
+		return echo.NewHTTPError(http.StatusBadRequest, "cannot delete the current user")
+	}
+	_, err := h.handler.DeleteUser(ctx, connect.NewRequest(eratov1.DeleteUserRequest_builder{
+		Path: db.User{Name: name}.Path(),
+	}.Build()))
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return h.renderUsers(c, "Deleted "+name+".")
+}
+
+// renderUsers renders the user table, either as an HTMX fragment or the full
+// admin page, announcing status if set.
+func (h adminHandler) renderUsers(c echo.Context, status string) error {
+	ctx := c.Request().Context()
+	users, err := h.listUsers(ctx)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	current := sec.GetAuthenticatedUser(ctx).Name
+	comp := page.Admin(users, current, status)
+	if isHTMX(c) {
+		comp = component.UserAdmin(users, current, status)
+	}
+	return render(ctx, comp, c.Response().Writer)
+}
+
+func (h adminHandler) listUsers(ctx context.Context) (users []*eratov1.User, err error) {
+	pageToken := ""
+	for {
+		resp, err := h.handler.ListUsers(ctx, connect.NewRequest(eratov1.ListUsersRequest_builder{
+			PageToken: pageToken,
+		}.Build()))
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, resp.Msg.GetResults()...)
+		if pageToken = resp.Msg.GetNextPageToken(); pageToken == "" {
+			return users, nil
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 
+	"github.com/stolasapp/erato/internal/app/component"
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
 	"github.com/stolasapp/erato/internal/sec"
@@ -19,35 +20,50 @@ import (
 //go:embed static
 var staticFiles embed.FS
 
-// New creates a web front-end server.
+// New creates a web front-end server. Logins are limited by throttle and
+// recorded by audit. If proxy is non-nil, the reverse proxy may authenticate
+// users. If oidc is non-nil, users may log in with the
+// OpenID Connect provider. In dev mode, there are no logins; instead, dev
+// authenticates every request and must be non-nil.
 func New(
 	cfg *eratov1.Config,
 	logger *slog.Logger,
-	users storage.Users,
+	store storage.Store,
+	throttle *sec.Throttle,
+	audit *sec.Auditor,
+	proxy *sec.ProxyAuth,
+	oidc *sec.OIDC,
+	dev *sec.DevAuth,
 	archive eratov1connect.ArchiveServiceHandler,
 ) *echo.Echo {
 	srv := echo.New()
 
 	srv.HideBanner = true
 	srv.HidePort = true
+	srv.HTTPErrorHandler = handleError
 	srv.Logger.SetLevel(log.OFF)
+	srv.Use(echo.WrapMiddleware(sec.ClientMiddleware))
 
+	var sessions *sessionHandler
 	if cfg.GetDevMode() {
 		srv.Debug = true
-		srv.Use(logRequests(logger))
+		srv.Use(
+			logRequests(logger),
+			echo.WrapMiddleware(dev.Middleware),
+		)
 	} else {
+		sessions = &sessionHandler{
+			archive:  archive,
+			sessions: sec.NewSessions(cfg, store),
+			users:    store,
+			throttle: throttle,
+			audit:    audit,
+			proxy:    proxy,
+			oidc:     oidc,
+		}
 		srv.Use(
 			middleware.Recover(),
-			middleware.BasicAuth(func(_, _ string, c echo.Context) (bool, error) {
-				ctx := c.Request().Context()
-				usr, err := sec.Authenticate(ctx, c.Request(), users)
-				if err != nil {
-					return false, err
-				}
-				ctx = sec.SetAuthenticatedUser(ctx, usr)
-				c.SetRequest(c.Request().WithContext(ctx))
-				return true, nil
-			}),
+			sessions.requireSession,
 		)
 	}
 
@@ -62,6 +78,12 @@ func New(
 	)
 
 	handler{handler: archive}.register(srv)
+	adminHandler{handler: archive}.register(srv)
+	if sessions != nil {
+		sessions.register(srv)
+	} else {
+		srv.POST(component.PathDevUser, switchDevUser)
+	}
 	staticFS := echo.MustSubFS(staticFiles, "static")
 	srv.StaticFS("/static/", staticFS)
 	srv.FileFS("/robots.txt", "robots.txt", staticFS)
@@ -4,12 +4,15 @@ package component
 const (
 	IDContentActions = "content-actions"
 	IDListContainer  = "list-container"
+	IDBatchActions   = "batch-actions"
+	IDUserAdmin      = "user-admin"
 )
 
 // HTMX target selectors.
 const (
 	TargetContentActions = "#" + IDContentActions
 	TargetListContainer  = "#" + IDListContainer
+	TargetUserAdmin      = "#" + IDUserAdmin
 	TargetClosestArticle = "closest article"
 )
 
@@ -35,6 +38,79 @@ const (
 	KindCategory  = "category"
 )
 
+// Form field names and values for batch operations on list items.
+const (
+	FormFieldOp    = "op"
+	FormFieldScope = "scope"
+	FormFieldSlug  = "slug"
+
+	BatchScopePage = "page" // every item on the current page
+	BatchScopeAll  = "all"  // every item across all pages
+)
+
+// Form field names for the login and registration forms.
+const (
+	FormFieldUsername   = "username"
+	FormFieldPassword   = "password"
+	FormFieldNext       = "next" // the page to return to after logging in
+	FormFieldInviteCode = "code"
+)
+
+// Routes for managing login sessions and registering with an invite.
+const (
+	PathLogin             = "/login"
+	PathLoginOIDC         = PathLogin + "/oidc"
+	PathLoginOIDCCallback = PathLoginOIDC + "/callback"
+	PathLogout            = "/logout"
+	PathLogoutOthers      = "/logout/others"
+	PathRegister          = "/register"
+)
+
+// PathDevUser switches the user impersonated in dev mode, named by the
+// [FormFieldUsername] field.
+const PathDevUser = "/dev/user"
+
+// Routes for the admin pages. User routes are suffixed with the username and
+// the operation (e.g., /admin/users/alice/password).
+const (
+	PathAdmin      = "/admin"
+	PathAdminUsers = PathAdmin + "/users"
+
+	AdminOpPassword = "password"
+	AdminOpRename   = "rename"
+	AdminOpDelete   = "delete"
+)
+
+// Routes for managing saved views. A view is deleted by suffixing its ID and
+// the operation (e.g., /views/42/delete). Both return to the [FormFieldNext]
+// page.
+const (
+	PathSavedViews    = "/views"
+	SavedViewOpDelete = "delete"
+)
+
+// Form field names for saving a view.
+const (
+	FormFieldDisplayName = "name"
+	FormFieldQuery       = "query"    // the query string of the filters to save
+	FormFieldCategory    = "category" // the category the view is scoped to, if any
+)
+
+// PathExport is appended to an entry's URL to download it as an EPUB.
+const PathExport = "/ops/export"
+
+// The slug and path of the list of every category's entries.
+const (
+	AllCategoriesSlug = "-"
+	AllCategoriesPath = "categories/" + AllCategoriesSlug
+)
+
+// HTTP headers sent with HTMX requests.
+const (
+	// HeaderIfMatch carries the etag of the resource a toggle modifies.
+	HeaderIfMatch = "If-Match"
+)
+
 // CSS class names.
 const (
 	ClassSiteHeader  = "site-header"
@@ -42,4 +118,11 @@ const (
 	ClassFilters     = "filters"
 	ClassPagination  = "pagination"
 	ClassBreadcrumbs = "breadcrumbs"
+	ClassUserMenu    = "user-menu"
+	ClassLogin       = "login"
+	ClassUserAdmin   = "user-admin"
+	ClassResultCount = "result-count"
+	ClassSavedViews  = "saved-views"
+	ClassChapterNav  = "chapter-nav"
+	ClassError       = "error"
 )
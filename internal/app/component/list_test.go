@@ -0,0 +1,54 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCount_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		count ResultCount
+		want  string
+	}{
+		{
+			name:  "unfiltered",
+			count: ResultCount{Matched: 380, Total: 380},
+			want:  "380 entries",
+		},
+		{
+			name:  "single entry",
+			count: ResultCount{Matched: 1, Total: 1},
+			want:  "1 entry",
+		},
+		{
+			name:  "filtered",
+			count: ResultCount{Matched: 42, Total: 380},
+			want:  "42 of 380 entries",
+		},
+		{
+			name:  "estimated",
+			count: ResultCount{Matched: 0, Total: 25, Estimated: true},
+			want:  "at least 0 of 25 entries",
+		},
+		{
+			name:  "estimated filtered",
+			count: ResultCount{Matched: 42, Total: 380, Estimated: true},
+			want:  "at least 42 of 380 entries",
+		},
+		{
+			name:  "truncated",
+			count: ResultCount{Matched: 2000, Total: 2000, Estimated: true, Truncated: true},
+			want:  "at least 2000 entries (only the most recently updated are shown)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.count.String())
+		})
+	}
+}
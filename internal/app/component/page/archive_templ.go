@@ -13,7 +13,7 @@ import (
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 )
 
-func Archive(categories []*eratov1.Category, filters component.FilterParams) templ.Component {
+func Archive(categories []*eratov1.Category, props component.ListProps) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -46,12 +46,7 @@ func Archive(categories []*eratov1.Category, filters component.FilterParams) tem
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = component.CategoryList(categories, component.ListProps{
-				Title:    "Categories",
-				ListType: component.ListTypeCategories,
-				Filters:  filters,
-				BaseURL:  "/",
-			}).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = component.CategoryList(categories, props).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
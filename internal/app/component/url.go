@@ -11,6 +11,7 @@ const boolTrue = "true"
 type FilterParams struct {
 	Filters
 
+	View   string // ID of the applied saved view (empty for none)
 	Page   string // Current page token (empty for first page)
 	Parent string // Parent page's complete query string (for hierarchical navigation)
 }
@@ -32,6 +33,12 @@ func (f FilterParams) QueryString() string {
 	if f.ShowHidden {
 		params.Set("hidden", "true")
 	}
+	if f.OrderBy != "" {
+		params.Set("sort", f.OrderBy)
+	}
+	if f.View != "" {
+		params.Set("view", f.View)
+	}
 
 	// Pagination params
 	if f.Page != "" {
@@ -83,6 +90,13 @@ func (f FilterParams) WithHidden(hidden bool) FilterParams {
 	return f.WithoutPagination()
 }
 
+// WithOrderBy returns a copy with the sort order changed.
+// This resets pagination since the page tokens depend on the order.
+func (f FilterParams) WithOrderBy(orderBy string) FilterParams {
+	f.OrderBy = orderBy
+	return f.WithoutPagination()
+}
+
 // WithoutPagination returns a copy with pagination reset to the first page.
 // Use this when changing filters.
 func (f FilterParams) WithoutPagination() FilterParams {
@@ -125,7 +139,9 @@ func ParseQueryString(qs string) FilterParams {
 			OnlyUnread:  values.Get("unread") == boolTrue,
 			OnlyStarred: values.Get("starred") == boolTrue,
 			ShowHidden:  values.Get("hidden") == boolTrue,
+			OrderBy:     values.Get("sort"),
 		},
+		View:   values.Get("view"),
 		Page:   values.Get("page"),
 		Parent: values.Get("pf"),
 	}
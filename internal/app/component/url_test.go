@@ -53,6 +53,21 @@ func TestFilterParams_QueryString(t *testing.T) {
 			},
 			want: "hidden=true",
 		},
+		{
+			name: "sort order",
+			params: FilterParams{
+				Filters: Filters{OrderBy: "read_time desc"},
+			},
+			want: "sort=read_time+desc",
+		},
+		{
+			name: "saved view",
+			params: FilterParams{
+				Filters: Filters{OnlyUnread: true},
+				View:    "42",
+			},
+			want: "unread=true&view=42",
+		},
 		{
 			name: "multiple filters",
 			params: FilterParams{
@@ -160,6 +175,21 @@ func TestFilterParams_WithType(t *testing.T) {
 	assert.Equal(t, "abc123", original.Page)
 }
 
+func TestFilterParams_WithOrderBy(t *testing.T) {
+	t.Parallel()
+	original := FilterParams{
+		Filters: Filters{OnlyUnread: true, OrderBy: "display_name"},
+		Page:    "abc123",
+	}
+
+	result := original.WithOrderBy("update_time desc")
+
+	assert.Equal(t, "update_time desc", result.OrderBy)
+	assert.True(t, result.OnlyUnread, "other filters should be preserved")
+	assert.Empty(t, result.Page, "pagination should be reset")
+	assert.Equal(t, "display_name", original.OrderBy)
+}
+
 func TestFilterParams_WithoutPagination(t *testing.T) {
 	t.Parallel()
 	original := FilterParams{
@@ -269,14 +299,16 @@ func TestParseQueryString(t *testing.T) {
 		},
 		{
 			name: "all filters and pagination",
-			qs:   "type=anthology&unread=true&starred=true&hidden=true&page=abc123",
+			qs:   "type=anthology&unread=true&starred=true&hidden=true&sort=display_name&view=7&page=abc123",
 			want: FilterParams{
 				Filters: Filters{
 					TypeFilter:  "anthology",
 					OnlyUnread:  true,
 					OnlyStarred: true,
 					ShowHidden:  true,
+					OrderBy:     "display_name",
 				},
+				View: "7",
 				Page: "abc123",
 			},
 		},
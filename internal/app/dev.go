@@ -0,0 +1,35 @@
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/sec"
+)
+
+// switchDevUser impersonates another seeded user in dev mode, returning to
+// the page the switch was made from.
+func switchDevUser(c echo.Context) error {
+	name := c.FormValue(component.FormFieldUsername)
+	if !slices.Contains(sec.GetDevUsers(c.Request().Context()), name) {
+		return echo.NewHTTPError(http.StatusBadRequest, "not a dev user")
+	}
+	// not Secure, since dev mode is usually served over plain HTTP
+	c.SetCookie(&http.Cookie{
+		Name:     sec.DevUserCookieName,
+		Value:    name,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	next := "/"
+	if referer, err := url.Parse(c.Request().Referer()); err == nil {
+		next = safeRedirect(referer.RequestURI())
+	}
+	return c.Redirect(http.StatusSeeOther, next)
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/archive"
+	"github.com/stolasapp/erato/internal/config"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+)
+
+func TestDevUserSwitcher(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.SetDbFilepath(filepath.Join(t.TempDir(), "db.sqlite"))
+	cfg.SetDevMode(true)
+	cfg.SetDevUsers([]string{"alice", "bob"})
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	dev, err := sec.NewDevAuth(t.Context(), cfg, store)
+	require.NoError(t, err)
+	srv := New(cfg, slog.Default(), store, nil, nil, nil, nil, dev,
+		archive.NewUsers(eratov1connect.UnimplementedArchiveServiceHandler{}, store, nil, nil))
+
+	get := func(path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, http.NoBody)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+	switchTo := func(name string) *httptest.ResponseRecorder {
+		form := url.Values{component.FormFieldUsername: {name}}
+		req := httptest.NewRequestWithContext(t.Context(),
+			http.MethodPost,
+			component.PathDevUser,
+			strings.NewReader(form.Encode()),
+		)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Referer", "http://localhost/admin")
+		req.AddCookie(&http.Cookie{Name: middleware.DefaultCSRFConfig.CookieName, Value: "csrf"})
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// the default user is an admin, with no login required
+	rec := get(component.PathAdmin)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Switch to bob")
+	assert.NotContains(t, rec.Body.String(), "Sign out")
+
+	rec = switchTo("mallory")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = switchTo("bob")
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, component.PathAdmin, rec.Header().Get("Location"))
+	var cookie *http.Cookie
+	for _, header := range rec.Header().Values("Set-Cookie") {
+		if parsed, err := http.ParseSetCookie(header); err == nil && parsed.Name == sec.DevUserCookieName {
+			cookie = parsed
+		}
+	}
+	require.NotNil(t, cookie, "dev user cookie must be set")
+	assert.Equal(t, "bob", cookie.Value)
+
+	// bob is a member, so cannot manage users
+	rec = get(component.PathAdmin, cookie)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/stolasapp/erato/internal/app/component/page"
+)
+
+// handleError responds with err as an error page, in place of echo's default
+// JSON response, listing the field violations of an invalid request. HTMX
+// does not swap error responses, so they are sent as plain text instead. The
+// messages of internal errors are only shown in dev mode.
+func handleError(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, message := http.StatusInternalServerError, ""
+	var violations []*errdetails.BadRequest_FieldViolation
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+		if msg, ok := httpErr.Message.(string); ok {
+			message = msg
+		}
+		violations = fieldViolations(httpErr.Internal)
+	} else if c.Echo().Debug {
+		message = err.Error()
+	}
+
+	switch {
+	case c.Request().Method == http.MethodHead:
+		err = c.NoContent(status)
+	case isHTMX(c):
+		lines := []string{cmp.Or(message, http.StatusText(status))}
+		for _, violation := range violations {
+			lines = append(lines, violation.GetField()+": "+violation.GetDescription())
+		}
+		err = c.String(status, strings.Join(lines, "\n"))
+	default:
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		c.Response().WriteHeader(status)
+		err = render(c.Request().Context(), page.Error(status, message, violations), c.Response().Writer)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// fieldViolations returns the violations in err's google.rpc.BadRequest
+// details, if err is a ConnectRPC error with any.
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		return nil
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range cerr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			continue
+		}
+		if badReq, ok := value.(*errdetails.BadRequest); ok {
+			violations = append(violations, badReq.GetFieldViolations()...)
+		}
+	}
+	return violations
+}
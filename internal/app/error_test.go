@@ -0,0 +1,112 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestHandleError(t *testing.T) {
+	t.Parallel()
+
+	invalid := connect.NewError(connect.CodeInvalidArgument, errors.New("validation error"))
+	detail, err := connect.NewErrorDetail(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       "filter",
+			Description: "undeclared reference to 'title'",
+			Reason:      "INVALID_FILTER",
+		}},
+	})
+	require.NoError(t, err)
+	invalid.AddDetail(detail)
+
+	tests := []struct {
+		name       string
+		err        error
+		htmx       bool
+		debug      bool
+		wantStatus int
+		contains   []string
+		excludes   []string
+	}{
+		{
+			name:       "field violations",
+			err:        toHTTPError(invalid),
+			wantStatus: http.StatusBadRequest,
+			contains:   []string{"Bad Request", "The request is invalid.", "<code>filter</code>", "undeclared reference to &#39;title&#39;"},
+			excludes:   []string{"validation error"},
+		},
+		{
+			name:       "htmx field violations",
+			err:        toHTTPError(invalid),
+			htmx:       true,
+			wantStatus: http.StatusBadRequest,
+			contains:   []string{"The request is invalid.\nfilter: undeclared reference to 'title'"},
+		},
+		{
+			name:       "not found",
+			err:        toHTTPError(connect.NewError(connect.CodeNotFound, errors.New("no such entry"))),
+			wantStatus: http.StatusNotFound,
+			contains:   []string{"Not Found", "no such entry"},
+		},
+		{
+			name: "partial batch",
+			err: partialBatchError(
+				connect.NewError(connect.CodeNotFound, errors.New("no such chapter")), 100, 150, "chapters",
+			),
+			wantStatus: http.StatusNotFound,
+			contains:   []string{"Only 100 of 150 chapters were updated.", "no such chapter"},
+		},
+		{
+			name:       "internal partial batch",
+			err:        partialBatchError(errors.New("database is locked"), 100, 150, "chapters"),
+			wantStatus: http.StatusInternalServerError,
+			contains:   []string{"Only 100 of 150 chapters were updated."},
+			excludes:   []string{"database is locked"},
+		},
+		{
+			name:       "internal",
+			err:        errors.New("database is locked"),
+			wantStatus: http.StatusInternalServerError,
+			contains:   []string{"Internal Server Error"},
+			excludes:   []string{"database is locked"},
+		},
+		{
+			name:       "internal in dev mode",
+			err:        errors.New("database is locked"),
+			debug:      true,
+			wantStatus: http.StatusInternalServerError,
+			contains:   []string{"database is locked"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			srv := echo.New()
+			srv.Debug = test.debug
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+			if test.htmx {
+				req.Header.Set("Hx-Request", htmxTrue)
+			}
+			rec := httptest.NewRecorder()
+
+			handleError(test.err, srv.NewContext(req, rec))
+
+			assert.Equal(t, test.wantStatus, rec.Code)
+			for _, want := range test.contains {
+				assert.Contains(t, rec.Body.String(), want)
+			}
+			for _, unwanted := range test.excludes {
+				assert.NotContains(t, rec.Body.String(), unwanted)
+			}
+		})
+	}
+}
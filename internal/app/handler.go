@@ -2,12 +2,16 @@ package app
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -19,6 +23,7 @@ import (
 
 	"github.com/stolasapp/erato/internal/app/component"
 	"github.com/stolasapp/erato/internal/app/component/page"
+	"github.com/stolasapp/erato/internal/export"
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
 	"github.com/stolasapp/erato/internal/slugconv"
@@ -30,14 +35,18 @@ type handler struct {
 
 func (h handler) register(e *echo.Echo) {
 	e.GET("/", h.archive)
+	h.registerSavedViews(e)
 
 	category := e.Group("/:category")
 	category.GET("", h.category)
+	category.PUT("", h.entryBatchOp)
 	category.PUT("/ops/:op", h.categoryOp)
 
 	entry := category.Group("/:entry")
 	entry.GET("", h.entry)
+	entry.PUT("", h.chapterBatchOp)
 	entry.PUT("/ops/:op", h.entryOp)
+	entry.GET(component.PathExport, h.exportEntry)
 
 	chapter := entry.Group("/:chapter")
 	chapter.GET("", h.chapter)
@@ -45,92 +54,193 @@ func (h handler) register(e *echo.Echo) {
 }
 
 func (h handler) archive(c echo.Context) error {
+	ctx := c.Request().Context()
+	filters := parseFilterParams(c)
 	resp, err := h.handler.ListCategories(
-		c.Request().Context(),
-		connect.NewRequest(eratov1.ListCategoriesRequest_builder{}.Build()),
+		ctx,
+		connect.NewRequest(eratov1.ListCategoriesRequest_builder{
+			OrderBy: filters.OrderBy,
+		}.Build()),
 	)
 	if err != nil {
-		return err
+		return toHTTPError(err)
+	}
+	views, err := h.listSavedViews(ctx)
+	if err != nil {
+		return toHTTPError(err)
 	}
 
-	filters := parseFilterParams(c)
+	listProps := component.ListProps{
+		Title:      "Categories",
+		ListType:   component.ListTypeCategories,
+		Filters:    filters,
+		BaseURL:    "/",
+		SavedViews: views,
+	}
 
 	// HTMX request - return just the list component
 	if isHTMX(c) {
-		return component.CategoryList(resp.Msg.GetResults(), component.ListProps{
-			Title:    "Categories",
-			ListType: component.ListTypeCategories,
-			Filters:  filters,
-			BaseURL:  "/",
-		}).Render(
-			c.Request().Context(),
+		return component.CategoryList(resp.Msg.GetResults(), listProps).Render(
+			ctx,
 			c.Response().Writer,
 		)
 	}
 
 	return render(
-		c.Request().Context(),
-		page.Archive(resp.Msg.GetResults(), filters),
+		ctx,
+		page.Archive(resp.Msg.GetResults(), listProps),
 		c.Response().Writer,
 	)
 }
 
 func (h handler) category(c echo.Context) error {
+	ctx := c.Request().Context()
 	slug := c.Param("category")
-	path, err := slugconv.ToCategoryPath(slug)
+	path, err := categoryPath(slug)
 	if err != nil {
 		return err
 	}
 
-	category, err := h.handler.GetCategory(
-		c.Request().Context(),
-		connect.NewRequest(eratov1.GetCategoryRequest_builder{Path: path}.Build()),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to get category %q: %w", path, err)
+	category := eratov1.Category_builder{
+		Path:        path,
+		DisplayName: "All categories",
+	}.Build()
+	if path != component.AllCategoriesPath {
+		res, err := h.handler.GetCategory(
+			ctx,
+			connect.NewRequest(eratov1.GetCategoryRequest_builder{Path: path}.Build()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get category %q: %w", path, err)
+		}
+		category = res.Msg
 	}
 
 	filters := parseFilterParams(c)
-	entries, err := h.handler.ListEntries(
-		c.Request().Context(),
-		connect.NewRequest(eratov1.ListEntriesRequest_builder{
-			Parent:      path,
-			Filter:      buildEntryFilter(filters.Filters),
-			MaxPageSize: defaultPageSize,
-			PageToken:   filters.Page,
-		}.Build()),
-	)
+	query, err := h.entryQuery(ctx, path, filters)
 	if err != nil {
-		return err
+		return toHTTPError(err)
+	}
+	entries, err := h.listEntries(ctx, query)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	views, err := h.listSavedViews(ctx)
+	if err != nil {
+		return toHTTPError(err)
 	}
 
 	// Hidden filtering is done via CSS to preserve fragment navigation
 	listProps := component.ListProps{
-		Title:         category.Msg.GetDisplayName(),
+		Title:         category.GetDisplayName(),
 		ListType:      component.ListTypeMixed,
 		Filters:       filters,
 		BaseURL:       "/" + slug,
 		NextPageToken: entries.Msg.GetNextPageToken(),
+		Count:         countEntries(entries.Msg),
+		Category:      path,
+		SavedViews:    views,
 	}
 
 	// HTMX request - return just the list component
 	if isHTMX(c) {
 		return component.EntryList(entries.Msg.GetResults(), listProps).Render(
-			c.Request().Context(),
+			ctx,
 			c.Response().Writer,
 		)
 	}
 
 	return page.Category(
-		category.Msg,
+		category,
 		entries.Msg.GetResults(),
 		listProps,
 	).Render(
-		c.Request().Context(),
+		ctx,
 		c.Response().Writer,
 	)
 }
 
+// categoryPath converts a category slug into a path, including the slug of
+// every category's entries.
+func categoryPath(slug string) (string, error) {
+	if slug == component.AllCategoriesSlug {
+		return component.AllCategoriesPath, nil
+	}
+	return slugconv.ToCategoryPath(slug)
+}
+
+// entryQuery is how the entries of a category are listed, combining the quick
+// filters with any applied saved view.
+type entryQuery struct {
+	parent    string
+	filter    string
+	orderBy   string
+	pageToken string
+}
+
+// entryQuery resolves the filters for listing the category's entries. The
+// filters' order takes precedence over the view's.
+func (h handler) entryQuery(
+	ctx context.Context,
+	category string,
+	filters component.FilterParams,
+) (entryQuery, error) {
+	query := entryQuery{
+		parent:    category,
+		filter:    buildEntryFilter(filters.Filters),
+		orderBy:   filters.OrderBy,
+		pageToken: filters.Page,
+	}
+	if filters.View == "" {
+		return query, nil
+	}
+	view, err := h.handler.GetSavedView(
+		ctx,
+		connect.NewRequest(eratov1.GetSavedViewRequest_builder{
+			Path: savedViewPath(ctx, filters.View),
+		}.Build()),
+	)
+	if err != nil {
+		return query, err
+	}
+	if viewFilter := view.Msg.GetFilter(); viewFilter == "" {
+		// nothing to combine
+	} else if query.filter == "" {
+		query.filter = viewFilter
+	} else {
+		query.filter = query.filter + " && (" + viewFilter + ")"
+	}
+	query.orderBy = cmp.Or(query.orderBy, view.Msg.GetOrderBy())
+	return query, nil
+}
+
+func (h handler) listEntries(
+	ctx context.Context,
+	query entryQuery,
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	return h.handler.ListEntries(
+		ctx,
+		connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      query.parent,
+			Filter:      query.filter,
+			MaxPageSize: defaultPageSize,
+			PageToken:   query.pageToken,
+			OrderBy:     query.orderBy,
+		}.Build()),
+	)
+}
+
+// countEntries returns the size of the category's entry list res, as counted
+// by the listing itself along with the entries regardless of the filter.
+func countEntries(res *eratov1.ListEntriesResponse) *component.ResultCount {
+	return &component.ResultCount{
+		Matched:   res.GetTotalSize(),
+		Total:     res.GetUnfilteredTotalSize(),
+		Estimated: res.GetTotalSizeEstimated(),
+		Truncated: res.GetResultsTruncated(),
+	}
+}
+
 func (h handler) entry(c echo.Context) error {
 	slug := c.Param("category") + "/" + c.Param("entry")
 	path, err := slugconv.ToEntryPath(slug)
@@ -166,6 +276,9 @@ func (h handler) story(c echo.Context, entry *eratov1.Entry) error {
 		return toHTTPError(err)
 	}
 
+	// mark viewed before rendering so the page has the entry's latest etag
+	entry = h.markEntryViewed(c.Request().Context(), entry)
+
 	filters := parseFilterParams(c)
 	err = page.Story(
 		entry,
@@ -178,11 +291,32 @@ func (h handler) story(c echo.Context, entry *eratov1.Entry) error {
 	if err != nil {
 		return toHTTPError(err)
 	}
-
-	h.markEntryViewed(c.Request().Context(), entry.GetPath())
 	return nil
 }
 
+// exportEntry downloads the entry as an EPUB, streamed as it is built.
+func (h handler) exportEntry(c echo.Context) error {
+	slug := c.Param("category") + "/" + c.Param("entry")
+	path, err := slugconv.ToEntryPath(slug)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	epub, err := export.NewEPUB(ctx, h.handler, path)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, export.ContentTypeEPUB)
+	res.Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": epub.Filename()}))
+	res.WriteHeader(http.StatusOK)
+	// once streaming, errors can only abort the download
+	return epub.Write(ctx, res)
+}
+
 func (h handler) anthology(c echo.Context, entry *eratov1.Entry) error {
 	slug := c.Param("category") + "/" + c.Param("entry")
 	filters := parseFilterParams(c)
@@ -190,8 +324,10 @@ func (h handler) anthology(c echo.Context, entry *eratov1.Entry) error {
 		c.Request().Context(),
 		connect.NewRequest(eratov1.ListChaptersRequest_builder{
 			Parent:      entry.GetPath(),
+			Filter:      buildChapterFilter(filters.Filters),
 			MaxPageSize: defaultPageSize,
 			PageToken:   filters.Page,
+			OrderBy:     filters.OrderBy,
 		}.Build()),
 	)
 	if err != nil {
@@ -206,6 +342,18 @@ func (h handler) anthology(c echo.Context, entry *eratov1.Entry) error {
 		NextPageToken: chapters.Msg.GetNextPageToken(),
 	}
 
+	// HTMX request - return just the list component
+	if isHTMX(c) {
+		return component.ChapterList(chapters.Msg.GetResults(), listProps).Render(
+			c.Request().Context(),
+			c.Response().Writer,
+		)
+	}
+
+	// mark viewed after listing the chapters (which may update the entry's
+	// chapter count) and before rendering so the page has the latest etag
+	entry = h.markEntryViewed(c.Request().Context(), entry)
+
 	err = page.Anthology(
 		entry,
 		chapters.Msg.GetResults(),
@@ -217,8 +365,6 @@ func (h handler) anthology(c echo.Context, entry *eratov1.Entry) error {
 	if err != nil {
 		return toHTTPError(err)
 	}
-
-	h.markEntryViewed(c.Request().Context(), entry.GetPath())
 	return nil
 }
 
@@ -250,11 +396,21 @@ func (h handler) chapter(c echo.Context) error {
 		return toHTTPError(err)
 	}
 
+	prev, next, err := h.adjacentChapters(c.Request().Context(), chapter.Msg.GetPath())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	// mark viewed before rendering so the page has the chapter's latest etag
+	viewed := h.markChapterViewed(c.Request().Context(), chapter.Msg)
+
 	filters := parseFilterParams(c)
 	err = page.Chapter(
-		chapter.Msg,
+		viewed,
 		content.Msg.GetContent(),
 		filters,
+		prev,
+		next,
 	).Render(
 		c.Request().Context(),
 		c.Response().Writer,
@@ -262,8 +418,6 @@ func (h handler) chapter(c echo.Context) error {
 	if err != nil {
 		return toHTTPError(err)
 	}
-
-	h.markChapterViewed(c.Request().Context(), chapter.Msg.GetPath())
 	return nil
 }
 
@@ -279,6 +433,7 @@ func (h handler) entryOp(c echo.Context) error {
 	if mask == nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid operation")
 	}
+	entry.SetEtag(ifMatch(c))
 
 	_, err = h.handler.UpdateEntry(
 		c.Request().Context(),
@@ -308,6 +463,75 @@ func (h handler) entryOp(c echo.Context) error {
 	})
 }
 
+// entryBatchOp applies an operation to multiple entries of a category, either
+// the selected entries or every entry on the current page, then re-renders
+// the entry list.
+func (h handler) entryBatchOp(c echo.Context) error {
+	path, err := categoryPath(c.Param("category"))
+	if err != nil {
+		return err
+	}
+
+	entry, mask := applyEntryOp(c.FormValue(component.FormFieldOp))
+	if mask == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid operation")
+	}
+
+	var paths []string
+	if c.FormValue(component.FormFieldScope) == component.BatchScopePage {
+		query, err := h.entryQuery(c.Request().Context(), path, parseFilterParams(c))
+		if err != nil {
+			return toHTTPError(err)
+		}
+		entries, err := h.listEntries(c.Request().Context(), query)
+		if err != nil {
+			return toHTTPError(err)
+		}
+		for _, entry := range entries.Msg.GetResults() {
+			paths = append(paths, entry.GetPath())
+		}
+	} else if paths, err = selectedPaths(c, slugconv.ToEntryPath); err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "no entries selected")
+	}
+
+	// entries listed from every category are updated by their own category
+	byCategory := make(map[string][]string)
+	for _, entryPath := range paths {
+		parent := slugconv.EntryParent(entryPath)
+		byCategory[parent] = append(byCategory[parent], entryPath)
+	}
+	// each batch is applied on its own, so a failure keeps the earlier ones
+	var updated int
+	for parent, categoryPaths := range byCategory {
+		for chunk := range slices.Chunk(categoryPaths, maxBatchSize) {
+			requests := make([]*eratov1.UpdateEntryRequest, len(chunk))
+			for idx, entryPath := range chunk {
+				requests[idx] = eratov1.UpdateEntryRequest_builder{
+					Path:       entryPath,
+					Entry:      entry,
+					UpdateMask: mask,
+				}.Build()
+			}
+			_, err = h.handler.BatchUpdateEntries(
+				c.Request().Context(),
+				connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+					Parent:   parent,
+					Requests: requests,
+				}.Build()),
+			)
+			if err != nil {
+				return partialBatchError(err, updated, len(paths), "entries")
+			}
+			updated += len(chunk)
+		}
+	}
+
+	return h.category(c)
+}
+
 func applyEntryOp(op string) (*eratov1.Entry, *fieldmaskpb.FieldMask) {
 	entry := &eratov1.Entry{}
 	switch op {
@@ -352,6 +576,7 @@ func (h handler) chapterOp(c echo.Context) error {
 	if mask == nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid operation")
 	}
+	chapter.SetEtag(ifMatch(c))
 
 	_, err = h.handler.UpdateChapter(
 		c.Request().Context(),
@@ -381,6 +606,128 @@ func (h handler) chapterOp(c echo.Context) error {
 	})
 }
 
+// chapterBatchOp applies an operation to multiple chapters of an anthology,
+// either the selected chapters or all of them, then re-renders the chapter
+// list.
+func (h handler) chapterBatchOp(c echo.Context) error {
+	slug := c.Param("category") + "/" + c.Param("entry")
+	path, err := slugconv.ToEntryPath(slug)
+	if err != nil {
+		return err
+	}
+
+	chapter, mask := applyChapterOp(c.FormValue(component.FormFieldOp))
+	if mask == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid operation")
+	}
+
+	var paths []string
+	if c.FormValue(component.FormFieldScope) == component.BatchScopeAll {
+		if paths, err = h.allChapterPaths(c.Request().Context(), path); err != nil {
+			return toHTTPError(err)
+		}
+	} else if paths, err = selectedPaths(c, slugconv.ToChapterPath); err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "no chapters selected")
+	}
+
+	// each batch is applied on its own, so a failure keeps the earlier ones
+	var updated int
+	for chunk := range slices.Chunk(paths, maxBatchSize) {
+		requests := make([]*eratov1.UpdateChapterRequest, len(chunk))
+		for idx, chapterPath := range chunk {
+			requests[idx] = eratov1.UpdateChapterRequest_builder{
+				Path:       chapterPath,
+				Chapter:    chapter,
+				UpdateMask: mask,
+			}.Build()
+		}
+		_, err = h.handler.BatchUpdateChapters(
+			c.Request().Context(),
+			connect.NewRequest(eratov1.BatchUpdateChaptersRequest_builder{
+				Parent:   path,
+				Requests: requests,
+			}.Build()),
+		)
+		if err != nil {
+			return partialBatchError(err, updated, len(paths), "chapters")
+		}
+		updated += len(chunk)
+	}
+
+	return h.entry(c)
+}
+
+// allChapterPaths lists the paths of every chapter of the anthology entry.
+func (h handler) allChapterPaths(ctx context.Context, entry string) (paths []string, err error) {
+	chapters, err := h.allChapters(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	paths = make([]string, len(chapters))
+	for idx, chapter := range chapters {
+		paths[idx] = chapter.GetPath()
+	}
+	return paths, nil
+}
+
+// allChapters lists every chapter of the anthology entry in reading order.
+// Without a page size, the chapters are listed at once rather than a page at
+// a time.
+func (h handler) allChapters(ctx context.Context, entry string) ([]*eratov1.Chapter, error) {
+	res, err := h.handler.ListChapters(
+		ctx,
+		connect.NewRequest(eratov1.ListChaptersRequest_builder{
+			Parent: entry,
+		}.Build()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return res.Msg.GetResults(), nil
+}
+
+// adjacentChapters returns the chapters before and after the chapter in the
+// reading order of its anthology, if any.
+func (h handler) adjacentChapters(ctx context.Context, chapter string) (prev, next *eratov1.Chapter, err error) {
+	chapters, err := h.allChapters(ctx, slugconv.ChapterParent(chapter))
+	if err != nil {
+		return nil, nil, err
+	}
+	idx := slices.IndexFunc(chapters, func(other *eratov1.Chapter) bool {
+		return other.GetPath() == chapter
+	})
+	if idx == -1 {
+		return nil, nil, nil
+	}
+	if idx > 0 {
+		prev = chapters[idx-1]
+	}
+	if idx < len(chapters)-1 {
+		next = chapters[idx+1]
+	}
+	return prev, next, nil
+}
+
+// selectedPaths converts the slugs of the items selected in a list into
+// resource paths.
+func selectedPaths(c echo.Context, toPath func(string) (string, error)) ([]string, error) {
+	params, err := c.FormParams()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid form")
+	}
+	slugs := params[component.FormFieldSlug]
+	paths := make([]string, len(slugs))
+	for idx, slug := range slugs {
+		if paths[idx], err = toPath(slug); err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid selection")
+		}
+	}
+	return paths, nil
+}
+
 func (h handler) categoryOp(c echo.Context) error {
 	slug := c.Param("category")
 	path, err := slugconv.ToCategoryPath(slug)
@@ -393,6 +740,7 @@ func (h handler) categoryOp(c echo.Context) error {
 	if mask == nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid operation")
 	}
+	category.SetEtag(ifMatch(c))
 
 	_, err = h.handler.UpdateCategory(
 		c.Request().Context(),
@@ -457,45 +805,70 @@ func applyCategoryOp(op string) (*eratov1.Category, *fieldmaskpb.FieldMask) {
 	}
 }
 
-func (h handler) markEntryViewed(ctx context.Context, path string) {
-	entry, mask := applyEntryOp("view")
-	_, err := h.handler.UpdateEntry(
+// markEntryViewed marks the entry as viewed, returning the updated entry. If
+// the update fails, it is logged and the original entry is returned.
+func (h handler) markEntryViewed(ctx context.Context, entry *eratov1.Entry) *eratov1.Entry {
+	viewed, mask := applyEntryOp("view")
+	res, err := h.handler.UpdateEntry(
 		ctx,
 		connect.NewRequest(eratov1.UpdateEntryRequest_builder{
-			Path:       path,
-			Entry:      entry,
+			Path:       entry.GetPath(),
+			Entry:      viewed,
 			UpdateMask: mask,
 		}.Build()),
 	)
 	if err != nil {
 		slog.Error("failed to mark entry as viewed",
-			slog.String("path", path),
+			slog.String("path", entry.GetPath()),
 			slog.Any("error", err),
 		)
+		return entry
 	}
+	return res.Msg
 }
 
-func (h handler) markChapterViewed(ctx context.Context, path string) {
-	chapter, mask := applyChapterOp("view")
-	_, err := h.handler.UpdateChapter(
+// markChapterViewed marks the chapter as viewed, returning the updated
+// chapter. If the update fails, it is logged and the original chapter is
+// returned.
+func (h handler) markChapterViewed(ctx context.Context, chapter *eratov1.Chapter) *eratov1.Chapter {
+	viewed, mask := applyChapterOp("view")
+	res, err := h.handler.UpdateChapter(
 		ctx,
 		connect.NewRequest(eratov1.UpdateChapterRequest_builder{
-			Path:       path,
-			Chapter:    chapter,
+			Path:       chapter.GetPath(),
+			Chapter:    viewed,
 			UpdateMask: mask,
 		}.Build()),
 	)
 	if err != nil {
 		slog.Error("failed to mark chapter as viewed",
-			slog.String("path", path),
+			slog.String("path", chapter.GetPath()),
 			slog.Any("error", err),
 		)
+		return chapter
+	}
+	return res.Msg
+}
+
+// ifMatch returns the etag from the request's If-Match header, or an empty
+// string if the request is unconditional.
+func ifMatch(c echo.Context) string {
+	etag := strings.TrimPrefix(c.Request().Header.Get(component.HeaderIfMatch), "W/")
+	if etag == "*" {
+		return ""
+	} else if unquoted, err := strconv.Unquote(etag); err == nil {
+		return unquoted
 	}
+	return etag
 }
 
 const htmxTrue = "true"
 const defaultPageSize = 100
 
+// maxBatchSize matches the maximum number of requests accepted by the batch
+// update RPCs.
+const maxBatchSize = 100
+
 func isHTMX(c echo.Context) bool {
 	return c.Request().Header.Get("Hx-Request") == htmxTrue
 }
@@ -507,7 +880,9 @@ func parseFilterParams(c echo.Context) component.FilterParams {
 			OnlyUnread:  c.QueryParam("unread") == htmxTrue,
 			OnlyStarred: c.QueryParam("starred") == htmxTrue,
 			ShowHidden:  c.QueryParam("hidden") == htmxTrue,
+			OrderBy:     c.QueryParam("sort"),
 		},
+		View:   c.QueryParam("view"),
 		Page:   c.QueryParam("page"),
 		Parent: c.QueryParam("pf"),
 	}
@@ -530,9 +905,17 @@ func buildEntryFilter(filters component.Filters) string {
 	return strings.Join(conditions, " && ")
 }
 
+func buildChapterFilter(filters component.Filters) string {
+	if filters.OnlyUnread {
+		return "!has(this.read_time)"
+	}
+	return ""
+}
+
 // toHTTPError converts an error to an Echo HTTPError with the appropriate
 // HTTP status code. ConnectRPC errors are mapped to their corresponding HTTP
-// status codes; other errors pass through unchanged.
+// status codes, keeping the original error so any field violations can be
+// shown by [handleError]; other errors pass through unchanged.
 func toHTTPError(err error) error {
 	if err == nil {
 		return nil
@@ -548,13 +931,32 @@ func toHTTPError(err error) error {
 	code := connect.CodeOf(err)
 	status := connectCodeToHTTPStatus(code)
 	if status != http.StatusInternalServerError {
-		return echo.NewHTTPError(status, err.Error())
+		message := err.Error()
+		if len(fieldViolations(err)) > 0 {
+			message = "The request is invalid."
+		}
+		return echo.NewHTTPError(status, message).SetInternal(err)
 	}
 
 	// Unknown code or non-Connect error - return as-is for default handling
 	return err
 }
 
+// partialBatchError converts err from a batch update into an HTTP error like
+// [toHTTPError], reporting that only the first updated of total resources were
+// updated by the preceding batches.
+func partialBatchError(err error, updated, total int, noun string) error {
+	if updated == 0 {
+		return toHTTPError(err)
+	}
+	message := fmt.Sprintf("Only %d of %d %s were updated.", updated, total, noun)
+	status := connectCodeToHTTPStatus(connect.CodeOf(err))
+	if status != http.StatusInternalServerError && len(fieldViolations(err)) == 0 {
+		message += " " + err.Error()
+	}
+	return echo.NewHTTPError(status, message).SetInternal(err)
+}
+
 // connectCodeToHTTPStatus maps ConnectRPC error codes to HTTP status codes.
 // See: https://connectrpc.com/docs/protocol/#error-codes
 func connectCodeToHTTPStatus(code connect.Code) int {
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+)
+
+func (h handler) registerSavedViews(e *echo.Echo) {
+	e.POST(component.PathSavedViews, h.createSavedView)
+	e.POST(component.PathSavedViews+"/:view/"+component.SavedViewOpDelete, h.deleteSavedView)
+}
+
+// createSavedView saves the submitted filters, including any view they are
+// applied to, as a new view, then applies it.
+func (h handler) createSavedView(c echo.Context) error {
+	ctx := c.Request().Context()
+	filters := component.ParseQueryString(c.FormValue(component.FormFieldQuery))
+	category := c.FormValue(component.FormFieldCategory)
+	query, err := h.entryQuery(ctx, category, filters)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	created, err := h.handler.CreateSavedView(ctx, connect.NewRequest(eratov1.CreateSavedViewRequest_builder{
+		Parent: sec.GetAuthenticatedUser(ctx).Path(),
+		SavedView: eratov1.SavedView_builder{
+			DisplayName: c.FormValue(component.FormFieldDisplayName),
+			Filter:      query.filter,
+			OrderBy:     query.orderBy,
+			Category:    category,
+		}.Build(),
+	}.Build()))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	next := safeRedirect(c.FormValue(component.FormFieldNext))
+	if category != "" {
+		next = "/" + component.CategorySlug(category)
+	}
+	view := component.FilterParams{View: component.SavedViewID(created.Msg.GetPath())}
+	return c.Redirect(http.StatusSeeOther, view.BuildURL(next))
+}
+
+// deleteSavedView deletes one of the user's views, then returns to the list it
+// was deleted from without any view applied.
+func (h handler) deleteSavedView(c echo.Context) error {
+	ctx := c.Request().Context()
+	_, err := h.handler.DeleteSavedView(ctx, connect.NewRequest(eratov1.DeleteSavedViewRequest_builder{
+		Path: savedViewPath(ctx, c.Param("view")),
+	}.Build()))
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.Redirect(http.StatusSeeOther, safeRedirect(c.FormValue(component.FormFieldNext)))
+}
+
+// listSavedViews pages through every saved view of the user.
+func (h handler) listSavedViews(ctx context.Context) (views []*eratov1.SavedView, err error) {
+	pageToken := ""
+	for {
+		resp, err := h.handler.ListSavedViews(ctx, connect.NewRequest(eratov1.ListSavedViewsRequest_builder{
+			Parent:    sec.GetAuthenticatedUser(ctx).Path(),
+			PageToken: pageToken,
+		}.Build()))
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, resp.Msg.GetResults()...)
+		if pageToken = resp.Msg.GetNextPageToken(); pageToken == "" {
+			return views, nil
+		}
+	}
+}
+
+// savedViewPath returns the path of the user's saved view with the ID.
+func savedViewPath(ctx context.Context, id string) string {
+	return sec.GetAuthenticatedUser(ctx).Path() + "/saved-views/" + id
+}
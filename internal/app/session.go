@@ -0,0 +1,340 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/app/component/page"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// oidcLoginCookieName is the name of the cookie holding the state of an
+// in-progress OpenID Connect login.
+const oidcLoginCookieName = "erato_oidc_login"
+
+// oidcLoginTimeout is how long the user has to log in with the OpenID Connect
+// provider before the login must be restarted.
+const oidcLoginTimeout = 10 * time.Minute
+
+// sessionHandler serves the login and registration pages and manages the
+// user's login sessions. Password logins and registrations are limited by
+// throttle, and all logins are recorded by audit. Registration creates users
+// with archive. If oidc is set, users may also log in with the OpenID Connect
+// provider. If proxy is set, requests from the trusted reverse proxy need no
+// session.
+type sessionHandler struct {
+	archive  eratov1connect.ArchiveServiceHandler
+	sessions *sec.Sessions
+	users    sec.IdentityStore
+	throttle *sec.Throttle
+	audit    *sec.Auditor
+	proxy    *sec.ProxyAuth
+	oidc     *sec.OIDC
+}
+
+func (h sessionHandler) register(e *echo.Echo) {
+	e.GET(component.PathLogin, h.loginPage)
+	e.POST(component.PathLogin, h.login)
+	e.POST(component.PathLogout, h.logout)
+	e.POST(component.PathLogoutOthers, h.logoutOthers)
+	e.GET(component.PathRegister, h.registerPage)
+	e.POST(component.PathRegister, h.registerUser)
+	if h.oidc != nil {
+		e.GET(component.PathLoginOIDC, h.oidcLogin)
+		e.GET(component.PathLoginOIDCCallback, h.oidcCallback)
+	}
+}
+
+func (h sessionHandler) loginPage(c echo.Context) error {
+	next := safeRedirect(c.QueryParam(component.FormFieldNext))
+	return page.Login(next, "", "", h.ssoURL(next)).Render(
+		c.Request().Context(),
+		c.Response().Writer,
+	)
+}
+
+func (h sessionHandler) login(c echo.Context) error {
+	ctx := c.Request().Context()
+	username := c.FormValue(component.FormFieldUsername)
+	next := safeRedirect(c.FormValue(component.FormFieldNext))
+
+	user, err := h.throttle.VerifyCredentials(ctx,
+		h.users,
+		c.Request().RemoteAddr,
+		username,
+		c.FormValue(component.FormFieldPassword),
+	)
+	if err != nil {
+		h.audit.Record(ctx, eratov1.AuditEvent_LOGIN, db.User{Name: username}, err)
+	}
+	if retryAfter, ok := sec.RetryAfter(err); ok {
+		c.Response().Header().Set("Retry-After", retryAfter)
+		c.Response().WriteHeader(http.StatusTooManyRequests)
+		return page.Login(next, username, "Too many failed attempts. Please try again later.", h.ssoURL(next)).Render(
+			ctx,
+			c.Response().Writer,
+		)
+	} else if err != nil {
+		c.Response().WriteHeader(http.StatusUnauthorized)
+		return page.Login(next, username, "Invalid username or password.", h.ssoURL(next)).Render(
+			ctx,
+			c.Response().Writer,
+		)
+	}
+	return h.startSession(c, user, next)
+}
+
+func (h sessionHandler) registerPage(c echo.Context) error {
+	return page.Register(c.QueryParam(component.FormFieldInviteCode), "", "").Render(
+		c.Request().Context(),
+		c.Response().Writer,
+	)
+}
+
+// registerUser creates a user by redeeming an invite code, logging them in.
+// Like logins, registrations with invalid codes are limited by throttle.
+func (h sessionHandler) registerUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	code := c.FormValue(component.FormFieldInviteCode)
+	username := c.FormValue(component.FormFieldUsername)
+
+	err := h.throttle.Register(ctx, c.Request().RemoteAddr, func() error {
+		_, err := h.archive.CreateUser(ctx, connect.NewRequest(eratov1.CreateUserRequest_builder{
+			Id:         username,
+			User:       eratov1.User_builder{Password: c.FormValue(component.FormFieldPassword)}.Build(),
+			InviteCode: code,
+		}.Build()))
+		return err
+	})
+	if retryAfter, ok := sec.RetryAfter(err); ok {
+		c.Response().Header().Set("Retry-After", retryAfter)
+	}
+	var errMsg string
+	switch connect.CodeOf(err) {
+	case connect.CodeResourceExhausted:
+		errMsg = "Too many failed attempts. Please try again later."
+	case connect.CodePermissionDenied:
+		errMsg = "This invite code is invalid, expired or has already been used."
+	case connect.CodeAlreadyExists:
+		errMsg = "That username is already taken."
+	case connect.CodeInvalidArgument:
+		errMsg = "Usernames must be 3-64 letters, numbers and underscores, and passwords 8-1024 characters."
+	default:
+		if err != nil {
+			return err
+		}
+	}
+	if errMsg != "" {
+		c.Response().WriteHeader(connectCodeToHTTPStatus(connect.CodeOf(err)))
+		return page.Register(code, username, errMsg).Render(ctx, c.Response().Writer)
+	}
+
+	user, err := h.users.GetUserByName(ctx, username)
+	if err != nil {
+		return err
+	}
+	return h.startSession(c, user, "/")
+}
+
+// oidcLogin sends the user to the OpenID Connect provider to log in. The
+// login state is kept in a cookie until the provider returns the user to
+// oidcCallback.
+func (h sessionHandler) oidcLogin(c echo.Context) error {
+	next := safeRedirect(c.QueryParam(component.FormFieldNext))
+	login, authURL := h.oidc.StartLogin()
+	c.SetCookie(oidcLoginCookie(url.Values{
+		"state":    {login.State},
+		"verifier": {login.Verifier},
+		"nonce":    {login.Nonce},
+		"next":     {next},
+	}.Encode(), int(oidcLoginTimeout.Seconds())))
+	return c.Redirect(http.StatusSeeOther, authURL)
+}
+
+func (h sessionHandler) oidcCallback(c echo.Context) error {
+	ctx := c.Request().Context()
+	var state url.Values
+	if cookie, err := c.Cookie(oidcLoginCookieName); err == nil {
+		state, _ = url.ParseQuery(cookie.Value)
+	}
+	c.SetCookie(oidcLoginCookie("", -1))
+
+	next := safeRedirect(state.Get("next"))
+	user, err := h.oidc.FinishLogin(ctx,
+		h.users,
+		sec.OIDCLogin{
+			State:    state.Get("state"),
+			Verifier: state.Get("verifier"),
+			Nonce:    state.Get("nonce"),
+		},
+		c.QueryParam("state"),
+		c.QueryParam("code"),
+	)
+	if err != nil {
+		h.audit.Record(ctx, eratov1.AuditEvent_LOGIN, db.User{}, err)
+	}
+	if errors.Is(err, sec.ErrOIDCLogin) {
+		c.Response().WriteHeader(http.StatusUnauthorized)
+		return page.Login(next, "", "Single sign-on failed. Please try again.", h.ssoURL(next)).Render(
+			ctx,
+			c.Response().Writer,
+		)
+	} else if err != nil {
+		return err
+	}
+	return h.startSession(c, user, next)
+}
+
+// startSession logs the user in, returning them to the next page.
+func (h sessionHandler) startSession(c echo.Context, user db.User, next string) error {
+	ctx := c.Request().Context()
+	token, err := h.sessions.Create(ctx, user, c.Request().UserAgent())
+	h.audit.Record(sec.SetAuthenticatedUser(ctx, user), eratov1.AuditEvent_LOGIN, user, err)
+	if err != nil {
+		return err
+	}
+	c.SetCookie(h.sessions.Cookie(token))
+	return c.Redirect(http.StatusSeeOther, next)
+}
+
+// ssoURL returns the URL to log in with the OpenID Connect provider, or an
+// empty string if it is not configured.
+func (h sessionHandler) ssoURL(next string) string {
+	if h.oidc == nil {
+		return ""
+	}
+	if next == "/" {
+		return component.PathLoginOIDC
+	}
+	return component.PathLoginOIDC + "?" + url.Values{component.FormFieldNext: {next}}.Encode()
+}
+
+// oidcLoginCookie returns the cookie holding the state of an OpenID Connect
+// login. It is scoped to the login routes and, like the session cookie, is
+// only sent over HTTPS and is inaccessible to scripts. It must be sent on the
+// top-level navigation back from the provider, so cannot be SameSite=Strict.
+func oidcLoginCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcLoginCookieName,
+		Value:    value,
+		Path:     component.PathLoginOIDC,
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func (h sessionHandler) logout(c echo.Context) error {
+	if cookie, err := c.Cookie(sec.SessionCookieName); err == nil {
+		if err = h.sessions.Revoke(c.Request().Context(), cookie.Value); err != nil {
+			return err
+		}
+	}
+	c.SetCookie(h.sessions.ClearCookie())
+	return c.Redirect(http.StatusSeeOther, component.PathLogin)
+}
+
+func (h sessionHandler) logoutOthers(c echo.Context) error {
+	cookie, err := c.Cookie(sec.SessionCookieName)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	ctx := c.Request().Context()
+	if err = h.sessions.RevokeOthers(ctx, sec.GetAuthenticatedUser(ctx).ID, cookie.Value); err != nil {
+		return err
+	}
+	return c.Redirect(http.StatusSeeOther, "/")
+}
+
+// requireSession rejects requests without a valid login session, sending the
+// user to the login page. The login page and static assets are exempt, as are
+// requests authenticated by the trusted reverse proxy.
+func (h sessionHandler) requireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if isPublicPath(c.Request().URL.Path) {
+			return next(c)
+		}
+
+		ctx := c.Request().Context()
+		if h.proxy != nil {
+			user, ok, err := h.proxy.Authenticate(ctx, c.Request(), h.users)
+			if err != nil {
+				return toHTTPError(err)
+			} else if ok {
+				h.audit.RecordLogin(ctx, h.proxy.Credential(c.Request()), user)
+				c.SetRequest(c.Request().WithContext(sec.SetAuthenticatedUser(ctx, user)))
+				return next(c)
+			}
+		}
+
+		if cookie, err := c.Cookie(sec.SessionCookieName); err == nil {
+			user, err := h.sessions.Authenticate(ctx, cookie.Value)
+			switch {
+			case err == nil:
+				c.SetRequest(c.Request().WithContext(sec.SetAuthenticatedUser(ctx, user)))
+				return next(c)
+			case !errors.Is(err, sec.ErrInvalidSession):
+				return err
+			}
+		}
+		return redirectToLogin(c)
+	}
+}
+
+func isPublicPath(path string) bool {
+	return path == component.PathLogin ||
+		path == component.PathRegister ||
+		path == component.PathLoginOIDC ||
+		path == component.PathLoginOIDCCallback ||
+		path == "/robots.txt" ||
+		strings.HasPrefix(path, "/static/")
+}
+
+// redirectToLogin sends the user to the login page, returning them to the
+// current page after logging in. HTMX requests are fragments of the page the
+// user is on, so HTMX is instructed to redirect the whole page instead.
+func redirectToLogin(c echo.Context) error {
+	next := c.Request().URL.RequestURI()
+	if isHTMX(c) {
+		next = "/"
+		if current, err := url.Parse(c.Request().Header.Get("Hx-Current-Url")); err == nil {
+			next = current.RequestURI()
+		}
+		c.Response().Header().Set("Hx-Redirect", loginURL(next))
+		return c.NoContent(http.StatusUnauthorized)
+	}
+	if c.Request().Method != http.MethodGet {
+		next = "/"
+	}
+	return c.Redirect(http.StatusSeeOther, loginURL(next))
+}
+
+func loginURL(next string) string {
+	if next = safeRedirect(next); next == "/" {
+		return component.PathLogin
+	}
+	return component.PathLogin + "?" + url.Values{component.FormFieldNext: {next}}.Encode()
+}
+
+// safeRedirect ensures next is a path on this site, preventing the login form
+// from being used as an open redirect.
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") ||
+		strings.HasPrefix(next, "//") ||
+		strings.HasPrefix(next, "/\\") ||
+		next == component.PathLogin {
+		return "/"
+	}
+	return next
+}
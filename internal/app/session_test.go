@@ -0,0 +1,286 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/archive"
+	"github.com/stolasapp/erato/internal/config"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/sec/oidctest"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.SetDbFilepath(filepath.Join(t.TempDir(), "db.sqlite"))
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	// the app's URL is needed to configure the provider before the app exists
+	var handler http.Handler
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := oidctest.NewProvider(t, map[string]any{"sub": "sso_user"})
+	oidc, err := sec.NewOIDC(t.Context(), provider.Config(srv.URL+component.PathLoginOIDCCallback), provider.Client())
+	require.NoError(t, err)
+	handler = New(cfg, slog.Default(), store, nil, nil, nil, oidc, nil, eratov1connect.UnimplementedArchiveServiceHandler{})
+
+	const next = "/some/page"
+
+	t.Run("login page links to provider", func(t *testing.T) {
+		t.Parallel()
+		client := newBrowser(t, srv)
+
+		res := get(t, client, srv.URL+component.PathLogin+"?next="+url.QueryEscape(next))
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, string(body), `href="`+component.PathLoginOIDC+"?next="+url.QueryEscape(next)+`"`)
+	})
+
+	t.Run("provisions user and starts session", func(t *testing.T) {
+		t.Parallel()
+		client := newBrowser(t, srv)
+
+		res := get(t, client, srv.URL+component.PathLoginOIDC+"?next="+url.QueryEscape(next))
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Equal(t, next, res.Header.Get("Location"))
+
+		var session *http.Cookie
+		for _, cookie := range client.Jar.Cookies(res.Request.URL) {
+			if cookie.Name == sec.SessionCookieName {
+				session = cookie
+			}
+		}
+		require.NotNil(t, session, "session cookie must be set")
+
+		user, err := store.GetUserByName(t.Context(), "sso_user")
+		require.NoError(t, err)
+		assert.Equal(t, db.RoleMember, user.Role)
+
+		sessions := sec.NewSessions(cfg, store)
+		authd, err := sessions.Authenticate(t.Context(), session.Value)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, authd.ID)
+	})
+
+	t.Run("rejects callback without login", func(t *testing.T) {
+		t.Parallel()
+		client := newBrowser(t, srv)
+
+		res := get(t, client, srv.URL+component.PathLoginOIDCCallback+"?state=forged&code=forged")
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
+
+// newBrowser returns a client for srv that keeps cookies and follows
+// redirects until it is sent away from the login routes.
+func newBrowser(t *testing.T, srv *httptest.Server) *http.Client {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := *srv.Client() // copied, as the client is shared
+	client.Jar = jar
+	client.CheckRedirect = func(req *http.Request, _ []*http.Request) error {
+		if req.URL.Host == srv.Listener.Addr().String() && req.URL.Path != component.PathLoginOIDCCallback {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	return &client
+}
+
+func get(t *testing.T, client *http.Client, target string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, target, http.NoBody)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestProxyAuth(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.SetDbFilepath(filepath.Join(t.TempDir(), "db.sqlite"))
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: "admin", PasswordHash: []byte{}, Role: db.RoleAdmin}))
+
+	proxy, err := sec.NewProxyAuth(eratov1.Config_ProxyAuth_builder{
+		TrustedProxies: []string{"10.0.0.0/8"},
+	}.Build())
+	require.NoError(t, err)
+	srv := New(cfg, slog.Default(), store, nil, nil, proxy, nil, nil,
+		archive.NewUsers(eratov1connect.UnimplementedArchiveServiceHandler{}, store, nil, nil))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		user       string
+		status     int
+	}{
+		{"admin", "10.0.0.1:1234", "admin", http.StatusOK},
+		{"provisioned member", "10.0.0.1:1234", "member", http.StatusForbidden},
+		{"untrusted address", "192.0.2.1:1234", "admin", http.StatusSeeOther},
+		{"invalid username", "10.0.0.1:1234", "not valid", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, component.PathAdmin, http.NoBody)
+			req.RemoteAddr = test.remoteAddr
+			req.Header.Set("Remote-User", test.user)
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+			assert.Equal(t, test.status, rec.Code)
+		})
+	}
+}
+
+func TestLoginThrottle(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.SetDbFilepath(filepath.Join(t.TempDir(), "db.sqlite"))
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	srv := New(cfg, slog.Default(), store,
+		sec.NewThrottle(slog.Default()), sec.NewAuditor(store, slog.Default()), nil, nil, nil,
+		eratov1connect.UnimplementedArchiveServiceHandler{})
+
+	login := func() *httptest.ResponseRecorder {
+		form := url.Values{
+			component.FormFieldUsername: {"nobody"},
+			component.FormFieldPassword: {"wrong"},
+		}
+		req := httptest.NewRequestWithContext(t.Context(),
+			http.MethodPost,
+			component.PathLogin,
+			strings.NewReader(form.Encode()),
+		)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: middleware.DefaultCSRFConfig.CookieName, Value: "csrf"})
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 5 {
+		assert.Equal(t, http.StatusUnauthorized, login().Code)
+	}
+	rec := login()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "Too many failed attempts")
+
+	events, err := store.ListAuditEvents(t.Context(), storage.AuditEventFilter{}, math.MaxInt64, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 6, "throttled attempts are audited too")
+	for _, event := range events {
+		assert.Equal(t, int64(eratov1.AuditEvent_LOGIN), event.Action)
+		assert.Equal(t, int64(eratov1.AuditEvent_FAILURE), event.Outcome)
+		assert.Equal(t, "nobody", event.Subject)
+		assert.Empty(t, event.Actor)
+		assert.Equal(t, "192.0.2.1", event.IpAddress)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.SetDbFilepath(filepath.Join(t.TempDir(), "db.sqlite"))
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	code, hash := sec.NewInviteCode()
+	_, err = store.CreateInvite(t.Context(), db.Invite{
+		CodeHash:   hash,
+		CreateTime: time.Now().UTC(),
+		ExpireTime: time.Now().Add(time.Hour).UTC(),
+	})
+	require.NoError(t, err)
+
+	throttle := sec.NewThrottle(slog.Default())
+	srv := New(cfg, slog.Default(), store, throttle, nil, nil, nil, nil,
+		archive.NewUsers(eratov1connect.UnimplementedArchiveServiceHandler{}, store, throttle, nil))
+
+	register := func(username string) *httptest.ResponseRecorder {
+		form := url.Values{
+			component.FormFieldInviteCode: {code},
+			component.FormFieldUsername:   {username},
+			component.FormFieldPassword:   {"password"},
+		}
+		req := httptest.NewRequestWithContext(t.Context(),
+			http.MethodPost,
+			component.PathRegister,
+			strings.NewReader(form.Encode()),
+		)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: middleware.DefaultCSRFConfig.CookieName, Value: "csrf"})
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := register("invited")
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/", rec.Header().Get("Location"))
+	assert.True(t, slices.ContainsFunc(rec.Header().Values("Set-Cookie"), func(cookie string) bool {
+		return strings.HasPrefix(cookie, sec.SessionCookieName+"=")
+	}), "session cookie must be set")
+
+	user, err := store.GetUserByName(t.Context(), "invited")
+	require.NoError(t, err)
+	assert.Equal(t, db.RoleMember, user.Role)
+
+	rec = register("invited_again")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "already been used")
+
+	// invalid codes lock out the client address like failed logins
+	for range 4 {
+		rec = register("invited_again")
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	}
+	rec = register("invited_again")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "Too many failed attempts")
+}
@@ -0,0 +1,133 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const accessTokensCollection = "/access-tokens/"
+
+// AccessTokens is an [eratov1connect.ArchiveServiceHandler] decorator to handle
+// personal access token operations. Like [Users], this decorator should be
+// attached inside the [Paginator] to ensure ListAccessTokens paginates
+// correctly.
+type AccessTokens struct {
+	eratov1connect.ArchiveServiceHandler
+
+	store storage.AccessTokens
+}
+
+// NewAccessTokens wraps inner and uses the provided store to handle access
+// token operations.
+func NewAccessTokens(inner eratov1connect.ArchiveServiceHandler, store storage.AccessTokens) AccessTokens {
+	return AccessTokens{
+		ArchiveServiceHandler: inner,
+		store:                 store,
+	}
+}
+
+// CreateAccessToken satisfies [eratov1connect.ArchiveServiceHandler].
+func (a AccessTokens) CreateAccessToken(
+	ctx context.Context,
+	req *connect.Request[eratov1.CreateAccessTokenRequest],
+) (*connect.Response[eratov1.AccessToken], error) {
+	// only allowed to create tokens for yourself
+	authd := sec.GetAuthenticatedUser(ctx)
+	if req.Msg.GetParent() != authd.Path() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	token, hash := sec.NewAccessToken()
+	accessToken := db.AccessToken{
+		User:        authd.ID,
+		TokenHash:   hash,
+		DisplayName: req.Msg.GetAccessToken().GetDisplayName(),
+		Scope:       int64(req.Msg.GetAccessToken().GetScope()),
+		CreateTime:  time.Now().UTC(),
+	}
+	id, err := a.store.CreateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	accessToken.ID = id
+
+	res := accessTokenToProto(authd, accessToken)
+	res.SetToken(token)
+	return connect.NewResponse(res), nil
+}
+
+// ListAccessTokens satisfies [eratov1connect.ArchiveServiceHandler].
+func (a AccessTokens) ListAccessTokens(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListAccessTokensRequest],
+) (*connect.Response[eratov1.ListAccessTokensResponse], error) {
+	// only allowed to list your own tokens
+	authd := sec.GetAuthenticatedUser(ctx)
+	if req.Msg.GetParent() != authd.Path() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	tokens, err := a.store.ListAccessTokens(ctx, authd.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	results := make([]*eratov1.AccessToken, len(tokens))
+	for i, token := range tokens {
+		results[i] = accessTokenToProto(authd, token)
+	}
+	return connect.NewResponse(eratov1.ListAccessTokensResponse_builder{
+		Results: results,
+	}.Build()), nil
+}
+
+// DeleteAccessToken satisfies [eratov1connect.ArchiveServiceHandler].
+func (a AccessTokens) DeleteAccessToken(
+	ctx context.Context,
+	req *connect.Request[eratov1.DeleteAccessTokenRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	// only allowed to delete your own tokens
+	authd := sec.GetAuthenticatedUser(ctx)
+	rawID, ok := strings.CutPrefix(req.Msg.GetPath(), authd.Path()+accessTokensCollection)
+	if !ok {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, nil)
+	}
+
+	if err = a.store.DeleteAccessToken(ctx, authd.ID, id); errors.Is(err, storage.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func accessTokenToProto(user db.User, token db.AccessToken) *eratov1.AccessToken {
+	res := eratov1.AccessToken_builder{
+		Path:        user.Path() + accessTokensCollection + strconv.FormatInt(token.ID, 10),
+		DisplayName: token.DisplayName,
+		Scope:       eratov1.AccessToken_Scope(token.Scope), //nolint:gosec // stored from a valid enum
+		CreateTime:  timestamppb.New(token.CreateTime),
+	}.Build()
+	if lastUsed := token.LastUsedTime; lastUsed.Valid {
+		res.SetLastUsedTime(timestamppb.New(lastUsed.Time))
+	}
+	return res
+}
+
+var _ eratov1connect.ArchiveServiceHandler = AccessTokens{}
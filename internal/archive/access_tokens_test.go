@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestAccessTokens(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	other := db.User{ID: 456, Name: "other", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), other))
+
+	paginator, err := NewPaginator(NewAccessTokens(eratov1connect.UnimplementedArchiveServiceHandler{}, store), testTokens)
+	require.NoError(t, err)
+	handler, err := NewValidator(paginator, slog.Default())
+	require.NoError(t, err)
+
+	ctx := sec.SetAuthenticatedUser(t.Context(), user)
+	created, err := handler.CreateAccessToken(ctx, connect.NewRequest(eratov1.CreateAccessTokenRequest_builder{
+		Parent: user.Path(),
+		AccessToken: eratov1.AccessToken_builder{
+			DisplayName: "script",
+			Scope:       eratov1.AccessToken_READ_ONLY,
+		}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	token := created.Msg.GetToken()
+	assert.Regexp(t, `^`+sec.AccessTokenPrefix, token)
+	assert.Regexp(t, `^users/test/access-tokens/\d+$`, created.Msg.GetPath())
+	assert.True(t, created.Msg.HasCreateTime())
+
+	authd, scope, err := sec.AuthenticateAccessToken(ctx, store, token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, authd.ID)
+	assert.Equal(t, eratov1.AccessToken_READ_ONLY, scope)
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := handler.ListAccessTokens(ctx, connect.NewRequest(eratov1.ListAccessTokensRequest_builder{
+			Parent: user.Path(),
+			Filter: `this.display_name == "script"`,
+		}.Build()))
+		require.NoError(t, err)
+		require.Len(t, res.Msg.GetResults(), 1)
+		listed := res.Msg.GetResults()[0]
+		assert.Equal(t, created.Msg.GetPath(), listed.GetPath())
+		assert.Empty(t, listed.GetToken(), "token must not be retrievable")
+		assert.True(t, listed.HasLastUsedTime())
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		t.Parallel()
+		otherCtx := sec.SetAuthenticatedUser(t.Context(), other)
+
+		_, err := handler.ListAccessTokens(otherCtx, connect.NewRequest(eratov1.ListAccessTokensRequest_builder{
+			Parent: user.Path(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.DeleteAccessToken(otherCtx, connect.NewRequest(eratov1.DeleteAccessTokenRequest_builder{
+			Path: created.Msg.GetPath(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.DeleteAccessToken(otherCtx, connect.NewRequest(eratov1.DeleteAccessTokenRequest_builder{
+			Path: strings.Replace(created.Msg.GetPath(), user.Path(), other.Path(), 1),
+		}.Build()))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("scope required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := handler.CreateAccessToken(ctx, connect.NewRequest(eratov1.CreateAccessTokenRequest_builder{
+			Parent:      user.Path(),
+			AccessToken: &eratov1.AccessToken{},
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := handler.CreateAccessToken(ctx, connect.NewRequest(eratov1.CreateAccessTokenRequest_builder{
+			Parent:      user.Path(),
+			AccessToken: eratov1.AccessToken_builder{Scope: eratov1.AccessToken_READ_WRITE}.Build(),
+		}.Build()))
+		require.NoError(t, err)
+
+		req := connect.NewRequest(eratov1.DeleteAccessTokenRequest_builder{
+			Path: res.Msg.GetPath(),
+		}.Build())
+		_, err = handler.DeleteAccessToken(ctx, req)
+		require.NoError(t, err)
+
+		_, _, err = sec.AuthenticateAccessToken(ctx, store, res.Msg.GetToken())
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+		_, err = handler.DeleteAccessToken(ctx, req)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
@@ -8,17 +8,26 @@
 //
 // The chain is constructed innermost-first in [Default]:
 //
-//	Request → Validator → Paginator → Users → Interactivity → Hydrator → Scraper
-//	                                                                        ↓
-//	Response ← Validator ← Paginator ← Users ← Interactivity ← Hydrator ← Scraper
+//	Request → Validator → Exporter → Paginator → AuditEvents → SavedViews → AccessTokens → Users → Interactivity → Hydrator → Listings → Scraper
+//	                                                                                                                                        ↓
+//	Response ← Validator ← Exporter ← Paginator ← AuditEvents ← SavedViews ← AccessTokens ← Users ← Interactivity ← Hydrator ← Listings ← Scraper
 //
 // Each decorator's role:
 //
 //   - Scraper: Fetches and parses content from the upstream archive
+//   - Listings: Lists every upstream page of a category at once, kept for a
+//     while and shared by every user
 //   - Hydrator: Enriches resources with user-specific data (read times, bookmarks)
 //   - Interactivity: Handles resource update operations (star, hide, mark read)
-//   - Users: Implements user CRUD operations
-//   - Paginator: Applies pagination and CEL filtering to list responses
+//   - Users: Implements user CRUD operations and invites, recording them in
+//     the audit log
+//   - AccessTokens: Implements personal access token operations
+//   - SavedViews: Implements users' saved filter and order presets
+//   - AuditEvents: Implements reading the audit log
+//   - Paginator: Applies pagination and CEL filtering to list responses, and
+//     checks the filters of saved views
+//   - Exporter: Exports entries as files, such as EPUBs, streamed as they are
+//     built
 //   - Validator: Validates requests before processing and responses after
 //
 // # Why Order Matters
@@ -26,40 +35,86 @@
 // The Validator must be outermost to reject invalid requests before any
 // processing occurs and to validate responses before they reach clients.
 // The Paginator must wrap the data-providing decorators so it can filter and
-// paginate their results. The Hydrator must run after Scraper so it can enrich
-// the scraped resources with user data.
+// paginate their results, and the Exporter must wrap the Paginator to export
+// an anthology's chapters in reading order. The Hydrator must run after
+// Scraper so it can enrich the scraped resources with user data, and after
+// Listings so the shared listings never hold one user's data.
 package archive
 
 import (
+	"context"
+	"crypto/rand"
+	"fmt"
 	"log/slog"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/pagination"
+	"github.com/stolasapp/erato/internal/sec"
 	"github.com/stolasapp/erato/internal/storage"
 )
 
 // Default returns a fully configured handler with the standard decorator chain.
-// See package documentation for the chain order and rationale.
+// Password hashing is limited by the [sec.Throttle] and user changes are
+// recorded by the [sec.Auditor]. See package documentation for the chain order
+// and rationale.
 func Default(
+	ctx context.Context,
 	cfg *eratov1.Config,
 	logger *slog.Logger,
 	store storage.Store,
+	throttle *sec.Throttle,
+	audit *sec.Auditor,
 ) (
 	handler eratov1connect.ArchiveServiceHandler,
 	err error,
 ) {
-	handler, err = NewScraper(cfg, logger, eratov1connect.UnimplementedArchiveServiceHandler{})
+	tokens, err := pageTokenCodec(ctx, cfg, store)
 	if err != nil {
 		return nil, err
 	}
+	handler, err = NewScraper(cfg, logger, tokens, eratov1connect.UnimplementedArchiveServiceHandler{})
+	if err != nil {
+		return nil, err
+	}
+	handler = NewListings(handler, tokens)
 	handler = NewHydrator(handler, store)
-	handler = NewInteractivity(handler, store)
-	handler = NewUsers(handler, store)
-	if handler, err = NewPaginator(handler); err != nil {
+	handler = NewInteractivity(cfg, handler, store)
+	handler = NewUsers(handler, store, throttle, audit)
+	handler = NewAccessTokens(handler, store)
+	handler = NewSavedViews(handler, store)
+	handler = NewAuditEvents(handler, store, tokens)
+	if handler, err = NewPaginator(handler, tokens); err != nil {
 		return nil, err
 	}
+	handler = NewExporter(handler)
 	if handler, err = NewValidator(handler, logger); err != nil {
 		return nil, err
 	}
 	return handler, nil
 }
+
+// pageTokenSecret is the name of the stored page token key, used when the
+// config does not set one.
+const pageTokenSecret = "page_token_key"
+
+// pageTokenCodec returns the codec signing page tokens with the configured key,
+// or else with a random key generated on first use and stored in store.
+func pageTokenCodec(
+	ctx context.Context,
+	cfg *eratov1.Config,
+	store storage.Secrets,
+) (*pagination.Codec, error) {
+	key := cfg.GetPageTokenKey()
+	if len(key) == 0 {
+		generated := make([]byte, pagination.MinKeySize)
+		if _, err := rand.Read(generated); err != nil {
+			return nil, fmt.Errorf("failed to generate page token key: %w", err)
+		}
+		var err error
+		if key, err = store.GetOrCreateSecret(ctx, pageTokenSecret, generated); err != nil {
+			return nil, fmt.Errorf("failed to load page token key: %w", err)
+		}
+	}
+	return pagination.NewCodec(key, cfg.GetPageTokenTtl().AsDuration())
+}
@@ -0,0 +1,239 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/parser"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/pagination"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const auditEventsCollection = "audit-events/"
+
+// listAuditEventsBatchSize is the number of audit events fetched from the
+// store at a time when listing audit events.
+const listAuditEventsBatchSize = 500
+
+// defaultAuditEventsPageSize is the size of a page of audit events if the
+// request does not set one.
+const defaultAuditEventsPageSize = 50
+
+// AuditEvents is an [eratov1connect.ArchiveServiceHandler] decorator to read
+// the audit log, which only admins may do. Events are recorded by [Users] and
+// the authentication layer via [sec.Auditor]. Like [Users], this decorator
+// should be attached inside the [Paginator] to ensure ListAuditEvents
+// paginates correctly.
+type AuditEvents struct {
+	eratov1connect.ArchiveServiceHandler
+
+	store  storage.AuditEvents
+	tokens *pagination.Codec
+}
+
+// NewAuditEvents wraps inner and uses the provided store to read the audit
+// log, with page tokens signed by tokens.
+func NewAuditEvents(
+	inner eratov1connect.ArchiveServiceHandler,
+	store storage.AuditEvents,
+	tokens *pagination.Codec,
+) AuditEvents {
+	return AuditEvents{
+		ArchiveServiceHandler: inner,
+		store:                 store,
+		tokens:                tokens,
+	}
+}
+
+// ListAuditEvents satisfies [eratov1connect.ArchiveServiceHandler]. A single
+// batch of up to max_page_size events is read from the store, newest first,
+// resuming after the page token. Comparisons of the action and outcome that
+// must hold for the whole filter to match are applied by the store, but the
+// [Paginator] is responsible for applying the rest of the filter and
+// paginating the matching events. The total size is only set if there is no
+// filter.
+func (a AuditEvents) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListAuditEventsRequest],
+) (*connect.Response[eratov1.ListAuditEventsResponse], error) {
+	if !sec.GetAuthenticatedUser(ctx).IsAdmin() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	beforeID := int64(math.MaxInt64)
+	if tkn := req.Msg.GetPageToken(); tkn != "" {
+		token := &eratov1.ListAuditEventsPaginationToken{}
+		if err := a.tokens.FromToken(req.Msg, tkn, token); err != nil {
+			return nil, fieldError(pageTokenField, reasonInvalidPageToken, err)
+		}
+		id, err := auditEventID(token.GetAfterAuditEvent())
+		if err != nil {
+			return nil, fieldError(pageTokenField, reasonInvalidPageToken, err)
+		}
+		beforeID = id
+	}
+	limit := req.Msg.GetMaxPageSize()
+	if limit <= 0 {
+		limit = listAuditEventsBatchSize
+	}
+
+	events, err := a.store.ListAuditEvents(ctx, auditEventFilter(req.Msg.GetFilter()), beforeID, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	res := &eratov1.ListAuditEventsResponse{}
+	results := make([]*eratov1.AuditEvent, len(events))
+	for i, event := range events {
+		results[i] = auditEventToProto(event)
+	}
+	res.SetResults(results)
+	if len(events) == int(limit) {
+		tkn, err := a.tokens.ToToken(req.Msg, eratov1.ListAuditEventsPaginationToken_builder{
+			AfterAuditEvent: results[len(results)-1].GetPath(),
+		}.Build())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		res.SetNextPageToken(tkn)
+	}
+	if req.Msg.GetFilter() == "" {
+		count, err := a.store.CountAuditEvents(ctx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		res.SetTotalSize(int32(min(count, math.MaxInt32))) //nolint:gosec // clamped to the range of int32
+	}
+	return connect.NewResponse(res), nil
+}
+
+// auditEventID returns the ID of the audit event with the given path.
+func auditEventID(path string) (int64, error) {
+	id, ok := strings.CutPrefix(path, auditEventsCollection)
+	if !ok {
+		return 0, errors.New("invalid audit event path")
+	}
+	return strconv.ParseInt(id, 10, 64)
+}
+
+// auditEventFilter returns the conditions of filter the store can apply: the
+// action and outcome compared for equality with an enum value or integer in
+// the top-level conjunction. Other conditions are ignored; the filter is only
+// parsed, so an invalid filter matches anything and is rejected by the
+// [Paginator] instead.
+func auditEventFilter(filter string) storage.AuditEventFilter {
+	var out storage.AuditEventFilter
+	if filter == "" {
+		return out
+	}
+	parsed, errs := parser.Parse(common.NewTextSource(filter))
+	if len(errs.GetErrors()) > 0 {
+		return out
+	}
+	var visit func(expr ast.Expr)
+	visit = func(expr ast.Expr) {
+		if expr.Kind() != ast.CallKind {
+			return
+		}
+		call := expr.AsCall()
+		switch call.FunctionName() {
+		case operators.LogicalAnd:
+			for _, arg := range call.Args() {
+				visit(arg)
+			}
+		case operators.Equals:
+			args := call.Args()
+			field, value := thisField(args[0]), args[1]
+			if field == "" {
+				field, value = thisField(args[1]), args[0]
+			}
+			switch field {
+			case "action":
+				out.Action = enumValue(value, eratov1.AuditEvent_Action_value, "stolasapp.erato.v1.AuditEvent.Action.")
+			case "outcome":
+				out.Outcome = enumValue(value, eratov1.AuditEvent_Outcome_value, "stolasapp.erato.v1.AuditEvent.Outcome.")
+			}
+		}
+	}
+	visit(parsed.Expr())
+	return out
+}
+
+// thisField returns the name of the field of the result selected by expr, or
+// an empty string if expr is not a field selection of the result.
+func thisField(expr ast.Expr) string {
+	if expr.Kind() != ast.SelectKind {
+		return ""
+	}
+	sel := expr.AsSelect()
+	if sel.IsTestOnly() || sel.Operand().Kind() != ast.IdentKind || sel.Operand().AsIdent() != thisVar {
+		return ""
+	}
+	return sel.FieldName()
+}
+
+// enumValue returns the number of the enum value expr names, either as a
+// fully qualified name with the given prefix or as an integer. Zero is
+// returned if expr is neither.
+func enumValue(expr ast.Expr, values map[string]int32, prefix string) int64 {
+	switch expr.Kind() {
+	case ast.LiteralKind:
+		if val, ok := expr.AsLiteral().Value().(int64); ok {
+			return val
+		}
+	case ast.SelectKind:
+		var name []string
+		for expr.Kind() == ast.SelectKind {
+			name = append(name, expr.AsSelect().FieldName())
+			expr = expr.AsSelect().Operand()
+		}
+		if expr.Kind() != ast.IdentKind {
+			return 0
+		}
+		name = append(name, expr.AsIdent())
+		for i, j := 0, len(name)-1; i < j; i, j = i+1, j-1 {
+			name[i], name[j] = name[j], name[i]
+		}
+		if value, ok := strings.CutPrefix(strings.Join(name, "."), prefix); ok {
+			return int64(values[value])
+		}
+	}
+	return 0
+}
+
+func auditEventToProto(event storage.AuditEvent) *eratov1.AuditEvent {
+	bldr := eratov1.AuditEvent_builder{
+		Path:       auditEventsCollection + strconv.FormatInt(event.ID, 10),
+		Actor:      event.Actor,
+		Subject:    event.Subject,
+		Action:     eratov1.AuditEvent_Action(event.Action),   //nolint:gosec // stored from a valid enum
+		Outcome:    eratov1.AuditEvent_Outcome(event.Outcome), //nolint:gosec // stored from a valid enum
+		IpAddress:  event.IpAddress,
+		UserAgent:  event.UserAgent,
+		CreateTime: timestamppb.New(event.CreateTime),
+	}
+	if event.ActorName != "" {
+		bldr.ActorUser = db.User{Name: event.ActorName}.Path()
+	}
+	if event.SubjectName != "" {
+		bldr.SubjectUser = db.User{Name: event.SubjectName}.Path()
+	}
+	if event.Fields != "" {
+		bldr.Fields = strings.Split(event.Fields, ",")
+	}
+	return bldr.Build()
+}
+
+var _ eratov1connect.ArchiveServiceHandler = AuditEvents{}
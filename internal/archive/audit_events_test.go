@@ -0,0 +1,269 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	admin := db.User{ID: 123, Name: "admin", PasswordHash: []byte{}, Role: db.RoleAdmin}
+	require.NoError(t, store.UpsertUser(t.Context(), admin))
+	member := db.User{ID: 456, Name: "member", PasswordHash: []byte{}, Role: db.RoleMember}
+	require.NoError(t, store.UpsertUser(t.Context(), member))
+
+	var handler eratov1connect.ArchiveServiceHandler = eratov1connect.UnimplementedArchiveServiceHandler{}
+	handler = NewUsers(handler, store, nil, sec.NewAuditor(store, slog.Default()))
+	handler = NewAuditEvents(handler, store, testTokens)
+	handler, err = NewPaginator(handler, testTokens)
+	require.NoError(t, err)
+	handler, err = NewValidator(handler, slog.Default())
+	require.NoError(t, err)
+
+	adminCtx := sec.WithClient(sec.SetAuthenticatedUser(t.Context(), admin), "192.0.2.1:1234", "test-agent")
+	memberCtx := sec.SetAuthenticatedUser(t.Context(), member)
+
+	_, err = handler.CreateUser(adminCtx, connect.NewRequest(eratov1.CreateUserRequest_builder{
+		Id:   "created",
+		User: eratov1.User_builder{Password: "password"}.Build(),
+	}.Build()))
+	require.NoError(t, err)
+	_, err = handler.DeleteUser(memberCtx, connect.NewRequest(eratov1.DeleteUserRequest_builder{
+		Path: "users/created",
+	}.Build()))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = handler.DeleteUser(adminCtx, connect.NewRequest(eratov1.DeleteUserRequest_builder{
+		Path: "users/created",
+	}.Build()))
+	require.NoError(t, err)
+	_, err = handler.UpdateUser(adminCtx, connect.NewRequest(eratov1.UpdateUserRequest_builder{
+		Path:       "users/member",
+		User:       eratov1.User_builder{Password: "new password"}.Build(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password", "password"}},
+	}.Build()))
+	require.NoError(t, err)
+	_, err = handler.RenameUser(adminCtx, connect.NewRequest(eratov1.RenameUserRequest_builder{
+		Path:  "users/member",
+		NewId: "renamed",
+	}.Build()))
+	require.NoError(t, err)
+
+	listAll := func(t *testing.T, filter string, size int32) (events []*eratov1.AuditEvent) {
+		t.Helper()
+		req := eratov1.ListAuditEventsRequest_builder{Filter: filter, MaxPageSize: size}.Build()
+		var totals []int32
+		for {
+			res, err := handler.ListAuditEvents(adminCtx, connect.NewRequest(req))
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(res.Msg.GetResults()), int(size))
+			events = append(events, res.Msg.GetResults()...)
+			totals = append(totals, res.Msg.GetTotalSize())
+			if res.Msg.GetNextPageToken() == "" {
+				for _, total := range totals {
+					if filter == "" {
+						assert.Equal(t, int32(len(events)), total, "every page counts every event")
+					} else {
+						assert.Zero(t, total, "matches are not counted")
+					}
+				}
+				return events
+			}
+			req.SetPageToken(res.Msg.GetNextPageToken())
+		}
+	}
+	actions := func(events []*eratov1.AuditEvent) (out []eratov1.AuditEvent_Action) {
+		for _, event := range events {
+			out = append(out, event.GetAction())
+		}
+		return out
+	}
+
+	t.Run("admins list events", func(t *testing.T) {
+		t.Parallel()
+		res, err := handler.ListAuditEvents(adminCtx, connect.NewRequest(&eratov1.ListAuditEventsRequest{}))
+		require.NoError(t, err)
+		events := res.Msg.GetResults()
+		require.Len(t, events, 5)
+		assert.Equal(t, int32(5), res.Msg.GetTotalSize())
+
+		renamed := events[0]
+		assert.Equal(t, eratov1.AuditEvent_RENAME_USER, renamed.GetAction(), "newest first")
+		assert.Equal(t, "member", renamed.GetSubject(), "names are kept as they were")
+		assert.Equal(t, "users/renamed", renamed.GetSubjectUser(), "references follow renames")
+
+		updated := events[1]
+		assert.Equal(t, eratov1.AuditEvent_UPDATE_USER, updated.GetAction())
+		assert.Equal(t, []string{"password"}, updated.GetFields())
+		assert.Equal(t, "users/admin", updated.GetActorUser())
+		assert.Equal(t, "users/renamed", updated.GetSubjectUser())
+
+		assert.Equal(t, eratov1.AuditEvent_DELETE_USER, events[2].GetAction())
+		assert.Equal(t, eratov1.AuditEvent_SUCCESS, events[2].GetOutcome())
+
+		assert.Equal(t, eratov1.AuditEvent_DELETE_USER, events[3].GetAction())
+		assert.Equal(t, eratov1.AuditEvent_FAILURE, events[3].GetOutcome())
+		assert.Equal(t, "member", events[3].GetActor())
+		assert.Equal(t, "users/renamed", events[3].GetActorUser())
+		assert.Empty(t, events[3].GetIpAddress())
+
+		created := events[4]
+		assert.Equal(t, "audit-events/1", created.GetPath())
+		assert.Equal(t, "admin", created.GetActor())
+		assert.Equal(t, "created", created.GetSubject())
+		assert.Empty(t, created.GetSubjectUser(), "the subject was deleted")
+		assert.Empty(t, created.GetFields())
+		assert.Equal(t, eratov1.AuditEvent_CREATE_USER, created.GetAction())
+		assert.Equal(t, eratov1.AuditEvent_SUCCESS, created.GetOutcome())
+		assert.Equal(t, "192.0.2.1", created.GetIpAddress())
+		assert.Equal(t, "test-agent", created.GetUserAgent())
+		assert.True(t, created.HasCreateTime())
+	})
+
+	t.Run("filter and paginate", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			filter   string
+			expected []eratov1.AuditEvent_Action
+		}{
+			{
+				name:   "all",
+				filter: "",
+				expected: []eratov1.AuditEvent_Action{
+					eratov1.AuditEvent_RENAME_USER,
+					eratov1.AuditEvent_UPDATE_USER,
+					eratov1.AuditEvent_DELETE_USER,
+					eratov1.AuditEvent_DELETE_USER,
+					eratov1.AuditEvent_CREATE_USER,
+				},
+			},
+			{
+				name:   "applied by the store",
+				filter: "this.outcome == stolasapp.erato.v1.AuditEvent.Outcome.SUCCESS && this.action == 4",
+				expected: []eratov1.AuditEvent_Action{
+					eratov1.AuditEvent_DELETE_USER,
+				},
+			},
+			{
+				name:   "applied by the paginator",
+				filter: `this.subject == "created"`,
+				expected: []eratov1.AuditEvent_Action{
+					eratov1.AuditEvent_DELETE_USER,
+					eratov1.AuditEvent_DELETE_USER,
+					eratov1.AuditEvent_CREATE_USER,
+				},
+			},
+			{
+				name:   "applied by both",
+				filter: `this.outcome == stolasapp.erato.v1.AuditEvent.Outcome.SUCCESS && this.subject != "member"`,
+				expected: []eratov1.AuditEvent_Action{
+					eratov1.AuditEvent_DELETE_USER,
+					eratov1.AuditEvent_CREATE_USER,
+				},
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+				for _, size := range []int32{1, 2, 100} {
+					assert.Equal(t, test.expected, actions(listAll(t, test.filter, size)), "page size %d", size)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+		_, err := handler.ListAuditEvents(adminCtx, connect.NewRequest(eratov1.ListAuditEventsRequest_builder{
+			Filter: "this.missing == 1",
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("members are denied", func(t *testing.T) {
+		t.Parallel()
+		_, err := handler.ListAuditEvents(memberCtx, connect.NewRequest(&eratov1.ListAuditEventsRequest{}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestAuditEventsDefaultPageSize(t *testing.T) {
+	t.Parallel()
+
+	paginator, err := NewPaginator(auditLog{size: defaultAuditEventsPageSize + 1}, testTokens)
+	require.NoError(t, err)
+	res, err := paginator.ListAuditEvents(t.Context(), connect.NewRequest(&eratov1.ListAuditEventsRequest{}))
+	require.NoError(t, err)
+	assert.Len(t, res.Msg.GetResults(), defaultAuditEventsPageSize)
+	assert.NotEmpty(t, res.Msg.GetNextPageToken())
+}
+
+func TestAuditEventFilter(t *testing.T) {
+	t.Parallel()
+
+	const outcome = "stolasapp.erato.v1.AuditEvent.Outcome."
+	tests := []struct {
+		filter   string
+		expected storage.AuditEventFilter
+	}{
+		{"", storage.AuditEventFilter{}},
+		{"this.action == 4", storage.AuditEventFilter{Action: 4}},
+		{"stolasapp.erato.v1.AuditEvent.Action.LOGIN == this.action", storage.AuditEventFilter{Action: 1}},
+		{
+			"this.action == 2 && (this.outcome == " + outcome + "FAILURE && this.actor == 'admin')",
+			storage.AuditEventFilter{Action: 2, Outcome: 2},
+		},
+		{"this.action == 2 || this.outcome == " + outcome + "FAILURE", storage.AuditEventFilter{}},
+		{"!(this.action == 2)", storage.AuditEventFilter{}},
+		{"this.action != 2", storage.AuditEventFilter{}},
+		{"this.outcome == " + outcome + "UNKNOWN", storage.AuditEventFilter{}},
+		{"this.action == this.outcome", storage.AuditEventFilter{}},
+		{"this.action ==", storage.AuditEventFilter{}},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, auditEventFilter(test.filter))
+		})
+	}
+}
+
+// auditLog serves the given number of audit events in a single batch.
+type auditLog struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	size int
+}
+
+func (a auditLog) ListAuditEvents(
+	context.Context,
+	*connect.Request[eratov1.ListAuditEventsRequest],
+) (*connect.Response[eratov1.ListAuditEventsResponse], error) {
+	events := make([]*eratov1.AuditEvent, a.size)
+	for idx := range events {
+		events[idx] = eratov1.AuditEvent_builder{Path: fmt.Sprintf("audit-events/%d", a.size-idx)}.Build()
+	}
+	return connect.NewResponse(eratov1.ListAuditEventsResponse_builder{Results: events}.Build()), nil
+}
@@ -0,0 +1,37 @@
+package archive
+
+import (
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	"github.com/stolasapp/erato/internal/storage"
+)
+
+// errStaleEtag is returned when an update provides an etag that no longer
+// matches the stored version of the resource.
+var errStaleEtag = errors.New("etag does not match; the resource has been modified")
+
+// formatEtag renders the stored version of a resource or user as an etag.
+func formatEtag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// checkEtag returns an Aborted error if etag is provided and does not match
+// the stored version. An empty etag always matches.
+func checkEtag(etag string, version int64) error {
+	if etag == "" || etag == formatEtag(version) {
+		return nil
+	}
+	return connect.NewError(connect.CodeAborted, errStaleEtag)
+}
+
+// upsertError converts an error from upserting into storage into a connect
+// error, reporting concurrent modifications as Aborted.
+func upsertError(err error) error {
+	if errors.Is(err, storage.ErrConflict) {
+		return connect.NewError(connect.CodeAborted, errStaleEtag)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
@@ -0,0 +1,66 @@
+package archive
+
+import (
+	"bufio"
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	"github.com/stolasapp/erato/internal/export"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+// exportChunkSize is the most data sent in each message of an export stream.
+const exportChunkSize = 64 << 10
+
+// Exporter is an [eratov1connect.ArchiveServiceHandler] decorator to export
+// entries as files, built from the entries and chapters of the wrapped
+// handler. It should be attached outside the [Paginator] so an anthology's
+// chapters are exported in reading order.
+type Exporter struct {
+	eratov1connect.ArchiveServiceHandler
+}
+
+// NewExporter wraps inner, exporting the entries it serves.
+func NewExporter(inner eratov1connect.ArchiveServiceHandler) Exporter {
+	return Exporter{ArchiveServiceHandler: inner}
+}
+
+// ExportEntry satisfies [eratov1connect.ArchiveServiceHandler]. The file is
+// sent in chunks of up to [exportChunkSize] as it is built.
+func (e Exporter) ExportEntry(
+	ctx context.Context,
+	req *connect.Request[eratov1.ExportEntryRequest],
+	stream *connect.ServerStream[httpbody.HttpBody],
+) error {
+	exp, err := export.NewEPUB(ctx, e.ArchiveServiceHandler, req.Msg.GetPath())
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriterSize(&bodyWriter{stream: stream, contentType: export.ContentTypeEPUB}, exportChunkSize)
+	if err = exp.Write(ctx, out); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// bodyWriter sends the data written to it as HttpBody messages, the first of
+// which carries the content type.
+type bodyWriter struct {
+	stream      *connect.ServerStream[httpbody.HttpBody]
+	contentType string
+}
+
+func (b *bodyWriter) Write(data []byte) (int, error) {
+	// Send marshals the message before returning, so data is not retained
+	err := b.stream.Send(&httpbody.HttpBody{ContentType: b.contentType, Data: data})
+	if err != nil {
+		return 0, err
+	}
+	b.contentType = ""
+	return len(data), nil
+}
+
+var _ eratov1connect.ArchiveServiceHandler = Exporter{}
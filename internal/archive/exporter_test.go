@@ -0,0 +1,140 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stolasapp/erato/internal/export"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+func TestExporter(t *testing.T) {
+	t.Parallel()
+
+	const entry = "categories/cat/entries/ant"
+	chapter := func(slug, name string, number int32) *eratov1.Chapter {
+		return eratov1.Chapter_builder{
+			Path:        entry + "/chapters/" + slug,
+			DisplayName: name,
+			Number:      number,
+		}.Build()
+	}
+	inner := stubArchive{
+		entries: []*eratov1.Entry{
+			eratov1.Entry_builder{Path: entry, DisplayName: "Anthology", Kind: eratov1.Entry_ANTHOLOGY}.Build(),
+		},
+		chapters: []*eratov1.Chapter{
+			chapter("chapter-10", "Ten", 10),
+			chapter("chapter-2", "Two", 2),
+			chapter("chapter-1", "One", 1),
+		},
+	}
+	paginator, err := NewPaginator(inner, testTokens)
+	require.NoError(t, err)
+	validator, err := NewValidator(NewExporter(paginator), slog.Default())
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.Handle(eratov1connect.NewArchiveServiceHandler(validator))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := eratov1connect.NewArchiveServiceClient(srv.Client(), srv.URL)
+
+	t.Run("streams an EPUB", func(t *testing.T) {
+		t.Parallel()
+		stream, err := client.ExportEntry(t.Context(), connect.NewRequest(eratov1.ExportEntryRequest_builder{
+			Path:   entry,
+			Format: eratov1.ExportEntryRequest_EPUB,
+		}.Build()))
+		require.NoError(t, err)
+		var (
+			epub         bytes.Buffer
+			contentTypes []string
+		)
+		for stream.Receive() {
+			contentTypes = append(contentTypes, stream.Msg().GetContentType())
+			epub.Write(stream.Msg().GetData())
+		}
+		require.NoError(t, stream.Err())
+		require.NotEmpty(t, contentTypes)
+		assert.Equal(t, export.ContentTypeEPUB, contentTypes[0])
+		for _, contentType := range contentTypes[1:] {
+			assert.Empty(t, contentType)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(epub.Bytes()), int64(epub.Len()))
+		require.NoError(t, err)
+		var chapters []string
+		for _, file := range zr.File {
+			if matched, _ := path.Match("OEBPS/chapter-*.xhtml", file.Name); matched {
+				rc, err := file.Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+				chapters = append(chapters, string(data))
+			}
+		}
+		require.Len(t, chapters, 3)
+		for idx, name := range []string{"One", "Two", "Ten"} {
+			assert.Contains(t, chapters[idx], "<h1>"+name+"</h1>", "chapters are in reading order")
+			assert.Contains(t, chapters[idx], "<p>content of "+name+"</p>")
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		t.Parallel()
+		stream, err := client.ExportEntry(t.Context(), connect.NewRequest(eratov1.ExportEntryRequest_builder{
+			Path: entry,
+		}.Build()))
+		require.NoError(t, err)
+		assert.False(t, stream.Receive())
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("missing entries", func(t *testing.T) {
+		t.Parallel()
+		stream, err := client.ExportEntry(t.Context(), connect.NewRequest(eratov1.ExportEntryRequest_builder{
+			Path:   "categories/cat/entries/missing",
+			Format: eratov1.ExportEntryRequest_EPUB,
+		}.Build()))
+		require.NoError(t, err)
+		assert.False(t, stream.Receive())
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(stream.Err()))
+	})
+}
+
+func (s stubArchive) GetCategory(
+	_ context.Context,
+	req *connect.Request[eratov1.GetCategoryRequest],
+) (*connect.Response[eratov1.Category], error) {
+	return connect.NewResponse(eratov1.Category_builder{
+		Path:        req.Msg.GetPath(),
+		DisplayName: "Category",
+	}.Build()), nil
+}
+
+func (s stubArchive) ReadChapter(
+	_ context.Context,
+	req *connect.Request[eratov1.ReadChapterRequest],
+) (*connect.Response[eratov1.ReadChapterResponse], error) {
+	for _, chapter := range s.chapters {
+		if chapter.GetPath() == req.Msg.GetPath() {
+			return connect.NewResponse(eratov1.ReadChapterResponse_builder{
+				Content: "<p>content of " + chapter.GetDisplayName() + "</p>",
+			}.Build()), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, nil)
+}
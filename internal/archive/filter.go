@@ -0,0 +1,146 @@
+package archive
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/slugconv"
+)
+
+const (
+	filterField  = "filter"
+	orderByField = "order_by"
+	categoryVar  = "category"
+	userVar      = "user"
+
+	// nowVar holds the time now() returns, which is captured once so every
+	// result is filtered as of the same time. Its name is not a valid
+	// identifier, so filters can only reference it through now().
+	nowVar = "@now"
+)
+
+// filterLibrary returns the functions available to every filter, in addition to
+// the standard CEL and protovalidate libraries:
+//
+//   - now() returns the time of the request, such as `this.update_time > now() - days(7)`
+//   - minutes(int), hours(int), days(int) and weeks(int) return durations,
+//     failing if they overflow
+//   - string.lower() and string.upper() change the case of a string
+//   - string.containsFold(string) tests for a substring ignoring case
+//
+// Filters may also reference the authenticated user as a map with its name,
+// path and role (`admin` or `member`), such as `user.name == "alice"`.
+func filterLibrary() []cel.EnvOption {
+	opts := []cel.EnvOption{
+		cel.Variable(userVar, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(nowVar, cel.TimestampType),
+		cel.Macros(cel.GlobalMacro("now", 0,
+			func(eh cel.MacroExprFactory, _ ast.Expr, _ []ast.Expr) (ast.Expr, *common.Error) {
+				return eh.NewIdent(nowVar), nil
+			},
+		)),
+		cel.Function("lower",
+			cel.MemberOverload("string_lower", []*cel.Type{cel.StringType}, cel.StringType,
+				cel.UnaryBinding(stringBinding(strings.ToLower)),
+			),
+		),
+		cel.Function("upper",
+			cel.MemberOverload("string_upper", []*cel.Type{cel.StringType}, cel.StringType,
+				cel.UnaryBinding(stringBinding(strings.ToUpper)),
+			),
+		),
+		cel.Function("containsFold",
+			cel.MemberOverload("string_contains_fold_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+					str, _ := lhs.Value().(string)
+					substr, _ := rhs.Value().(string)
+					return types.Bool(strings.Contains(strings.ToLower(str), strings.ToLower(substr)))
+				}),
+			),
+		),
+	}
+	for name, unit := range map[string]time.Duration{
+		"minutes": time.Minute,
+		"hours":   time.Hour,
+		"days":    24 * time.Hour,
+		"weeks":   7 * 24 * time.Hour,
+	} {
+		opts = append(opts, cel.Function(name,
+			cel.Overload(name+"_int", []*cel.Type{cel.IntType}, cel.DurationType,
+				cel.UnaryBinding(func(val ref.Val) ref.Val {
+					n, _ := val.Value().(int64)
+					if n > int64(math.MaxInt64/unit) || n < int64(math.MinInt64/unit) {
+						return types.NewErr("%s(%d) overflows the range of durations", name, n)
+					}
+					return types.Duration{Duration: time.Duration(n) * unit}
+				}),
+			),
+		))
+	}
+	return opts
+}
+
+func stringBinding(fn func(string) string) func(ref.Val) ref.Val {
+	return func(val ref.Val) ref.Val {
+		str, _ := val.Value().(string)
+		return types.String(fn(str))
+	}
+}
+
+// categoryVariable declares the path of the category the results belong to,
+// such as `category == "categories/adventure"`, for lists with a category or
+// entry parent.
+func categoryVariable() cel.EnvOption {
+	return cel.Variable(categoryVar, cel.StringType)
+}
+
+// filterTimeKey is the context key of the time filters are evaluated at.
+type filterTimeKey struct{}
+
+// withFilterTime sets the time now() returns in filters evaluated with ctx,
+// for requests whose results are filtered a batch at a time.
+func withFilterTime(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, filterTimeKey{}, now)
+}
+
+// filterVars returns the variables of the filter applied to req, other than
+// the results. now() returns the time set by [withFilterTime], if any, or
+// else the current time.
+func filterVars(ctx context.Context, req paginatedRequest) map[string]any {
+	user := sec.GetAuthenticatedUser(ctx)
+	now, ok := ctx.Value(filterTimeKey{}).(time.Time)
+	if !ok {
+		now = time.Now()
+	}
+	vars := map[string]any{
+		userVar: map[string]string{
+			"name": user.Name,
+			"path": user.Path(),
+			"role": user.Role,
+		},
+		nowVar: now,
+	}
+	switch req := req.(type) {
+	case *eratov1.ListEntriesRequest:
+		vars[categoryVar] = req.GetParent()
+	case *eratov1.ListChaptersRequest:
+		vars[categoryVar] = slugconv.EntryParent(req.GetParent())
+	}
+	return vars
+}
+
+// filterError reports err, from compiling or evaluating a filter, as a violation of the
+// request's filter field.
+func filterError(err error) error {
+	return fieldError(filterField, reasonInvalidFilter, err)
+}
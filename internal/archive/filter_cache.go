@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"expvar"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/cel-go/cel"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	// maxFilterPrograms bounds the number of compiled filter programs cached by
+	// a [Paginator].
+	maxFilterPrograms = 256
+
+	// maxFilterCost bounds the runtime cost of evaluating a filter against a
+	// list's results, so expensive filters fail instead of consuming CPU.
+	// Typical filters cost under 10 per result.
+	maxFilterCost = 250_000
+
+	// filterInterruptFrequency is how many comprehension iterations a filter
+	// evaluates between checks for cancellation of the request.
+	filterInterruptFrequency = 100
+)
+
+// filterCacheMetrics counts the filter program cache hits and misses of every
+// [Paginator] in the process. It is published with expvar, which the RPC server
+// serves to admins at [observability.MetricsPath].
+var filterCacheMetrics = expvar.NewMap("erato_filter_cache")
+
+// filterCacheStats describes the usage of a [filterCache].
+type filterCacheStats struct {
+	Hits   uint64 // Filters with a cached program
+	Misses uint64 // Filters that had to be compiled
+	Size   int    // Programs currently cached
+}
+
+type filterProgramKey struct {
+	env    *cel.Env
+	filter string
+}
+
+// filterCache is a bounded, concurrency-safe LRU cache of compiled filter
+// programs by environment and expression. Filters that fail to compile are not
+// cached.
+type filterCache struct {
+	programs *lru.Cache[filterProgramKey, cel.Program]
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+func newFilterCache(size int) (*filterCache, error) {
+	programs, err := lru.New[filterProgramKey, cel.Program](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filter program cache: %w", err)
+	}
+	return &filterCache{programs: programs}, nil
+}
+
+// program returns the compiled filter for env, compiling and caching it if
+// needed. Concurrent misses for the same filter may each compile it.
+func (c *filterCache) program(env *cel.Env, resultsType *cel.Type, filter string) (cel.Program, error) {
+	key := filterProgramKey{env: env, filter: filter}
+	if prog, ok := c.programs.Get(key); ok {
+		c.hits.Add(1)
+		filterCacheMetrics.Add("hits", 1)
+		return prog, nil
+	}
+	c.misses.Add(1)
+	filterCacheMetrics.Add("misses", 1)
+	prog, err := compileFilter(env, resultsType, filter)
+	if err != nil {
+		return nil, err
+	}
+	c.programs.Add(key, prog)
+	return prog, nil
+}
+
+func (c *filterCache) stats() filterCacheStats {
+	return filterCacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   c.programs.Len(),
+	}
+}
@@ -0,0 +1,105 @@
+package archive
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+)
+
+func TestFilterCache(t *testing.T) {
+	t.Parallel()
+
+	paginator, err := NewPaginator(nil, testTokens)
+	require.NoError(t, err)
+	cache, err := newFilterCache(2)
+	require.NoError(t, err)
+
+	first, err := cache.program(paginator.categoriesEnv, categoriesCELType, "true")
+	require.NoError(t, err)
+	second, err := cache.program(paginator.categoriesEnv, categoriesCELType, "true")
+	require.NoError(t, err)
+	assert.Same(t, first, second, "programs are reused")
+	assert.Equal(t, filterCacheStats{Hits: 1, Misses: 1, Size: 1}, cache.stats())
+
+	// the same expression in another environment is compiled separately
+	_, err = cache.program(paginator.usersEnv, usersCELType, "true")
+	require.NoError(t, err)
+	assert.Equal(t, filterCacheStats{Hits: 1, Misses: 2, Size: 2}, cache.stats())
+
+	// invalid filters are not cached
+	_, err = cache.program(paginator.categoriesEnv, categoriesCELType, "this.title")
+	require.Error(t, err)
+	assert.Equal(t, filterCacheStats{Hits: 1, Misses: 3, Size: 2}, cache.stats())
+
+	// the least recently used program is evicted
+	_, err = cache.program(paginator.categoriesEnv, categoriesCELType, "false")
+	require.NoError(t, err)
+	_, err = cache.program(paginator.categoriesEnv, categoriesCELType, "true")
+	require.NoError(t, err)
+	assert.Equal(t, filterCacheStats{Hits: 1, Misses: 5, Size: 2}, cache.stats())
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			_, err := cache.program(paginator.categoriesEnv, categoriesCELType, "true")
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(9), cache.stats().Hits)
+}
+
+func TestFilterCostLimit(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]*eratov1.Entry, 1000)
+	for i := range entries {
+		entries[i] = eratov1.Entry_builder{
+			Path:       fmt.Sprintf("categories/cat/entries/%d", i),
+			UpdateTime: timestamppb.Now(),
+		}.Build()
+	}
+	paginator, err := NewPaginator(&pagedEntries{pages: [][]*eratov1.Entry{entries}}, testTokens)
+	require.NoError(t, err)
+	list := func(filter string) error {
+		_, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent: "categories/cat",
+			Filter: filter,
+		}.Build()))
+		return err
+	}
+
+	require.NoError(t, list(`!has(this.read_time) && this.path.containsFold("/entries/1")`))
+
+	err = list(`results.all(other, other.path != this.path + "/")`)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "cost limit exceeded")
+}
+
+func BenchmarkFilterPrograms(b *testing.B) {
+	paginator, err := NewPaginator(nil, testTokens)
+	require.NoError(b, err)
+	// like the filters sent by the web app
+	filter := fmt.Sprintf("!has(this.read_time) && this.starred && this.kind == %d", eratov1.Entry_STORY.Number())
+
+	b.Run("compiled", func(b *testing.B) {
+		for b.Loop() {
+			_, err := compileFilter(paginator.entriesEnv, entriesCELType, filter)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			_, err := paginator.programs.program(paginator.entriesEnv, entriesCELType, filter)
+			require.NoError(b, err)
+		}
+	})
+}
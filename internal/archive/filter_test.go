@@ -0,0 +1,120 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestFilterLibrary(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	entry := func(slug, name string, age time.Duration, read bool) *eratov1.Entry {
+		bldr := eratov1.Entry_builder{
+			Path:        "categories/cat/entries/" + slug,
+			DisplayName: name,
+			UpdateTime:  timestamppb.New(now.Add(-age)),
+		}
+		if read {
+			bldr.ReadTime = timestamppb.New(now)
+		}
+		return bldr.Build()
+	}
+	paginator, err := NewPaginator(&pagedEntries{pages: [][]*eratov1.Entry{{
+		entry("a", "Dragon Tales", time.Hour, false),
+		entry("b", "The Red Dragon", 30*24*time.Hour, true),
+		entry("c", "the end", 2*24*time.Hour, false),
+		entry("d", "Other", 10*24*time.Hour, false),
+	}}}, testTokens)
+	require.NoError(t, err)
+	ctx := sec.SetAuthenticatedUser(t.Context(), db.User{Name: "alice", Role: db.RoleMember})
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		// the examples documented on ListEntriesRequest.filter
+		{filter: `this.update_time > now() - days(7)`, want: []string{"a", "c"}},
+		{filter: `!has(this.read_time) && this.display_name.containsFold("dragon")`, want: []string{"a"}},
+		{filter: `this.display_name.matches("(?i)^the ")`, want: []string{"b", "c"}},
+
+		{filter: `this.display_name.lower() == "other"`, want: []string{"d"}},
+		{filter: `this.display_name.upper().startsWith("THE")`, want: []string{"b", "c"}},
+		{filter: `this.update_time < now() - weeks(2) - hours(1) - minutes(1)`, want: []string{"b"}},
+		{filter: `category == "categories/cat"`, want: []string{"a", "b", "c", "d"}},
+		{filter: `user.name == "alice" && user.path == "users/alice"`, want: []string{"a", "b", "c", "d"}},
+		{filter: `user.role == "admin"`, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			t.Parallel()
+			res, err := paginator.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent: "categories/cat",
+				Filter: test.filter,
+			}.Build()))
+			require.NoError(t, err)
+			var got []string
+			for _, entry := range res.Msg.GetResults() {
+				got = append(got, entry.GetPath()[len("categories/cat/entries/"):])
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("compile errors are field violations", func(t *testing.T) {
+		t.Parallel()
+		_, err := paginator.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent: "categories/cat",
+			Filter: "this.title == 'x'",
+		}.Build()))
+		violations := fieldViolations(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "filter", violations[0].GetField())
+		assert.Equal(t, reasonInvalidFilter, violations[0].GetReason())
+		assert.Contains(t, violations[0].GetDescription(), "title")
+	})
+
+	t.Run("now is captured once", func(t *testing.T) {
+		t.Parallel()
+		res, err := paginator.ListEntries(withFilterTime(ctx, now.Add(-36*time.Hour)), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent: "categories/cat",
+			Filter: "this.update_time < now()",
+		}.Build()))
+		require.NoError(t, err)
+		assert.Len(t, res.Msg.GetResults(), 3)
+	})
+
+	t.Run("duration overflows are errors", func(t *testing.T) {
+		t.Parallel()
+		for _, filter := range []string{
+			"this.update_time > now() - days(9223372036854775807)",
+			"this.update_time > now() - minutes(-9223372036854775807)",
+			"this.update_time > now() - weeks(15300)",
+		} {
+			_, err := paginator.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent: "categories/cat",
+				Filter: filter,
+			}.Build()))
+			violations := fieldViolations(t, err)
+			require.Len(t, violations, 1, filter)
+			assert.Equal(t, "filter", violations[0].GetField())
+			assert.Contains(t, violations[0].GetDescription(), "overflows", filter)
+		}
+	})
+
+	t.Run("category is not available to categories", func(t *testing.T) {
+		t.Parallel()
+		_, err := compileFilter(paginator.categoriesEnv, categoriesCELType, `category == ""`)
+		require.Error(t, err)
+	})
+}
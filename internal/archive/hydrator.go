@@ -41,7 +41,7 @@ func (h Hydrator) ListCategories(
 		req,
 		h.store,
 		h.ArchiveServiceHandler.ListCategories,
-		h.hydrateCategory,
+		hydrateCategory,
 	)
 }
 
@@ -55,7 +55,7 @@ func (h Hydrator) GetCategory(
 		req,
 		h.store,
 		h.ArchiveServiceHandler.GetCategory,
-		h.hydrateCategory,
+		hydrateCategory,
 	)
 }
 
@@ -64,13 +64,20 @@ func (h Hydrator) ListEntries(
 	ctx context.Context,
 	req *connect.Request[eratov1.ListEntriesRequest],
 ) (*connect.Response[eratov1.ListEntriesResponse], error) {
-	return hydrateList(
+	res, err := hydrateList(
 		ctx,
 		req,
 		h.store,
 		h.ArchiveServiceHandler.ListEntries,
-		h.hydrateEntry,
+		hydrateEntry,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.hydrateProgress(ctx, res.Msg.GetResults()...); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // GetEntry satisfies [eratov1connect.ArchiveServiceHandler].
@@ -78,13 +85,26 @@ func (h Hydrator) GetEntry(
 	ctx context.Context,
 	req *connect.Request[eratov1.GetEntryRequest],
 ) (res *connect.Response[eratov1.Entry], err error) {
-	return hydrateResource(
+	res, err = hydrateResource(
 		ctx,
 		req,
 		h.store,
 		h.ArchiveServiceHandler.GetEntry,
-		h.hydrateEntry,
+		hydrateEntry,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if count := res.Msg.GetChapterCount(); count > 0 {
+		// the archive counted the chapters while loading the anthology
+		if err = h.recordChapterCount(ctx, res.Msg.GetPath(), int(count)); err != nil {
+			return nil, err
+		}
+	}
+	if err = h.hydrateProgress(ctx, res.Msg); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // ListChapters satisfies [eratov1connect.ArchiveServiceHandler].
@@ -92,13 +112,20 @@ func (h Hydrator) ListChapters(
 	ctx context.Context,
 	req *connect.Request[eratov1.ListChaptersRequest],
 ) (*connect.Response[eratov1.ListChaptersResponse], error) {
-	return hydrateList(
+	res, err := hydrateList(
 		ctx,
 		req,
 		h.store,
 		h.ArchiveServiceHandler.ListChapters,
-		h.hydrateChapter,
+		hydrateChapter,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.recordChapterCount(ctx, req.Msg.GetParent(), len(res.Msg.GetResults())); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // GetChapter satisfies [eratov1connect.ArchiveServiceHandler].
@@ -111,15 +138,57 @@ func (h Hydrator) GetChapter(
 		req,
 		h.store,
 		h.ArchiveServiceHandler.GetChapter,
-		h.hydrateChapter,
+		hydrateChapter,
 	)
 }
 
-func (h Hydrator) hydrateCategory(category *eratov1.Category, resource *db.Resource) {
+// hydrateProgress populates the chapter counts of the anthologies in entries
+// from storage, along with how many of them the user has read. Anthologies
+// whose chapters have never been counted are left without progress.
+func (h Hydrator) hydrateProgress(ctx context.Context, entries ...*eratov1.Entry) error {
+	var paths []string
+	for _, entry := range entries {
+		if entry.GetKind() == eratov1.Entry_ANTHOLOGY {
+			paths = append(paths, entry.GetPath())
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	progress, err := h.store.GetProgress(ctx, sec.GetAuthenticatedUser(ctx).ID, paths...)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	for _, entry := range entries {
+		prog, ok := progress[entry.GetPath()]
+		if !ok || entry.GetKind() != eratov1.Entry_ANTHOLOGY {
+			continue
+		}
+		entry.SetChapterCount(int32(prog.Chapters))                     //nolint:gosec // bounded by the number of chapters
+		entry.SetReadChapterCount(int32(min(prog.Read, prog.Chapters))) //nolint:gosec // bounded by the number of chapters
+		entry.SetUnreadChapterCount(entry.GetChapterCount() - entry.GetReadChapterCount())
+	}
+	return nil
+}
+
+// recordChapterCount stores the number of chapters in the entry so that the
+// progress of every user can be computed without listing its chapters. The
+// count is kept apart from the user's data, so recording it does not change
+// the entry's etag.
+func (h Hydrator) recordChapterCount(ctx context.Context, entryPath string, count int) error {
+	if err := h.store.SetChapterCount(ctx, entryPath, int64(count)); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+func hydrateCategory(category *eratov1.Category, resource *db.Resource) {
 	category.SetHidden(resource.Hidden)
+	category.SetEtag(formatEtag(resource.Version))
 }
 
-func (h Hydrator) hydrateEntry(entry *eratov1.Entry, resource *db.Resource) {
+func hydrateEntry(entry *eratov1.Entry, resource *db.Resource) {
+	entry.SetEtag(formatEtag(resource.Version))
 	entry.SetStarred(resource.Starred)
 	entry.SetHidden(resource.Hidden)
 	if viewTime := resource.ViewTime; viewTime.Valid {
@@ -130,7 +199,8 @@ func (h Hydrator) hydrateEntry(entry *eratov1.Entry, resource *db.Resource) {
 	}
 }
 
-func (h Hydrator) hydrateChapter(chapter *eratov1.Chapter, resource *db.Resource) {
+func hydrateChapter(chapter *eratov1.Chapter, resource *db.Resource) {
+	chapter.SetEtag(formatEtag(resource.Version))
 	if viewTime := resource.ViewTime; viewTime.Valid {
 		chapter.SetViewTime(timestamppb.New(viewTime.Time))
 	}
@@ -164,22 +234,23 @@ func hydrateList[
 	var msg ResP = res.Msg
 	results := msg.GetResults()
 	paths := make([]string, len(results))
-	lookup := make(map[string]Item, len(results))
 	for i, result := range results {
-		path := result.GetPath()
-		paths[i] = path
-		lookup[path] = result
+		paths[i] = result.GetPath()
 	}
 
 	userData, err := store.ListResources(ctx, sec.GetAuthenticatedUser(ctx).ID, paths...)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	lookup := make(map[string]*db.Resource, len(userData))
 	for i := range userData {
-		data := &userData[i]
-		result, ok := lookup[data.Path]
+		lookup[userData[i].Path] = &userData[i]
+	}
+	for _, result := range results {
+		data, ok := lookup[result.GetPath()]
 		if !ok {
-			continue
+			// user hasn't interacted with this resource yet
+			data = &db.Resource{}
 		}
 		hydrate(result, data)
 	}
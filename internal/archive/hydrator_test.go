@@ -0,0 +1,137 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestHydratorProgress(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+	const (
+		parent    = "categories/progress"
+		anthology = parent + "/entries/anthology"
+		counted   = parent + "/entries/counted"
+		story     = parent + "/entries/story"
+	)
+	upstream := stubArchive{
+		entries: []*eratov1.Entry{
+			eratov1.Entry_builder{Path: anthology, Kind: eratov1.Entry_ANTHOLOGY}.Build(),
+			eratov1.Entry_builder{Path: counted, Kind: eratov1.Entry_ANTHOLOGY, ChapterCount: 5}.Build(),
+			eratov1.Entry_builder{Path: story, Kind: eratov1.Entry_STORY}.Build(),
+		},
+		chapters: []*eratov1.Chapter{
+			eratov1.Chapter_builder{Path: anthology + "/chapters/one"}.Build(),
+			eratov1.Chapter_builder{Path: anthology + "/chapters/two"}.Build(),
+			eratov1.Chapter_builder{Path: anthology + "/chapters/three"}.Build(),
+		},
+	}
+	handler, err := NewPaginator(NewHydrator(upstream, store), testTokens)
+	require.NoError(t, err)
+	getEntry := func(path string) *eratov1.Entry {
+		res, err := handler.GetEntry(ctx, connect.NewRequest(eratov1.GetEntryRequest_builder{
+			Path: path,
+		}.Build()))
+		require.NoError(t, err)
+		return res.Msg
+	}
+
+	require.NoError(t, store.UpsertResource(ctx, db.Resource{User: user.ID, Path: anthology, Starred: true}))
+	etag := getEntry(anthology).GetEtag()
+	_, err = handler.ListChapters(ctx, connect.NewRequest(eratov1.ListChaptersRequest_builder{
+		Parent: anthology,
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, etag, getEntry(anthology).GetEtag(), "listing chapters does not change the entry")
+	require.NoError(t, store.UpsertResource(ctx, db.Resource{
+		User:     user.ID,
+		Path:     upstream.chapters[0].GetPath(),
+		ReadTime: sql.NullTime{Valid: true, Time: time.Now()},
+	}))
+
+	entry := getEntry(anthology)
+	assert.EqualValues(t, 3, entry.GetChapterCount())
+	assert.EqualValues(t, 1, entry.GetReadChapterCount())
+	assert.EqualValues(t, 2, entry.GetUnreadChapterCount())
+
+	res, err := handler.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+		Parent: parent,
+		Filter: "this.unread_chapter_count > 0",
+	}.Build()))
+	require.NoError(t, err)
+	require.Len(t, res.Msg.GetResults(), 1, "the other anthology's chapters have not been counted")
+	assert.Equal(t, anthology, res.Msg.GetResults()[0].GetPath())
+
+	// loading an anthology records the number of chapters the archive counted
+	assert.EqualValues(t, 5, getEntry(counted).GetUnreadChapterCount())
+	res, err = handler.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+		Parent: parent,
+		Filter: "this.unread_chapter_count > 0",
+	}.Build()))
+	require.NoError(t, err)
+	assert.Len(t, res.Msg.GetResults(), 2)
+}
+
+// stubArchive serves a fixed set of entries and chapters in place of the
+// upstream archive.
+type stubArchive struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	entries  []*eratov1.Entry
+	chapters []*eratov1.Chapter
+}
+
+func (s stubArchive) ListEntries(
+	context.Context,
+	*connect.Request[eratov1.ListEntriesRequest],
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	return connect.NewResponse(eratov1.ListEntriesResponse_builder{
+		Results: s.entries,
+	}.Build()), nil
+}
+
+func (s stubArchive) GetEntry(
+	_ context.Context,
+	req *connect.Request[eratov1.GetEntryRequest],
+) (*connect.Response[eratov1.Entry], error) {
+	for _, entry := range s.entries {
+		if entry.GetPath() == req.Msg.GetPath() {
+			return connect.NewResponse(entry), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, nil)
+}
+
+func (s stubArchive) ListChapters(
+	context.Context,
+	*connect.Request[eratov1.ListChaptersRequest],
+) (*connect.Response[eratov1.ListChaptersResponse], error) {
+	return connect.NewResponse(eratov1.ListChaptersResponse_builder{
+		Results: s.chapters,
+	}.Build()), nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/proto"
@@ -13,6 +15,7 @@ import (
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
 	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/slugconv"
 	"github.com/stolasapp/erato/internal/storage"
 	"github.com/stolasapp/erato/internal/storage/db"
 )
@@ -22,15 +25,21 @@ import (
 type Interactivity struct {
 	eratov1connect.ArchiveServiceHandler
 
-	store storage.Resources
+	store               storage.Resources
+	autoReadAnthologies bool
 }
 
 // NewInteractivity wraps inner and updates resource information in the provided
 // store.
-func NewInteractivity(inner eratov1connect.ArchiveServiceHandler, store storage.Resources) Interactivity {
+func NewInteractivity(
+	cfg *eratov1.Config,
+	inner eratov1connect.ArchiveServiceHandler,
+	store storage.Resources,
+) Interactivity {
 	return Interactivity{
 		ArchiveServiceHandler: inner,
 		store:                 store,
+		autoReadAnthologies:   cfg.GetAutoReadAnthologies(),
 	}
 }
 
@@ -44,17 +53,7 @@ func (i Interactivity) UpdateCategory(
 		i.store,
 		req,
 		(*eratov1.UpdateCategoryRequest).GetCategory,
-		func(resource *db.Resource, category *eratov1.Category, mask *fieldmaskpb.FieldMask) error {
-			for _, path := range mask.GetPaths() {
-				switch strings.ToLower(path) {
-				case "hidden":
-					resource.Hidden = category.GetHidden()
-				default:
-					return connect.NewError(connect.CodeInvalidArgument, nil)
-				}
-			}
-			return nil
-		},
+		updateCategory,
 	); err != nil {
 		return nil, err
 	}
@@ -74,29 +73,7 @@ func (i Interactivity) UpdateEntry(
 		i.store,
 		req,
 		(*eratov1.UpdateEntryRequest).GetEntry,
-		func(resource *db.Resource, entry *eratov1.Entry, mask *fieldmaskpb.FieldMask) error {
-			for _, path := range mask.GetPaths() {
-				switch strings.ToLower(path) {
-				case "hidden":
-					resource.Hidden = entry.GetHidden()
-				case "starred":
-					resource.Starred = entry.GetStarred()
-				case "view_time":
-					resource.ViewTime = sql.NullTime{
-						Valid: entry.HasViewTime(),
-						Time:  entry.GetViewTime().AsTime(),
-					}
-				case "read_time":
-					resource.ReadTime = sql.NullTime{
-						Valid: entry.HasReadTime(),
-						Time:  entry.GetReadTime().AsTime(),
-					}
-				default:
-					return connect.NewError(connect.CodeInvalidArgument, nil)
-				}
-			}
-			return nil
-		},
+		updateEntry,
 	); err != nil {
 		return nil, err
 	}
@@ -106,6 +83,49 @@ func (i Interactivity) UpdateEntry(
 	}.Build()))
 }
 
+// BatchUpdateEntries satisfies [eratov1connect.ArchiveServiceHandler].
+func (i Interactivity) BatchUpdateEntries(
+	ctx context.Context,
+	req *connect.Request[eratov1.BatchUpdateEntriesRequest],
+) (*connect.Response[eratov1.BatchUpdateEntriesResponse], error) {
+	resources, err := batchUpdateResources(
+		ctx,
+		i.store,
+		req.Msg.GetParent(),
+		slugconv.EntryParent,
+		func(ctx context.Context) ([]string, bool, error) {
+			// only a listing of the category that is already kept is checked,
+			// as listing it again may load every upstream page
+			res, err := i.ListEntries(withKeptListing(ctx), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent: req.Msg.GetParent(),
+			}.Build()))
+			if err != nil {
+				return nil, false, err
+			}
+			return resourcePaths(res.Msg.GetResults()), !res.Msg.GetTotalSizeEstimated(), nil
+		},
+		func(ctx context.Context, path string) error {
+			_, err := i.GetEntry(ctx, connect.NewRequest(eratov1.GetEntryRequest_builder{Path: path}.Build()))
+			return err
+		},
+		req.Msg.GetRequests(),
+		(*eratov1.UpdateEntryRequest).GetEntry,
+		updateEntry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*eratov1.Entry, len(resources))
+	for idx := range resources {
+		results[idx] = eratov1.Entry_builder{Path: resources[idx].Path}.Build()
+		hydrateEntry(results[idx], &resources[idx])
+	}
+	return connect.NewResponse(eratov1.BatchUpdateEntriesResponse_builder{
+		Results: results,
+	}.Build()), nil
+}
+
 // UpdateChapter satisfies [eratov1connect.ArchiveServiceHandler].
 func (i Interactivity) UpdateChapter(
 	ctx context.Context,
@@ -116,27 +136,11 @@ func (i Interactivity) UpdateChapter(
 		i.store,
 		req,
 		(*eratov1.UpdateChapterRequest).GetChapter,
-		func(resource *db.Resource, chapter *eratov1.Chapter, mask *fieldmaskpb.FieldMask) error {
-			for _, path := range mask.GetPaths() {
-				switch strings.ToLower(path) {
-				case "view_time":
-					resource.ViewTime = sql.NullTime{
-						Valid: chapter.HasViewTime(),
-						Time:  chapter.GetViewTime().AsTime(),
-					}
-				case "read_time":
-					resource.ReadTime = sql.NullTime{
-						Valid: chapter.HasReadTime(),
-						Time:  chapter.GetReadTime().AsTime(),
-					}
-				default:
-					return connect.NewError(connect.CodeInvalidArgument, nil)
-				}
-			}
-			return nil
-		},
+		updateChapter,
 	); err != nil {
 		return nil, err
+	} else if err = i.completeAnthology(ctx, slugconv.ChapterParent(req.Msg.GetPath())); err != nil {
+		return nil, err
 	}
 
 	return i.GetChapter(ctx, connect.NewRequest(eratov1.GetChapterRequest_builder{
@@ -144,9 +148,150 @@ func (i Interactivity) UpdateChapter(
 	}.Build()))
 }
 
+// BatchUpdateChapters satisfies [eratov1connect.ArchiveServiceHandler].
+func (i Interactivity) BatchUpdateChapters(
+	ctx context.Context,
+	req *connect.Request[eratov1.BatchUpdateChaptersRequest],
+) (*connect.Response[eratov1.BatchUpdateChaptersResponse], error) {
+	resources, err := batchUpdateResources(
+		ctx,
+		i.store,
+		req.Msg.GetParent(),
+		slugconv.ChapterParent,
+		func(ctx context.Context) ([]string, bool, error) {
+			res, err := i.ListChapters(ctx, connect.NewRequest(eratov1.ListChaptersRequest_builder{
+				Parent: req.Msg.GetParent(),
+			}.Build()))
+			if err != nil {
+				return nil, false, err
+			}
+			return resourcePaths(res.Msg.GetResults()), true, nil
+		},
+		func(ctx context.Context, path string) error {
+			_, err := i.GetChapter(ctx, connect.NewRequest(eratov1.GetChapterRequest_builder{Path: path}.Build()))
+			return err
+		},
+		req.Msg.GetRequests(),
+		(*eratov1.UpdateChapterRequest).GetChapter,
+		updateChapter,
+	)
+	if err != nil {
+		return nil, err
+	} else if err = i.completeAnthology(ctx, req.Msg.GetParent()); err != nil {
+		return nil, err
+	}
+
+	results := make([]*eratov1.Chapter, len(resources))
+	for idx := range resources {
+		results[idx] = eratov1.Chapter_builder{
+			Path:   resources[idx].Path,
+			Number: slugconv.ToChapterNumber(resources[idx].Path),
+		}.Build()
+		hydrateChapter(results[idx], &resources[idx])
+	}
+	return connect.NewResponse(eratov1.BatchUpdateChaptersResponse_builder{
+		Results: results,
+	}.Build()), nil
+}
+
+// completeAnthology marks the entry as read if auto-read is enabled and the
+// user has read every one of its chapters. Entries already marked as read or
+// whose chapter count is unknown are left untouched.
+func (i Interactivity) completeAnthology(ctx context.Context, entryPath string) error {
+	if !i.autoReadAnthologies {
+		return nil
+	}
+	user := sec.GetAuthenticatedUser(ctx)
+	progress, err := i.store.GetProgress(ctx, user.ID, entryPath)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	} else if prog, ok := progress[entryPath]; !ok || prog.Chapters == 0 || prog.Read < prog.Chapters {
+		return nil
+	}
+
+	entry, err := i.store.GetResource(ctx, user.ID, entryPath)
+	if errors.Is(err, storage.ErrNotFound) {
+		// user hasn't interacted with the entry itself yet
+		entry = db.Resource{
+			User: user.ID,
+			Path: entryPath,
+		}
+	} else if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	} else if entry.ReadTime.Valid {
+		return nil
+	}
+
+	entry.ReadTime = sql.NullTime{Valid: true, Time: time.Now()}
+	if err = i.store.UpsertResource(ctx, entry); errors.Is(err, storage.ErrConflict) {
+		// the entry was modified concurrently; it will be re-evaluated on the
+		// next chapter update
+		return nil
+	} else if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+func updateCategory(resource *db.Resource, category *eratov1.Category, mask *fieldmaskpb.FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		switch strings.ToLower(path) {
+		case "hidden":
+			resource.Hidden = category.GetHidden()
+		default:
+			return unknownMaskPath(path)
+		}
+	}
+	return nil
+}
+
+func updateEntry(resource *db.Resource, entry *eratov1.Entry, mask *fieldmaskpb.FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		switch strings.ToLower(path) {
+		case "hidden":
+			resource.Hidden = entry.GetHidden()
+		case "starred":
+			resource.Starred = entry.GetStarred()
+		case "view_time":
+			resource.ViewTime = sql.NullTime{
+				Valid: entry.HasViewTime(),
+				Time:  entry.GetViewTime().AsTime(),
+			}
+		case "read_time":
+			resource.ReadTime = sql.NullTime{
+				Valid: entry.HasReadTime(),
+				Time:  entry.GetReadTime().AsTime(),
+			}
+		default:
+			return unknownMaskPath(path)
+		}
+	}
+	return nil
+}
+
+func updateChapter(resource *db.Resource, chapter *eratov1.Chapter, mask *fieldmaskpb.FieldMask) error {
+	for _, path := range mask.GetPaths() {
+		switch strings.ToLower(path) {
+		case "view_time":
+			resource.ViewTime = sql.NullTime{
+				Valid: chapter.HasViewTime(),
+				Time:  chapter.GetViewTime().AsTime(),
+			}
+		case "read_time":
+			resource.ReadTime = sql.NullTime{
+				Valid: chapter.HasReadTime(),
+				Time:  chapter.GetReadTime().AsTime(),
+			}
+		default:
+			return unknownMaskPath(path)
+		}
+	}
+	return nil
+}
+
 func updateResource[
 	Req any,
-	Res proto.Message,
+	Res etagged,
 	ReqP interface {
 		*Req
 		GetPath() string
@@ -173,20 +318,152 @@ func updateResource[
 	}
 
 	resource := get(msg)
-	mask := msg.GetUpdateMask()
-	if !mask.IsValid(resource) {
-		return connect.NewError(connect.CodeInvalidArgument, nil)
-	} else if len(mask.GetPaths()) == 0 {
-		return connect.NewError(connect.CodeInvalidArgument, nil)
-	}
-
-	if err = update(&dbRes, resource, mask); err != nil {
+	if err = checkEtag(resource.GetEtag(), dbRes.Version); err != nil {
+		return err
+	} else if err = applyUpdate(&dbRes, resource, msg.GetUpdateMask(), update); err != nil {
 		return err
 	} else if err = store.UpsertResource(ctx, dbRes); err != nil {
-		return connect.NewError(connect.CodeInternal, err)
+		return upsertError(err)
 	}
 
 	return nil
 }
 
+// batchUpdateResources applies each of the update requests to the user's
+// stored data and persists them together, returning the updated resources in
+// request order. Every request path must resolve to parent via parentOf and
+// exist according to [checkExist], and any etags must match the resource as
+// updated by the preceding requests.
+func batchUpdateResources[
+	Req interface {
+		GetPath() string
+		GetUpdateMask() *fieldmaskpb.FieldMask
+	},
+	Res etagged,
+](
+	ctx context.Context,
+	store storage.Resources,
+	parent string,
+	parentOf func(string) string,
+	list func(context.Context) (paths []string, complete bool, err error),
+	get func(context.Context, string) error,
+	reqs []Req,
+	resourceOf func(Req) Res,
+	update func(*db.Resource, Res, *fieldmaskpb.FieldMask) error,
+) ([]db.Resource, error) {
+	user := sec.GetAuthenticatedUser(ctx)
+	paths := make([]string, len(reqs))
+	for idx, req := range reqs {
+		paths[idx] = req.GetPath()
+		if parentOf(paths[idx]) != parent {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("%s is not a child of %s", paths[idx], parent))
+		}
+	}
+
+	existing, err := store.ListResources(ctx, user.ID, paths...)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	lookup := make(map[string]db.Resource, len(reqs))
+	for _, dbRes := range existing {
+		lookup[dbRes.Path] = dbRes
+	}
+
+	// requests are applied in order, so repeated paths see prior updates
+	resources := make([]db.Resource, len(reqs))
+	for idx, req := range reqs {
+		dbRes, ok := lookup[paths[idx]]
+		if !ok {
+			// user hasn't interacted with this resource yet
+			dbRes = db.Resource{
+				User: user.ID,
+				Path: paths[idx],
+			}
+		}
+		resource := resourceOf(req)
+		if err = checkEtag(resource.GetEtag(), dbRes.Version); err != nil {
+			return nil, err
+		} else if err = applyUpdate(&dbRes, resource, req.GetUpdateMask(), update); err != nil {
+			return nil, err
+		}
+		resources[idx] = dbRes
+		dbRes.Version++ // the version after this update is persisted
+		lookup[dbRes.Path] = dbRes
+	}
+
+	if err = checkExist(ctx, list, get, paths); err != nil {
+		return nil, err
+	} else if err = store.BatchUpsertResources(ctx, resources...); err != nil {
+		return nil, upsertError(err)
+	}
+	for idx := range resources {
+		resources[idx].Version++
+	}
+	return resources, nil
+}
+
+// checkExist verifies that each of paths is listed by its parent, so a batch
+// cannot store data for resources that are not in the archive. The parent is
+// listed once with list. If the listing is not complete, the paths missing
+// from it are looked up with get instead.
+func checkExist(
+	ctx context.Context,
+	list func(context.Context) (paths []string, complete bool, err error),
+	get func(context.Context, string) error,
+	paths []string,
+) error {
+	listed, complete, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(listed))
+	for _, path := range listed {
+		exists[path] = true
+	}
+	for _, path := range paths {
+		if exists[path] {
+			continue
+		}
+		if !complete {
+			err = get(ctx, path)
+			if err == nil {
+				exists[path] = true
+				continue
+			} else if connect.CodeOf(err) != connect.CodeNotFound {
+				return err
+			}
+		}
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("%s does not exist", path))
+	}
+	return nil
+}
+
+// resourcePaths returns the paths of resources.
+func resourcePaths[Res interface{ GetPath() string }](resources []Res) []string {
+	paths := make([]string, len(resources))
+	for idx, resource := range resources {
+		paths[idx] = resource.GetPath()
+	}
+	return paths
+}
+
+// etagged is an updatable resource message supporting optimistic concurrency.
+type etagged interface {
+	proto.Message
+	GetEtag() string
+}
+
+func applyUpdate[Res proto.Message](
+	dbRes *db.Resource,
+	resource Res,
+	mask *fieldmaskpb.FieldMask,
+	update func(*db.Resource, Res, *fieldmaskpb.FieldMask) error,
+) error {
+	if err := checkUpdateMask(mask, resource); err != nil {
+		return err
+	}
+	return update(dbRes, resource, mask)
+}
+
 var _ eratov1connect.ArchiveServiceHandler = Interactivity{}
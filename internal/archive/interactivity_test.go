@@ -0,0 +1,405 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestInteractivityBatchUpdate(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+
+	upstream := stubArchive{
+		entries: []*eratov1.Entry{
+			eratov1.Entry_builder{Path: "categories/batch/entries/one"}.Build(),
+			eratov1.Entry_builder{Path: "categories/batch/entries/two"}.Build(),
+		},
+		chapters: []*eratov1.Chapter{
+			eratov1.Chapter_builder{Path: "categories/batch/entries/anthology/chapters/one"}.Build(),
+		},
+	}
+	handler, err := NewValidator(NewInteractivity(cfg, upstream, store), slog.Default())
+	require.NoError(t, err)
+
+	readMask := &fieldmaskpb.FieldMask{Paths: []string{"read_time"}}
+	readTime := timestamppb.Now()
+
+	t.Run("entries", func(t *testing.T) {
+		t.Parallel()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+		const parent = "categories/batch"
+		paths := []string{parent + "/entries/one", parent + "/entries/two"}
+		require.NoError(t, store.UpsertResource(ctx, db.Resource{
+			User:    user.ID,
+			Path:    paths[0],
+			Starred: true,
+		}))
+
+		requests := make([]*eratov1.UpdateEntryRequest, len(paths))
+		for idx, path := range paths {
+			requests[idx] = eratov1.UpdateEntryRequest_builder{
+				Path:       path,
+				Entry:      eratov1.Entry_builder{ReadTime: readTime}.Build(),
+				UpdateMask: readMask,
+			}.Build()
+		}
+		res, err := handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+			Parent:   parent,
+			Requests: requests,
+		}.Build()))
+		require.NoError(t, err)
+
+		results := res.Msg.GetResults()
+		require.Len(t, results, len(paths))
+		for idx, result := range results {
+			assert.Equal(t, paths[idx], result.GetPath())
+			assert.True(t, result.HasReadTime())
+		}
+		assert.True(t, results[0].GetStarred(), "existing data should be preserved")
+
+		stored, err := store.ListResources(ctx, user.ID, paths...)
+		require.NoError(t, err)
+		require.Len(t, stored, len(paths))
+		for _, resource := range stored {
+			assert.True(t, resource.ReadTime.Valid)
+		}
+	})
+
+	t.Run("chapters", func(t *testing.T) {
+		t.Parallel()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+		const parent = "categories/batch/entries/anthology"
+		path := parent + "/chapters/one"
+		res, err := handler.BatchUpdateChapters(ctx, connect.NewRequest(eratov1.BatchUpdateChaptersRequest_builder{
+			Parent: parent,
+			Requests: []*eratov1.UpdateChapterRequest{
+				eratov1.UpdateChapterRequest_builder{
+					Path:       path,
+					Chapter:    eratov1.Chapter_builder{ReadTime: readTime}.Build(),
+					UpdateMask: readMask,
+				}.Build(),
+			},
+		}.Build()))
+		require.NoError(t, err)
+		require.Len(t, res.Msg.GetResults(), 1)
+		assert.True(t, res.Msg.GetResults()[0].HasReadTime())
+
+		stored, err := store.GetResource(ctx, user.ID, path)
+		require.NoError(t, err)
+		assert.True(t, stored.ReadTime.Valid)
+	})
+
+	t.Run("missing resources", func(t *testing.T) {
+		t.Parallel()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+		const parent = "categories/batch"
+		paths := []string{parent + "/entries/two", parent + "/entries/missing"}
+		requests := make([]*eratov1.UpdateEntryRequest, len(paths))
+		for idx, path := range paths {
+			requests[idx] = eratov1.UpdateEntryRequest_builder{
+				Path:       path,
+				Entry:      eratov1.Entry_builder{Hidden: true}.Build(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"hidden"}},
+			}.Build()
+		}
+		_, err := handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+			Parent:   parent,
+			Requests: requests,
+		}.Build()))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		_, err = store.GetResource(ctx, user.ID, paths[1])
+		require.ErrorIs(t, err, storage.ErrNotFound, "no data stored for a missing entry")
+	})
+
+	t.Run("against the kept listing", func(t *testing.T) {
+		t.Parallel()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+		const parent = "categories/cat"
+		pages := make([][]*eratov1.Entry, maxEntryPages+1)
+		for idx := range pages {
+			pages[idx] = []*eratov1.Entry{
+				eratov1.Entry_builder{
+					Path:       fmt.Sprintf("%s/entries/%03d", parent, idx),
+					UpdateTime: readTime,
+				}.Build(),
+			}
+		}
+		upstream := &gettableEntries{pagedEntries: &pagedEntries{pages: pages}}
+		listings := NewListings(upstream, testTokens)
+		handler := NewInteractivity(cfg, listings, store)
+
+		paths := []string{parent + "/entries/000", fmt.Sprintf("%s/entries/%03d", parent, maxEntryPages)}
+		requests := make([]*eratov1.UpdateEntryRequest, len(paths))
+		for idx, path := range paths {
+			requests[idx] = eratov1.UpdateEntryRequest_builder{
+				Path:       path,
+				Entry:      eratov1.Entry_builder{ReadTime: readTime}.Build(),
+				UpdateMask: readMask,
+			}.Build()
+		}
+		update := func() {
+			t.Helper()
+			upstream.got = nil
+			_, err := handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+				Parent:   parent,
+				Requests: requests,
+			}.Build()))
+			require.NoError(t, err)
+		}
+
+		update()
+		assert.Equal(t, paths, upstream.got, "every entry is looked up without a kept listing")
+		assert.Zero(t, upstream.calls.Load(), "the category is not listed")
+
+		listCtx, _ := withAllPages(ctx)
+		_, err := listings.ListEntries(listCtx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent: parent,
+		}.Build()))
+		require.NoError(t, err)
+		listed := upstream.calls.Load()
+		update()
+		assert.Equal(t, []string{paths[1]}, upstream.got, "only entries missing from the kept listing are looked up")
+		assert.Equal(t, listed, upstream.calls.Load(), "the category is not listed again")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+		tests := []struct {
+			name     string
+			parent   string
+			path     string
+			mask     *fieldmaskpb.FieldMask
+			requests int
+		}{
+			{
+				name:     "no requests",
+				parent:   "categories/invalid",
+				requests: 0,
+			},
+			{
+				name:     "path outside parent",
+				parent:   "categories/invalid",
+				path:     "categories/other/entries/one",
+				mask:     readMask,
+				requests: 1,
+			},
+			{
+				name:     "empty update mask",
+				parent:   "categories/invalid",
+				path:     "categories/invalid/entries/one",
+				mask:     &fieldmaskpb.FieldMask{},
+				requests: 1,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+				requests := make([]*eratov1.UpdateEntryRequest, test.requests)
+				for idx := range requests {
+					requests[idx] = eratov1.UpdateEntryRequest_builder{
+						Path:       test.path,
+						Entry:      eratov1.Entry_builder{ReadTime: readTime}.Build(),
+						UpdateMask: test.mask,
+					}.Build()
+				}
+				_, err := handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+					Parent:   test.parent,
+					Requests: requests,
+				}.Build()))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+		}
+	})
+}
+
+func TestInteractivityAutoReadAnthologies(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath:          filepath.Join(t.TempDir(), "db.sqlite"),
+		AutoReadAnthologies: true,
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+	const entry = "categories/auto/entries/anthology"
+	require.NoError(t, store.SetChapterCount(ctx, entry, 2))
+
+	upstream := stubArchive{
+		chapters: []*eratov1.Chapter{
+			eratov1.Chapter_builder{Path: entry + "/chapters/one"}.Build(),
+			eratov1.Chapter_builder{Path: entry + "/chapters/two"}.Build(),
+		},
+	}
+	handler := NewInteractivity(cfg, upstream, store)
+	readChapter := func(slug string) {
+		_, err := handler.BatchUpdateChapters(ctx, connect.NewRequest(eratov1.BatchUpdateChaptersRequest_builder{
+			Parent: entry,
+			Requests: []*eratov1.UpdateChapterRequest{
+				eratov1.UpdateChapterRequest_builder{
+					Path:       entry + "/chapters/" + slug,
+					Chapter:    eratov1.Chapter_builder{ReadTime: timestamppb.Now()}.Build(),
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"read_time"}},
+				}.Build(),
+			},
+		}.Build()))
+		require.NoError(t, err)
+	}
+
+	readChapter("one")
+	_, err = store.GetResource(ctx, user.ID, entry)
+	require.ErrorIs(t, err, storage.ErrNotFound, "entry should not be read until all chapters are")
+
+	readChapter("two")
+	stored, err := store.GetResource(ctx, user.ID, entry)
+	require.NoError(t, err)
+	assert.True(t, stored.ReadTime.Valid)
+}
+
+func TestInteractivityEtags(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	ctx := sec.SetAuthenticatedUser(t.Context(), user)
+
+	const (
+		parent = "categories/etags"
+		path   = parent + "/entries/story"
+	)
+	upstream := stubArchive{
+		entries: []*eratov1.Entry{
+			eratov1.Entry_builder{Path: path, Kind: eratov1.Entry_STORY}.Build(),
+		},
+	}
+	handler := NewInteractivity(cfg, NewHydrator(upstream, store), store)
+
+	star := func(etag string) (*eratov1.Entry, error) {
+		res, err := handler.UpdateEntry(ctx, connect.NewRequest(eratov1.UpdateEntryRequest_builder{
+			Path:       path,
+			Entry:      eratov1.Entry_builder{Starred: true, Etag: etag}.Build(),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"starred"}},
+		}.Build()))
+		if err != nil {
+			return nil, err
+		}
+		return res.Msg, nil
+	}
+
+	entry, err := handler.GetEntry(ctx, connect.NewRequest(eratov1.GetEntryRequest_builder{
+		Path: path,
+	}.Build()))
+	require.NoError(t, err)
+	initial := entry.Msg.GetEtag()
+	require.NotEmpty(t, initial)
+
+	updated, err := star(initial)
+	require.NoError(t, err)
+	assert.NotEqual(t, initial, updated.GetEtag())
+
+	_, err = star(initial)
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+
+	updated, err = star("")
+	require.NoError(t, err, "updates without an etag are unconditional")
+
+	_, err = handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+		Parent: parent,
+		Requests: []*eratov1.UpdateEntryRequest{
+			eratov1.UpdateEntryRequest_builder{
+				Path:       path,
+				Entry:      eratov1.Entry_builder{Hidden: true, Etag: initial}.Build(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"hidden"}},
+			}.Build(),
+		},
+	}.Build()))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+
+	res, err := handler.BatchUpdateEntries(ctx, connect.NewRequest(eratov1.BatchUpdateEntriesRequest_builder{
+		Parent: parent,
+		Requests: []*eratov1.UpdateEntryRequest{
+			eratov1.UpdateEntryRequest_builder{
+				Path:       path,
+				Entry:      eratov1.Entry_builder{Hidden: true, Etag: updated.GetEtag()}.Build(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"hidden"}},
+			}.Build(),
+		},
+	}.Build()))
+	require.NoError(t, err)
+
+	entry, err = handler.GetEntry(ctx, connect.NewRequest(eratov1.GetEntryRequest_builder{
+		Path: path,
+	}.Build()))
+	require.NoError(t, err)
+	assert.Equal(t, entry.Msg.GetEtag(), res.Msg.GetResults()[0].GetEtag())
+}
+
+// gettableEntries gets the entries on any of the pages, recording the paths
+// got.
+type gettableEntries struct {
+	*pagedEntries
+
+	got []string
+}
+
+func (g *gettableEntries) GetEntry(
+	_ context.Context,
+	req *connect.Request[eratov1.GetEntryRequest],
+) (*connect.Response[eratov1.Entry], error) {
+	g.got = append(g.got, req.Msg.GetPath())
+	for _, page := range g.pages {
+		for _, entry := range page {
+			if entry.GetPath() == req.Msg.GetPath() {
+				return connect.NewResponse(entry), nil
+			}
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, nil)
+}
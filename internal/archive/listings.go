@@ -0,0 +1,379 @@
+package archive
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/pagination"
+)
+
+const (
+	// maxEntryPages bounds the number of upstream pages of a category loaded
+	// to list its entries as a whole.
+	maxEntryPages = 100
+
+	// maxEntryListings bounds the number of category listings kept to serve
+	// later pages and other users, unless more categories are listed together.
+	maxEntryListings = 256
+
+	// entryListingTTL is how long a category listing is kept, after which it
+	// is listed again.
+	entryListingTTL = 5 * time.Minute
+
+	// maxRequestPages bounds the number of upstream pages loaded by a single
+	// request for every page of a category or the entries of every category.
+	// Listings are kept, so the next request continues where it stopped. It
+	// allows every page of a single category to be loaded at once.
+	maxRequestPages = maxEntryPages
+
+	// maxConcurrentCategories bounds the number of categories loaded at once
+	// to list the entries of every category.
+	maxConcurrentCategories = 4
+)
+
+// allCategoriesParent is the ListEntries parent for the entries of every
+// category.
+const allCategoriesParent = "categories/-"
+
+// Listings is an [eratov1connect.ArchiveServiceHandler] decorator that lists
+// every upstream page of a category at once, for entries ordered as a whole
+// and the entries of every category. Listing every page is expensive, so the
+// listings are kept for a while. They are made before hydration, so they are
+// shared by every user and never hold user data.
+//
+// Listings are kept for up to [entryListingTTL], so they may miss the latest
+// upstream changes. A kept listing is dropped if the first upstream page of
+// its category, listed live for a page without [withAllPages], disagrees
+// with it.
+type Listings struct {
+	eratov1connect.ArchiveServiceHandler
+
+	tokens *pagination.Codec
+
+	mu       sync.Mutex
+	listings *expirable.LRU[string, *entryListing]
+	capacity int // the number of listings that may be kept
+}
+
+// NewListings decorates inner, listing every upstream page of a category when
+// requested by [withAllPages], with upstream page tokens signed by tokens.
+// This should be called before hydration of the messages.
+func NewListings(inner eratov1connect.ArchiveServiceHandler, tokens *pagination.Codec) *Listings {
+	return &Listings{
+		ArchiveServiceHandler: inner,
+		tokens:                tokens,
+		listings:              expirable.NewLRU[string, *entryListing](maxEntryListings, nil, entryListingTTL),
+		capacity:              maxEntryListings,
+	}
+}
+
+// allPagesKey is the context key of the [pageBudget] requesting every upstream
+// page of a category.
+type allPagesKey struct{}
+
+// withAllPages requests every upstream page of a category from ListEntries
+// called with ctx, instead of the page selected by the page token. At most
+// [maxRequestPages] pages are loaded across every call with ctx; the returned
+// budget reports whether any listing was left partial.
+func withAllPages(ctx context.Context) (context.Context, *pageBudget) {
+	budget := &pageBudget{}
+	budget.remaining.Store(maxRequestPages)
+	return context.WithValue(ctx, allPagesKey{}, budget), budget
+}
+
+// keptListingKey is the context key requesting only the kept listing of a
+// category.
+type keptListingKey struct{}
+
+// withKeptListing requests the entries of the kept listing of a category from
+// ListEntries called with ctx, without loading any upstream pages. No entries
+// are returned if the category is not kept, and the total size is estimated
+// unless every page of the category is loaded.
+func withKeptListing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keptListingKey{}, true)
+}
+
+// pageBudget is the number of upstream pages a request may still load.
+type pageBudget struct {
+	remaining atomic.Int32
+	short     atomic.Bool
+}
+
+// take spends a page of the budget, or reports false if it has run out.
+func (b *pageBudget) take() bool {
+	if b.remaining.Add(-1) < 0 {
+		b.short.Store(true)
+		return false
+	}
+	return true
+}
+
+// partial reports whether a listing stopped short because the budget ran out.
+func (b *pageBudget) partial() bool {
+	return b.short.Load()
+}
+
+// ListEntries satisfies [eratov1connect.ArchiveServiceHandler]. The entries
+// of every category are always listed as a whole, including hidden
+// categories, since whether a category is hidden depends on the user. The
+// page token, filter and order of req are ignored when listing every page,
+// and the results are in upstream order. Kept listings may be up to
+// [entryListingTTL] old, so they can differ from a page listed upstream at
+// the same time until the first page of the category is listed again.
+func (l *Listings) ListEntries(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListEntriesRequest],
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	if kept, _ := ctx.Value(keptListingKey{}).(bool); kept {
+		return connect.NewResponse(l.keptResults(req.Msg.GetParent())), nil
+	}
+	budget, all := ctx.Value(allPagesKey{}).(*pageBudget)
+	if !all {
+		if req.Msg.GetParent() != allCategoriesParent {
+			res, err := l.ArchiveServiceHandler.ListEntries(ctx, req)
+			if err == nil && req.Msg.GetPageToken() == "" {
+				l.checkKept(req.Msg.GetParent(), res.Msg.GetResults())
+			}
+			return res, err
+		}
+		ctx, budget = withAllPages(ctx)
+	}
+	if req.Msg.GetParent() == allCategoriesParent {
+		return l.listAllCategoriesEntries(ctx, budget)
+	}
+	listing := l.listing(req.Msg.GetParent())
+	if err := listing.load(ctx, l, budget, maxEntryPages); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(listing.results()), nil
+}
+
+// reserve ensures that the listings of count categories can be kept together,
+// so a partial listing of every category continues where it stopped rather
+// than listing evicted categories again.
+func (l *Listings) reserve(count int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if count > l.capacity {
+		l.listings.Resize(count)
+		l.capacity = count
+	}
+}
+
+// listing returns the kept listing of the category at parent, or else a new
+// one to be loaded.
+func (l *Listings) listing(parent string) *entryListing {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if listing, ok := l.listings.Get(parent); ok {
+		return listing
+	}
+	listing := &entryListing{
+		parent: parent,
+		seen:   map[string]*eratov1.Entry{},
+	}
+	l.listings.Add(parent, listing)
+	return listing
+}
+
+// checkKept drops the kept listing of the category at parent, if any, unless
+// it holds each of entries, the first upstream page of the category, as of
+// the same update. The next listing of the category is then loaded afresh.
+func (l *Listings) checkKept(parent string, entries []*eratov1.Entry) {
+	l.mu.Lock()
+	listing, ok := l.listings.Peek(parent)
+	l.mu.Unlock()
+	if !ok || listing.holds(entries) {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// only the listing checked is dropped, not one loaded since
+	if kept, ok := l.listings.Peek(parent); ok && kept == listing {
+		l.listings.Remove(parent)
+	}
+}
+
+// keptResults returns the results of the kept listing of the category at
+// parent, if any, without loading it further.
+func (l *Listings) keptResults(parent string) *eratov1.ListEntriesResponse {
+	l.mu.Lock()
+	listing, ok := l.listings.Peek(parent)
+	l.mu.Unlock()
+	if !ok {
+		return eratov1.ListEntriesResponse_builder{TotalSizeEstimated: true}.Build()
+	}
+	return listing.results()
+}
+
+// listAllCategoriesEntries lists the entries of every category. The
+// categories are loaded a page at a time in turn, up to
+// [maxConcurrentCategories] at once, so a partial listing holds the most
+// recently updated entries of each. The total size is estimated if any
+// category is.
+func (l *Listings) listAllCategoriesEntries(
+	ctx context.Context,
+	budget *pageBudget,
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	categories, err := l.ListCategories(ctx, connect.NewRequest(&eratov1.ListCategoriesRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	l.reserve(len(categories.Msg.GetResults()))
+	listings := make([]*entryListing, len(categories.Msg.GetResults()))
+	for idx, category := range categories.Msg.GetResults() {
+		listings[idx] = l.listing(category.GetPath())
+	}
+
+	pending := slices.DeleteFunc(slices.Clone(listings), (*entryListing).complete)
+	for len(pending) > 0 && !budget.partial() {
+		grp, grpCtx := errgroup.WithContext(ctx)
+		grp.SetLimit(maxConcurrentCategories)
+		for _, listing := range pending {
+			// spent in order, so a partial listing favors the first categories
+			if !budget.take() {
+				break
+			}
+			grp.Go(func() error {
+				return listing.load(grpCtx, l, nil, 1)
+			})
+		}
+		if err = grp.Wait(); err != nil {
+			return nil, err
+		}
+		pending = slices.DeleteFunc(pending, (*entryListing).complete)
+	}
+
+	res := &eratov1.ListEntriesResponse{}
+	var entries []*eratov1.Entry
+	for _, listing := range listings {
+		page := listing.results()
+		entries = append(entries, page.GetResults()...)
+		if page.GetTotalSizeEstimated() {
+			res.SetTotalSizeEstimated(true)
+		}
+		if page.GetResultsTruncated() {
+			res.SetResultsTruncated(true)
+		}
+	}
+	res.SetResults(entries)
+	return connect.NewResponse(res), nil
+}
+
+// entryListing is the entries on the upstream pages of a category, loaded in
+// order until the last page or [maxEntryPages] pages.
+type entryListing struct {
+	mu        sync.Mutex
+	parent    string
+	next      *eratov1.ListEntriesPaginationToken // the next page to load, if any are loaded
+	pages     uint32                              // the number of pages loaded
+	entries   []*eratov1.Entry
+	seen      map[string]*eratov1.Entry // the loaded entries by path
+	done      bool
+	truncated bool
+}
+
+// load loads up to limit more pages of the listing with l, each spent from
+// budget unless it is nil. Pages loaded before an error are kept.
+func (e *entryListing) load(ctx context.Context, l *Listings, budget *pageBudget, limit int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for range limit {
+		if e.done || (budget != nil && !budget.take()) {
+			break
+		}
+		// the page token is signed as it is used, so it cannot expire while
+		// the listing is kept
+		pageReq := eratov1.ListEntriesRequest_builder{Parent: e.parent}.Build()
+		if e.next != nil {
+			tkn, err := l.tokens.ToToken(pageReq, e.next)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			pageReq.SetPageToken(tkn)
+		}
+		res, err := l.ArchiveServiceHandler.ListEntries(ctx, connect.NewRequest(pageReq))
+		if e.pages > 0 && connect.CodeOf(err) == connect.CodeNotFound {
+			e.done = true // past the last page
+			break
+		} else if err != nil {
+			return err
+		}
+		e.pages++
+
+		entries := res.Msg.GetResults()
+		for _, entry := range entries {
+			// entries updated while paging move to the first page
+			if e.seen[entry.GetPath()] == nil {
+				e.seen[entry.GetPath()] = entry
+				e.entries = append(e.entries, entry)
+			}
+		}
+		if res.Msg.GetNextPageToken() == "" || len(entries) == 0 {
+			e.done = true
+			break
+		}
+		if e.pages == maxEntryPages {
+			e.done, e.truncated = true, true
+			break
+		}
+		last := entries[len(entries)-1]
+		e.next = eratov1.ListEntriesPaginationToken_builder{
+			Page:            e.pages + 1,
+			AfterEntry:      last.GetPath(),
+			StartUpdateTime: last.GetUpdateTime(),
+		}.Build()
+	}
+	return nil
+}
+
+// holds reports whether each of entries is loaded in the listing with the same
+// update time.
+func (e *entryListing) holds(entries []*eratov1.Entry) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, entry := range entries {
+		loaded := e.seen[entry.GetPath()]
+		if loaded == nil || !proto.Equal(loaded.GetUpdateTime(), entry.GetUpdateTime()) {
+			return false
+		}
+	}
+	return true
+}
+
+// complete reports whether every page of the listing that will be loaded has
+// been.
+func (e *entryListing) complete() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.done
+}
+
+// results returns copies of the entries loaded so far, which the caller may
+// modify. The total size is estimated if the listing is truncated or
+// partial.
+func (e *entryListing) results() *eratov1.ListEntriesResponse {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	results := make([]*eratov1.Entry, len(e.entries))
+	for idx, entry := range e.entries {
+		results[idx] = proto.CloneOf(entry)
+	}
+	return eratov1.ListEntriesResponse_builder{
+		Results:            results,
+		TotalSizeEstimated: !e.done || e.truncated,
+		ResultsTruncated:   e.truncated,
+	}.Build()
+}
@@ -0,0 +1,255 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestListings(t *testing.T) {
+	t.Parallel()
+
+	entry := func(slug string) *eratov1.Entry {
+		return eratov1.Entry_builder{
+			Path:        "categories/cat/entries/" + slug,
+			DisplayName: slug,
+			UpdateTime:  timestamppb.Now(),
+		}.Build()
+	}
+	list := func(t *testing.T, handler eratov1connect.ArchiveServiceHandler, user db.User, token string) *eratov1.ListEntriesResponse {
+		t.Helper()
+		ctx := sec.SetAuthenticatedUser(t.Context(), user)
+		res, err := handler.ListEntries(ctx, connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      "categories/cat",
+			MaxPageSize: 1,
+			PageToken:   token,
+			OrderBy:     "display_name",
+		}.Build()))
+		require.NoError(t, err)
+		return res.Msg
+	}
+
+	t.Run("shared by every page and user", func(t *testing.T) {
+		t.Parallel()
+		upstream := &pagedEntries{pages: [][]*eratov1.Entry{
+			{entry("c"), entry("a")},
+			{entry("b")},
+		}}
+		paginator, err := NewPaginator(NewListings(upstream, testTokens), testTokens)
+		require.NoError(t, err)
+		alice, bob := db.User{ID: 1, Name: "alice"}, db.User{ID: 2, Name: "bob"}
+
+		res := list(t, paginator, alice, "")
+		assert.Equal(t, "a", res.GetResults()[0].GetDisplayName())
+		listed := upstream.calls.Load()
+		res = list(t, paginator, alice, res.GetNextPageToken())
+		assert.Equal(t, "b", res.GetResults()[0].GetDisplayName())
+		res = list(t, paginator, alice, res.GetNextPageToken())
+		assert.Equal(t, "c", res.GetResults()[0].GetDisplayName())
+
+		list(t, paginator, bob, "")
+		list(t, paginator, alice, "")
+		assert.Equal(t, listed, upstream.calls.Load(), "upstream is not listed again")
+	})
+
+	t.Run("hydrated for every page", func(t *testing.T) {
+		t.Parallel()
+		starred := &starredEntries{
+			ArchiveServiceHandler: NewListings(&pagedEntries{pages: [][]*eratov1.Entry{
+				{entry("a"), entry("b"), entry("c")},
+			}}, testTokens),
+			paths: map[string]bool{},
+		}
+		paginator, err := NewPaginator(starred, testTokens)
+		require.NoError(t, err)
+		list := func(token string) *eratov1.ListEntriesResponse {
+			res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent:      "categories/cat",
+				Filter:      "!this.starred",
+				MaxPageSize: 1,
+				PageToken:   token,
+				OrderBy:     "display_name",
+			}.Build()))
+			require.NoError(t, err)
+			return res.Msg
+		}
+
+		res := list("")
+		assert.Equal(t, "a", res.GetResults()[0].GetDisplayName())
+		assert.Equal(t, int32(3), res.GetTotalSize())
+		starred.paths["categories/cat/entries/b"] = true
+		res = list(res.GetNextPageToken())
+		assert.Equal(t, "c", res.GetResults()[0].GetDisplayName(), "filtered on the current data")
+		assert.Equal(t, int32(2), res.GetTotalSize())
+	})
+
+	t.Run("dropped when a live page disagrees", func(t *testing.T) {
+		t.Parallel()
+		upstream := &pagedEntries{pages: [][]*eratov1.Entry{{entry("a"), entry("b")}}}
+		listings := NewListings(upstream, testTokens)
+		allCtx, _ := withAllPages(t.Context())
+		req := connect.NewRequest(eratov1.ListEntriesRequest_builder{Parent: "categories/cat"}.Build())
+
+		_, err := listings.ListEntries(allCtx, req)
+		require.NoError(t, err)
+		_, err = listings.ListEntries(t.Context(), req)
+		require.NoError(t, err)
+		listed := upstream.calls.Load()
+		_, err = listings.ListEntries(allCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, listed, upstream.calls.Load(), "kept while the live page agrees")
+
+		upstream.pages[0] = []*eratov1.Entry{entry("b"), entry("a")}
+		_, err = listings.ListEntries(t.Context(), req)
+		require.NoError(t, err)
+		listed = upstream.calls.Load()
+		res, err := listings.ListEntries(allCtx, req)
+		require.NoError(t, err)
+		assert.Greater(t, upstream.calls.Load(), listed, "listed again once the live page disagrees")
+		assert.Equal(t, "b", res.Msg.GetResults()[0].GetDisplayName())
+	})
+
+	t.Run("results are copies", func(t *testing.T) {
+		t.Parallel()
+		listings := NewListings(&pagedEntries{pages: [][]*eratov1.Entry{{entry("a")}}}, testTokens)
+		ctx, _ := withAllPages(t.Context())
+		req := connect.NewRequest(eratov1.ListEntriesRequest_builder{Parent: "categories/cat"}.Build())
+
+		res, err := listings.ListEntries(ctx, req)
+		require.NoError(t, err)
+		res.Msg.GetResults()[0].SetStarred(true)
+		res, err = listings.ListEntries(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, res.Msg.GetResults()[0].GetStarred())
+	})
+
+	t.Run("only when requested", func(t *testing.T) {
+		t.Parallel()
+		upstream := &pagedEntries{pages: [][]*eratov1.Entry{{entry("a")}, {entry("b")}}}
+		res, err := NewListings(upstream, testTokens).ListEntries(t.Context(), connect.NewRequest(
+			eratov1.ListEntriesRequest_builder{Parent: "categories/cat"}.Build(),
+		))
+		require.NoError(t, err)
+		assert.Len(t, res.Msg.GetResults(), 1)
+		assert.NotEmpty(t, res.Msg.GetNextPageToken())
+		assert.Equal(t, int32(1), upstream.calls.Load())
+	})
+
+	t.Run("partial when the budget runs out", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		upstream := &categorizedEntries{entries: map[string][]*eratov1.Entry{}}
+		for i := range maxRequestPages + 1 {
+			path := fmt.Sprintf("categories/%03d", i)
+			upstream.categories = append(upstream.categories, eratov1.Category_builder{Path: path}.Build())
+			upstream.entries[path] = []*eratov1.Entry{eratov1.Entry_builder{
+				Path:        path + "/entries/a",
+				DisplayName: path,
+				UpdateTime:  timestamppb.New(now.Add(-time.Duration(i) * time.Minute)),
+			}.Build()}
+		}
+		paginator, err := NewPaginator(NewListings(upstream, testTokens), testTokens)
+		require.NoError(t, err)
+
+		var names []string
+		var token string
+		for {
+			res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent:      allCategoriesParent,
+				MaxPageSize: maxRequestPages + 1,
+				PageToken:   token,
+			}.Build()))
+			require.NoError(t, err)
+			if token == "" {
+				assert.Len(t, res.Msg.GetResults(), maxRequestPages)
+				assert.True(t, res.Msg.GetTotalSizeEstimated())
+				assert.NotEmpty(t, res.Msg.GetNextPageToken(), "continued on the next page")
+			}
+			for _, entry := range res.Msg.GetResults() {
+				names = append(names, entry.GetDisplayName())
+			}
+			if token = res.Msg.GetNextPageToken(); token == "" {
+				assert.False(t, res.Msg.GetTotalSizeEstimated())
+				break
+			}
+		}
+		assert.Len(t, names, maxRequestPages+1)
+		assert.True(t, slices.IsSorted(names), "most recently updated first")
+	})
+
+	t.Run("every category kept together", func(t *testing.T) {
+		t.Parallel()
+		upstream := &categorizedEntries{entries: map[string][]*eratov1.Entry{}}
+		for i := range maxEntryListings + 1 {
+			path := fmt.Sprintf("categories/%03d", i)
+			upstream.categories = append(upstream.categories, eratov1.Category_builder{Path: path}.Build())
+			upstream.entries[path] = []*eratov1.Entry{eratov1.Entry_builder{
+				Path:        path + "/entries/a",
+				DisplayName: path,
+				UpdateTime:  timestamppb.Now(),
+			}.Build()}
+		}
+		listings := NewListings(upstream, testTokens)
+		req := connect.NewRequest(eratov1.ListEntriesRequest_builder{Parent: allCategoriesParent}.Build())
+
+		for range 3 {
+			_, err := listings.ListEntries(t.Context(), req)
+			require.NoError(t, err)
+		}
+		listed := upstream.calls.Load()
+		res, err := listings.ListEntries(t.Context(), req)
+		require.NoError(t, err)
+		assert.Equal(t, listed, upstream.calls.Load(), "no category is listed again")
+		assert.False(t, res.Msg.GetTotalSizeEstimated())
+		assert.Len(t, res.Msg.GetResults(), maxEntryListings+1)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+		pages := make([][]*eratov1.Entry, maxEntryPages+1)
+		for i := range pages {
+			pages[i] = []*eratov1.Entry{entry(fmt.Sprintf("%03d", i))}
+		}
+		paginator, err := NewPaginator(NewListings(&pagedEntries{pages: pages}, testTokens), testTokens)
+		require.NoError(t, err)
+
+		res := list(t, paginator, db.User{}, "")
+		assert.True(t, res.GetResultsTruncated())
+		assert.True(t, res.GetTotalSizeEstimated())
+		assert.Equal(t, int32(maxEntryPages), res.GetTotalSize())
+	})
+}
+
+// starredEntries stars the listed entries at paths, standing in for the
+// [Hydrator].
+type starredEntries struct {
+	eratov1connect.ArchiveServiceHandler
+
+	paths map[string]bool
+}
+
+func (s *starredEntries) ListEntries(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListEntriesRequest],
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	res, err := s.ArchiveServiceHandler.ListEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range res.Msg.GetResults() {
+		entry.SetStarred(s.paths[entry.GetPath()])
+	}
+	return res, nil
+}
@@ -0,0 +1,213 @@
+package archive
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	pathField = "path"
+	etagField = "etag"
+	descOrder = "desc"
+	ascOrder  = "asc"
+)
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// ordering is a parsed AEP-132 order_by clause: the fields to sort list results
+// by, in order of precedence. Ties are always broken by path, so the order is
+// total and pagination is stable. A nil ordering leaves results in their
+// default order.
+type ordering []orderField
+
+type orderField struct {
+	field protoreflect.FieldDescriptor
+	desc  bool
+}
+
+// parseOrdering parses orderBy for results of type msg, such as
+// `read_time desc, display_name`. Any singular scalar, enum or timestamp field
+// of msg other than its etag may be used. An empty orderBy returns a nil
+// ordering.
+func parseOrdering(msg protoreflect.MessageDescriptor, orderBy string) (ordering, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+	clauses := strings.Split(orderBy, ",")
+	order := make(ordering, 0, len(clauses))
+	for _, clause := range clauses {
+		parts := strings.Fields(clause)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("invalid order_by clause %q", strings.TrimSpace(clause))
+		}
+		var desc bool
+		if len(parts) == 2 {
+			switch parts[1] {
+			case descOrder:
+				desc = true
+			case ascOrder:
+			default:
+				return nil, fmt.Errorf("invalid order_by direction %q, must be %q or %q", parts[1], ascOrder, descOrder)
+			}
+		}
+		field := msg.Fields().ByName(protoreflect.Name(parts[0]))
+		if field == nil || !isOrderable(field) {
+			return nil, fmt.Errorf("cannot order by %q", parts[0])
+		}
+		if slices.ContainsFunc(order, func(of orderField) bool { return of.field == field }) {
+			return nil, fmt.Errorf("duplicate order_by field %q", parts[0])
+		}
+		order = append(order, orderField{field: field, desc: desc})
+	}
+	return order, nil
+}
+
+func isOrderable(field protoreflect.FieldDescriptor) bool {
+	if field.Cardinality() == protoreflect.Repeated || field.Name() == etagField {
+		return false
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind,
+		protoreflect.BoolKind,
+		protoreflect.EnumKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	case protoreflect.MessageKind:
+		return field.Message().FullName() == timestampName
+	default:
+		return false
+	}
+}
+
+// String returns the normalized order_by clause, which is stored in page
+// tokens to ensure it does not change between pages.
+func (o ordering) String() string {
+	clauses := make([]string, len(o))
+	for i, of := range o {
+		clauses[i] = string(of.field.Name())
+		if of.desc {
+			clauses[i] += " " + descOrder
+		}
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// compare orders a before or after b, which must be of the ordered message
+// type.
+func (o ordering) compare(a, b protoreflect.Message) int {
+	for _, of := range o {
+		c := compareValues(of.field, a, b)
+		if of.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	path := a.Descriptor().Fields().ByName(pathField)
+	return strings.Compare(a.Get(path).String(), b.Get(path).String())
+}
+
+// compareValues compares field of a and b. Strings are compared
+// case-insensitively first, and unset timestamps come before all others.
+func compareValues(field protoreflect.FieldDescriptor, a, b protoreflect.Message) int {
+	av, bv := a.Get(field), b.Get(field)
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		as, bs := av.String(), bv.String()
+		return cmp.Or(strings.Compare(strings.ToLower(as), strings.ToLower(bs)), strings.Compare(as, bs))
+	case protoreflect.BoolKind:
+		switch {
+		case av.Bool() == bv.Bool():
+			return 0
+		case bv.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case protoreflect.EnumKind:
+		return cmp.Compare(av.Enum(), bv.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return cmp.Compare(av.Int(), bv.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return cmp.Compare(av.Uint(), bv.Uint())
+	case protoreflect.MessageKind:
+		if aSet, bSet := a.Has(field), b.Has(field); !aSet || !bSet {
+			return compareSet(aSet, bSet)
+		}
+		at, _ := av.Message().Interface().(*timestamppb.Timestamp)
+		bt, _ := bv.Message().Interface().(*timestamppb.Timestamp)
+		return at.AsTime().Compare(bt.AsTime())
+	default:
+		return 0 // rejected by parseOrdering
+	}
+}
+
+// compareSet orders unset values before set ones.
+func compareSet(aSet, bSet bool) int {
+	switch {
+	case aSet == bSet:
+		return 0
+	case aSet:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// sortResults sorts results in order o. It does nothing if o is nil.
+func sortResults[Elem any](o ordering, results []*Elem) {
+	if o == nil {
+		return
+	}
+	slices.SortStableFunc(results, func(a, b *Elem) int {
+		return o.compare(reflectElem(a), reflectElem(b))
+	})
+}
+
+// resultsAfter returns the results ordered after key, which need not be one of
+// the results: if the result it was taken from has since moved or been
+// removed, the results resume where it would have been.
+func resultsAfter[Elem any](o ordering, results []*Elem, key *Elem) []*Elem {
+	keyMsg := reflectElem(key)
+	idx, _ := slices.BinarySearchFunc(results, keyMsg, func(elem *Elem, key protoreflect.Message) int {
+		// never equal, so the search lands after any result equal to key
+		return cmp.Or(o.compare(reflectElem(elem), key), -1)
+	})
+	return results[idx:]
+}
+
+// orderKey returns a copy of elem with only its path and ordered fields set,
+// to be stored in a page token and passed to [resultsAfter].
+func orderKey[Elem any](o ordering, elem *Elem) *Elem {
+	src := reflectElem(elem)
+	dst := src.New()
+	fields := src.Descriptor().Fields()
+	dst.Set(fields.ByName(pathField), src.Get(fields.ByName(pathField)))
+	for _, of := range o {
+		if src.Has(of.field) {
+			dst.Set(of.field, src.Get(of.field))
+		}
+	}
+	return any(dst.Interface()).(*Elem) //nolint:forcetypeassert // guaranteed to be the right type
+}
+
+func reflectElem[Elem any](elem *Elem) protoreflect.Message {
+	msg, ok := any(elem).(proto.Message)
+	if !ok {
+		panic(errors.New("ordered results must be proto messages"))
+	}
+	return msg.ProtoReflect()
+}
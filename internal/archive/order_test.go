@@ -0,0 +1,199 @@
+package archive
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+func TestParseOrdering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		orderBy  string
+		expected string
+		wantErr  string
+	}{
+		{orderBy: "", expected: ""},
+		{orderBy: "display_name", expected: "display_name"},
+		{orderBy: " read_time desc ,display_name asc", expected: "read_time desc, display_name"},
+		{orderBy: "starred desc, unread_chapter_count desc, kind", expected: "starred desc, unread_chapter_count desc, kind"},
+		{orderBy: "display_name up", wantErr: "invalid order_by direction"},
+		{orderBy: "display_name desc extra", wantErr: "invalid order_by clause"},
+		{orderBy: "display_name,", wantErr: "invalid order_by clause"},
+		{orderBy: "title", wantErr: `cannot order by "title"`},
+		{orderBy: "etag", wantErr: `cannot order by "etag"`},
+		{orderBy: "display_name, display_name desc", wantErr: "duplicate order_by field"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.orderBy, func(t *testing.T) {
+			t.Parallel()
+			order, err := parseOrdering(entriesFieldDesc.Message(), test.orderBy)
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, order.String())
+		})
+	}
+}
+
+func TestPaginatorOrdering(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	entry := func(slug, name string, read time.Duration) *eratov1.Entry {
+		bldr := eratov1.Entry_builder{
+			Path:        "categories/cat/entries/" + slug,
+			DisplayName: name,
+			UpdateTime:  timestamppb.New(now),
+		}
+		if read != 0 {
+			bldr.ReadTime = timestamppb.New(now.Add(-read))
+		}
+		return bldr.Build()
+	}
+	inner := &pagedEntries{pages: [][]*eratov1.Entry{
+		{entry("a", "Delta", time.Hour), entry("b", "alpha", 0)},
+		{entry("c", "Charlie", time.Minute), entry("d", "bravo", 0)},
+		{entry("e", "Echo", 2*time.Hour)},
+	}}
+	paginator, err := NewPaginator(NewListings(inner, testTokens), testTokens)
+	require.NoError(t, err)
+
+	list := func(t *testing.T, orderBy, filter string) (names []string) {
+		t.Helper()
+		var token string
+		for {
+			res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+				Parent:      "categories/cat",
+				Filter:      filter,
+				MaxPageSize: 2,
+				PageToken:   token,
+				OrderBy:     orderBy,
+			}.Build()))
+			require.NoError(t, err)
+			for _, entry := range res.Msg.GetResults() {
+				names = append(names, entry.GetDisplayName())
+			}
+			if token = res.Msg.GetNextPageToken(); token == "" {
+				return names
+			}
+		}
+	}
+
+	t.Run("every upstream page is ordered", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"alpha", "bravo", "Charlie", "Delta", "Echo"}, list(t, "display_name", ""))
+		assert.Equal(t, []string{"Echo", "Delta", "Charlie", "bravo", "alpha"}, list(t, "display_name desc", ""))
+	})
+
+	t.Run("unset values first", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"alpha", "bravo", "Echo", "Delta", "Charlie"}, list(t, "read_time", ""))
+		assert.Equal(t, []string{"Echo", "Delta", "Charlie"}, list(t, "read_time", "has(this.read_time)"))
+	})
+
+	t.Run("order_by must not change", func(t *testing.T) {
+		t.Parallel()
+		res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      "categories/cat",
+			MaxPageSize: 2,
+			OrderBy:     "display_name",
+		}.Build()))
+		require.NoError(t, err)
+		_, err = paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      "categories/cat",
+			MaxPageSize: 2,
+			PageToken:   res.Msg.GetNextPageToken(),
+			OrderBy:     "display_name desc",
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid order_by", func(t *testing.T) {
+		t.Parallel()
+		_, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:  "categories/cat",
+			OrderBy: "title",
+		}.Build()))
+		violations := fieldViolations(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "order_by", violations[0].GetField())
+		assert.Equal(t, reasonInvalidOrderBy, violations[0].GetReason())
+	})
+}
+
+func TestResultsAfter(t *testing.T) {
+	t.Parallel()
+
+	order, err := parseOrdering(chaptersFieldDesc.Message(), "display_name")
+	require.NoError(t, err)
+	chapter := func(slug, name string) *eratov1.Chapter {
+		return eratov1.Chapter_builder{
+			Path:        "categories/cat/entries/ent/chapters/" + slug,
+			DisplayName: name,
+		}.Build()
+	}
+	results := []*eratov1.Chapter{chapter("a", "One"), chapter("b", "Three"), chapter("c", "Three"), chapter("d", "Two")}
+
+	key := orderKey(order, results[1])
+	assert.Equal(t, results[1].GetPath(), key.GetPath())
+	assert.Equal(t, "Three", key.GetDisplayName())
+	assert.Equal(t, results[2:], resultsAfter(order, results, key), "ties are broken by path")
+
+	removed := orderKey(order, chapter("bb", "Six"))
+	assert.Equal(t, results[1:], resultsAfter(order, results, removed), "resumes where the key would be")
+
+	last := orderKey(order, results[3])
+	assert.Empty(t, resultsAfter(order, results, last))
+}
+
+// pagedEntries lists entries on multiple upstream pages, like the [Scraper].
+type pagedEntries struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	pages [][]*eratov1.Entry
+	calls atomic.Int32
+}
+
+func (p *pagedEntries) ListEntries(
+	_ context.Context,
+	req *connect.Request[eratov1.ListEntriesRequest],
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	p.calls.Add(1)
+	page := eratov1.ListEntriesPaginationToken_builder{Page: 1}.Build()
+	if tkn := req.Msg.GetPageToken(); tkn != "" {
+		if err := testTokens.FromToken(req.Msg, tkn, page); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	if int(page.GetPage()) > len(p.pages) {
+		return nil, connect.NewError(connect.CodeNotFound, nil)
+	}
+	results := p.pages[page.GetPage()-1]
+	last := results[len(results)-1]
+	next, err := testTokens.ToToken(req.Msg, eratov1.ListEntriesPaginationToken_builder{
+		Page:            page.GetPage(),
+		AfterEntry:      last.GetPath(),
+		StartUpdateTime: last.GetUpdateTime(),
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(eratov1.ListEntriesResponse_builder{
+		Results:       results,
+		NextPageToken: next,
+	}.Build()), nil
+}
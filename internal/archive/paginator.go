@@ -3,8 +3,11 @@ package archive
 import (
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	celext "buf.build/go/protovalidate/cel"
 	"connectrpc.com/connect"
@@ -12,12 +15,18 @@ import (
 	"github.com/google/cel-go/common/types/ref"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
 	"github.com/stolasapp/erato/internal/pagination"
+	"github.com/stolasapp/erato/internal/slugconv"
 )
 
+// listedEntriesOrder is the default order of entries listed as a whole,
+// matching the order of each category upstream.
+const listedEntriesOrder = "update_time desc"
+
 const (
 	resultsVar = "results"
 	thisVar    = "this"
@@ -25,15 +34,21 @@ const (
 )
 
 var (
-	categoriesFieldDesc = (&eratov1.ListCategoriesResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
-	entriesFieldDesc    = (&eratov1.ListEntriesResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
-	chaptersFieldDesc   = (&eratov1.ListChaptersResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
-	usersFieldDesc      = (&eratov1.ListUsersResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
-
-	categoriesCELType = celext.ProtoFieldToType(categoriesFieldDesc, false, false)
-	entriesCELType    = celext.ProtoFieldToType(entriesFieldDesc, false, false)
-	chaptersCELType   = celext.ProtoFieldToType(chaptersFieldDesc, false, false)
-	usersCELType      = celext.ProtoFieldToType(usersFieldDesc, false, false)
+	categoriesFieldDesc   = (&eratov1.ListCategoriesResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	entriesFieldDesc      = (&eratov1.ListEntriesResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	chaptersFieldDesc     = (&eratov1.ListChaptersResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	usersFieldDesc        = (&eratov1.ListUsersResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	accessTokensFieldDesc = (&eratov1.ListAccessTokensResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	auditEventsFieldDesc  = (&eratov1.ListAuditEventsResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+	savedViewsFieldDesc   = (&eratov1.ListSavedViewsResponse{}).ProtoReflect().Descriptor().Fields().ByName("results")
+
+	categoriesCELType   = celext.ProtoFieldToType(categoriesFieldDesc, false, false)
+	entriesCELType      = celext.ProtoFieldToType(entriesFieldDesc, false, false)
+	chaptersCELType     = celext.ProtoFieldToType(chaptersFieldDesc, false, false)
+	usersCELType        = celext.ProtoFieldToType(usersFieldDesc, false, false)
+	accessTokensCELType = celext.ProtoFieldToType(accessTokensFieldDesc, false, false)
+	auditEventsCELType  = celext.ProtoFieldToType(auditEventsFieldDesc, false, false)
+	savedViewsCELType   = celext.ProtoFieldToType(savedViewsFieldDesc, false, false)
 )
 
 // Paginator is a [eratov1connect.ArchiveServiceHandler] decorator that applies
@@ -41,34 +56,56 @@ var (
 type Paginator struct {
 	eratov1connect.ArchiveServiceHandler
 
-	categoriesEnv *cel.Env
-	entriesEnv    *cel.Env
-	chaptersEnv   *cel.Env
-	usersEnv      *cel.Env
+	categoriesEnv   *cel.Env
+	entriesEnv      *cel.Env
+	chaptersEnv     *cel.Env
+	usersEnv        *cel.Env
+	accessTokensEnv *cel.Env
+	auditEventsEnv  *cel.Env
+	savedViewsEnv   *cel.Env
+
+	programs *filterCache
+	tokens   *pagination.Codec
 }
 
 // NewPaginator decorates inner, applying pagination and filtering to list
-// results. This should be called after hydration of the messages.
-func NewPaginator(inner eratov1connect.ArchiveServiceHandler) (*Paginator, error) {
-	base, err := cel.NewEnv(cel.Lib(celext.NewLibrary()))
+// results, with page tokens signed by tokens. This should be called after
+// hydration of the messages.
+func NewPaginator(inner eratov1connect.ArchiveServiceHandler, tokens *pagination.Codec) (*Paginator, error) {
+	base, err := cel.NewEnv(append(filterLibrary(), cel.Lib(celext.NewLibrary()))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create paginator base CEL environment: %w", err)
 	}
+	programs, err := newFilterCache(maxFilterPrograms)
+	if err != nil {
+		return nil, err
+	}
 	paginator := &Paginator{
 		ArchiveServiceHandler: inner,
+		programs:              programs,
+		tokens:                tokens,
 	}
 	if paginator.categoriesEnv, err = initCELEnv(base, categoriesFieldDesc, categoriesCELType, "categories"); err != nil {
 		return nil, err
 	}
-	if paginator.entriesEnv, err = initCELEnv(base, entriesFieldDesc, entriesCELType, "entries"); err != nil {
+	if paginator.entriesEnv, err = initCELEnv(base, entriesFieldDesc, entriesCELType, "entries", categoryVariable()); err != nil {
 		return nil, err
 	}
-	if paginator.chaptersEnv, err = initCELEnv(base, chaptersFieldDesc, chaptersCELType, "chapters"); err != nil {
+	if paginator.chaptersEnv, err = initCELEnv(base, chaptersFieldDesc, chaptersCELType, "chapters", categoryVariable()); err != nil {
 		return nil, err
 	}
 	if paginator.usersEnv, err = initCELEnv(base, usersFieldDesc, usersCELType, "users"); err != nil {
 		return nil, err
 	}
+	if paginator.accessTokensEnv, err = initCELEnv(base, accessTokensFieldDesc, accessTokensCELType, "access tokens"); err != nil {
+		return nil, err
+	}
+	if paginator.auditEventsEnv, err = initCELEnv(base, auditEventsFieldDesc, auditEventsCELType, "audit events"); err != nil {
+		return nil, err
+	}
+	if paginator.savedViewsEnv, err = initCELEnv(base, savedViewsFieldDesc, savedViewsCELType, "saved views"); err != nil {
+		return nil, err
+	}
 	return paginator, nil
 }
 
@@ -77,6 +114,10 @@ func (p *Paginator) ListCategories(
 	ctx context.Context,
 	req *connect.Request[eratov1.ListCategoriesRequest],
 ) (*connect.Response[eratov1.ListCategoriesResponse], error) {
+	order, err := parseOrdering(categoriesFieldDesc.Message(), req.Msg.GetOrderBy())
+	if err != nil {
+		return nil, fieldError(orderByField, reasonInvalidOrderBy, err)
+	}
 	res, err := p.ArchiveServiceHandler.ListCategories(ctx, req)
 	if err != nil {
 		return nil, err
@@ -84,10 +125,13 @@ func (p *Paginator) ListCategories(
 
 	return res, applyPagination(
 		ctx,
+		p.tokens,
 		req.Msg,
 		res.Msg,
+		p.programs,
 		p.categoriesEnv,
 		categoriesCELType,
+		order,
 		func(tkn *eratov1.ListCategoriesPaginationToken) {
 			results := res.Msg.GetResults()
 			if idx := slices.IndexFunc(results, func(cat *eratov1.Category) bool {
@@ -123,18 +167,49 @@ func (p *Paginator) ListEntries(
 	ctx context.Context,
 	req *connect.Request[eratov1.ListEntriesRequest],
 ) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	orderBy := req.Msg.GetOrderBy()
+	allCategories := req.Msg.GetParent() == allCategoriesParent
+	if allCategories && strings.TrimSpace(orderBy) == "" {
+		// entries of every category are merged, so they must be ordered
+		orderBy = listedEntriesOrder
+	}
+	order, err := parseOrdering(entriesFieldDesc.Message(), orderBy)
+	if err != nil {
+		return nil, fieldError(orderByField, reasonInvalidOrderBy, err)
+	}
+	var budget *pageBudget
+	if order != nil {
+		// ordered entries are listed as a whole, then hydrated and filtered
+		// for every page, so each page reflects the user's current data
+		ctx, budget = withAllPages(ctx)
+	}
 	res, err := p.ArchiveServiceHandler.ListEntries(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	if allCategories {
+		if err = p.skipHiddenCategories(ctx, res.Msg); err != nil {
+			return nil, err
+		}
+	}
+	// the total size is counted from the same results once filtered
+	res.Msg.SetUnfilteredTotalSize(int32(len(res.Msg.GetResults()))) //nolint:gosec // bounded by the results
+	// Only the requested upstream page is loaded if it is paginated, so the
+	// total only counts the matches on it
+	if order == nil && res.Msg.GetNextPageToken() != "" {
+		res.Msg.SetTotalSizeEstimated(true)
+	}
 	// Track remaining entries after cursor slicing to determine if we need to advance to next upstream page
 	var entriesAfterCursor int
-	return res, applyPagination(
+	err = applyPagination(
 		ctx,
+		p.tokens,
 		req.Msg,
 		res.Msg,
+		p.programs,
 		p.entriesEnv,
 		entriesCELType,
+		order,
 		func(tkn *eratov1.ListEntriesPaginationToken) {
 			results := res.Msg.GetResults()
 			if idx := slices.IndexFunc(results, func(entry *eratov1.Entry) bool {
@@ -176,6 +251,82 @@ func (p *Paginator) ListEntries(
 			return token
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	if budget != nil && budget.partial() {
+		// the rest of the listing is loaded by the next pages
+		if err = p.continuePartial(req.Msg, res.Msg, order); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// continuePartial ensures res, a page of ordered entries listed before the
+// page budget ran out, has a next page token, even if the page is not full.
+// The next page resumes after the last of its results, if any, or where this
+// page did.
+func (p *Paginator) continuePartial(
+	req *eratov1.ListEntriesRequest,
+	res *eratov1.ListEntriesResponse,
+	order ordering,
+) error {
+	res.SetTotalSizeEstimated(true)
+	if res.GetNextPageToken() != "" {
+		return nil
+	}
+	token := &eratov1.ListEntriesPaginationToken{}
+	if results := res.GetResults(); len(results) > 0 {
+		last := results[len(results)-1]
+		token = eratov1.ListEntriesPaginationToken_builder{
+			Page:            1,
+			AfterEntry:      last.GetPath(),
+			StartUpdateTime: last.GetUpdateTime(),
+			OrderBy:         order.String(),
+			AfterKey:        orderKey(order, last),
+		}.Build()
+	} else if pageTkn := req.GetPageToken(); pageTkn != "" {
+		if err := p.tokens.FromToken(req, pageTkn, token); err != nil {
+			return fieldError(pageTokenField, reasonInvalidPageToken, err)
+		}
+	} else {
+		// the page and after_entry are required, but ordered pages resume
+		// after the key, which is unset to start from the first result
+		token = eratov1.ListEntriesPaginationToken_builder{
+			Page:            1,
+			AfterEntry:      req.GetParent(),
+			StartUpdateTime: timestamppb.Now(),
+			OrderBy:         order.String(),
+		}.Build()
+	}
+	tkn, err := p.tokens.ToToken(req, token)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	res.SetNextPageToken(tkn)
+	return nil
+}
+
+// skipHiddenCategories removes the entries of the categories the user has
+// hidden from res, which lists the entries of every category.
+func (p *Paginator) skipHiddenCategories(ctx context.Context, res *eratov1.ListEntriesResponse) error {
+	categories, err := p.ArchiveServiceHandler.ListCategories(ctx, connect.NewRequest(&eratov1.ListCategoriesRequest{}))
+	if err != nil {
+		return err
+	}
+	hidden := make(map[string]bool)
+	for _, category := range categories.Msg.GetResults() {
+		if category.GetHidden() {
+			hidden[category.GetPath()] = true
+		}
+	}
+	if len(hidden) > 0 {
+		res.SetResults(slices.DeleteFunc(res.GetResults(), func(entry *eratov1.Entry) bool {
+			return hidden[slugconv.EntryParent(entry.GetPath())]
+		}))
+	}
+	return nil
 }
 
 // ListChapters satisfies [eratov1connect.ArchiveServiceHandler].
@@ -183,17 +334,27 @@ func (p *Paginator) ListChapters(
 	ctx context.Context,
 	req *connect.Request[eratov1.ListChaptersRequest],
 ) (*connect.Response[eratov1.ListChaptersResponse], error) {
+	order, err := parseOrdering(chaptersFieldDesc.Message(), req.Msg.GetOrderBy())
+	if err != nil {
+		return nil, fieldError(orderByField, reasonInvalidOrderBy, err)
+	}
 	res, err := p.ArchiveServiceHandler.ListChapters(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	if order == nil {
+		res.Msg.SetResults(readingOrder(res.Msg.GetResults()))
+	}
 
 	return res, applyPagination(
 		ctx,
+		p.tokens,
 		req.Msg,
 		res.Msg,
+		p.programs,
 		p.chaptersEnv,
 		chaptersCELType,
+		order,
 		func(tkn *eratov1.ListChaptersPaginationToken) {
 			results := res.Msg.GetResults()
 			if idx := slices.IndexFunc(results, func(chapter *eratov1.Chapter) bool {
@@ -214,6 +375,32 @@ func (p *Paginator) ListChapters(
 	)
 }
 
+// readingOrder returns the chapters in the order they were posted, except that
+// numbered chapters are ordered by number among the positions they occupy.
+// Unnumbered chapters, such as a prologue or an afterword, keep their place
+// between the numbered chapters posted before and after them.
+func readingOrder(chapters []*eratov1.Chapter) []*eratov1.Chapter {
+	ordered := slices.Clone(chapters)
+	slices.SortStableFunc(ordered, func(a, b *eratov1.Chapter) int {
+		return a.GetUpdateTime().AsTime().Compare(b.GetUpdateTime().AsTime())
+	})
+	var positions []int
+	var numbered []*eratov1.Chapter
+	for idx, chapter := range ordered {
+		if chapter.GetNumber() > 0 {
+			positions = append(positions, idx)
+			numbered = append(numbered, chapter)
+		}
+	}
+	slices.SortStableFunc(numbered, func(a, b *eratov1.Chapter) int {
+		return cmp.Compare(a.GetNumber(), b.GetNumber())
+	})
+	for idx, position := range positions {
+		ordered[position] = numbered[idx]
+	}
+	return ordered
+}
+
 // ListUsers satisfies [eratov1connect.ArchiveServiceHandler].
 func (p *Paginator) ListUsers(
 	ctx context.Context,
@@ -226,10 +413,13 @@ func (p *Paginator) ListUsers(
 
 	return res, applyPagination(
 		ctx,
+		p.tokens,
 		req.Msg,
 		res.Msg,
+		p.programs,
 		p.usersEnv,
 		usersCELType,
+		nil,
 		func(tkn *eratov1.ListUsersPaginationToken) {
 			results := res.Msg.GetResults()
 			if idx := slices.IndexFunc(results, func(user *eratov1.User) bool {
@@ -251,6 +441,213 @@ func (p *Paginator) ListUsers(
 	)
 }
 
+// ListAccessTokens satisfies [eratov1connect.ArchiveServiceHandler].
+func (p *Paginator) ListAccessTokens(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListAccessTokensRequest],
+) (*connect.Response[eratov1.ListAccessTokensResponse], error) {
+	res, err := p.ArchiveServiceHandler.ListAccessTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, applyPagination(
+		ctx,
+		p.tokens,
+		req.Msg,
+		res.Msg,
+		p.programs,
+		p.accessTokensEnv,
+		accessTokensCELType,
+		nil,
+		func(tkn *eratov1.ListAccessTokensPaginationToken) {
+			results := res.Msg.GetResults()
+			if idx := slices.IndexFunc(results, func(token *eratov1.AccessToken) bool {
+				return token.GetPath() == tkn.GetAfterAccessToken()
+			}); idx != -1 {
+				res.Msg.SetResults(results[idx+1:])
+				return
+			}
+		},
+		func(size int, token *eratov1.ListAccessTokensPaginationToken) *eratov1.ListAccessTokensPaginationToken {
+			results := res.Msg.GetResults()[:size]
+			res.Msg.SetResults(results)
+			if token == nil {
+				token = &eratov1.ListAccessTokensPaginationToken{}
+			}
+			token.SetAfterAccessToken(results[size-1].GetPath())
+			return token
+		},
+	)
+}
+
+// ListAuditEvents satisfies [eratov1connect.ArchiveServiceHandler]. Unlike the
+// other listings, the audit log is too large to load at once, so batches of
+// events are read from inner and filtered until the page is full and another
+// match follows it. Pages hold [defaultAuditEventsPageSize] events unless
+// max_page_size is set. The total size is only set if there is no filter, as
+// counting the matches would read the whole audit log.
+func (p *Paginator) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListAuditEventsRequest],
+) (*connect.Response[eratov1.ListAuditEventsResponse], error) {
+	if filter := req.Msg.GetFilter(); filter != "" {
+		if _, err := p.programs.program(p.auditEventsEnv, auditEventsCELType, filter); err != nil {
+			return nil, filterError(err)
+		}
+	}
+
+	// without a filter, every event in a batch is kept, so the batches need
+	// not be larger than the page and the event after it
+	size := int(req.Msg.GetMaxPageSize())
+	if size <= 0 {
+		size = defaultAuditEventsPageSize
+	}
+	batchReq := eratov1.ListAuditEventsRequest_builder{
+		Filter:      req.Msg.GetFilter(),
+		MaxPageSize: listAuditEventsBatchSize,
+	}.Build()
+	if req.Msg.GetFilter() == "" {
+		batchReq.SetMaxPageSize(int32(size + 1)) //nolint:gosec // bounded by validation
+	}
+	if tkn := req.Msg.GetPageToken(); tkn != "" {
+		token := &eratov1.ListAuditEventsPaginationToken{}
+		if err := p.tokens.FromToken(req.Msg, tkn, token); err != nil {
+			return nil, fieldError(pageTokenField, reasonInvalidPageToken, err)
+		}
+		batchTkn, err := p.tokens.ToToken(batchReq, token)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		batchReq.SetPageToken(batchTkn)
+	}
+
+	// every batch is filtered as of the same time
+	ctx = withFilterTime(ctx, time.Now())
+	res := &eratov1.ListAuditEventsResponse{}
+	var results []*eratov1.AuditEvent
+	for first := true; ; first = false {
+		batch, err := p.ArchiveServiceHandler.ListAuditEvents(ctx, connect.NewRequest(batchReq))
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			res.SetTotalSize(batch.Msg.GetTotalSize())
+		}
+		matched, err := filterResults(ctx, p.programs, p.auditEventsEnv, req.Msg, batch.Msg.GetResults(), auditEventsCELType)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range matched {
+			if len(results) < size {
+				results = append(results, event)
+				continue
+			}
+			// another match follows the full page
+			tkn, err := p.tokens.ToToken(req.Msg, eratov1.ListAuditEventsPaginationToken_builder{
+				AfterAuditEvent: results[size-1].GetPath(),
+			}.Build())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			res.SetResults(results)
+			res.SetNextPageToken(tkn)
+			return connect.NewResponse(res), nil
+		}
+		if batch.Msg.GetNextPageToken() == "" {
+			break
+		}
+		batchReq.SetPageToken(batch.Msg.GetNextPageToken())
+	}
+	res.SetResults(results)
+	return connect.NewResponse(res), nil
+}
+
+// ListSavedViews satisfies [eratov1connect.ArchiveServiceHandler].
+func (p *Paginator) ListSavedViews(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListSavedViewsRequest],
+) (*connect.Response[eratov1.ListSavedViewsResponse], error) {
+	res, err := p.ArchiveServiceHandler.ListSavedViews(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, applyPagination(
+		ctx,
+		p.tokens,
+		req.Msg,
+		res.Msg,
+		p.programs,
+		p.savedViewsEnv,
+		savedViewsCELType,
+		nil,
+		func(tkn *eratov1.ListSavedViewsPaginationToken) {
+			results := res.Msg.GetResults()
+			if idx := slices.IndexFunc(results, func(view *eratov1.SavedView) bool {
+				return view.GetPath() == tkn.GetAfterSavedView()
+			}); idx != -1 {
+				res.Msg.SetResults(results[idx+1:])
+				return
+			}
+		},
+		func(size int, token *eratov1.ListSavedViewsPaginationToken) *eratov1.ListSavedViewsPaginationToken {
+			results := res.Msg.GetResults()[:size]
+			res.Msg.SetResults(results)
+			if token == nil {
+				token = &eratov1.ListSavedViewsPaginationToken{}
+			}
+			token.SetAfterSavedView(results[size-1].GetPath())
+			return token
+		},
+	)
+}
+
+// CreateSavedView satisfies [eratov1connect.ArchiveServiceHandler]. The view's
+// filter and order are checked as if listing entries with them.
+func (p *Paginator) CreateSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.CreateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	if err := p.checkSavedView(req.Msg.GetSavedView(), nil); err != nil {
+		return nil, err
+	}
+	return p.ArchiveServiceHandler.CreateSavedView(ctx, req)
+}
+
+// UpdateSavedView satisfies [eratov1connect.ArchiveServiceHandler]. The view's
+// updated filter and order are checked as if listing entries with them.
+func (p *Paginator) UpdateSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.UpdateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	if err := p.checkSavedView(req.Msg.GetSavedView(), req.Msg.GetUpdateMask().GetPaths()); err != nil {
+		return nil, err
+	}
+	return p.ArchiveServiceHandler.UpdateSavedView(ctx, req)
+}
+
+// checkSavedView checks the view's filter and order compile against entries.
+// If paths is non-nil, only the fields in it are checked.
+func (p *Paginator) checkSavedView(view *eratov1.SavedView, paths []string) error {
+	checked := func(field string) bool {
+		return paths == nil || slices.ContainsFunc(paths, func(path string) bool {
+			return strings.EqualFold(path, field)
+		})
+	}
+	if checked(filterField) && view.GetFilter() != "" {
+		if _, err := p.programs.program(p.entriesEnv, entriesCELType, view.GetFilter()); err != nil {
+			return fieldError("saved_view.filter", reasonInvalidFilter, err)
+		}
+	}
+	if checked(orderByField) {
+		if _, err := parseOrdering(entriesFieldDesc.Message(), view.GetOrderBy()); err != nil {
+			return fieldError("saved_view.order_by", reasonInvalidOrderBy, err)
+		}
+	}
+	return nil
+}
+
 type paginatedRequest interface {
 	GetPageToken() string
 	GetMaxPageSize() int32
@@ -261,6 +658,14 @@ type filteredRequest interface {
 	GetFilter() string
 }
 
+// keyedToken is the page token of a list that may be ordered with order_by.
+type keyedToken[E any] interface {
+	GetOrderBy() string
+	SetOrderBy(value string)
+	GetAfterKey() *E
+	SetAfterKey(value *E)
+}
+
 type paginatedResponse[E any] interface {
 	proto.Message
 
@@ -268,45 +673,79 @@ type paginatedResponse[E any] interface {
 	SetResults(value []*E)
 	GetNextPageToken() string
 	SetNextPageToken(value string)
+	GetTotalSize() int32
+	SetTotalSize(value int32)
 }
 
 func applyPagination[Elem any, Tkn proto.Message](
 	ctx context.Context,
+	tokens *pagination.Codec,
 	req paginatedRequest,
 	res paginatedResponse[Elem],
+	programs *filterCache,
 	env *cel.Env,
 	resultsType *cel.Type,
+	order ordering,
 	applyPageTokenFn func(tkn Tkn),
 	applyPageSizeFn func(size int, tkn Tkn) Tkn,
 ) error {
-	if err := applyToken(req.GetPageToken(), applyPageTokenFn); err != nil {
+	sortResults(order, res.GetResults())
+	// the whole result set is filtered to count it, but page tokens are
+	// resolved against the unfiltered results
+	all := res.GetResults()
+	matched, err := filterResults(ctx, programs, env, req, all, resultsType)
+	if err != nil {
 		return err
 	}
-	if err := applyFilter(ctx, env, req, res, resultsType); err != nil {
+	res.SetTotalSize(int32(len(matched))) //nolint:gosec // bounded by the results
+	if err := applyToken(tokens, req, res, order, applyPageTokenFn); err != nil {
 		return err
 	}
-	return applyPageSize(req, res, applyPageSizeFn)
+	if len(matched) < len(all) {
+		res.SetResults(onlyMatched(res.GetResults(), matched))
+	}
+	return applyPageSize(tokens, req, res, order, applyPageSizeFn)
 }
 
-func applyToken[Tkn proto.Message](
-	pageTkn string,
+// applyToken resumes results after the page token. Ordered results resume
+// after the token's key; otherwise, applyFn slices the results.
+func applyToken[Elem any, Tkn proto.Message](
+	tokens *pagination.Codec,
+	req paginatedRequest,
+	res paginatedResponse[Elem],
+	order ordering,
 	applyFn func(tkn Tkn),
 ) error {
+	pageTkn := req.GetPageToken()
 	if pageTkn == "" {
 		return nil
 	}
 	var tkn Tkn
 	tkn = tkn.ProtoReflect().New().Interface().(Tkn) //nolint:forcetypeassert // guaranteed to be the right type
-	if err := pagination.FromToken(pageTkn, tkn); err != nil {
-		return connect.NewError(connect.CodeInvalidArgument, err)
+	if err := tokens.FromToken(req, pageTkn, tkn); err != nil {
+		return fieldError(pageTokenField, reasonInvalidPageToken, err)
+	}
+	if keyed, ok := any(tkn).(keyedToken[Elem]); ok {
+		if keyed.GetOrderBy() != order.String() {
+			return fieldError(orderByField, reasonInvalidOrderBy, errors.New("order_by must not change between pages"))
+		}
+		if order != nil {
+			// the key is unset to start from the first result
+			if key := keyed.GetAfterKey(); key != nil {
+				res.SetResults(resultsAfter(order, res.GetResults(), key))
+			}
+			return nil
+		}
 	}
 	applyFn(tkn)
 	return nil
 }
 
 func applyPageSize[Tkn proto.Message, Elem any](
+	tokens *pagination.Codec,
 	req paginatedRequest,
 	res paginatedResponse[Elem],
+	order ordering,
 	applyFn func(size int, tkn Tkn) Tkn,
 ) error {
 	results := res.GetResults()
@@ -318,13 +757,18 @@ func applyPageSize[Tkn proto.Message, Elem any](
 	var tkn Tkn
 	if nextTkn := res.GetNextPageToken(); nextTkn != "" {
 		tkn = tkn.ProtoReflect().New().Interface().(Tkn) //nolint:forcetypeassert // guaranteed to be the right type
-		if err := pagination.FromToken(nextTkn, tkn); err != nil {
+		if err := tokens.FromToken(req, nextTkn, tkn); err != nil {
 			return connect.NewError(connect.CodeInvalidArgument, err)
 		}
 	}
 
 	if tkn = applyFn(size, tkn); tkn.ProtoReflect().IsValid() {
-		tknStr, err := pagination.ToToken(tkn)
+		if keyed, ok := any(tkn).(keyedToken[Elem]); ok && order != nil {
+			results = res.GetResults()
+			keyed.SetOrderBy(order.String())
+			keyed.SetAfterKey(orderKey(order, results[len(results)-1]))
+		}
+		tknStr, err := tokens.ToToken(req, tkn)
 		if err != nil {
 			return connect.NewError(connect.CodeInternal, err)
 		}
@@ -336,42 +780,58 @@ func applyPageSize[Tkn proto.Message, Elem any](
 	return nil
 }
 
-func applyFilter[Elem any](
+// filterResults returns the results matching the request's filter, or all of
+// them if it has none.
+func filterResults[Elem any](
 	ctx context.Context,
+	programs *filterCache,
 	env *cel.Env,
 	req paginatedRequest,
-	res paginatedResponse[Elem],
+	results []*Elem,
 	resultsType *cel.Type,
-) error {
+) ([]*Elem, error) {
 	freq, ok := req.(filteredRequest)
-	if !ok || freq.GetFilter() == "" || len(res.GetResults()) == 0 {
-		return nil
+	if !ok || freq.GetFilter() == "" || len(results) == 0 {
+		return results, nil
 	}
-	prog, err := compileFilter(env, resultsType, freq.GetFilter())
+	prog, err := programs.program(env, resultsType, freq.GetFilter())
 	if err != nil {
-		return connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, filterError(err)
 	}
-	val, _, err := prog.ContextEval(ctx, map[string]any{
-		resultsVar: res.GetResults(),
-	})
+	vars := filterVars(ctx, req)
+	vars[resultsVar] = results
+	val, _, err := prog.ContextEval(ctx, vars)
 	if err != nil {
-		return connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, filterError(err)
 	}
 	list, ok := val.Value().([]ref.Val)
 	if !ok {
-		return connect.NewError(connect.CodeInternal, fmt.Errorf("expected list, got %T", val.Value()))
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("expected list, got %T", val.Value()))
 	}
 	out := make([]*Elem, len(list))
 	for i, el := range list {
 		elem, ok := el.Value().(*Elem)
 		if !ok {
-			return connect.NewError(connect.CodeInternal, fmt.Errorf("expected %T, got %T", (*Elem)(nil), el.Value()))
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("expected %T, got %T", (*Elem)(nil), el.Value()))
 		}
 		out[i] = elem
 	}
+	return out, nil
+}
 
-	res.SetResults(out)
-	return nil
+// onlyMatched returns the results that are also in matched.
+func onlyMatched[Elem any](results, matched []*Elem) []*Elem {
+	keep := make(map[*Elem]bool, len(matched))
+	for _, elem := range matched {
+		keep[elem] = true
+	}
+	out := make([]*Elem, 0, min(len(results), len(matched)))
+	for _, elem := range results {
+		if keep[elem] {
+			out = append(out, elem)
+		}
+	}
+	return out
 }
 
 func compileFilter(env *cel.Env, resultsType *cel.Type, filter string) (cel.Program, error) {
@@ -386,7 +846,10 @@ func compileFilter(env *cel.Env, resultsType *cel.Type, filter string) (cel.Prog
 		return nil, fmt.Errorf("filter expression must return %s but got %s", resultsType.String(), outType.String())
 	}
 
-	return env.Program(ast)
+	return env.Program(ast,
+		cel.CostLimit(maxFilterCost),
+		cel.InterruptCheckFrequency(filterInterruptFrequency),
+	)
 }
 
 func initCELEnv(
@@ -394,10 +857,11 @@ func initCELEnv(
 	resultsField protoreflect.FieldDescriptor,
 	resultsType *cel.Type,
 	name string,
+	opts ...cel.EnvOption,
 ) (*cel.Env, error) {
 	env, err := base.Extend(append(
 		celext.RequiredEnvOptions(resultsField),
-		cel.Variable(resultsVar, resultsType),
+		append(opts, cel.Variable(resultsVar, resultsType))...,
 	)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create %s CEL environment: %w", name, err)
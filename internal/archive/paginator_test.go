@@ -2,17 +2,35 @@ package archive
 
 import (
 	"context"
+	"path"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	celext "buf.build/go/protovalidate/cel"
+	"connectrpc.com/connect"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/pagination"
+	"github.com/stolasapp/erato/internal/slugconv"
 )
 
+// testTokens signs the page tokens of the handlers under test.
+var testTokens = func() *pagination.Codec {
+	codec, err := pagination.NewCodec([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}()
+
 func TestCompileFilter(t *testing.T) {
 	t.Parallel()
 
@@ -157,10 +175,300 @@ func TestNewPaginator(t *testing.T) {
 	t.Parallel()
 
 	// Test that NewPaginator successfully initializes all CEL environments
-	paginator, err := NewPaginator(nil)
+	paginator, err := NewPaginator(nil, testTokens)
 	require.NoError(t, err)
 	assert.NotNil(t, paginator.categoriesEnv)
 	assert.NotNil(t, paginator.entriesEnv)
 	assert.NotNil(t, paginator.chaptersEnv)
 	assert.NotNil(t, paginator.usersEnv)
 }
+
+func TestPaginatorTotalSize(t *testing.T) {
+	t.Parallel()
+
+	entry := func(slug string, starred bool) *eratov1.Entry {
+		return eratov1.Entry_builder{
+			Path:        "categories/cat/entries/" + slug,
+			DisplayName: slug,
+			UpdateTime:  timestamppb.Now(),
+			Starred:     starred,
+		}.Build()
+	}
+	paginator, err := NewPaginator(NewListings(&pagedEntries{pages: [][]*eratov1.Entry{
+		{entry("a", true), entry("b", false), entry("c", true)},
+		{entry("d", false), entry("e", true)},
+	}}, testTokens), testTokens)
+	require.NoError(t, err)
+
+	list := func(t *testing.T, filter, orderBy, token string) *eratov1.ListEntriesResponse {
+		t.Helper()
+		res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      "categories/cat",
+			Filter:      filter,
+			MaxPageSize: 1,
+			PageToken:   token,
+			OrderBy:     orderBy,
+		}.Build()))
+		require.NoError(t, err)
+		return res.Msg
+	}
+
+	t.Run("counted on the upstream page", func(t *testing.T) {
+		t.Parallel()
+		res := list(t, "", "", "")
+		assert.Len(t, res.GetResults(), 1)
+		assert.Equal(t, int32(3), res.GetTotalSize())
+		assert.Equal(t, int32(3), res.GetUnfilteredTotalSize())
+		assert.True(t, res.GetTotalSizeEstimated())
+	})
+
+	t.Run("filtered on the upstream page", func(t *testing.T) {
+		t.Parallel()
+		res := list(t, "this.starred", "", "")
+		assert.Equal(t, int32(2), res.GetTotalSize(), "only the matches on the upstream page are counted")
+		assert.Equal(t, int32(3), res.GetUnfilteredTotalSize(), "counted on the same page")
+		assert.True(t, res.GetTotalSizeEstimated())
+	})
+
+	t.Run("exact when ordered", func(t *testing.T) {
+		t.Parallel()
+		res := list(t, "this.starred", "display_name", "")
+		assert.Equal(t, int32(3), res.GetTotalSize())
+		assert.Equal(t, int32(5), res.GetUnfilteredTotalSize())
+		assert.False(t, res.GetTotalSizeEstimated())
+
+		res = list(t, "this.starred", "display_name", res.GetNextPageToken())
+		require.Len(t, res.GetResults(), 1)
+		assert.Equal(t, "c", res.GetResults()[0].GetDisplayName())
+		assert.Equal(t, int32(3), res.GetTotalSize(), "unchanged between pages")
+	})
+}
+
+func TestPaginatorAllCategories(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	entry := func(category, slug string, age time.Duration) *eratov1.Entry {
+		return eratov1.Entry_builder{
+			Path:        "categories/" + category + "/entries/" + slug,
+			DisplayName: slug,
+			UpdateTime:  timestamppb.New(now.Add(-age)),
+		}.Build()
+	}
+	upstream := &categorizedEntries{
+		categories: []*eratov1.Category{
+			eratov1.Category_builder{Path: "categories/one"}.Build(),
+			eratov1.Category_builder{Path: "categories/two"}.Build(),
+			eratov1.Category_builder{Path: "categories/hidden", Hidden: true}.Build(),
+		},
+		entries: map[string][]*eratov1.Entry{
+			"categories/one":    {entry("one", "a", time.Hour), entry("one", "c", 3*time.Hour)},
+			"categories/two":    {entry("two", "b", 2*time.Hour)},
+			"categories/hidden": {entry("hidden", "d", 0)},
+		},
+	}
+	paginator, err := NewPaginator(NewListings(upstream, testTokens), testTokens)
+	require.NoError(t, err)
+
+	list := func(t *testing.T, orderBy, token string) *eratov1.ListEntriesResponse {
+		t.Helper()
+		res, err := paginator.ListEntries(t.Context(), connect.NewRequest(eratov1.ListEntriesRequest_builder{
+			Parent:      allCategoriesParent,
+			MaxPageSize: 2,
+			PageToken:   token,
+			OrderBy:     orderBy,
+		}.Build()))
+		require.NoError(t, err)
+		return res.Msg
+	}
+	names := func(res *eratov1.ListEntriesResponse) (names []string) {
+		for _, entry := range res.GetResults() {
+			names = append(names, entry.GetDisplayName())
+		}
+		return names
+	}
+
+	res := list(t, "", "")
+	assert.Equal(t, []string{"a", "b"}, names(res), "most recently updated first")
+	assert.Equal(t, int32(3), res.GetTotalSize(), "hidden categories are skipped")
+	listed := upstream.calls.Load()
+	res = list(t, "", res.GetNextPageToken())
+	assert.Equal(t, []string{"c"}, names(res))
+	assert.Empty(t, res.GetNextPageToken())
+	assert.Equal(t, listed, upstream.calls.Load(), "later pages reuse the listing")
+
+	res = list(t, "display_name desc", "")
+	assert.Equal(t, []string{"c", "b"}, names(res))
+}
+
+// categorizedEntries lists entries on a single upstream page per category.
+type categorizedEntries struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	categories []*eratov1.Category
+	entries    map[string][]*eratov1.Entry
+	calls      atomic.Int32
+}
+
+func (c *categorizedEntries) ListCategories(
+	context.Context,
+	*connect.Request[eratov1.ListCategoriesRequest],
+) (*connect.Response[eratov1.ListCategoriesResponse], error) {
+	return connect.NewResponse(eratov1.ListCategoriesResponse_builder{
+		Results: c.categories,
+	}.Build()), nil
+}
+
+func (c *categorizedEntries) ListEntries(
+	_ context.Context,
+	req *connect.Request[eratov1.ListEntriesRequest],
+) (*connect.Response[eratov1.ListEntriesResponse], error) {
+	c.calls.Add(1)
+	entries, ok := c.entries[req.Msg.GetParent()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, nil)
+	}
+	return connect.NewResponse(eratov1.ListEntriesResponse_builder{
+		Results: entries,
+	}.Build()), nil
+}
+
+func TestPaginatorChapters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	chapter := func(slug string, age time.Duration, read bool) *eratov1.Chapter {
+		bldr := eratov1.Chapter_builder{
+			Path:       "categories/cat/entries/anthology/chapters/" + slug,
+			UpdateTime: timestamppb.New(now.Add(-age)),
+			Number:     slugconv.ToChapterNumber(slug),
+		}
+		if read {
+			bldr.ReadTime = timestamppb.New(now)
+		}
+		return bldr.Build()
+	}
+	paginator, err := NewPaginator(&listedChapters{chapters: []*eratov1.Chapter{
+		chapter("chapter-10-end", time.Hour, false),
+		chapter("chapter-2-middle", 2*time.Hour, false),
+		chapter("afterword", 3*time.Hour, false),
+		chapter("chapter-1-start", 4*time.Hour, true),
+		chapter("prologue", 5*time.Hour, false),
+	}}, testTokens)
+	require.NoError(t, err)
+
+	list := func(t *testing.T, filter, orderBy string) (slugs []string) {
+		t.Helper()
+		var token string
+		for {
+			res, err := paginator.ListChapters(t.Context(), connect.NewRequest(eratov1.ListChaptersRequest_builder{
+				Parent:      "categories/cat/entries/anthology",
+				Filter:      filter,
+				MaxPageSize: 2,
+				PageToken:   token,
+				OrderBy:     orderBy,
+			}.Build()))
+			require.NoError(t, err)
+			for _, chapter := range res.Msg.GetResults() {
+				slugs = append(slugs, path.Base(chapter.GetPath()))
+			}
+			if token = res.Msg.GetNextPageToken(); token == "" {
+				return slugs
+			}
+		}
+	}
+
+	assert.Equal(t,
+		[]string{"prologue", "chapter-1-start", "afterword", "chapter-2-middle", "chapter-10-end"},
+		list(t, "", ""),
+		"reading order keeps unnumbered chapters where they were posted",
+	)
+	assert.Equal(t,
+		[]string{"prologue", "afterword", "chapter-1-start", "chapter-2-middle", "chapter-10-end"},
+		list(t, "", "number, update_time"),
+	)
+	assert.Equal(t,
+		[]string{"chapter-2-middle", "chapter-10-end"},
+		list(t, `!has(this.read_time) && this.number > 0 && category == "categories/cat"`, "number"),
+	)
+
+	_, err = paginator.ListChapters(t.Context(), connect.NewRequest(eratov1.ListChaptersRequest_builder{
+		Parent: "categories/cat/entries/anthology",
+		Filter: "this.starred",
+	}.Build()))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "chapters cannot be starred")
+}
+
+func TestReadingOrder(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	chapter := func(slug string, age time.Duration) *eratov1.Chapter {
+		return eratov1.Chapter_builder{
+			Path:       slug,
+			UpdateTime: timestamppb.New(now.Add(-age)),
+			Number:     slugconv.ToChapterNumber(slug),
+		}.Build()
+	}
+	tests := []struct {
+		name     string
+		chapters []*eratov1.Chapter
+		expected []string
+	}{
+		{"empty", nil, nil},
+		{
+			"unnumbered in posting order",
+			[]*eratov1.Chapter{chapter("epilogue", time.Hour), chapter("prologue", 2*time.Hour)},
+			[]string{"prologue", "epilogue"},
+		},
+		{
+			"numbered by number",
+			[]*eratov1.Chapter{chapter("ch-1", time.Hour), chapter("ch-3", 2*time.Hour), chapter("ch-2", 3*time.Hour)},
+			[]string{"ch-1", "ch-2", "ch-3"},
+		},
+		{
+			"unnumbered between numbered",
+			[]*eratov1.Chapter{
+				chapter("epilogue", time.Hour),
+				chapter("ch-1", 2*time.Hour),
+				chapter("interlude", 3*time.Hour),
+				chapter("ch-2", 4*time.Hour),
+				chapter("prologue", 5*time.Hour),
+			},
+			[]string{"prologue", "ch-1", "interlude", "ch-2", "epilogue"},
+		},
+		{
+			"repeated numbers in posting order",
+			[]*eratov1.Chapter{chapter("part-1-b", time.Hour), chapter("part-1-a", 2*time.Hour)},
+			[]string{"part-1-a", "part-1-b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			upstream := slices.Clone(test.chapters)
+			var slugs []string
+			for _, chapter := range readingOrder(test.chapters) {
+				slugs = append(slugs, chapter.GetPath())
+			}
+			assert.Equal(t, test.expected, slugs)
+			assert.Equal(t, upstream, test.chapters, "the listing is not reordered in place")
+		})
+	}
+}
+
+// listedChapters lists the chapters of any anthology.
+type listedChapters struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	chapters []*eratov1.Chapter
+}
+
+func (l *listedChapters) ListChapters(
+	context.Context,
+	*connect.Request[eratov1.ListChaptersRequest],
+) (*connect.Response[eratov1.ListChaptersResponse], error) {
+	return connect.NewResponse(eratov1.ListChaptersResponse_builder{
+		Results: l.chapters,
+	}.Build()), nil
+}
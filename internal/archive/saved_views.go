@@ -0,0 +1,218 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const savedViewsCollection = "/saved-views/"
+
+// SavedViews is an [eratov1connect.ArchiveServiceHandler] decorator to handle
+// users' saved views. Like [AccessTokens], this decorator should be attached
+// inside the [Paginator], which also validates the views' filters and orders.
+type SavedViews struct {
+	eratov1connect.ArchiveServiceHandler
+
+	store storage.SavedViews
+}
+
+// NewSavedViews wraps inner and uses the provided store to handle saved view
+// operations.
+func NewSavedViews(inner eratov1connect.ArchiveServiceHandler, store storage.SavedViews) SavedViews {
+	return SavedViews{
+		ArchiveServiceHandler: inner,
+		store:                 store,
+	}
+}
+
+// CreateSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (s SavedViews) CreateSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.CreateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	// only allowed to create views for yourself
+	authd := sec.GetAuthenticatedUser(ctx)
+	if req.Msg.GetParent() != authd.Path() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	now := time.Now().UTC()
+	view := db.SavedView{
+		User:        authd.ID,
+		DisplayName: req.Msg.GetSavedView().GetDisplayName(),
+		Filter:      req.Msg.GetSavedView().GetFilter(),
+		OrderBy:     req.Msg.GetSavedView().GetOrderBy(),
+		Category:    req.Msg.GetSavedView().GetCategory(),
+		Version:     1,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+	id, err := s.store.CreateSavedView(ctx, view)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	view.ID = id
+	return connect.NewResponse(savedViewToProto(authd, view)), nil
+}
+
+// ListSavedViews satisfies [eratov1connect.ArchiveServiceHandler].
+func (s SavedViews) ListSavedViews(
+	ctx context.Context,
+	req *connect.Request[eratov1.ListSavedViewsRequest],
+) (*connect.Response[eratov1.ListSavedViewsResponse], error) {
+	// only allowed to list your own views
+	authd := sec.GetAuthenticatedUser(ctx)
+	if req.Msg.GetParent() != authd.Path() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	views, err := s.store.ListSavedViews(ctx, authd.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	results := make([]*eratov1.SavedView, len(views))
+	for i, view := range views {
+		results[i] = savedViewToProto(authd, view)
+	}
+	return connect.NewResponse(eratov1.ListSavedViewsResponse_builder{
+		Results: results,
+	}.Build()), nil
+}
+
+// GetSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (s SavedViews) GetSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.GetSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	authd := sec.GetAuthenticatedUser(ctx)
+	view, err := s.resolveSavedView(ctx, authd, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(savedViewToProto(authd, view)), nil
+}
+
+// UpdateSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (s SavedViews) UpdateSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.UpdateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	authd := sec.GetAuthenticatedUser(ctx)
+	view, err := s.resolveSavedView(ctx, authd, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	update := req.Msg.GetSavedView()
+	if err = checkEtag(update.GetEtag(), view.Version); err != nil {
+		return nil, err
+	}
+
+	mask := req.Msg.GetUpdateMask()
+	if err = checkUpdateMask(mask, update); err != nil {
+		return nil, err
+	}
+	for _, path := range mask.GetPaths() {
+		switch strings.ToLower(path) {
+		case "display_name":
+			if update.GetDisplayName() == "" {
+				return nil, fieldError("saved_view.display_name", "REQUIRED", errors.New("display_name is required"))
+			}
+			view.DisplayName = update.GetDisplayName()
+		case "filter":
+			view.Filter = update.GetFilter()
+		case "order_by":
+			view.OrderBy = update.GetOrderBy()
+		case "category":
+			view.Category = update.GetCategory()
+		default:
+			return nil, unknownMaskPath(path)
+		}
+	}
+
+	view.UpdateTime = time.Now().UTC()
+	if err = s.store.UpdateSavedView(ctx, view); errors.Is(err, storage.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return nil, upsertError(err)
+	}
+	view.Version++ // incremented by the update
+	return connect.NewResponse(savedViewToProto(authd, view)), nil
+}
+
+// DeleteSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (s SavedViews) DeleteSavedView(
+	ctx context.Context,
+	req *connect.Request[eratov1.DeleteSavedViewRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	authd := sec.GetAuthenticatedUser(ctx)
+	id, err := savedViewID(authd, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.store.DeleteSavedView(ctx, authd.ID, id); errors.Is(err, storage.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// resolveSavedView returns the authenticated user's saved view at path.
+func (s SavedViews) resolveSavedView(ctx context.Context, authd db.User, path string) (db.SavedView, error) {
+	id, err := savedViewID(authd, path)
+	if err != nil {
+		return db.SavedView{}, err
+	}
+	view, err := s.store.GetSavedView(ctx, authd.ID, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return view, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return view, connect.NewError(connect.CodeInternal, err)
+	}
+	return view, nil
+}
+
+// savedViewID parses the ID of a saved view at path, which must belong to
+// authd.
+func savedViewID(authd db.User, path string) (int64, error) {
+	// only allowed to access your own views
+	rawID, ok := strings.CutPrefix(path, authd.Path()+savedViewsCollection)
+	if !ok {
+		return 0, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return 0, connect.NewError(connect.CodeNotFound, nil)
+	}
+	return id, nil
+}
+
+func savedViewToProto(user db.User, view db.SavedView) *eratov1.SavedView {
+	return eratov1.SavedView_builder{
+		Path:        user.Path() + savedViewsCollection + strconv.FormatInt(view.ID, 10),
+		DisplayName: view.DisplayName,
+		Filter:      view.Filter,
+		OrderBy:     view.OrderBy,
+		Category:    view.Category,
+		Etag:        formatEtag(view.Version),
+		CreateTime:  timestamppb.New(view.CreateTime),
+		UpdateTime:  timestamppb.New(view.UpdateTime),
+	}.Build()
+}
+
+var _ eratov1connect.ArchiveServiceHandler = SavedViews{}
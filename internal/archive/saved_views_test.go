@@ -0,0 +1,194 @@
+package archive
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestSavedViews(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	other := db.User{ID: 456, Name: "other", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), other))
+
+	paginator, err := NewPaginator(NewSavedViews(eratov1connect.UnimplementedArchiveServiceHandler{}, store), testTokens)
+	require.NoError(t, err)
+	handler, err := NewValidator(paginator, slog.Default())
+	require.NoError(t, err)
+
+	ctx := sec.SetAuthenticatedUser(t.Context(), user)
+	create := func(t *testing.T, view *eratov1.SavedView) (*eratov1.SavedView, error) {
+		t.Helper()
+		res, err := handler.CreateSavedView(ctx, connect.NewRequest(eratov1.CreateSavedViewRequest_builder{
+			Parent:    user.Path(),
+			SavedView: view,
+		}.Build()))
+		if err != nil {
+			return nil, err
+		}
+		return res.Msg, nil
+	}
+
+	created, err := create(t, eratov1.SavedView_builder{
+		DisplayName: "Unread favorites",
+		Filter:      "this.starred && !has(this.read_time)",
+		OrderBy:     "display_name",
+		Category:    "categories/adventure",
+	}.Build())
+	require.NoError(t, err)
+	assert.Regexp(t, `^users/test/saved-views/\d+$`, created.GetPath())
+	assert.Equal(t, "Unread favorites", created.GetDisplayName())
+	assert.NotEmpty(t, created.GetEtag())
+	assert.True(t, created.HasCreateTime())
+
+	t.Run("get and list", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := handler.GetSavedView(ctx, connect.NewRequest(eratov1.GetSavedViewRequest_builder{
+			Path: created.GetPath(),
+		}.Build()))
+		require.NoError(t, err)
+		assert.Equal(t, created.GetFilter(), got.Msg.GetFilter())
+		assert.Equal(t, created.GetCategory(), got.Msg.GetCategory())
+
+		res, err := handler.ListSavedViews(ctx, connect.NewRequest(eratov1.ListSavedViewsRequest_builder{
+			Parent: user.Path(),
+			Filter: `this.category == "categories/adventure"`,
+		}.Build()))
+		require.NoError(t, err)
+		require.Len(t, res.Msg.GetResults(), 1)
+		assert.Equal(t, created.GetPath(), res.Msg.GetResults()[0].GetPath())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			view  *eratov1.SavedView
+			field string
+		}{
+			{"no name", eratov1.SavedView_builder{Filter: "this.starred"}.Build(), ""},
+			{"filter", eratov1.SavedView_builder{DisplayName: "bad", Filter: "this.title"}.Build(), "saved_view.filter"},
+			{"order", eratov1.SavedView_builder{DisplayName: "bad", OrderBy: "etag"}.Build(), "saved_view.order_by"},
+			{"category", eratov1.SavedView_builder{DisplayName: "bad", Category: "entries/foo"}.Build(), ""},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+				_, err := create(t, test.view)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				if test.field == "" {
+					return
+				}
+				violations := fieldViolations(t, err)
+				require.Len(t, violations, 1)
+				assert.Equal(t, test.field, violations[0].GetField())
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		view, err := create(t, eratov1.SavedView_builder{DisplayName: "Starred", Filter: "this.starred"}.Build())
+		require.NoError(t, err)
+		// a separate view, so the other subtests are unaffected
+		update := func(update *eratov1.SavedView, paths ...string) (*connect.Response[eratov1.SavedView], error) {
+			return handler.UpdateSavedView(ctx, connect.NewRequest(eratov1.UpdateSavedViewRequest_builder{
+				Path:       view.GetPath(),
+				SavedView:  update,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+			}.Build()))
+		}
+
+		res, err := update(eratov1.SavedView_builder{
+			DisplayName: "Renamed",
+			OrderBy:     "update_time",
+			Etag:        view.GetEtag(),
+		}.Build(), "display_name", "order_by")
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", res.Msg.GetDisplayName())
+		assert.Equal(t, "update_time", res.Msg.GetOrderBy())
+		assert.Equal(t, "this.starred", res.Msg.GetFilter(), "unmasked fields are unchanged")
+		assert.NotEqual(t, view.GetEtag(), res.Msg.GetEtag())
+
+		_, err = update(eratov1.SavedView_builder{Filter: "this.starred", Etag: view.GetEtag()}.Build(), "filter")
+		assert.Equal(t, connect.CodeAborted, connect.CodeOf(err), "stale etag")
+
+		_, err = update(eratov1.SavedView_builder{Filter: "this.title"}.Build(), "filter")
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = update(eratov1.SavedView_builder{DisplayName: "Again", Filter: "this.title"}.Build(), "display_name")
+		require.NoError(t, err, "unmasked filters are not checked")
+
+		_, err = update(eratov1.SavedView_builder{Filter: "this.read"}.Build(), "display_name")
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "display_name is required")
+
+		_, err = update(&eratov1.SavedView{})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "mask is required")
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		t.Parallel()
+		otherCtx := sec.SetAuthenticatedUser(t.Context(), other)
+
+		_, err := handler.ListSavedViews(otherCtx, connect.NewRequest(eratov1.ListSavedViewsRequest_builder{
+			Parent: user.Path(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.GetSavedView(otherCtx, connect.NewRequest(eratov1.GetSavedViewRequest_builder{
+			Path: created.GetPath(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.DeleteSavedView(otherCtx, connect.NewRequest(eratov1.DeleteSavedViewRequest_builder{
+			Path: strings.Replace(created.GetPath(), user.Path(), other.Path(), 1),
+		}.Build()))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		view, err := create(t, eratov1.SavedView_builder{DisplayName: "Temporary"}.Build())
+		require.NoError(t, err)
+
+		req := connect.NewRequest(eratov1.DeleteSavedViewRequest_builder{
+			Path: view.GetPath(),
+		}.Build())
+		_, err = handler.DeleteSavedView(ctx, req)
+		require.NoError(t, err)
+
+		_, err = handler.DeleteSavedView(ctx, req)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		_, err = handler.GetSavedView(ctx, connect.NewRequest(eratov1.GetSavedViewRequest_builder{
+			Path: view.GetPath(),
+		}.Build()))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
@@ -48,12 +48,15 @@ type Scraper struct {
 	client *http.Client
 	logger *slog.Logger
 	locale *time.Location
+	tokens *pagination.Codec
 }
 
-// NewScraper creates a Scraper with the provided config and base logger.
+// NewScraper creates a Scraper with the provided config and base logger. Page
+// tokens are signed by tokens.
 func NewScraper(
 	cfg *eratov1.Config,
 	logger *slog.Logger,
+	tokens *pagination.Codec,
 	inner eratov1connect.ArchiveServiceHandler,
 ) (*Scraper, error) {
 	base, err := url.Parse(cfg.GetRootUri())
@@ -89,6 +92,7 @@ func NewScraper(
 		},
 		logger: logger.With(slog.String("component", "scraper")),
 		locale: locale,
+		tokens: tokens,
 	}, nil
 }
 
@@ -177,6 +181,12 @@ func (s *Scraper) ListEntries(
 	// categories are only paginated if they have a #scroll element
 	col.OnHTML("#scroll", func(_ *colly.HTMLElement) { paginated = true })
 
+	// distinguish missing pages, such as those past the last, from failures
+	notFound := false
+	col.OnError(func(res *colly.Response, _ error) {
+		notFound = res.StatusCode == http.StatusNotFound
+	})
+
 	s.scrapeRows(ctx, col, categorySlug, slugconv.ToEntryPath, func(
 		kind eratov1.Entry_Kind,
 		lastUpdated time.Time,
@@ -193,9 +203,8 @@ func (s *Scraper) ListEntries(
 
 	page := eratov1.ListEntriesPaginationToken_builder{Page: 1}.Build()
 	if tkn := req.Msg.GetPageToken(); tkn != "" {
-		if err := pagination.FromToken(tkn, page); err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument,
-				fmt.Errorf("malformed pagination token: %w", err))
+		if err := s.tokens.FromToken(req.Msg, tkn, page); err != nil {
+			return nil, fieldError(pageTokenField, reasonInvalidPageToken, err)
 		}
 		paginated = page.GetPage() > 1
 	}
@@ -205,7 +214,10 @@ func (s *Scraper) ListEntries(
 		addr = addr.JoinPath(fmt.Sprintf("index%d.html", page.GetPage()-1))
 	}
 
-	if err := col.Visit(addr.String()); err != nil {
+	if err := col.Visit(addr.String()); notFound {
+		return nil, connect.NewError(connect.CodeNotFound,
+			fmt.Errorf("category page %v not found", addr))
+	} else if err != nil {
 		return nil, connect.NewError(connect.CodeInternal,
 			fmt.Errorf("failed to scrape %v: %w", addr, err))
 	}
@@ -223,7 +235,7 @@ func (s *Scraper) ListEntries(
 			nextPage.AfterEntry = last.GetPath()
 			nextPage.StartUpdateTime = last.GetUpdateTime()
 		}
-		tkn, err := pagination.ToToken(nextPage.Build())
+		tkn, err := s.tokens.ToToken(req.Msg, nextPage.Build())
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInternal,
 				fmt.Errorf("failed to construct pagination token: %w", err))
@@ -259,9 +271,13 @@ func (s *Scraper) GetEntry(
 			bldr.Kind = eratov1.Entry_ANTHOLOGY
 		}
 	})
+	// count the chapters ListChapters would list
+	s.scrapeRows(ctx, col, slug, slugconv.ToChapterPath, func(eratov1.Entry_Kind, time.Time, string, string) {
+		bldr.ChapterCount++
+	})
 
-	if err := col.Visit(s.base.JoinPath(slug).String()); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	if err := visit(col, s.base.JoinPath(slug).String()); err != nil {
+		return nil, err
 	}
 
 	return connect.NewResponse(bldr.Build()), nil
@@ -297,6 +313,7 @@ func (s *Scraper) ListChapters(
 			Path:        chapterPath,
 			DisplayName: displayName,
 			UpdateTime:  timestamppb.New(lastUpdated),
+			Number:      slugconv.ToChapterNumber(chapterPath),
 		}.Build())
 	})
 
@@ -327,14 +344,15 @@ func (s *Scraper) GetChapter(
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 	bldr.DisplayName = slugconv.ToTitle(slug)
+	bldr.Number = slugconv.ToChapterNumber(slug)
 
 	col := s.newCollector(ctx)
 	s.scrapeLastModifiedHeader(ctx, col, func(timestamp time.Time) {
 		bldr.UpdateTime = timestamppb.New(timestamp)
 	})
 
-	if err := col.Visit(s.base.JoinPath(slug).String()); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	if err := visit(col, s.base.JoinPath(slug).String()); err != nil {
+		return nil, err
 	}
 
 	return connect.NewResponse(bldr.Build()), nil
@@ -487,6 +505,20 @@ func (s *Scraper) parseRow(row *colly.HTMLElement, parentLastUpdated time.Time,
 	return kind, lastUpdated, slug, title, nil
 }
 
+// visit scrapes addr with col, distinguishing missing pages from failures.
+func visit(col *colly.Collector, addr string) error {
+	notFound := false
+	col.OnError(func(res *colly.Response, _ error) {
+		notFound = res.StatusCode == http.StatusNotFound
+	})
+	if err := col.Visit(addr); notFound {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("page %v not found", addr))
+	} else if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to scrape %v: %w", addr, err))
+	}
+	return nil
+}
+
 func (s *Scraper) scrapeLastModifiedHeader(ctx context.Context, col *colly.Collector, onSuccess func(time.Time)) {
 	col.OnResponseHeaders(func(resp *colly.Response) {
 		hdr := resp.Headers.Get("Last-Modified")
@@ -3,10 +3,14 @@ package archive
 import (
 	"context"
 	"errors"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
@@ -15,54 +19,138 @@ import (
 	"github.com/stolasapp/erato/internal/storage/db"
 )
 
+// UserStore is the storage required to manage users.
+type UserStore interface {
+	storage.Users
+	storage.Invites
+}
+
 // Users is an [eratov1connect.ArchiveServiceHandler] decorator to handle user
-// CRUD operations. This decorator should be attached inside the [Paginator] to
+// CRUD operations and invites. Users may only manage themselves, unless they
+// have the admin role. Passwords are hashed within the throttle's limit on
+// concurrent hashes. Changes, including rejected attempts, are recorded in
+// the audit log. This decorator should be attached inside the [Paginator] to
 // ensure ListUsers paginates correctly.
 type Users struct {
 	eratov1connect.ArchiveServiceHandler
 
-	store storage.Users
+	store    UserStore
+	throttle *sec.Throttle
+	audit    *sec.Auditor
 }
 
-// NewUsers wraps inner and uses the provided store to handle user operations.
-func NewUsers(inner eratov1connect.ArchiveServiceHandler, store storage.Users) Users {
+// NewUsers wraps inner and uses the provided store to handle user operations,
+// hashing passwords with throttle and recording changes with audit.
+func NewUsers(
+	inner eratov1connect.ArchiveServiceHandler,
+	store UserStore,
+	throttle *sec.Throttle,
+	audit *sec.Auditor,
+) Users {
 	return Users{
 		ArchiveServiceHandler: inner,
 		store:                 store,
+		throttle:              throttle,
+		audit:                 audit,
 	}
 }
 
-// CreateUser satisfies [eratov1connect.ArchiveServiceHandler].
+const invitesCollection = "invites/"
+
+// errInvalidInvite is returned when creating a user with an invite code that
+// does not exist, has expired or has already been used.
+var errInvalidInvite = errors.New("invalid, expired or already used invite code")
+
+// listUsersBatchSize is the number of users fetched from the store at a time
+// when listing users.
+const listUsersBatchSize = 100
+
+// CreateUser satisfies [eratov1connect.ArchiveServiceHandler]. Admins may
+// create users freely, while anyone else must redeem an invite to do so. The
+// invite is checked before the password is hashed, so invalid codes are
+// rejected cheaply.
 func (u Users) CreateUser(
 	ctx context.Context,
 	req *connect.Request[eratov1.CreateUserRequest],
-) (*connect.Response[eratov1.User], error) {
-	hash, err := sec.HashPassword(req.Msg.GetUser().GetPassword())
+) (_ *connect.Response[eratov1.User], err error) {
+	subject := db.User{Name: req.Msg.GetId()}
+	defer func() { u.audit.Record(ctx, eratov1.AuditEvent_CREATE_USER, subject, err) }()
+	inviteCode := req.Msg.GetInviteCode()
+	if inviteCode == "" && !sec.GetAuthenticatedUser(ctx).IsAdmin() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	password := req.Msg.GetUser().GetPassword()
+	if password == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password is required"))
+	}
+	inviteHash, now := sec.HashInviteCode(inviteCode), time.Now()
+	if inviteCode != "" {
+		if err = u.store.CheckInvite(ctx, inviteHash, now); errors.Is(err, storage.ErrNotFound) {
+			return nil, connect.NewError(connect.CodePermissionDenied, errInvalidInvite)
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	hash, err := u.throttle.HashPassword(ctx, password)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, hashError(err)
 	}
 	user := db.User{
 		Name:         req.Msg.GetId(),
 		PasswordHash: hash,
+		Role:         db.RoleMember,
+	}
+	if inviteCode != "" {
+		err = u.store.RedeemInvite(ctx, inviteHash, now, user)
+	} else {
+		err = u.store.UpsertUser(ctx, user)
 	}
-	if err = u.store.UpsertUser(ctx, user); errors.Is(err, storage.ErrAlreadyExists) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, connect.NewError(connect.CodePermissionDenied, errInvalidInvite)
+	case errors.Is(err, storage.ErrAlreadyExists):
 		return nil, connect.NewError(connect.CodeAlreadyExists, err)
-	} else if err != nil {
+	case errors.Is(err, storage.ErrInvalidUsername):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	return connect.NewResponse(eratov1.User_builder{
-		Path: user.Path(),
-		Id:   user.Name,
-	}.Build()), nil
+	user.Version++ // incremented by the upsert
+	if created, err := u.store.GetUserByName(ctx, user.Name); err == nil {
+		subject = created // the ID is assigned by the store
+	}
+	return connect.NewResponse(userToProto(user)), nil
 }
 
-// ListUsers satisfies [eratov1connect.ArchiveServiceHandler].
+// ListUsers satisfies [eratov1connect.ArchiveServiceHandler]. All users are
+// returned; the [Paginator] is responsible for filtering and pagination.
 func (u Users) ListUsers(
-	_ context.Context,
+	ctx context.Context,
 	_ *connect.Request[eratov1.ListUsersRequest],
 ) (*connect.Response[eratov1.ListUsersResponse], error) {
-	// TODO: support getting other users if the caller's permissions allow it
-	return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	if !sec.GetAuthenticatedUser(ctx).IsAdmin() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	var results []*eratov1.User
+	afterName := ""
+	for {
+		users, err := u.store.ListUsers(ctx, afterName, listUsersBatchSize)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, user := range users {
+			results = append(results, userToProto(user))
+		}
+		if len(users) < listUsersBatchSize {
+			break
+		}
+		afterName = users[len(users)-1].Name
+	}
+	return connect.NewResponse(eratov1.ListUsersResponse_builder{
+		Results: results,
+	}.Build()), nil
 }
 
 // GetUser satisfies [eratov1connect.ArchiveServiceHandler].
@@ -70,76 +158,209 @@ func (u Users) GetUser(
 	ctx context.Context,
 	req *connect.Request[eratov1.GetUserRequest],
 ) (*connect.Response[eratov1.User], error) {
-	// TODO: support getting other users if the caller's permissions allow it
-	user := sec.GetAuthenticatedUser(ctx)
-	if req.Msg.GetPath() != user.Path() {
-		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	user, err := u.resolveUser(ctx, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
 	}
-
-	return connect.NewResponse(eratov1.User_builder{
-		Path: user.Path(),
-		Id:   user.Name,
-	}.Build()), nil
+	return connect.NewResponse(userToProto(user)), nil
 }
 
 // UpdateUser satisfies [eratov1connect.ArchiveServiceHandler].
 func (u Users) UpdateUser(
 	ctx context.Context,
 	req *connect.Request[eratov1.UpdateUserRequest],
-) (*connect.Response[eratov1.User], error) {
-	// only allowed to update yourself
-	authd := sec.GetAuthenticatedUser(ctx)
-	if req.Msg.GetPath() != authd.Path() {
-		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+) (_ *connect.Response[eratov1.User], err error) {
+	// the fields are those requested until they are changed
+	subject, fields := db.User{Name: userName(req.Msg.GetPath())}, req.Msg.GetUpdateMask().GetPaths()
+	defer func() { u.audit.RecordUpdate(ctx, subject, fields, err) }()
+	target, err := u.resolveUser(ctx, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
 	}
+	subject = target
 
-	user := eratov1.User_builder{
-		Path: authd.Path(),
-		Id:   authd.Name,
-	}.Build()
+	if err = checkEtag(req.Msg.GetUser().GetEtag(), target.Version); err != nil {
+		return nil, err
+	}
 
 	mask := req.Msg.GetUpdateMask()
-	if !mask.IsValid(user) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, nil)
-	} else if len(mask.GetPaths()) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, nil)
+	if err = checkUpdateMask(mask, userToProto(target)); err != nil {
+		return nil, err
 	}
 
+	updated := target
+	var changed []string
 	for _, path := range mask.GetPaths() {
-		switch strings.ToLower(path) {
+		field := strings.ToLower(path)
+		switch field {
 		case "password":
-			hash, err := sec.HashPassword(req.Msg.GetUser().GetPassword())
+			hash, err := u.throttle.HashPassword(ctx, req.Msg.GetUser().GetPassword())
 			if err != nil {
-				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+				return nil, hashError(err)
 			}
-			authd.PasswordHash = hash
+			updated.PasswordHash = hash // salted, so it changes even if the password does not
 		default:
-			return nil, connect.NewError(connect.CodeInvalidArgument, nil)
+			return nil, unknownMaskPath(path)
+		}
+		if !slices.Contains(changed, field) {
+			changed = append(changed, field)
 		}
 	}
-	if err := u.store.UpsertUser(ctx, authd); errors.Is(err, storage.ErrAlreadyExists) {
+	fields = changed
+	if err = u.store.UpsertUser(ctx, updated); errors.Is(err, storage.ErrAlreadyExists) {
 		return nil, connect.NewError(connect.CodeAlreadyExists, err)
 	} else if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, upsertError(err)
 	}
-	return connect.NewResponse(user), nil
+	updated.Version++ // incremented by the upsert
+	return connect.NewResponse(userToProto(updated)), nil
 }
 
 // DeleteUser satisfies [eratov1connect.ArchiveServiceHandler].
 func (u Users) DeleteUser(
 	ctx context.Context,
 	req *connect.Request[eratov1.DeleteUserRequest],
-) (*connect.Response[emptypb.Empty], error) {
-	// only allowed to delete yourself
+) (_ *connect.Response[emptypb.Empty], err error) {
+	subject := db.User{Name: userName(req.Msg.GetPath())}
+	defer func() { u.audit.Record(ctx, eratov1.AuditEvent_DELETE_USER, subject, err) }()
+	target, err := u.resolveUser(ctx, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	subject = target
+
+	if err = u.store.DeleteUser(ctx, target.ID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// RenameUser satisfies [eratov1connect.ArchiveServiceHandler]. Only admins
+// may rename users, including themselves. Paths are derived from the name, so
+// the user's resources follow them without further changes.
+func (u Users) RenameUser(
+	ctx context.Context,
+	req *connect.Request[eratov1.RenameUserRequest],
+) (_ *connect.Response[eratov1.User], err error) {
+	// the event names the user before the rename
+	subject := db.User{Name: userName(req.Msg.GetPath())}
+	defer func() { u.audit.Record(ctx, eratov1.AuditEvent_RENAME_USER, subject, err) }()
+	if !sec.GetAuthenticatedUser(ctx).IsAdmin() {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+	target, err := u.resolveUser(ctx, req.Msg.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	subject = target
+	if err = checkEtag(req.Msg.GetEtag(), target.Version); err != nil {
+		return nil, err
+	}
+
+	target.Name = req.Msg.GetNewId()
+	if err = u.store.UpsertUser(ctx, target); errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, connect.NewError(connect.CodeAlreadyExists, err)
+	} else if errors.Is(err, storage.ErrInvalidUsername) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
+		return nil, upsertError(err)
+	}
+	target.Version++ // incremented by the upsert
+	return connect.NewResponse(userToProto(target)), nil
+}
+
+// CreateInvite satisfies [eratov1connect.ArchiveServiceHandler]. Only admins
+// may create invites.
+func (u Users) CreateInvite(
+	ctx context.Context,
+	req *connect.Request[eratov1.CreateInviteRequest],
+) (_ *connect.Response[eratov1.Invite], err error) {
+	defer func() { u.audit.Record(ctx, eratov1.AuditEvent_CREATE_INVITE, db.User{}, err) }()
 	authd := sec.GetAuthenticatedUser(ctx)
-	if req.Msg.GetPath() != authd.Path() {
+	if !authd.IsAdmin() {
 		return nil, connect.NewError(connect.CodePermissionDenied, nil)
 	}
 
-	if err := u.store.DeleteUser(ctx, authd.ID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+	now := time.Now().UTC()
+	expireTime := now.Add(sec.DefaultInviteTTL)
+	if req.Msg.GetInvite().HasExpireTime() {
+		expireTime = req.Msg.GetInvite().GetExpireTime().AsTime()
+	}
+	code, hash := sec.NewInviteCode()
+	id, err := u.store.CreateInvite(ctx, db.Invite{
+		CodeHash:   hash,
+		Creator:    authd.Name,
+		CreateTime: now,
+		ExpireTime: expireTime,
+	})
+	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	return connect.NewResponse(eratov1.Invite_builder{
+		Path:       invitesCollection + strconv.FormatInt(id, 10),
+		Code:       code,
+		ExpireTime: timestamppb.New(expireTime),
+		CreateTime: timestamppb.New(now),
+	}.Build()), nil
+}
+
+// resolveUser returns the user at path if the authenticated user may manage
+// them. Users may always manage themselves, while admins may manage anyone.
+func (u Users) resolveUser(ctx context.Context, path string) (db.User, error) {
+	authd := sec.GetAuthenticatedUser(ctx)
+	if path == authd.Path() {
+		return authd, nil
+	} else if !authd.IsAdmin() {
+		return db.User{}, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+
+	name, ok := strings.CutPrefix(path, db.User{}.Path())
+	if !ok {
+		return db.User{}, connect.NewError(connect.CodeNotFound, nil)
+	}
+	user, err := u.store.GetUserByName(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		return user, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return user, connect.NewError(connect.CodeInternal, err)
+	}
+	return user, nil
+}
+
+// hashError converts an error from hashing a password into a connect error,
+// keeping those the throttle returns when it is busy or the request ends.
+func hashError(err error) error {
+	var connectErr *connect.Error
+	switch {
+	case errors.As(err, &connectErr):
+		return connectErr
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err // converted by connect
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+}
+
+// userName returns the name of the user at path, or path itself if it is not
+// a user path.
+func userName(path string) string {
+	if name, ok := strings.CutPrefix(path, db.User{}.Path()); ok {
+		return name
+	}
+	return path
+}
+
+func userToProto(user db.User) *eratov1.User {
+	role := eratov1.User_MEMBER
+	if user.IsAdmin() {
+		role = eratov1.User_ADMIN
+	}
+	return eratov1.User_builder{
+		Path: user.Path(),
+		Id:   user.Name,
+		Etag: formatEtag(user.Version),
+		Role: role,
+	}.Build()
 }
 
 var _ eratov1connect.ArchiveServiceHandler = Users{}
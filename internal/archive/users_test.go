@@ -0,0 +1,259 @@
+package archive
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestUsersRoles(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	createUser := func(name, role string) db.User {
+		t.Helper()
+		require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: name, PasswordHash: []byte{}, Role: role}))
+		user, err := store.GetUserByName(t.Context(), name)
+		require.NoError(t, err)
+		return user
+	}
+	admin := createUser("admin", db.RoleAdmin)
+	member := createUser("member", db.RoleMember)
+
+	paginator, err := NewPaginator(NewUsers(eratov1connect.UnimplementedArchiveServiceHandler{}, store, nil, nil), testTokens)
+	require.NoError(t, err)
+	handler, err := NewValidator(paginator, slog.Default())
+	require.NoError(t, err)
+
+	adminCtx := sec.SetAuthenticatedUser(t.Context(), admin)
+	memberCtx := sec.SetAuthenticatedUser(t.Context(), member)
+
+	t.Run("members manage themselves", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := handler.GetUser(memberCtx, connect.NewRequest(eratov1.GetUserRequest_builder{
+			Path: member.Path(),
+		}.Build()))
+		require.NoError(t, err)
+		assert.Equal(t, eratov1.User_MEMBER, res.Msg.GetRole())
+
+		_, err = handler.GetUser(memberCtx, connect.NewRequest(eratov1.GetUserRequest_builder{
+			Path: admin.Path(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.ListUsers(memberCtx, connect.NewRequest(&eratov1.ListUsersRequest{}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.CreateUser(memberCtx, connect.NewRequest(eratov1.CreateUserRequest_builder{
+			Id:   "created_by_member",
+			User: eratov1.User_builder{Password: "password"}.Build(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.DeleteUser(memberCtx, connect.NewRequest(eratov1.DeleteUserRequest_builder{
+			Path: admin.Path(),
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.RenameUser(memberCtx, connect.NewRequest(eratov1.RenameUserRequest_builder{
+			Path:  member.Path(),
+			NewId: "renamed_member",
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "members cannot rename themselves")
+	})
+
+	t.Run("admins list users", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := handler.ListUsers(adminCtx, connect.NewRequest(eratov1.ListUsersRequest_builder{
+			MaxPageSize: 1,
+		}.Build()))
+		require.NoError(t, err)
+		require.Len(t, res.Msg.GetResults(), 1)
+		assert.Equal(t, admin.Path(), res.Msg.GetResults()[0].GetPath())
+		assert.Equal(t, eratov1.User_ADMIN, res.Msg.GetResults()[0].GetRole())
+		require.NotEmpty(t, res.Msg.GetNextPageToken())
+
+		next, err := handler.ListUsers(adminCtx, connect.NewRequest(eratov1.ListUsersRequest_builder{
+			MaxPageSize: 1,
+			PageToken:   res.Msg.GetNextPageToken(),
+		}.Build()))
+		require.NoError(t, err)
+		require.Len(t, next.Msg.GetResults(), 1)
+		assert.NotEqual(t, admin.Path(), next.Msg.GetResults()[0].GetPath())
+
+		_, err = handler.ListUsers(adminCtx, connect.NewRequest(eratov1.ListUsersRequest_builder{
+			Filter:      `this.role == stolasapp.erato.v1.User.Role.MEMBER`,
+			MaxPageSize: 1,
+			PageToken:   res.Msg.GetNextPageToken(),
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "the filter must not change between pages")
+
+		res, err = handler.ListUsers(adminCtx, connect.NewRequest(eratov1.ListUsersRequest_builder{
+			Filter: `this.role == stolasapp.erato.v1.User.Role.MEMBER`,
+		}.Build()))
+		require.NoError(t, err)
+		paths := make([]string, len(res.Msg.GetResults()))
+		for i, user := range res.Msg.GetResults() {
+			paths[i] = user.GetPath()
+		}
+		assert.Contains(t, paths, member.Path())
+		assert.NotContains(t, paths, admin.Path())
+	})
+
+	t.Run("admins reset passwords", func(t *testing.T) {
+		t.Parallel()
+		target := createUser("reset_target", db.RoleMember)
+
+		res, err := handler.UpdateUser(adminCtx, connect.NewRequest(eratov1.UpdateUserRequest_builder{
+			Path:       target.Path(),
+			User:       eratov1.User_builder{Password: "new password"}.Build(),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+		}.Build()))
+		require.NoError(t, err)
+		assert.Equal(t, formatEtag(target.Version+1), res.Msg.GetEtag())
+
+		updated, err := store.GetUser(t.Context(), target.ID)
+		require.NoError(t, err)
+		require.NoError(t, sec.ComparePassword("new password", updated.PasswordHash))
+	})
+
+	t.Run("admins delete users", func(t *testing.T) {
+		t.Parallel()
+		target := createUser("delete_target", db.RoleMember)
+
+		req := connect.NewRequest(eratov1.DeleteUserRequest_builder{Path: target.Path()}.Build())
+		_, err := handler.DeleteUser(adminCtx, req)
+		require.NoError(t, err)
+
+		_, err = store.GetUser(t.Context(), target.ID)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		_, err = handler.DeleteUser(adminCtx, req)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("admins rename users", func(t *testing.T) {
+		t.Parallel()
+		target := createUser("rename_target", db.RoleMember)
+		createUser("rename_taken", db.RoleMember)
+
+		rename := func(path, newID, etag string) (*connect.Response[eratov1.User], error) {
+			return handler.RenameUser(adminCtx, connect.NewRequest(eratov1.RenameUserRequest_builder{
+				Path:  path,
+				NewId: newID,
+				Etag:  etag,
+			}.Build()))
+		}
+
+		_, err := rename(target.Path(), "rename_taken", "")
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+		_, err = rename(target.Path(), "not valid", "")
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = rename(target.Path(), "renamed", formatEtag(target.Version+1))
+		assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+
+		_, err = rename(db.User{Name: "missing"}.Path(), "renamed", "")
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		res, err := rename(target.Path(), "renamed", formatEtag(target.Version))
+		require.NoError(t, err)
+		assert.Equal(t, "users/renamed", res.Msg.GetPath())
+		assert.Equal(t, "renamed", res.Msg.GetId())
+		assert.Equal(t, formatEtag(target.Version+1), res.Msg.GetEtag())
+
+		renamed, err := store.GetUserByName(t.Context(), "renamed")
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, renamed.ID, "the user's data is kept")
+		_, err = store.GetUserByName(t.Context(), target.Name)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		got, err := handler.GetUser(adminCtx, connect.NewRequest(eratov1.GetUserRequest_builder{
+			Path: res.Msg.GetPath(),
+		}.Build()))
+		require.NoError(t, err)
+		assert.Equal(t, res.Msg.GetEtag(), got.Msg.GetEtag())
+	})
+
+	t.Run("admins create users", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := handler.CreateUser(adminCtx, connect.NewRequest(eratov1.CreateUserRequest_builder{
+			Id:   "created_by_admin",
+			User: eratov1.User_builder{Password: "password"}.Build(),
+		}.Build()))
+		require.NoError(t, err)
+		assert.Equal(t, eratov1.User_MEMBER, res.Msg.GetRole())
+	})
+
+	t.Run("invites", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := handler.CreateInvite(memberCtx, connect.NewRequest(eratov1.CreateInviteRequest_builder{
+			Invite: &eratov1.Invite{},
+		}.Build()))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "members cannot invite")
+
+		_, err = handler.CreateInvite(adminCtx, connect.NewRequest(eratov1.CreateInviteRequest_builder{
+			Invite: eratov1.Invite_builder{ExpireTime: timestamppb.New(time.Now().Add(-time.Hour))}.Build(),
+		}.Build()))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "invites must expire in the future")
+
+		invite, err := handler.CreateInvite(adminCtx, connect.NewRequest(eratov1.CreateInviteRequest_builder{
+			Invite: &eratov1.Invite{},
+		}.Build()))
+		require.NoError(t, err)
+		assert.Regexp(t, `^invites/\d+$`, invite.Msg.GetPath())
+		require.NotEmpty(t, invite.Msg.GetCode())
+		assert.WithinDuration(t, time.Now().Add(sec.DefaultInviteTTL), invite.Msg.GetExpireTime().AsTime(), time.Minute)
+
+		register := func(name, code string) error {
+			_, err := handler.CreateUser(t.Context(), connect.NewRequest(eratov1.CreateUserRequest_builder{
+				Id:         name,
+				User:       eratov1.User_builder{Password: "password"}.Build(),
+				InviteCode: code,
+			}.Build()))
+			return err
+		}
+
+		err = register("uninvited", "")
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		err = register("uninvited", "not a real code")
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		err = register(member.Name, invite.Msg.GetCode())
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+		// codes are case-insensitive
+		err = register("invited", " "+strings.ToLower(invite.Msg.GetCode())+" ")
+		require.NoError(t, err)
+		invited, err := store.GetUserByName(t.Context(), "invited")
+		require.NoError(t, err)
+		assert.Equal(t, db.RoleMember, invited.Role)
+
+		err = register("invited_again", invite.Msg.GetCode())
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "invites are single-use")
+	})
+}
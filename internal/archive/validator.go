@@ -6,6 +6,7 @@ import (
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -79,6 +80,13 @@ func (v *Validator) UpdateEntry(
 	return validate(ctx, v, "UpdateEntry", req, v.ArchiveServiceHandler.UpdateEntry)
 }
 
+// BatchUpdateEntries satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) BatchUpdateEntries(
+	ctx context.Context, req *connect.Request[eratov1.BatchUpdateEntriesRequest],
+) (*connect.Response[eratov1.BatchUpdateEntriesResponse], error) {
+	return validate(ctx, v, "BatchUpdateEntries", req, v.ArchiveServiceHandler.BatchUpdateEntries)
+}
+
 // ListChapters satisfies [eratov1connect.ArchiveServiceHandler].
 func (v *Validator) ListChapters(
 	ctx context.Context, req *connect.Request[eratov1.ListChaptersRequest],
@@ -100,6 +108,13 @@ func (v *Validator) UpdateChapter(
 	return validate(ctx, v, "UpdateChapter", req, v.ArchiveServiceHandler.UpdateChapter)
 }
 
+// BatchUpdateChapters satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) BatchUpdateChapters(
+	ctx context.Context, req *connect.Request[eratov1.BatchUpdateChaptersRequest],
+) (*connect.Response[eratov1.BatchUpdateChaptersResponse], error) {
+	return validate(ctx, v, "BatchUpdateChapters", req, v.ArchiveServiceHandler.BatchUpdateChapters)
+}
+
 // ReadEntry satisfies [eratov1connect.ArchiveServiceHandler].
 func (v *Validator) ReadEntry(
 	ctx context.Context, req *connect.Request[eratov1.ReadEntryRequest],
@@ -114,6 +129,19 @@ func (v *Validator) ReadChapter(
 	return validate(ctx, v, "ReadChapter", req, v.ArchiveServiceHandler.ReadChapter)
 }
 
+// ExportEntry satisfies [eratov1connect.ArchiveServiceHandler]. The streamed
+// file is not validated.
+func (v *Validator) ExportEntry(
+	ctx context.Context,
+	req *connect.Request[eratov1.ExportEntryRequest],
+	stream *connect.ServerStream[httpbody.HttpBody],
+) error {
+	if err := v.validator.Validate(req.Msg); err != nil {
+		return validationError(err)
+	}
+	return v.ArchiveServiceHandler.ExportEntry(ctx, req, stream)
+}
+
 // CreateUser satisfies [eratov1connect.ArchiveServiceHandler].
 func (v *Validator) CreateUser(
 	ctx context.Context, req *connect.Request[eratov1.CreateUserRequest],
@@ -149,6 +177,83 @@ func (v *Validator) DeleteUser(
 	return validate(ctx, v, "DeleteUser", req, v.ArchiveServiceHandler.DeleteUser)
 }
 
+// RenameUser satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) RenameUser(
+	ctx context.Context, req *connect.Request[eratov1.RenameUserRequest],
+) (*connect.Response[eratov1.User], error) {
+	return validate(ctx, v, "RenameUser", req, v.ArchiveServiceHandler.RenameUser)
+}
+
+// CreateAccessToken satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) CreateAccessToken(
+	ctx context.Context, req *connect.Request[eratov1.CreateAccessTokenRequest],
+) (*connect.Response[eratov1.AccessToken], error) {
+	return validate(ctx, v, "CreateAccessToken", req, v.ArchiveServiceHandler.CreateAccessToken)
+}
+
+// ListAccessTokens satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) ListAccessTokens(
+	ctx context.Context, req *connect.Request[eratov1.ListAccessTokensRequest],
+) (*connect.Response[eratov1.ListAccessTokensResponse], error) {
+	return validate(ctx, v, "ListAccessTokens", req, v.ArchiveServiceHandler.ListAccessTokens)
+}
+
+// DeleteAccessToken satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) DeleteAccessToken(
+	ctx context.Context, req *connect.Request[eratov1.DeleteAccessTokenRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	return validate(ctx, v, "DeleteAccessToken", req, v.ArchiveServiceHandler.DeleteAccessToken)
+}
+
+// CreateInvite satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) CreateInvite(
+	ctx context.Context, req *connect.Request[eratov1.CreateInviteRequest],
+) (*connect.Response[eratov1.Invite], error) {
+	return validate(ctx, v, "CreateInvite", req, v.ArchiveServiceHandler.CreateInvite)
+}
+
+// ListAuditEvents satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) ListAuditEvents(
+	ctx context.Context, req *connect.Request[eratov1.ListAuditEventsRequest],
+) (*connect.Response[eratov1.ListAuditEventsResponse], error) {
+	return validate(ctx, v, "ListAuditEvents", req, v.ArchiveServiceHandler.ListAuditEvents)
+}
+
+// CreateSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) CreateSavedView(
+	ctx context.Context, req *connect.Request[eratov1.CreateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	return validate(ctx, v, "CreateSavedView", req, v.ArchiveServiceHandler.CreateSavedView)
+}
+
+// ListSavedViews satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) ListSavedViews(
+	ctx context.Context, req *connect.Request[eratov1.ListSavedViewsRequest],
+) (*connect.Response[eratov1.ListSavedViewsResponse], error) {
+	return validate(ctx, v, "ListSavedViews", req, v.ArchiveServiceHandler.ListSavedViews)
+}
+
+// GetSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) GetSavedView(
+	ctx context.Context, req *connect.Request[eratov1.GetSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	return validate(ctx, v, "GetSavedView", req, v.ArchiveServiceHandler.GetSavedView)
+}
+
+// UpdateSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) UpdateSavedView(
+	ctx context.Context, req *connect.Request[eratov1.UpdateSavedViewRequest],
+) (*connect.Response[eratov1.SavedView], error) {
+	return validate(ctx, v, "UpdateSavedView", req, v.ArchiveServiceHandler.UpdateSavedView)
+}
+
+// DeleteSavedView satisfies [eratov1connect.ArchiveServiceHandler].
+func (v *Validator) DeleteSavedView(
+	ctx context.Context, req *connect.Request[eratov1.DeleteSavedViewRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	return validate(ctx, v, "DeleteSavedView", req, v.ArchiveServiceHandler.DeleteSavedView)
+}
+
 func validate[
 	Req, Res any,
 	ReqP interface {
@@ -168,7 +273,7 @@ func validate[
 ) (*connect.Response[Res], error) {
 	var reqP ReqP = req.Msg
 	if err := validator.validator.Validate(reqP); err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, validationError(err)
 	}
 
 	res, err := handle(ctx, req)
@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Reasons for field violations not reported by protovalidate, which instead
+// uses the constraint ID, such as string.user_id.
+const (
+	reasonInvalidFilter     = "INVALID_FILTER"
+	reasonInvalidOrderBy    = "INVALID_ORDER_BY"
+	reasonInvalidUpdateMask = "INVALID_UPDATE_MASK"
+	reasonInvalidPageToken  = "INVALID_PAGE_TOKEN"
+
+	updateMaskField = "update_mask"
+	pageTokenField  = "page_token"
+)
+
+// fieldError reports err as an invalid argument violating the request's field
+// for reason.
+func fieldError(field, reason string, err error) error {
+	return badRequest(err, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+		Reason:      reason,
+	})
+}
+
+// validationError reports err, from protovalidate, as an invalid argument with
+// a field violation for each of its violations. Other errors, such as failing
+// to compile a rule, are reported without details.
+func validationError(err error) error {
+	var valErr *protovalidate.ValidationError
+	if !errors.As(err, &valErr) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(valErr.Violations))
+	for _, violation := range valErr.Violations {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+	}
+	return badRequest(err, violations...)
+}
+
+// checkUpdateMask reports an invalid argument if mask is empty or names a field
+// not in resource.
+func checkUpdateMask(mask *fieldmaskpb.FieldMask, resource proto.Message) error {
+	if len(mask.GetPaths()) == 0 {
+		return maskError(errors.New("update_mask must not be empty"))
+	}
+	for _, path := range mask.GetPaths() {
+		if !(&fieldmaskpb.FieldMask{Paths: []string{path}}).IsValid(resource) {
+			return unknownMaskPath(path)
+		}
+	}
+	return nil
+}
+
+// unknownMaskPath reports an invalid argument for an update_mask path that
+// cannot be updated.
+func unknownMaskPath(path string) error {
+	return maskError(fmt.Errorf("cannot update %q", path))
+}
+
+func maskError(err error) error {
+	return fieldError(updateMaskField, reasonInvalidUpdateMask, err)
+}
+
+// badRequest reports err as an invalid argument with violations attached as a
+// google.rpc.BadRequest detail.
+func badRequest(err error, violations ...*errdetails.BadRequest_FieldViolation) error {
+	cerr := connect.NewError(connect.CodeInvalidArgument, err)
+	detail, detailErr := connect.NewErrorDetail(&errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+	if detailErr != nil {
+		return connect.NewError(connect.CodeInternal, errors.Join(err, detailErr))
+	}
+	cerr.AddDetail(detail)
+	return cerr
+}
@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+func TestValidatorViolations(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewValidator(eratov1connect.UnimplementedArchiveServiceHandler{}, slog.Default())
+	require.NoError(t, err)
+
+	_, err = validator.CreateUser(t.Context(), connect.NewRequest(eratov1.CreateUserRequest_builder{
+		Id: "no spaces allowed",
+	}.Build()))
+	violations := fieldViolations(t, err)
+	require.Len(t, violations, 2)
+
+	byField := make(map[string]*errdetails.BadRequest_FieldViolation, len(violations))
+	for _, violation := range violations {
+		byField[violation.GetField()] = violation
+	}
+	require.Contains(t, byField, "id")
+	assert.Equal(t, "string.user_id", byField["id"].GetReason())
+	assert.Equal(t, "must be 3-64 characters, alphanumeric and underscores only", byField["id"].GetDescription())
+	require.Contains(t, byField, "user")
+	assert.Equal(t, "required", byField["user"].GetReason())
+}
+
+func TestCheckUpdateMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr string
+	}{
+		{name: "valid", paths: []string{"starred", "read_time"}},
+		{name: "empty", wantErr: "update_mask must not be empty"},
+		{name: "unknown", paths: []string{"starred", "title"}, wantErr: `cannot update "title"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkUpdateMask(&fieldmaskpb.FieldMask{Paths: test.paths}, &eratov1.Entry{})
+			if test.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			violations := fieldViolations(t, err)
+			require.Len(t, violations, 1)
+			assert.Equal(t, "update_mask", violations[0].GetField())
+			assert.Equal(t, reasonInvalidUpdateMask, violations[0].GetReason())
+			assert.Equal(t, test.wantErr, violations[0].GetDescription())
+		})
+	}
+}
+
+// fieldViolations asserts err is an invalid argument with a BadRequest detail,
+// returning its field violations.
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+	var cerr *connect.Error
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, connect.CodeInvalidArgument, cerr.Code())
+	require.Len(t, cerr.Details(), 1)
+	detail, err := cerr.Details()[0].Value()
+	require.NoError(t, err)
+	badReq, ok := detail.(*errdetails.BadRequest)
+	require.True(t, ok, "expected BadRequest, got %T", detail)
+	return badReq.GetFieldViolations()
+}
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+)
+
+const (
+	// auditTailDefaultCount is the number of events printed by default.
+	auditTailDefaultCount = 20
+
+	// auditFollowInterval is how often new events are polled for when
+	// following the audit log.
+	auditFollowInterval = time.Second
+
+	// auditFollowBatchSize is the number of new events fetched at a time when
+	// following the audit log.
+	auditFollowBatchSize = 100
+)
+
+func auditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit log commands",
+	}
+	cmd.AddCommand(
+		auditTailCommand(),
+	)
+	return cmd
+}
+
+func auditTailCommand() *cobra.Command {
+	var (
+		count  int32
+		follow bool
+		asJSON bool
+	)
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print recent audit events",
+		Long: "Prints the most recent authentication attempts and user account changes,\n" +
+			"oldest first. With --follow, new events are printed as they occur.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (runErr error) {
+			if count < 0 {
+				return errors.New("--lines must not be negative")
+			}
+			_, _, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			ctx := cmd.Context()
+			events, err := store.ListAuditEvents(ctx, storage.AuditEventFilter{}, math.MaxInt64, count)
+			if err != nil {
+				return err
+			}
+			slices.Reverse(events)
+
+			write := writeAuditEvents
+			if asJSON {
+				write = writeAuditEventsJSON
+			}
+			if err = write(cmd.OutOrStdout(), events); err != nil || !follow {
+				return err
+			}
+
+			var afterID int64
+			if len(events) > 0 {
+				afterID = events[len(events)-1].ID
+			} else if latest, err := store.ListAuditEvents(ctx, storage.AuditEventFilter{}, math.MaxInt64, 1); err != nil {
+				return err
+			} else if len(latest) > 0 {
+				afterID = latest[0].ID
+			}
+
+			ticker := time.NewTicker(auditFollowInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+				for {
+					events, err = store.ListAuditEventsAfter(ctx, afterID, auditFollowBatchSize)
+					if ctx.Err() != nil {
+						return nil // interrupted
+					} else if err != nil {
+						return err
+					} else if len(events) == 0 {
+						break
+					}
+					if err = write(cmd.OutOrStdout(), events); err != nil {
+						return err
+					}
+					afterID = events[len(events)-1].ID
+				}
+			}
+		},
+	}
+	cmd.Flags().Int32VarP(&count, "lines", "n", auditTailDefaultCount, "number of events to print")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "print new events as they occur")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print events as JSON lines")
+	return cmd
+}
+
+// writeAuditEvents writes events as a table. Unlike other tables, there is no
+// header, since followed events are appended to it.
+func writeAuditEvents(w io.Writer, events []storage.AuditEvent) error {
+	const padding = 2
+	out := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	for _, event := range events {
+		_, _ = fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			formatTime(event.CreateTime),
+			eratov1.AuditEvent_Outcome(event.Outcome), //nolint:gosec // stored from a valid enum
+			eratov1.AuditEvent_Action(event.Action),   //nolint:gosec // stored from a valid enum
+			orDash(event.Fields),
+			orDash(event.Actor),
+			orDash(event.Subject),
+			orDash(event.IpAddress),
+			orDash(event.UserAgent),
+		)
+	}
+	return out.Flush()
+}
+
+// writeAuditEventsJSON writes events as JSON lines.
+func writeAuditEventsJSON(w io.Writer, events []storage.AuditEvent) error {
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(newAuditEventJSON(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditEventJSON is the JSON output of an audit event. Like [userJSON], IDs
+// are strings.
+type auditEventJSON struct {
+	ID         string    `json:"id"`
+	CreateTime time.Time `json:"create_time"`
+	Actor      string    `json:"actor,omitempty"`
+	Subject    string    `json:"subject,omitempty"`
+	Action     string    `json:"action"`
+	Outcome    string    `json:"outcome"`
+	Fields     []string  `json:"fields,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+}
+
+func newAuditEventJSON(event storage.AuditEvent) auditEventJSON {
+	out := auditEventJSON{
+		ID:         strconv.FormatInt(event.ID, 10),
+		CreateTime: event.CreateTime.UTC(),
+		Actor:      event.Actor,
+		Subject:    event.Subject,
+		Action:     eratov1.AuditEvent_Action(event.Action).String(),   //nolint:gosec // stored from a valid enum
+		Outcome:    eratov1.AuditEvent_Outcome(event.Outcome).String(), //nolint:gosec // stored from a valid enum
+		IPAddress:  event.IpAddress,
+		UserAgent:  event.UserAgent,
+	}
+	if event.Fields != "" {
+		out.Fields = strings.Split(event.Fields, ",")
+	}
+	return out
+}
+
+// orDash returns s, or a dash if it is empty, so table columns are never
+// blank.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
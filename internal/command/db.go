@@ -0,0 +1,160 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func dbCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Database commands",
+	}
+	cmd.AddCommand(
+		dbStatusCommand(),
+		dbMigrateCommand(),
+		dbDownCommand(),
+	)
+	return cmd
+}
+
+func dbStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show migration status",
+		Long:  "Lists the known database migrations and whether each has been applied.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(_ *slog.Logger, migrator *goose.Provider) error {
+				statuses, err := migrator.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				const padding = 2
+				out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, padding, ' ', 0)
+				_, _ = fmt.Fprintln(out, "VERSION\tSTATE\tAPPLIED\tSOURCE")
+				for _, status := range statuses {
+					applied := "-"
+					if status.State == goose.StateApplied {
+						applied = status.AppliedAt.Local().Format(time.DateTime)
+					}
+					_, _ = fmt.Fprintf(out, "%d\t%s\t%s\t%s\n",
+						status.Source.Version,
+						status.State,
+						applied,
+						filepath.Base(status.Source.Path),
+					)
+				}
+				return out.Flush()
+			})
+		},
+	}
+}
+
+func dbMigrateCommand() *cobra.Command {
+	var toVersion int64
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending migrations",
+		Long: "Applies pending database migrations, either all of them or up to and\n" +
+			"including the version provided by --to.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(logger *slog.Logger, migrator *goose.Provider) (err error) {
+				var results []*goose.MigrationResult
+				if toVersion > 0 {
+					results, err = migrator.UpTo(cmd.Context(), toVersion)
+				} else {
+					results, err = migrator.Up(cmd.Context())
+				}
+				for _, result := range results {
+					db.LogMigrationResult(cmd.Context(), logger, result)
+				}
+				if err != nil {
+					return err
+				}
+				current, err := migrator.GetDBVersion(cmd.Context())
+				if err != nil {
+					return err
+				}
+				logger.InfoContext(cmd.Context(), "database migrated",
+					slog.Int("applied", len(results)),
+					slog.Int64("version", current),
+				)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().Int64Var(&toVersion, "to", 0, "migrate up to and including this version (default: latest)")
+	return cmd
+}
+
+func dbDownCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the latest migration",
+		Long: "Rolls back the most recently applied database migration. This may\n" +
+			"permanently delete data and is irreversible.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(cmd.Context(), func(logger *slog.Logger, migrator *goose.Provider) error {
+				current, err := migrator.GetDBVersion(cmd.Context())
+				if err != nil {
+					return err
+				} else if current == 0 {
+					logger.InfoContext(cmd.Context(), "no migrations to roll back")
+					return nil
+				}
+				resp, err := prompt(fmt.Sprintf("Are you sure you want to roll back version %d? [y|N] ", current), false)
+				if !bytes.Equal(resp, []byte{'y'}) || err != nil {
+					logger.InfoContext(cmd.Context(), "aborted migration rollback", slog.Int64("version", current))
+					return err
+				}
+				result, err := migrator.Down(cmd.Context())
+				if errors.Is(err, goose.ErrNoNextVersion) {
+					return errors.New("no migrations to roll back")
+				} else if err != nil {
+					return err
+				}
+				db.LogMigrationResult(cmd.Context(), logger, result)
+				return nil
+			})
+		},
+	}
+}
+
+// withMigrator opens the configured database without applying any migrations
+// and invokes fn with a migration provider bound to it.
+func withMigrator(ctx context.Context, fn func(*slog.Logger, *goose.Provider) error) (runErr error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	logger := slog.Default()
+	handle, err := db.Connect(ctx, cfg.GetDbFilepath())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := handle.Close(); err != nil {
+			runErr = errors.Join(runErr, err)
+		}
+	}()
+
+	migrator, err := db.NewMigrator(handle)
+	if err != nil {
+		return err
+	}
+	return fn(logger, migrator)
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func inviteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Invite commands",
+	}
+	cmd.AddCommand(
+		inviteCreateCommand(),
+	)
+	return cmd
+}
+
+func inviteCreateCommand() *cobra.Command {
+	var expires time.Duration
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create invite",
+		Long: "Creates a single-use invite and prints its code. The code is entered on the\n" +
+			"web app's registration page to create a user, and cannot be retrieved again.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (runErr error) {
+			if expires <= 0 {
+				return errors.New("--expires must be positive")
+			}
+
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			now := time.Now().UTC()
+			code, hash := sec.NewInviteCode()
+			id, err := store.CreateInvite(cmd.Context(), db.Invite{
+				CodeHash:   hash,
+				CreateTime: now,
+				ExpireTime: now.Add(expires),
+			})
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_CREATE_INVITE, db.User{}, err)
+			if err != nil {
+				return err
+			}
+
+			logger.InfoContext(cmd.Context(), "created invite",
+				slog.Int64("id", id),
+				slog.Time("expire_time", now.Add(expires)),
+			)
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), code)
+			return err
+		},
+	}
+	cmd.Flags().DurationVar(&expires, "expires", sec.DefaultInviteTTL, "how long until the invite expires")
+	return cmd
+}
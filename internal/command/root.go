@@ -55,6 +55,10 @@ func RootCommand() *cobra.Command {
 	cmd.AddCommand(
 		serveCommand(),
 		userCommand(),
+		tokenCommand(),
+		inviteCommand(),
+		auditCommand(),
+		dbCommand(),
 	)
 
 	return cmd
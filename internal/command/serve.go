@@ -5,7 +5,9 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -15,11 +17,14 @@ import (
 	"github.com/stolasapp/erato/internal/archive"
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/observability"
 	"github.com/stolasapp/erato/internal/sec"
 	"github.com/stolasapp/erato/internal/server"
-	"github.com/stolasapp/erato/internal/storage"
 )
 
+// oidcRequestTimeout bounds requests made to the OpenID Connect provider.
+const oidcRequestTimeout = 30 * time.Second
+
 func serveCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "serve",
@@ -38,23 +43,49 @@ func serveCommand() *cobra.Command {
 
 			grp, ctx := errgroup.WithContext(cmd.Context())
 
-			// In dev mode, start the fake upstream service
+			// In dev mode, start the fake upstream service and seed the users
+			// authenticating every request
+			var dev *sec.DevAuth
 			if cfg.GetDevMode() {
 				devAddr, err := serveDevUpstream(ctx, grp, logger)
 				if err != nil {
 					return err
 				}
 				cfg.SetRootUri("http://" + devAddr + "/")
+				if dev, err = sec.NewDevAuth(ctx, cfg, store); err != nil {
+					return err
+				}
+				logger.InfoContext(ctx,
+					"seeded dev users",
+					slog.Any("users", dev.Users()),
+				)
 			}
 
-			rpcHandler, err := archive.Default(cfg, logger, store)
+			// shared, so password guesses and hashes count against both servers
+			throttle := sec.NewThrottle(logger)
+			audit := sec.NewAuditor(store, logger)
+			rpcHandler, err := archive.Default(ctx, cfg, logger, store, throttle, audit)
 			if err != nil {
 				return err
 			}
 
-			appServer := app.New(cfg, logger, store, rpcHandler)
+			var proxy *sec.ProxyAuth
+			if cfg.HasProxyAuth() {
+				if proxy, err = sec.NewProxyAuth(cfg.GetProxyAuth()); err != nil {
+					return err
+				}
+			}
+			var oidc *sec.OIDC
+			if cfg.HasOidc() {
+				client := &http.Client{Timeout: oidcRequestTimeout}
+				if oidc, err = sec.NewOIDC(ctx, cfg.GetOidc(), client); err != nil {
+					return err
+				}
+			}
 
-			serveRPC(ctx, grp, cfg, logger, store, rpcHandler)
+			appServer := app.New(cfg, logger, store, throttle, audit, proxy, oidc, dev, rpcHandler)
+
+			serveRPC(ctx, grp, cfg, logger, store, throttle, audit, proxy, oidc, dev, rpcHandler)
 			serveApp(ctx, grp, cfg, logger, appServer)
 			return grp.Wait()
 		},
@@ -66,7 +97,12 @@ func serveRPC(
 	grp *errgroup.Group,
 	cfg *eratov1.Config,
 	logger *slog.Logger,
-	store storage.Users,
+	store sec.AuthStore,
+	throttle *sec.Throttle,
+	audit *sec.Auditor,
+	proxy *sec.ProxyAuth,
+	oidc *sec.OIDC,
+	dev *sec.DevAuth,
 	handler eratov1connect.ArchiveServiceHandler,
 ) {
 	addr := cfg.GetRpcAddress()
@@ -81,8 +117,17 @@ func serveRPC(
 	}
 
 	mux := http.NewServeMux()
-	mux.Handle(eratov1connect.NewArchiveServiceHandler(handler))
-	srv := &http.Server{Handler: sec.NewConnectAuthMiddleware(store).Wrap(mux)} //nolint:gosec // Serve() sets timeouts
+	mux.Handle(eratov1connect.NewArchiveServiceHandler(handler,
+		connect.WithInterceptors(sec.NewScopeInterceptor()),
+	))
+	mux.Handle(observability.MetricsPath, sec.RequireAdmin(observability.MetricsHandler()))
+	var authd http.Handler
+	if dev != nil {
+		authd = dev.Middleware(mux)
+	} else {
+		authd = sec.NewConnectAuthMiddleware(store, throttle, audit, proxy, oidc).Wrap(mux)
+	}
+	srv := &http.Server{Handler: sec.ClientMiddleware(authd)} //nolint:gosec // Serve() sets timeouts
 
 	logger.InfoContext(ctx,
 		"starting RPC server...",
@@ -0,0 +1,202 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// tokenScopes are the --scope flag values, in order of increasing access.
+var tokenScopes = []eratov1.AccessToken_Scope{
+	eratov1.AccessToken_READ_ONLY,
+	eratov1.AccessToken_READ_WRITE,
+	eratov1.AccessToken_USER_ADMIN,
+}
+
+func tokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Personal access token commands",
+	}
+	cmd.AddCommand(
+		tokenCreateCommand(),
+		tokenListCommand(),
+		tokenDeleteCommand(),
+	)
+	return cmd
+}
+
+func tokenCreateCommand() *cobra.Command {
+	var scopeName, displayName string
+	cmd := &cobra.Command{
+		Use:   "create USER",
+		Short: "Create personal access token",
+		Long: "Creates a personal access token for the user and prints it. The token is\n" +
+			"presented to the RPC API as a Bearer token and cannot be retrieved again.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			scope, err := parseTokenScope(scopeName)
+			if err != nil {
+				return err
+			}
+
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			token, hash := sec.NewAccessToken()
+			id, err := store.CreateAccessToken(cmd.Context(), db.AccessToken{
+				User:        user.ID,
+				TokenHash:   hash,
+				DisplayName: displayName,
+				Scope:       int64(scope),
+				CreateTime:  time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.InfoContext(cmd.Context(), "created access token",
+				slog.String("name", user.Name),
+				slog.Int64("id", id),
+				slog.String("scope", formatTokenScope(scope)),
+			)
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), token)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&scopeName, "scope", "", "operations the token may perform: "+strings.Join(tokenScopeNames(), ", "))
+	cmd.Flags().StringVar(&displayName, "name", "", "name describing the purpose of the token")
+	_ = cmd.MarkFlagRequired("scope")
+	return cmd
+}
+
+func tokenListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list USER",
+		Short: "List personal access tokens",
+		Long:  "Lists the user's personal access tokens. The tokens themselves are not shown.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, _, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			tokens, err := store.ListAccessTokens(cmd.Context(), user.ID)
+			if err != nil {
+				return err
+			}
+
+			const padding = 2
+			out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, padding, ' ', 0)
+			_, _ = fmt.Fprintln(out, "ID\tSCOPE\tCREATED\tLAST USED\tNAME")
+			for _, token := range tokens {
+				lastUsed := "-"
+				if token.LastUsedTime.Valid {
+					lastUsed = token.LastUsedTime.Time.Local().Format(time.DateTime)
+				}
+				_, _ = fmt.Fprintf(out, "%d\t%s\t%s\t%s\t%s\n",
+					token.ID,
+					formatTokenScope(eratov1.AccessToken_Scope(token.Scope)), //nolint:gosec // stored from a valid enum
+					token.CreateTime.Local().Format(time.DateTime),
+					lastUsed,
+					token.DisplayName,
+				)
+			}
+			return out.Flush()
+		},
+	}
+}
+
+func tokenDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete USER ID",
+		Short: "Delete personal access token",
+		Long:  "Revokes the user's personal access token with the ID shown by the list command.",
+		Args:  cobra.ExactArgs(2), //nolint:mnd // USER and ID
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid token ID %q", args[1])
+			}
+
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			if err = store.DeleteAccessToken(cmd.Context(), user.ID, id); err != nil {
+				return err
+			}
+			logger.InfoContext(cmd.Context(), "access token deleted",
+				slog.String("name", user.Name),
+				slog.Int64("id", id),
+			)
+			return nil
+		},
+	}
+}
+
+// formatTokenScope converts scope to its --scope flag value (e.g.,
+// READ_ONLY to read-only).
+func formatTokenScope(scope eratov1.AccessToken_Scope) string {
+	return strings.ReplaceAll(strings.ToLower(scope.String()), "_", "-")
+}
+
+func parseTokenScope(name string) (eratov1.AccessToken_Scope, error) {
+	idx := slices.IndexFunc(tokenScopes, func(scope eratov1.AccessToken_Scope) bool {
+		return formatTokenScope(scope) == name
+	})
+	if idx == -1 {
+		return 0, fmt.Errorf("invalid scope %q, must be one of: %s", name, strings.Join(tokenScopeNames(), ", "))
+	}
+	return tokenScopes[idx], nil
+}
+
+func tokenScopeNames() []string {
+	names := make([]string, len(tokenScopes))
+	for i, scope := range tokenScopes {
+		names[i] = formatTokenScope(scope)
+	}
+	return names
+}
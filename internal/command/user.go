@@ -2,15 +2,30 @@ package command
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"strconv"
+	"text/tabwriter"
+	"time"
 
+	"buf.build/go/protovalidate"
 	"github.com/spf13/cobra"
 
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/sec"
+	"github.com/stolasapp/erato/internal/storage"
 	"github.com/stolasapp/erato/internal/storage/db"
 )
 
+// listUsersBatchSize is the number of users fetched from the store at a time
+// when listing users.
+const listUsersBatchSize = 100
+
 func userCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "user",
@@ -18,7 +33,15 @@ func userCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		userCreateCommand(),
+		userListCommand(),
+		userShowCommand(),
+		userPasswdCommand(),
 		userDeleteCommand(),
+		userRenameCommand(),
+		userLinkCommand(),
+		userUnlinkCommand(),
+		userRoleCommand("promote", "Grant user the admin role", db.RoleAdmin),
+		userRoleCommand("demote", "Revoke the admin role from user", db.RoleMember),
 	)
 	return cmd
 }
@@ -43,15 +66,27 @@ func userCreateCommand() *cobra.Command {
 			}()
 
 			name := args[0]
-			if passwd, err := prompt("password: ", true); err != nil {
+			passwd, err := promptPassword()
+			if err != nil {
 				return err
-			} else if hash, err := sec.HashPassword(passwd); err != nil {
+			}
+			hash, err := sec.HashPassword(passwd)
+			if err != nil {
 				return err
-			} else if err = store.UpsertUser(cmd.Context(), db.User{
+			}
+			err = store.UpsertUser(cmd.Context(), db.User{
 				Name:         name,
 				PasswordHash: hash,
-			}); err != nil {
-				return err
+			})
+			subject := db.User{Name: name}
+			if err == nil {
+				if created, getErr := store.GetUserByName(cmd.Context(), name); getErr == nil {
+					subject = created
+				}
+			}
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_CREATE_USER, subject, err)
+			if err != nil {
+				return userError(name, err)
 			}
 
 			logger.InfoContext(cmd.Context(), "created user", slog.String("name", name))
@@ -60,6 +95,162 @@ func userCreateCommand() *cobra.Command {
 	}
 }
 
+func userListCommand() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Long:  "Lists all users in alphabetic order.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (runErr error) {
+			_, _, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			users := []userJSON{} // so JSON output is an empty array, not null
+			afterName := ""
+			for {
+				batch, err := store.ListUsers(cmd.Context(), afterName, listUsersBatchSize)
+				if err != nil {
+					return err
+				}
+				for _, user := range batch {
+					users = append(users, newUserJSON(user))
+				}
+				if len(batch) < listUsersBatchSize {
+					break
+				}
+				afterName = batch[len(batch)-1].Name
+			}
+
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), users)
+			}
+			const padding = 2
+			out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, padding, ' ', 0)
+			_, _ = fmt.Fprintln(out, "ID\tROLE\tCREATED\tNAME")
+			for _, user := range users {
+				_, _ = fmt.Fprintf(out, "%s\t%s\t%s\t%s\n",
+					user.ID,
+					user.Role,
+					formatTime(user.CreateTime),
+					user.Name,
+				)
+			}
+			return out.Flush()
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print users as JSON")
+	return cmd
+}
+
+func userShowCommand() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "show NAME",
+		Short: "Show user details",
+		Long: "Shows the user's details, how many resources they have interacted with and\n" +
+			"when they were last active.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, _, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			activity, err := store.GetUserActivity(cmd.Context(), user.ID)
+			if err != nil {
+				return err
+			}
+			identities, err := store.ListIdentities(cmd.Context(), user.ID)
+			if err != nil {
+				return err
+			}
+			details := newUserDetailsJSON(user, activity, identities)
+
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), details)
+			}
+			const padding = 2
+			out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, padding, ' ', 0)
+			_, _ = fmt.Fprintf(out, "ID:\t%s\n", details.ID)
+			_, _ = fmt.Fprintf(out, "Name:\t%s\n", details.Name)
+			_, _ = fmt.Fprintf(out, "Role:\t%s\n", details.Role)
+			_, _ = fmt.Fprintf(out, "Created:\t%s\n", formatTime(details.CreateTime))
+			_, _ = fmt.Fprintf(out, "Resources:\t%d (%d read, %d starred, %d hidden)\n",
+				details.Resources,
+				details.Read,
+				details.Starred,
+				details.Hidden,
+			)
+			_, _ = fmt.Fprintf(out, "Last active:\t%s\n", formatTime(details.LastActiveTime))
+			for _, identity := range details.Identities {
+				_, _ = fmt.Fprintf(out, "Identity:\t%s %s\n", identity.Issuer, identity.Subject)
+			}
+			return out.Flush()
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print user as JSON")
+	return cmd
+}
+
+func userPasswdCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "passwd NAME",
+		Short: "Reset user password",
+		Long: "Sets a new password for the user. Passwords may be provided via stdin or\n" +
+			"through the interactive prompt.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			passwd, err := promptPassword()
+			if err != nil {
+				return err
+			}
+			if user.PasswordHash, err = sec.HashPassword(passwd); err != nil {
+				return err
+			}
+			err = store.UpsertUser(cmd.Context(), user)
+			ctx, audit := cliAuditor(cmd.Context(), store, logger)
+			audit.RecordUpdate(ctx, user, []string{"password"}, err)
+			if err != nil {
+				return userError(user.Name, err)
+			}
+
+			logger.InfoContext(cmd.Context(), "user password reset", slog.String("name", user.Name))
+			return nil
+		},
+	}
+}
+
 func userDeleteCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete NAME",
@@ -80,7 +271,7 @@ func userDeleteCommand() *cobra.Command {
 
 			name := args[0]
 			logger = logger.With(slog.String("name", name))
-			user, err := store.GetUserByName(cmd.Context(), name)
+			user, err := getUser(cmd.Context(), store, name)
 			if err != nil {
 				return err
 			}
@@ -89,7 +280,9 @@ func userDeleteCommand() *cobra.Command {
 				logger.InfoContext(cmd.Context(), "aborted user deletion")
 				return err
 			}
-			if err = store.DeleteUser(cmd.Context(), user.ID); err != nil {
+			err = store.DeleteUser(cmd.Context(), user.ID)
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_DELETE_USER, user, err)
+			if err != nil {
 				return err
 			}
 			logger.InfoContext(cmd.Context(), "user deleted")
@@ -97,3 +290,304 @@ func userDeleteCommand() *cobra.Command {
 		},
 	}
 }
+
+func userRenameCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename OLD NEW",
+		Short: "Rename user",
+		Long: "Changes the user's name, which they log in with. Their data, sessions,\n" +
+			"access tokens and linked identities are kept.",
+		Args: cobra.ExactArgs(2), //nolint:mnd // OLD and NEW
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			// the event names the user before the rename
+			subject := user
+			user.Name = args[1]
+			err = store.UpsertUser(cmd.Context(), user)
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_RENAME_USER, subject, err)
+			if errors.Is(err, storage.ErrConflict) {
+				return userError(args[0], err)
+			} else if err != nil {
+				return userError(args[1], err)
+			}
+			logger.InfoContext(cmd.Context(), "user renamed",
+				slog.String("old", args[0]),
+				slog.String("new", args[1]),
+			)
+			return nil
+		},
+	}
+}
+
+func userLinkCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "link NAME ISSUER SUBJECT",
+		Short: "Link user to an identity provider subject",
+		Long: "Lets the subject of the OpenID Connect issuer log in as the user. Users\n" +
+			"provisioned by the issuer are linked to their subject automatically, but\n" +
+			"existing users must be linked explicitly.",
+		Args: cobra.ExactArgs(3), //nolint:mnd // NAME, ISSUER and SUBJECT
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			err = store.LinkIdentity(cmd.Context(), user.ID, args[1], args[2])
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_LINK_IDENTITY, user, err)
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return fmt.Errorf("subject %q of %q is already linked to a user", args[2], args[1])
+			} else if err != nil {
+				return err
+			}
+			logger.InfoContext(cmd.Context(), "user linked",
+				slog.String("name", user.Name),
+				slog.String("issuer", args[1]),
+				slog.String("subject", args[2]),
+			)
+			return nil
+		},
+	}
+}
+
+func userUnlinkCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlink NAME ISSUER SUBJECT",
+		Short: "Unlink user from an identity provider subject",
+		Long: "Stops the subject of the OpenID Connect issuer from logging in as the user.\n" +
+			"Sessions the subject has already established are kept.",
+		Args: cobra.ExactArgs(3), //nolint:mnd // NAME, ISSUER and SUBJECT
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			err = store.UnlinkIdentity(cmd.Context(), user.ID, args[1], args[2])
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_UNLINK_IDENTITY, user, err)
+			if errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("subject %q of %q is not linked to user %q", args[2], args[1], user.Name)
+			} else if err != nil {
+				return err
+			}
+			logger.InfoContext(cmd.Context(), "user unlinked",
+				slog.String("name", user.Name),
+				slog.String("issuer", args[1]),
+				slog.String("subject", args[2]),
+			)
+			return nil
+		},
+	}
+}
+
+func userRoleCommand(name, short, role string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " NAME",
+		Short: short,
+		Long: "Sets the user's role to " + role + ". Admins may list, get, rename, reset\n" +
+			"the password of and delete other users.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (runErr error) {
+			_, logger, store, err := loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := store.Close(); err != nil {
+					runErr = errors.Join(runErr, err)
+				}
+			}()
+
+			user, err := getUser(cmd.Context(), store, args[0])
+			if err != nil {
+				return err
+			}
+			logger = logger.With(slog.String("name", user.Name), slog.String("role", role))
+			if user.Role == role {
+				logger.InfoContext(cmd.Context(), "user already has role")
+				return nil
+			}
+			user.Role = role
+			err = store.UpsertUser(cmd.Context(), user)
+			recordCLIEvent(cmd.Context(), store, logger, eratov1.AuditEvent_UPDATE_ROLE, user, err)
+			if err != nil {
+				return userError(user.Name, err)
+			}
+			logger.InfoContext(cmd.Context(), "user role updated")
+			return nil
+		},
+	}
+}
+
+// getUser returns the user with the given name, reporting that they do not
+// exist in terms fit for the CLI's output.
+// promptPassword reads a new password, checking it against the same rules as
+// passwords set through the API.
+func promptPassword() ([]byte, error) {
+	passwd, err := prompt("password: ", true)
+	if err != nil {
+		return nil, err
+	}
+	if len(passwd) == 0 {
+		return nil, errors.New("password must be 8-1024 characters")
+	}
+	if err := protovalidate.Validate(eratov1.User_builder{Password: string(passwd)}.Build()); err != nil {
+		return nil, err
+	}
+	return passwd, nil
+}
+
+func getUser(ctx context.Context, store storage.Users, name string) (db.User, error) {
+	user, err := store.GetUserByName(ctx, name)
+	return user, userError(name, err)
+}
+
+// userError describes the storage error from reading or changing the user
+// with the given name in terms fit for the CLI's output, like the RPC service
+// maps them to status codes.
+func userError(name string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("user %q does not exist", name)
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return fmt.Errorf("user %q already exists", name)
+	case errors.Is(err, storage.ErrInvalidUsername):
+		return fmt.Errorf("invalid username %q: %w", name, err)
+	case errors.Is(err, storage.ErrConflict):
+		return fmt.Errorf("user %q was modified concurrently, try again", name)
+	}
+	return err
+}
+
+// cliUserAgent identifies the CLI as the client of its audit events.
+const cliUserAgent = "erato-cli"
+
+// recordCLIEvent records a change made by a CLI command to the subject user in
+// the audit log.
+func recordCLIEvent(
+	ctx context.Context,
+	store storage.AuditEvents,
+	logger *slog.Logger,
+	action eratov1.AuditEvent_Action,
+	subject db.User,
+	err error,
+) {
+	ctx, audit := cliAuditor(ctx, store, logger)
+	audit.Record(ctx, action, subject, err)
+}
+
+// cliAuditor returns an auditor recording changes made by CLI commands, and
+// the context to record them with. The CLI operates on the database directly,
+// so its events have no actor or client address.
+func cliAuditor(ctx context.Context, store storage.AuditEvents, logger *slog.Logger) (context.Context, *sec.Auditor) {
+	return sec.WithClient(ctx, "", cliUserAgent), sec.NewAuditor(store, logger)
+}
+
+// userJSON is the JSON output of a user. IDs are strings, as they may exceed
+// the integers JSON parsers can represent exactly.
+type userJSON struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Role       string    `json:"role"`
+	CreateTime time.Time `json:"create_time,omitzero"`
+}
+
+func newUserJSON(user db.User) userJSON {
+	return userJSON{
+		ID:         strconv.FormatUint(user.ID, 10),
+		Name:       user.Name,
+		Role:       user.Role,
+		CreateTime: nullTime(user.CreateTime),
+	}
+}
+
+// userDetailsJSON is the JSON output of a user's details.
+type userDetailsJSON struct {
+	userJSON
+
+	Resources      int64          `json:"resources"`
+	Read           int64          `json:"read"`
+	Starred        int64          `json:"starred"`
+	Hidden         int64          `json:"hidden"`
+	LastActiveTime time.Time      `json:"last_active_time,omitzero"`
+	Identities     []identityJSON `json:"identities,omitempty"`
+}
+
+func newUserDetailsJSON(user db.User, activity storage.UserActivity, identities []db.Identity) userDetailsJSON {
+	details := userDetailsJSON{
+		userJSON:       newUserJSON(user),
+		Resources:      activity.Resources,
+		Read:           activity.Read,
+		Starred:        activity.Starred,
+		Hidden:         activity.Hidden,
+		LastActiveTime: nullTime(activity.LastActiveTime),
+	}
+	for _, identity := range identities {
+		details.Identities = append(details.Identities, identityJSON{
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+		})
+	}
+	return details
+}
+
+// identityJSON is the JSON output of an identity provider subject linked to a
+// user.
+type identityJSON struct {
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+}
+
+// nullTime returns the time in UTC, or the zero time if it is invalid.
+func nullTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time.UTC()
+}
+
+// formatTime formats t in the local time zone for tables, or a dash if it is
+// the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
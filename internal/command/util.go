@@ -87,10 +87,20 @@ func version() string {
 	return ver
 }
 
-func loadConfig(ctx context.Context) (*eratov1.Config, *slog.Logger, storage.Store, error) {
+func getConfig(ctx context.Context) (*eratov1.Config, error) {
 	cfg, ok := ctx.Value(configKey{}).(*eratov1.Config)
 	if !ok {
-		return nil, nil, nil, errors.New("config file resolution failed")
+		return nil, errors.New("config file resolution failed")
+	}
+	return cfg, nil
+}
+
+// loadConfig resolves the config and opens its database. Pending migrations
+// are applied unless the config requires manual migrations.
+func loadConfig(ctx context.Context) (*eratov1.Config, *slog.Logger, storage.Store, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	logger := slog.Default()
 	store, err := storage.NewDB(ctx, cfg, logger)
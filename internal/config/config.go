@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"buf.build/go/protoyaml"
 	"github.com/adrg/xdg"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 )
@@ -18,12 +20,17 @@ import (
 // Note that this configuration is _not_ valid, as the user must set root_uri.
 func Default() *eratov1.Config {
 	return eratov1.Config_builder{
-		LogLevel:   eratov1.Config_INFO,
-		RpcAddress: proto.String("localhost:9998"),
-		WebAddress: proto.String("localhost:9999"),
-		DbFilepath: filepath.Join(xdg.DataHome, "erato", "db.sqlite"),
-		RootUri:    "", // must be set by the user
-		DevMode:    false,
+		LogLevel:            eratov1.Config_INFO,
+		RpcAddress:          proto.String("localhost:9998"),
+		WebAddress:          proto.String("localhost:9999"),
+		DbFilepath:          filepath.Join(xdg.DataHome, "erato", "db.sqlite"),
+		RootUri:             "", // must be set by the user
+		DevMode:             false,
+		ManualMigrations:    false,
+		AutoReadAnthologies: false,
+		SessionIdleTimeout:  durationpb.New(7 * 24 * time.Hour),
+		SessionMaxLifetime:  durationpb.New(30 * 24 * time.Hour),
+		PageTokenTtl:        durationpb.New(24 * time.Hour),
 	}.Build()
 }
 
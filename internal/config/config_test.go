@@ -32,6 +32,16 @@ func TestLoad(t *testing.T) {
 			yaml:    `root_uri: ""`,
 			wantErr: "config validation failed",
 		},
+		{
+			name:    "session durations",
+			yaml:    "root_uri: \"https://example.com\"\nsession_idle_timeout: 1h\nsession_max_lifetime: 24h",
+			wantErr: "",
+		},
+		{
+			name:    "negative session duration fails validation",
+			yaml:    "root_uri: \"https://example.com\"\nsession_idle_timeout: -1h",
+			wantErr: "config validation failed",
+		},
 		{
 			name:    "invalid yaml syntax",
 			yaml:    `invalid: [yaml: content`,
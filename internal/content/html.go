@@ -9,6 +9,7 @@ import (
 	"github.com/PuerkitoBio/goquery"
 	"github.com/microcosm-cc/bluemonday"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // ExtractHTMLBody extracts just the body content from a full HTML document.
@@ -52,6 +53,39 @@ func ScrubHTML() TransformerFunc {
 	}
 }
 
+// HTMLToXHTML re-serializes an HTML body fragment as well-formed XHTML, as
+// required by EPUB content documents: void elements are self-closed, attribute
+// values are quoted, and characters not allowed in XML are dropped.
+func HTMLToXHTML() TransformerFunc {
+	return func(input []byte) ([]byte, error) {
+		body := &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: atom.Body.String()}
+		nodes, err := html.ParseFragment(bytes.NewReader(input), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		}
+		var out bytes.Buffer
+		for _, node := range nodes {
+			if err = html.Render(&out, node); err != nil {
+				return nil, fmt.Errorf("failed to render XHTML: %w", err)
+			}
+		}
+		return bytes.Map(xmlRune, out.Bytes()), nil
+	}
+}
+
+// xmlRune drops runes outside the XML 1.0 character range, such as control
+// characters, which HTML tolerates.
+func xmlRune(r rune) rune {
+	switch {
+	case r == '\t', r == '\n', r == '\r':
+		return r
+	case r < 0x20, r == 0xFFFE, r == 0xFFFF:
+		return -1
+	default:
+		return r
+	}
+}
+
 const (
 	// maxConsecutiveBRs is the maximum number of consecutive <br> elements
 	// allowed before collapsing occurs.
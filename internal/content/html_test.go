@@ -282,3 +282,49 @@ func TestSanitizeHTML(t *testing.T) {
 		})
 	}
 }
+
+func TestHTMLToXHTML(t *testing.T) {
+	t.Parallel()
+	toXHTML := HTMLToXHTML()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "self-closes void elements",
+			input: "<p>one<br>two</p><hr>",
+			want:  "<p>one<br/>two</p><hr/>",
+		},
+		{
+			name:  "closes implied end tags",
+			input: "<ul><li>one<li>two</ul><p>para",
+			want:  "<ul><li>one</li><li>two</li></ul><p>para</p>",
+		},
+		{
+			name:  "quotes attributes and escapes text",
+			input: `<details open><summary>a &amp; b</summary></details><a href=/x?a=1&b=2>link</a>`,
+			want:  `<details open=""><summary>a &amp; b</summary></details><a href="/x?a=1&amp;b=2">link</a>`,
+		},
+		{
+			name:  "decodes named entities",
+			input: "caf&eacute; &mdash; done",
+			want:  "café — done",
+		},
+		{
+			name:  "drops characters not allowed in XML",
+			input: "<p>form\ffeed\x01</p>",
+			want:  "<p>formfeed</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := toXHTML([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
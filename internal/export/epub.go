@@ -0,0 +1,244 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ContentTypeEPUB is the media type of EPUB files.
+const ContentTypeEPUB = "application/epub+zip"
+
+// The files of an EPUB, besides its chapters. The mimetype file must come
+// first, uncompressed, so readers can identify the archive.
+const (
+	mimetypeFile   = "mimetype"
+	containerFile  = "META-INF/container.xml"
+	packageFile    = "OEBPS/content.opf"
+	navFile        = "nav.xhtml"
+	stylesheetFile = "style.css"
+)
+
+// metadata describes an EPUB in its package document and title page.
+type metadata struct {
+	Identifier string
+	Title      string
+	Category   string
+	UpdateTime time.Time
+}
+
+// chapterFile is a chapter written to an EPUB, listed in its table of contents.
+type chapterFile struct {
+	ID    string
+	Href  string
+	Title string
+}
+
+// book writes an EPUB 3 to a zip archive as its chapters are added, so it can
+// be streamed. The table of contents and package document, which list the
+// chapters, are written last.
+type book struct {
+	zip      *zip.Writer
+	modified time.Time
+	chapters []chapterFile
+}
+
+// newBook starts an EPUB written to w, with files last modified at modified.
+func newBook(w io.Writer, modified time.Time) (*book, error) {
+	bk := &book{zip: zip.NewWriter(w), modified: modified}
+	mimetype := []byte(ContentTypeEPUB)
+	out, err := bk.zip.CreateRaw(&zip.FileHeader{
+		Name:               mimetypeFile,
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", mimetypeFile, err)
+	}
+	if _, err = out.Write(mimetype); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", mimetypeFile, err)
+	}
+	if err = bk.write(containerFile, containerTemplate, nil); err != nil {
+		return nil, err
+	}
+	if err = bk.write(oebps(stylesheetFile), stylesheetTemplate, nil); err != nil {
+		return nil, err
+	}
+	return bk, nil
+}
+
+// addChapter writes the next chapter in reading order, with body as its XHTML
+// content.
+func (b *book) addChapter(title string, body []byte) error {
+	chapter := chapterFile{
+		ID:    fmt.Sprintf("chapter-%04d", len(b.chapters)+1),
+		Title: title,
+	}
+	chapter.Href = chapter.ID + ".xhtml"
+	err := b.write(oebps(chapter.Href), chapterTemplate, struct {
+		Title string
+		Body  string
+	}{title, string(body)})
+	if err != nil {
+		return err
+	}
+	b.chapters = append(b.chapters, chapter)
+	return nil
+}
+
+// close writes the table of contents and package document, describing the
+// book with meta, and finishes the archive.
+func (b *book) close(meta metadata) error {
+	data := struct {
+		metadata
+		Modified string
+		Updated  string
+		Chapters []chapterFile
+	}{
+		metadata: meta,
+		Modified: meta.UpdateTime.UTC().Format(time.RFC3339),
+		Updated:  meta.UpdateTime.UTC().Format("January 2, 2006"),
+		Chapters: b.chapters,
+	}
+	if err := b.write(oebps(navFile), navTemplate, data); err != nil {
+		return err
+	}
+	if err := b.write(packageFile, packageTemplate, data); err != nil {
+		return err
+	}
+	if err := b.zip.Close(); err != nil {
+		return fmt.Errorf("failed to finish EPUB: %w", err)
+	}
+	return nil
+}
+
+func (b *book) write(name string, tmpl *template.Template, data any) error {
+	out, err := b.zip.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: b.modified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err = tmpl.Execute(out, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// oebps returns the path of a file in the same directory as the package
+// document, which the package document's links are relative to.
+func oebps(name string) string {
+	return "OEBPS/" + name
+}
+
+// escapeXML escapes s for XML text and attribute values, replacing characters
+// not allowed in XML.
+func escapeXML(s string) string {
+	var out strings.Builder
+	_ = xml.EscapeText(&out, []byte(s)) // strings.Builder does not return errors
+	return out.String()
+}
+
+var funcs = template.FuncMap{"xml": escapeXML}
+
+var containerTemplate = template.Must(template.New(containerFile).Parse(
+	`<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="` + packageFile + `" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`))
+
+var stylesheetTemplate = template.Must(template.New(stylesheetFile).Parse(
+	`body { margin: 0 5%; line-height: 1.5; }
+h1 { text-align: center; margin: 1em 0 1.5em; }
+p { margin: 0 0 1em; }
+blockquote { margin: 1em 2em; }
+nav ol { list-style: none; padding: 0; }
+nav li { margin: 0.5em 0; }
+.category, .updated { text-align: center; font-style: italic; }
+`))
+
+var packageTemplate = template.Must(template.New(packageFile).Funcs(funcs).Parse(
+	`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id" xml:lang="en">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="id">{{ xml .Identifier }}</dc:identifier>
+    <dc:title>{{ xml .Title }}</dc:title>
+    <dc:language>en</dc:language>
+    {{- if .Category }}
+    <dc:subject>{{ xml .Category }}</dc:subject>
+    {{- end }}
+    <dc:date>{{ .Modified }}</dc:date>
+    <meta property="dcterms:modified">{{ .Modified }}</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="` + navFile + `" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="style" href="` + stylesheetFile + `" media-type="text/css"/>
+    {{- range .Chapters }}
+    <item id="{{ .ID }}" href="{{ .Href }}" media-type="application/xhtml+xml"/>
+    {{- end }}
+  </manifest>
+  <spine>
+    <itemref idref="nav"/>
+    {{- range .Chapters }}
+    <itemref idref="{{ .ID }}"/>
+    {{- end }}
+  </spine>
+</package>
+`))
+
+var navTemplate = template.Must(template.New(navFile).Funcs(funcs).Parse(
+	`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="en" lang="en">
+<head>
+  <title>{{ xml .Title }}</title>
+  <link rel="stylesheet" type="text/css" href="` + stylesheetFile + `"/>
+</head>
+<body>
+  <section epub:type="titlepage">
+    <h1>{{ xml .Title }}</h1>
+    {{- if .Category }}
+    <p class="category">{{ xml .Category }}</p>
+    {{- end }}
+    <p class="updated">Updated {{ .Updated }}</p>
+  </section>
+  <nav epub:type="toc" id="toc">
+    <h2>Contents</h2>
+    <ol>
+      {{- range .Chapters }}
+      <li><a href="{{ .Href }}">{{ xml .Title }}</a></li>
+      {{- end }}
+    </ol>
+  </nav>
+</body>
+</html>
+`))
+
+var chapterTemplate = template.Must(template.New("chapter").Funcs(funcs).Parse(
+	`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="en" lang="en">
+<head>
+  <title>{{ xml .Title }}</title>
+  <link rel="stylesheet" type="text/css" href="` + stylesheetFile + `"/>
+</head>
+<body>
+  <section epub:type="chapter">
+    <h1>{{ xml .Title }}</h1>
+    {{ .Body }}
+  </section>
+</body>
+</html>
+`))
@@ -0,0 +1,214 @@
+// Package export builds files of archive entries to read elsewhere, such as
+// EPUBs for e-readers.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/stolasapp/erato/internal/content"
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/slugconv"
+)
+
+const (
+	// maxConcurrentChapters bounds the number of chapters fetched at once,
+	// which is also the number fetched ahead of the chapter being written.
+	maxConcurrentChapters = 4
+
+	chaptersPageSize = 100
+)
+
+// EPUB exports an entry as an EPUB 3: a story as a single chapter, or an
+// anthology with a chapter for each of its chapters in reading order.
+type EPUB struct {
+	archive  eratov1connect.ArchiveServiceHandler
+	meta     metadata
+	slug     string
+	chapters []chapter
+}
+
+// chapter is a chapter of an [EPUB], read from the archive when written.
+type chapter struct {
+	title string
+	read  func(ctx context.Context) (string, error)
+}
+
+// NewEPUB loads the entry at path, and an anthology's chapters, from archive.
+// Their content is only read by [EPUB.Write], so a missing entry is reported
+// before anything is written.
+func NewEPUB(
+	ctx context.Context,
+	archive eratov1connect.ArchiveServiceHandler,
+	path string,
+) (*EPUB, error) {
+	entry, err := archive.GetEntry(ctx, connect.NewRequest(eratov1.GetEntryRequest_builder{
+		Path: path,
+	}.Build()))
+	if err != nil {
+		return nil, err
+	}
+	category, err := archive.GetCategory(ctx, connect.NewRequest(eratov1.GetCategoryRequest_builder{
+		Path: slugconv.EntryParent(path),
+	}.Build()))
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := slugconv.FromEntryPath(entry.Msg.GetPath())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	updateTime := time.Now()
+	if entry.Msg.HasUpdateTime() {
+		updateTime = entry.Msg.GetUpdateTime().AsTime()
+	}
+	exp := &EPUB{
+		archive: archive,
+		meta: metadata{
+			Identifier: "erato:" + entry.Msg.GetPath(),
+			Title:      entry.Msg.GetDisplayName(),
+			Category:   category.Msg.GetDisplayName(),
+			UpdateTime: updateTime,
+		},
+		slug: slug,
+	}
+
+	if entry.Msg.GetKind() != eratov1.Entry_ANTHOLOGY {
+		exp.chapters = []chapter{{
+			title: entry.Msg.GetDisplayName(),
+			read:  exp.readEntry(entry.Msg.GetPath()),
+		}}
+		return exp, nil
+	}
+	if err = exp.listChapters(ctx, entry.Msg.GetPath()); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+// Filename is the name to save the EPUB as, from the entry's slug.
+func (e *EPUB) Filename() string {
+	return path.Base(e.slug) + ".epub"
+}
+
+// Write streams the EPUB to w. Chapters are written in order as they are
+// read, reading up to [maxConcurrentChapters] at a time.
+func (e *EPUB) Write(ctx context.Context, w io.Writer) error {
+	bk, err := newBook(w, e.meta.UpdateTime)
+	if err != nil {
+		return err
+	}
+
+	grp, grpCtx := errgroup.WithContext(ctx)
+	contents := make([]chan []byte, len(e.chapters))
+	for idx := range contents {
+		contents[idx] = make(chan []byte, 1)
+	}
+	slots := make(chan struct{}, maxConcurrentChapters)
+	grp.Go(func() error {
+		for idx, chapter := range e.chapters {
+			select {
+			case slots <- struct{}{}:
+			case <-grpCtx.Done():
+				return grpCtx.Err()
+			}
+			grp.Go(func() error {
+				body, err := readXHTML(grpCtx, chapter)
+				if err != nil {
+					return err
+				}
+				contents[idx] <- body
+				return nil
+			})
+		}
+		return nil
+	})
+	grp.Go(func() error {
+		for idx, chapter := range e.chapters {
+			var body []byte
+			select {
+			case body = <-contents[idx]:
+				<-slots
+			case <-grpCtx.Done():
+				return grpCtx.Err()
+			}
+			if err := bk.addChapter(chapter.title, body); err != nil {
+				return err
+			}
+		}
+		return bk.close(e.meta)
+	})
+	return grp.Wait()
+}
+
+func (e *EPUB) listChapters(ctx context.Context, entry string) error {
+	var pageToken string
+	for {
+		res, err := e.archive.ListChapters(ctx, connect.NewRequest(eratov1.ListChaptersRequest_builder{
+			Parent:      entry,
+			MaxPageSize: chaptersPageSize,
+			PageToken:   pageToken,
+		}.Build()))
+		if err != nil {
+			return err
+		}
+		for _, result := range res.Msg.GetResults() {
+			e.chapters = append(e.chapters, chapter{
+				title: result.GetDisplayName(),
+				read:  e.readChapter(result.GetPath()),
+			})
+		}
+		if pageToken = res.Msg.GetNextPageToken(); pageToken == "" {
+			return nil
+		}
+	}
+}
+
+func (e *EPUB) readEntry(path string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		res, err := e.archive.ReadEntry(ctx, connect.NewRequest(eratov1.ReadEntryRequest_builder{
+			Path:     path,
+			MimeType: eratov1.ReadEntryRequest_HTML,
+		}.Build()))
+		if err != nil {
+			return "", err
+		}
+		return res.Msg.GetContent(), nil
+	}
+}
+
+func (e *EPUB) readChapter(path string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		res, err := e.archive.ReadChapter(ctx, connect.NewRequest(eratov1.ReadChapterRequest_builder{
+			Path:     path,
+			MimeType: eratov1.ReadEntryRequest_HTML,
+		}.Build()))
+		if err != nil {
+			return "", err
+		}
+		return res.Msg.GetContent(), nil
+	}
+}
+
+var htmlToXHTML = content.HTMLToXHTML()
+
+// readXHTML reads the chapter's HTML content, converting it to XHTML.
+func readXHTML(ctx context.Context, chapter chapter) ([]byte, error) {
+	html, err := chapter.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := htmlToXHTML([]byte(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %q to XHTML: %w", chapter.title, err)
+	}
+	return body, nil
+}
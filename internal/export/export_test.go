@@ -0,0 +1,250 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+func TestEPUB(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC)
+	const (
+		story     = "categories/cat/entries/story"
+		anthology = "categories/cat/entries/anthology"
+	)
+
+	t.Run("story", func(t *testing.T) {
+		t.Parallel()
+		archive := &stubArchive{entry: eratov1.Entry_builder{
+			Path:        story,
+			DisplayName: "A Story",
+			Kind:        eratov1.Entry_STORY,
+			UpdateTime:  timestamppb.New(updated),
+		}.Build()}
+
+		files := export(t, archive, story)
+
+		opf := files[packageFile]
+		assert.Contains(t, opf, "<dc:title>A Story</dc:title>")
+		assert.Contains(t, opf, "<dc:subject>Cat &amp; Mouse</dc:subject>")
+		assert.Contains(t, opf, `<meta property="dcterms:modified">2026-03-04T05:06:07Z</meta>`)
+		assert.Contains(t, files[oebps("chapter-0001.xhtml")], "<p>story<br/>content</p>")
+		assert.NotContains(t, files, oebps("chapter-0002.xhtml"))
+	})
+
+	t.Run("anthology", func(t *testing.T) {
+		t.Parallel()
+		archive := &stubArchive{
+			entry: eratov1.Entry_builder{
+				Path:        anthology,
+				DisplayName: "An Anthology",
+				Kind:        eratov1.Entry_ANTHOLOGY,
+				UpdateTime:  timestamppb.New(updated),
+			}.Build(),
+			chapters: 250,
+		}
+
+		files := export(t, archive, anthology)
+
+		nav := files[oebps(navFile)]
+		assert.Contains(t, nav, "<h1>An Anthology</h1>")
+		assert.Contains(t, nav, "Updated March 4, 2026")
+		for num := 1; num <= archive.chapters; num++ {
+			href := fmt.Sprintf("chapter-%04d.xhtml", num)
+			assert.Contains(t, nav, fmt.Sprintf(`<li><a href="%s">Chapter %d &lt;%d&gt;</a></li>`, href, num, num))
+			assert.Contains(t, files[oebps(href)], fmt.Sprintf("<p>chapter %d</p>", num))
+		}
+		assert.Empty(t, archive.orderBy.Load(), "chapters are listed in reading order by default")
+		assert.LessOrEqual(t, archive.maxReading.Load(), int32(maxConcurrentChapters))
+	})
+
+	t.Run("read errors stop the export", func(t *testing.T) {
+		t.Parallel()
+		archive := &stubArchive{
+			entry: eratov1.Entry_builder{
+				Path: anthology,
+				Kind: eratov1.Entry_ANTHOLOGY,
+			}.Build(),
+			chapters: 20,
+			failRead: 7,
+		}
+		exp, err := NewEPUB(t.Context(), archive, anthology)
+		require.NoError(t, err)
+		err = exp.Write(t.Context(), io.Discard)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("missing entries are reported before writing", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewEPUB(t.Context(), &stubArchive{}, story)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+// export writes the EPUB of the entry at path, checking the archive's layout,
+// and returns its files by name.
+func export(t *testing.T, archive eratov1connect.ArchiveServiceHandler, path string) map[string]string {
+	t.Helper()
+	exp, err := NewEPUB(t.Context(), archive, path)
+	require.NoError(t, err)
+	assert.Equal(t, path[strings.LastIndex(path, "/")+1:]+".epub", exp.Filename())
+
+	var buf bytes.Buffer
+	require.NoError(t, exp.Write(t.Context(), &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.NotEmpty(t, zr.File)
+	mimetype := zr.File[0]
+	assert.Equal(t, mimetypeFile, mimetype.Name)
+	assert.Equal(t, zip.Store, mimetype.Method)
+	assert.Empty(t, mimetype.Extra)
+	assert.Equal(t, buf.Bytes()[30:30+len(mimetypeFile)+len(ContentTypeEPUB)], []byte(mimetypeFile+ContentTypeEPUB),
+		"mimetype is readable at a fixed offset")
+
+	files := make(map[string]string, len(zr.File))
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[file.Name] = string(data)
+
+		if strings.HasSuffix(file.Name, ".xhtml") || strings.HasSuffix(file.Name, ".xml") || strings.HasSuffix(file.Name, ".opf") {
+			assertWellFormed(t, file.Name, data)
+		}
+	}
+	assert.Contains(t, files, containerFile)
+	assert.Contains(t, files, oebps(stylesheetFile))
+	return files
+}
+
+func assertWellFormed(t *testing.T, name string, data []byte) {
+	t.Helper()
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if !assert.NoError(t, err, "%s is not well-formed XML", name) {
+			return
+		}
+	}
+}
+
+// stubArchive serves an entry in the "Cat & Mouse" category. An anthology has
+// the number of chapters, listed in pages and read with a delay so that they
+// are read concurrently.
+type stubArchive struct {
+	eratov1connect.UnimplementedArchiveServiceHandler
+
+	entry    *eratov1.Entry
+	chapters int
+	failRead int // the chapter number failing to be read, if any
+
+	orderBy    atomic.Value
+	reading    atomic.Int32
+	maxReading atomic.Int32
+}
+
+func (s *stubArchive) GetEntry(
+	_ context.Context,
+	req *connect.Request[eratov1.GetEntryRequest],
+) (*connect.Response[eratov1.Entry], error) {
+	if s.entry == nil || req.Msg.GetPath() != s.entry.GetPath() {
+		return nil, connect.NewError(connect.CodeNotFound, nil)
+	}
+	return connect.NewResponse(s.entry), nil
+}
+
+func (s *stubArchive) GetCategory(
+	_ context.Context,
+	req *connect.Request[eratov1.GetCategoryRequest],
+) (*connect.Response[eratov1.Category], error) {
+	return connect.NewResponse(eratov1.Category_builder{
+		Path:        req.Msg.GetPath(),
+		DisplayName: "Cat & Mouse",
+	}.Build()), nil
+}
+
+func (s *stubArchive) ReadEntry(
+	context.Context,
+	*connect.Request[eratov1.ReadEntryRequest],
+) (*connect.Response[eratov1.ReadEntryResponse], error) {
+	return connect.NewResponse(eratov1.ReadEntryResponse_builder{
+		Content: "<p>story<br>content",
+	}.Build()), nil
+}
+
+func (s *stubArchive) ListChapters(
+	_ context.Context,
+	req *connect.Request[eratov1.ListChaptersRequest],
+) (*connect.Response[eratov1.ListChaptersResponse], error) {
+	s.orderBy.Store(req.Msg.GetOrderBy())
+	start := 0
+	if tkn := req.Msg.GetPageToken(); tkn != "" {
+		start, _ = strconv.Atoi(tkn)
+	}
+	end := min(start+int(req.Msg.GetMaxPageSize()), s.chapters)
+	res := &eratov1.ListChaptersResponse{}
+	var chapters []*eratov1.Chapter
+	for idx := start; idx < end; idx++ {
+		chapters = append(chapters, eratov1.Chapter_builder{
+			Path:        fmt.Sprintf("%s/chapters/%d", s.entry.GetPath(), idx+1),
+			DisplayName: fmt.Sprintf("Chapter %d <%d>", idx+1, idx+1),
+		}.Build())
+	}
+	res.SetResults(chapters)
+	if end < s.chapters {
+		res.SetNextPageToken(strconv.Itoa(end))
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *stubArchive) ReadChapter(
+	ctx context.Context,
+	req *connect.Request[eratov1.ReadChapterRequest],
+) (*connect.Response[eratov1.ReadChapterResponse], error) {
+	reading := s.reading.Add(1)
+	defer s.reading.Add(-1)
+	for {
+		highest := s.maxReading.Load()
+		if reading <= highest || s.maxReading.CompareAndSwap(highest, reading) {
+			break
+		}
+	}
+
+	num := req.Msg.GetPath()[strings.LastIndex(req.Msg.GetPath(), "/")+1:]
+	if num == strconv.Itoa(s.failRead) {
+		return nil, connect.NewError(connect.CodeNotFound, nil)
+	}
+	select {
+	case <-time.After(time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return connect.NewResponse(eratov1.ReadChapterResponse_builder{
+		Content: "<p>chapter " + num,
+	}.Build()), nil
+}
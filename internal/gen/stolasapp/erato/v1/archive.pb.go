@@ -10,6 +10,7 @@ import (
 	_ "buf.build/gen/go/aep/api/protocolbuffers/go/aep/api"
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -73,6 +74,51 @@ func (x ReadEntryRequest_MimeType) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
+// Supported file formats.
+type ExportEntryRequest_Format int32
+
+const (
+	// Unknown format
+	ExportEntryRequest_FORMAT_UNSPECIFIED ExportEntryRequest_Format = 0
+	// EPUB 3 e-book, with a chapter for a story's content or for each of an
+	// anthology's chapters in reading order.
+	ExportEntryRequest_EPUB ExportEntryRequest_Format = 1
+)
+
+// Enum value maps for ExportEntryRequest_Format.
+var (
+	ExportEntryRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "EPUB",
+	}
+	ExportEntryRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"EPUB":               1,
+	}
+)
+
+func (x ExportEntryRequest_Format) Enum() *ExportEntryRequest_Format {
+	p := new(ExportEntryRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ExportEntryRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportEntryRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_stolasapp_erato_v1_archive_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportEntryRequest_Format) Type() protoreflect.EnumType {
+	return &file_stolasapp_erato_v1_archive_proto_enumTypes[1]
+}
+
+func (x ExportEntryRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 // ListCategories Request.
 //
 // TODO: linter bug not skipping parent field when none exists
@@ -82,6 +128,7 @@ type ListCategoriesRequest struct {
 	xxx_hidden_Filter      string                 `protobuf:"bytes,1,opt,name=filter,proto3"`
 	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,2,opt,name=max_page_size,json=maxPageSize,proto3"`
 	xxx_hidden_PageToken   string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3"`
+	xxx_hidden_OrderBy     string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -132,6 +179,13 @@ func (x *ListCategoriesRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListCategoriesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
 func (x *ListCategoriesRequest) SetFilter(v string) {
 	x.xxx_hidden_Filter = v
 }
@@ -144,17 +198,31 @@ func (x *ListCategoriesRequest) SetPageToken(v string) {
 	x.xxx_hidden_PageToken = v
 }
 
+func (x *ListCategoriesRequest) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
 type ListCategoriesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// Boolean CEL expression to filter category results.
 	//
-	// The variable `this` refers to a Category.
+	// The variable `this` refers to a Category and `user` to the authenticated user.
+	// See ListEntriesRequest.filter for the functions available to filters.
 	Filter string
 	// The maximum size of the page.
 	MaxPageSize int32
 	// The opaque page token to request.
 	PageToken string
+	// Comma-separated fields to order results by, each optionally followed by
+	// `desc` for descending order (e.g., `hidden, display_name desc`). Any
+	// singular scalar, enum or timestamp field of a Category other than
+	// etag may be used. Strings are ordered case-insensitively, unset
+	// timestamps come first, and ties are broken by path. Must not change
+	// between pages.
+	//
+	// Defaults to alphabetic order by display name.
+	OrderBy string
 }
 
 func (b0 ListCategoriesRequest_builder) Build() *ListCategoriesRequest {
@@ -164,6 +232,7 @@ func (b0 ListCategoriesRequest_builder) Build() *ListCategoriesRequest {
 	x.xxx_hidden_Filter = b.Filter
 	x.xxx_hidden_MaxPageSize = b.MaxPageSize
 	x.xxx_hidden_PageToken = b.PageToken
+	x.xxx_hidden_OrderBy = b.OrderBy
 	return m0
 }
 
@@ -172,6 +241,7 @@ type ListCategoriesResponse struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Results       *[]*Category           `protobuf:"bytes,1,rep,name=results,proto3"`
 	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
@@ -217,6 +287,13 @@ func (x *ListCategoriesResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListCategoriesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
 func (x *ListCategoriesResponse) SetResults(v []*Category) {
 	x.xxx_hidden_Results = &v
 }
@@ -225,13 +302,19 @@ func (x *ListCategoriesResponse) SetNextPageToken(v string) {
 	x.xxx_hidden_NextPageToken = v
 }
 
+func (x *ListCategoriesResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
 type ListCategoriesResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The categories, in alphabetic order.
+	// The categories, in alphabetic order unless order_by is set.
 	Results []*Category
 	// The opaque page token indicating the ending point of this response.
 	NextPageToken string
+	// The number of categories matching the filter across every page.
+	TotalSize int32
 }
 
 func (b0 ListCategoriesResponse_builder) Build() *ListCategoriesResponse {
@@ -240,6 +323,7 @@ func (b0 ListCategoriesResponse_builder) Build() *ListCategoriesResponse {
 	_, _ = b, x
 	x.xxx_hidden_Results = &b.Results
 	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
 	return m0
 }
 
@@ -422,6 +506,7 @@ type ListEntriesRequest struct {
 	xxx_hidden_Filter      string                 `protobuf:"bytes,2,opt,name=filter,proto3"`
 	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,3,opt,name=max_page_size,json=maxPageSize,proto3"`
 	xxx_hidden_PageToken   string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3"`
+	xxx_hidden_OrderBy     string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -479,6 +564,13 @@ func (x *ListEntriesRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListEntriesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
 func (x *ListEntriesRequest) SetParent(v string) {
 	x.xxx_hidden_Parent = v
 }
@@ -495,19 +587,50 @@ func (x *ListEntriesRequest) SetPageToken(v string) {
 	x.xxx_hidden_PageToken = v
 }
 
+func (x *ListEntriesRequest) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
 type ListEntriesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The parent category for these entries.
+	// The parent category for these entries, or `categories/-` for the entries
+	// of every category that is not hidden. Listing every category loads every
+	// upstream page of each, and defaults to ordering by `update_time desc`.
 	Parent string
 	// Boolean CEL expression to filter entry results.
 	//
-	// The variable `this` refers to an Entry.
+	// The variable `this` refers to an Entry, `category` to the path of the
+	// parent category, and `user` to a map of the authenticated user's `name`,
+	// `path` and `role` (`admin` or `member`). In addition to the standard CEL
+	// and protovalidate functions, filters may use `now()`, the durations
+	// `minutes(n)`, `hours(n)`, `days(n)` and `weeks(n)`, and the string methods
+	// `lower()`, `upper()` and `containsFold(substr)`. For example:
+	//
+	//   - updated in the last week: `this.update_time > now() - days(7)`
+	//   - unread with a title containing "dragon" in any case:
+	//     `!has(this.read_time) && this.display_name.containsFold("dragon")`
+	//   - title matching a case-insensitive regular expression:
+	//     `this.display_name.matches("(?i)^the ")`
+	//
+	// Filters too costly to evaluate against the results fail with
+	// INVALID_ARGUMENT.
 	Filter string
 	// The maximum size of the page.
 	MaxPageSize int32
 	// The opaque page token to request.
 	PageToken string
+	// Comma-separated fields to order results by, each optionally followed by
+	// `desc` for descending order (e.g., `read_time desc, display_name`). Any
+	// singular scalar, enum or timestamp field of an Entry other than
+	// etag may be used. Strings are ordered case-insensitively, unset
+	// timestamps come first, and ties are broken by path. Must not change
+	// between pages.
+	//
+	// Defaults to reverse-chronological order by update_time, as listed
+	// upstream. When set, every upstream page of the category must be loaded,
+	// so listing is slower.
+	OrderBy string
 }
 
 func (b0 ListEntriesRequest_builder) Build() *ListEntriesRequest {
@@ -518,16 +641,21 @@ func (b0 ListEntriesRequest_builder) Build() *ListEntriesRequest {
 	x.xxx_hidden_Filter = b.Filter
 	x.xxx_hidden_MaxPageSize = b.MaxPageSize
 	x.xxx_hidden_PageToken = b.PageToken
+	x.xxx_hidden_OrderBy = b.OrderBy
 	return m0
 }
 
 // ListEntries Response
 type ListEntriesResponse struct {
-	state                    protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Results       *[]*Entry              `protobuf:"bytes,1,rep,name=results,proto3"`
-	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results             *[]*Entry              `protobuf:"bytes,1,rep,name=results,proto3"`
+	xxx_hidden_NextPageToken       string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize           int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	xxx_hidden_TotalSizeEstimated  bool                   `protobuf:"varint,4,opt,name=total_size_estimated,json=totalSizeEstimated,proto3"`
+	xxx_hidden_ResultsTruncated    bool                   `protobuf:"varint,5,opt,name=results_truncated,json=resultsTruncated,proto3"`
+	xxx_hidden_UnfilteredTotalSize int32                  `protobuf:"varint,6,opt,name=unfiltered_total_size,json=unfilteredTotalSize,proto3"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *ListEntriesResponse) Reset() {
@@ -571,6 +699,34 @@ func (x *ListEntriesResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListEntriesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *ListEntriesResponse) GetTotalSizeEstimated() bool {
+	if x != nil {
+		return x.xxx_hidden_TotalSizeEstimated
+	}
+	return false
+}
+
+func (x *ListEntriesResponse) GetResultsTruncated() bool {
+	if x != nil {
+		return x.xxx_hidden_ResultsTruncated
+	}
+	return false
+}
+
+func (x *ListEntriesResponse) GetUnfilteredTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_UnfilteredTotalSize
+	}
+	return 0
+}
+
 func (x *ListEntriesResponse) SetResults(v []*Entry) {
 	x.xxx_hidden_Results = &v
 }
@@ -579,13 +735,46 @@ func (x *ListEntriesResponse) SetNextPageToken(v string) {
 	x.xxx_hidden_NextPageToken = v
 }
 
+func (x *ListEntriesResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
+func (x *ListEntriesResponse) SetTotalSizeEstimated(v bool) {
+	x.xxx_hidden_TotalSizeEstimated = v
+}
+
+func (x *ListEntriesResponse) SetResultsTruncated(v bool) {
+	x.xxx_hidden_ResultsTruncated = v
+}
+
+func (x *ListEntriesResponse) SetUnfilteredTotalSize(v int32) {
+	x.xxx_hidden_UnfilteredTotalSize = v
+}
+
 type ListEntriesResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The entries, in reverse-chronological order by update_time.
+	// The entries, in reverse-chronological order by update_time unless
+	// order_by is set.
 	Results []*Entry
 	// The opaque page token indicating the ending point of this response.
 	NextPageToken string
+	// The number of entries matching the filter across every page. Unless
+	// order_by is set, only the upstream page holding these results is loaded,
+	// so the count only includes the matches on that page.
+	TotalSize int32
+	// Whether total_size is a lower bound rather than an exact count, as only
+	// some upstream pages were loaded. A request loads at most 100 upstream
+	// pages; if that leaves the results partial, the next page token continues
+	// loading them even if this page is not full.
+	TotalSizeEstimated bool
+	// Whether entries were left out of ordered results. Ordering entries loads
+	// every upstream page of their category, up to 100 pages; the entries on
+	// any later pages, which were updated least recently, are omitted.
+	ResultsTruncated bool
+	// The number of entries regardless of the filter, counted from the same
+	// upstream pages as total_size. It equals total_size if there is no filter.
+	UnfilteredTotalSize int32
 }
 
 func (b0 ListEntriesResponse_builder) Build() *ListEntriesResponse {
@@ -594,6 +783,10 @@ func (b0 ListEntriesResponse_builder) Build() *ListEntriesResponse {
 	_, _ = b, x
 	x.xxx_hidden_Results = &b.Results
 	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
+	x.xxx_hidden_TotalSizeEstimated = b.TotalSizeEstimated
+	x.xxx_hidden_ResultsTruncated = b.ResultsTruncated
+	x.xxx_hidden_UnfilteredTotalSize = b.UnfilteredTotalSize
 	return m0
 }
 
@@ -769,19 +962,160 @@ func (b0 UpdateEntryRequest_builder) Build() *UpdateEntryRequest {
 	return m0
 }
 
+// BatchUpdateEntries Request
+type BatchUpdateEntriesRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Parent   string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_Requests *[]*UpdateEntryRequest `protobuf:"bytes,2,rep,name=requests,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchUpdateEntriesRequest) Reset() {
+	*x = BatchUpdateEntriesRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateEntriesRequest) ProtoMessage() {}
+
+func (x *BatchUpdateEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchUpdateEntriesRequest) GetParent() string {
+	if x != nil {
+		return x.xxx_hidden_Parent
+	}
+	return ""
+}
+
+func (x *BatchUpdateEntriesRequest) GetRequests() []*UpdateEntryRequest {
+	if x != nil {
+		if x.xxx_hidden_Requests != nil {
+			return *x.xxx_hidden_Requests
+		}
+	}
+	return nil
+}
+
+func (x *BatchUpdateEntriesRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
+}
+
+func (x *BatchUpdateEntriesRequest) SetRequests(v []*UpdateEntryRequest) {
+	x.xxx_hidden_Requests = &v
+}
+
+type BatchUpdateEntriesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The parent category of the entries being updated.
+	Parent string
+	// The updates to apply. Each path must be a child of the parent. The
+	// updates are applied atomically; either all succeed or none do.
+	Requests []*UpdateEntryRequest
+}
+
+func (b0 BatchUpdateEntriesRequest_builder) Build() *BatchUpdateEntriesRequest {
+	m0 := &BatchUpdateEntriesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_Requests = &b.Requests
+	return m0
+}
+
+// BatchUpdateEntries Response
+type BatchUpdateEntriesResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results *[]*Entry              `protobuf:"bytes,1,rep,name=results,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchUpdateEntriesResponse) Reset() {
+	*x = BatchUpdateEntriesResponse{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateEntriesResponse) ProtoMessage() {}
+
+func (x *BatchUpdateEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchUpdateEntriesResponse) GetResults() []*Entry {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *BatchUpdateEntriesResponse) SetResults(v []*Entry) {
+	x.xxx_hidden_Results = &v
+}
+
+type BatchUpdateEntriesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The updated entries, in the same order as the requests. Only the path,
+	// etag and user-modifiable fields are populated.
+	Results []*Entry
+}
+
+func (b0 BatchUpdateEntriesResponse_builder) Build() *BatchUpdateEntriesResponse {
+	m0 := &BatchUpdateEntriesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
 // ListChapters Request.
 type ListChaptersRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Parent      string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_Filter      string                 `protobuf:"bytes,2,opt,name=filter,proto3"`
 	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,3,opt,name=max_page_size,json=maxPageSize,proto3"`
 	xxx_hidden_PageToken   string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3"`
+	xxx_hidden_OrderBy     string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
 func (x *ListChaptersRequest) Reset() {
 	*x = ListChaptersRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[8]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -793,7 +1127,7 @@ func (x *ListChaptersRequest) String() string {
 func (*ListChaptersRequest) ProtoMessage() {}
 
 func (x *ListChaptersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[8]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,6 +1145,13 @@ func (x *ListChaptersRequest) GetParent() string {
 	return ""
 }
 
+func (x *ListChaptersRequest) GetFilter() string {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return ""
+}
+
 func (x *ListChaptersRequest) GetMaxPageSize() int32 {
 	if x != nil {
 		return x.xxx_hidden_MaxPageSize
@@ -825,10 +1166,21 @@ func (x *ListChaptersRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListChaptersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
 func (x *ListChaptersRequest) SetParent(v string) {
 	x.xxx_hidden_Parent = v
 }
 
+func (x *ListChaptersRequest) SetFilter(v string) {
+	x.xxx_hidden_Filter = v
+}
+
 func (x *ListChaptersRequest) SetMaxPageSize(v int32) {
 	x.xxx_hidden_MaxPageSize = v
 }
@@ -837,15 +1189,38 @@ func (x *ListChaptersRequest) SetPageToken(v string) {
 	x.xxx_hidden_PageToken = v
 }
 
+func (x *ListChaptersRequest) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
 type ListChaptersRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The parent anthology entry for these chapters.
 	Parent string
+	// Boolean CEL expression to filter chapter results.
+	//
+	// The variable `this` refers to a Chapter, `category` to the path of the
+	// anthology's category, and `user` to a map of the authenticated user's
+	// `name`, `path` and `role`. The same functions are available as for
+	// ListEntries filters. For example, unread chapters after the tenth:
+	// `!has(this.read_time) && this.number > 10`.
+	Filter string
 	// The maximum size of the page.
 	MaxPageSize int32
 	// The opaque page token to request.
 	PageToken string
+	// Comma-separated fields to order results by, each optionally followed by
+	// `desc` for descending order (e.g., `read_time desc, display_name`). Any
+	// singular scalar, enum or timestamp field of a Chapter other than
+	// etag may be used. Strings are ordered case-insensitively, unset
+	// timestamps come first, and ties are broken by path. Must not change
+	// between pages.
+	//
+	// Defaults to reading order: the order the chapters were posted, except
+	// that numbered chapters are ordered by number among the positions they
+	// occupy, so unnumbered chapters keep their place between them.
+	OrderBy string
 }
 
 func (b0 ListChaptersRequest_builder) Build() *ListChaptersRequest {
@@ -853,8 +1228,10 @@ func (b0 ListChaptersRequest_builder) Build() *ListChaptersRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_Filter = b.Filter
 	x.xxx_hidden_MaxPageSize = b.MaxPageSize
 	x.xxx_hidden_PageToken = b.PageToken
+	x.xxx_hidden_OrderBy = b.OrderBy
 	return m0
 }
 
@@ -863,13 +1240,14 @@ type ListChaptersResponse struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Results       *[]*Chapter            `protobuf:"bytes,1,rep,name=results,proto3"`
 	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ListChaptersResponse) Reset() {
 	*x = ListChaptersResponse{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[9]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -881,7 +1259,7 @@ func (x *ListChaptersResponse) String() string {
 func (*ListChaptersResponse) ProtoMessage() {}
 
 func (x *ListChaptersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[9]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -908,6 +1286,13 @@ func (x *ListChaptersResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListChaptersResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
 func (x *ListChaptersResponse) SetResults(v []*Chapter) {
 	x.xxx_hidden_Results = &v
 }
@@ -916,13 +1301,19 @@ func (x *ListChaptersResponse) SetNextPageToken(v string) {
 	x.xxx_hidden_NextPageToken = v
 }
 
+func (x *ListChaptersResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
 type ListChaptersResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The chapters, in reverse-chronological order by update_time.
+	// The chapters, in reading order unless order_by is set.
 	Results []*Chapter
 	// The opaque page token indicating the ending point of this response.
 	NextPageToken string
+	// The number of chapters matching the filter across every page.
+	TotalSize int32
 }
 
 func (b0 ListChaptersResponse_builder) Build() *ListChaptersResponse {
@@ -931,6 +1322,7 @@ func (b0 ListChaptersResponse_builder) Build() *ListChaptersResponse {
 	_, _ = b, x
 	x.xxx_hidden_Results = &b.Results
 	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
 	return m0
 }
 
@@ -944,7 +1336,7 @@ type GetChapterRequest struct {
 
 func (x *GetChapterRequest) Reset() {
 	*x = GetChapterRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[10]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -956,7 +1348,7 @@ func (x *GetChapterRequest) String() string {
 func (*GetChapterRequest) ProtoMessage() {}
 
 func (x *GetChapterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[10]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1397,7 @@ type UpdateChapterRequest struct {
 
 func (x *UpdateChapterRequest) Reset() {
 	*x = UpdateChapterRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[11]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1017,7 +1409,7 @@ func (x *UpdateChapterRequest) String() string {
 func (*UpdateChapterRequest) ProtoMessage() {}
 
 func (x *UpdateChapterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[11]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,30 +1498,30 @@ func (b0 UpdateChapterRequest_builder) Build() *UpdateChapterRequest {
 	return m0
 }
 
-// ReadEntry Request
-type ReadEntryRequest struct {
-	state               protoimpl.MessageState    `protogen:"opaque.v1"`
-	xxx_hidden_Path     string                    `protobuf:"bytes,1,opt,name=path,proto3"`
-	xxx_hidden_MimeType ReadEntryRequest_MimeType `protobuf:"varint,2,opt,name=mime_type,json=mimeType,proto3,enum=stolasapp.erato.v1.ReadEntryRequest_MimeType"`
+// BatchUpdateChapters Request
+type BatchUpdateChaptersRequest struct {
+	state               protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_Parent   string                   `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_Requests *[]*UpdateChapterRequest `protobuf:"bytes,2,rep,name=requests,proto3"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ReadEntryRequest) Reset() {
-	*x = ReadEntryRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[12]
+func (x *BatchUpdateChaptersRequest) Reset() {
+	*x = BatchUpdateChaptersRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReadEntryRequest) String() string {
+func (x *BatchUpdateChaptersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadEntryRequest) ProtoMessage() {}
+func (*BatchUpdateChaptersRequest) ProtoMessage() {}
 
-func (x *ReadEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[12]
+func (x *BatchUpdateChaptersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,39 +1532,178 @@ func (x *ReadEntryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ReadEntryRequest) GetPath() string {
+func (x *BatchUpdateChaptersRequest) GetParent() string {
 	if x != nil {
-		return x.xxx_hidden_Path
+		return x.xxx_hidden_Parent
 	}
 	return ""
 }
 
-func (x *ReadEntryRequest) GetMimeType() ReadEntryRequest_MimeType {
+func (x *BatchUpdateChaptersRequest) GetRequests() []*UpdateChapterRequest {
 	if x != nil {
-		return x.xxx_hidden_MimeType
+		if x.xxx_hidden_Requests != nil {
+			return *x.xxx_hidden_Requests
+		}
 	}
-	return ReadEntryRequest_MIME_TYPE_UNSPECIFIED
+	return nil
 }
 
-func (x *ReadEntryRequest) SetPath(v string) {
-	x.xxx_hidden_Path = v
+func (x *BatchUpdateChaptersRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
 }
 
-func (x *ReadEntryRequest) SetMimeType(v ReadEntryRequest_MimeType) {
-	x.xxx_hidden_MimeType = v
+func (x *BatchUpdateChaptersRequest) SetRequests(v []*UpdateChapterRequest) {
+	x.xxx_hidden_Requests = &v
 }
 
-type ReadEntryRequest_builder struct {
+type BatchUpdateChaptersRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The globally unique identifier for the story entry.
-	Path string
-	// The content type of the response data.
-	MimeType ReadEntryRequest_MimeType
+	// The parent anthology entry of the chapters being updated.
+	Parent string
+	// The updates to apply. Each path must be a child of the parent. The
+	// updates are applied atomically; either all succeed or none do.
+	Requests []*UpdateChapterRequest
 }
 
-func (b0 ReadEntryRequest_builder) Build() *ReadEntryRequest {
-	m0 := &ReadEntryRequest{}
+func (b0 BatchUpdateChaptersRequest_builder) Build() *BatchUpdateChaptersRequest {
+	m0 := &BatchUpdateChaptersRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_Requests = &b.Requests
+	return m0
+}
+
+// BatchUpdateChapters Response
+type BatchUpdateChaptersResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results *[]*Chapter            `protobuf:"bytes,1,rep,name=results,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchUpdateChaptersResponse) Reset() {
+	*x = BatchUpdateChaptersResponse{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateChaptersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateChaptersResponse) ProtoMessage() {}
+
+func (x *BatchUpdateChaptersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchUpdateChaptersResponse) GetResults() []*Chapter {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *BatchUpdateChaptersResponse) SetResults(v []*Chapter) {
+	x.xxx_hidden_Results = &v
+}
+
+type BatchUpdateChaptersResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The updated chapters, in the same order as the requests. Only the path,
+	// etag and user-modifiable fields are populated.
+	Results []*Chapter
+}
+
+func (b0 BatchUpdateChaptersResponse_builder) Build() *BatchUpdateChaptersResponse {
+	m0 := &BatchUpdateChaptersResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
+// ReadEntry Request
+type ReadEntryRequest struct {
+	state               protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_Path     string                    `protobuf:"bytes,1,opt,name=path,proto3"`
+	xxx_hidden_MimeType ReadEntryRequest_MimeType `protobuf:"varint,2,opt,name=mime_type,json=mimeType,proto3,enum=stolasapp.erato.v1.ReadEntryRequest_MimeType"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ReadEntryRequest) Reset() {
+	*x = ReadEntryRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadEntryRequest) ProtoMessage() {}
+
+func (x *ReadEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ReadEntryRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *ReadEntryRequest) GetMimeType() ReadEntryRequest_MimeType {
+	if x != nil {
+		return x.xxx_hidden_MimeType
+	}
+	return ReadEntryRequest_MIME_TYPE_UNSPECIFIED
+}
+
+func (x *ReadEntryRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *ReadEntryRequest) SetMimeType(v ReadEntryRequest_MimeType) {
+	x.xxx_hidden_MimeType = v
+}
+
+type ReadEntryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the story entry.
+	Path string
+	// The content type of the response data.
+	MimeType ReadEntryRequest_MimeType
+}
+
+func (b0 ReadEntryRequest_builder) Build() *ReadEntryRequest {
+	m0 := &ReadEntryRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Path = b.Path
@@ -1190,7 +1721,7 @@ type ReadEntryResponse struct {
 
 func (x *ReadEntryResponse) Reset() {
 	*x = ReadEntryResponse{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[13]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1202,7 +1733,7 @@ func (x *ReadEntryResponse) String() string {
 func (*ReadEntryResponse) ProtoMessage() {}
 
 func (x *ReadEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[13]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1250,7 +1781,7 @@ type ReadChapterRequest struct {
 
 func (x *ReadChapterRequest) Reset() {
 	*x = ReadChapterRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[14]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1262,7 +1793,7 @@ func (x *ReadChapterRequest) String() string {
 func (*ReadChapterRequest) ProtoMessage() {}
 
 func (x *ReadChapterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[14]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1323,7 +1854,7 @@ type ReadChapterResponse struct {
 
 func (x *ReadChapterResponse) Reset() {
 	*x = ReadChapterResponse{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[15]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1335,7 +1866,7 @@ func (x *ReadChapterResponse) String() string {
 func (*ReadChapterResponse) ProtoMessage() {}
 
 func (x *ReadChapterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[15]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1372,18 +1903,93 @@ func (b0 ReadChapterResponse_builder) Build() *ReadChapterResponse {
 	return m0
 }
 
+// ExportEntry Request
+type ExportEntryRequest struct {
+	state             protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_Path   string                    `protobuf:"bytes,1,opt,name=path,proto3"`
+	xxx_hidden_Format ExportEntryRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=stolasapp.erato.v1.ExportEntryRequest_Format"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportEntryRequest) Reset() {
+	*x = ExportEntryRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportEntryRequest) ProtoMessage() {}
+
+func (x *ExportEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportEntryRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *ExportEntryRequest) GetFormat() ExportEntryRequest_Format {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return ExportEntryRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportEntryRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *ExportEntryRequest) SetFormat(v ExportEntryRequest_Format) {
+	x.xxx_hidden_Format = v
+}
+
+type ExportEntryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the story or anthology entry.
+	Path string
+	// The file format of the export.
+	Format ExportEntryRequest_Format
+}
+
+func (b0 ExportEntryRequest_builder) Build() *ExportEntryRequest {
+	m0 := &ExportEntryRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_Format = b.Format
+	return m0
+}
+
 // CreateUser Request
 type CreateUserRequest struct {
-	state           protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id   string                 `protobuf:"bytes,1,opt,name=id,proto3"`
-	xxx_hidden_User *User                  `protobuf:"bytes,2,opt,name=user,proto3"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id         string                 `protobuf:"bytes,1,opt,name=id,proto3"`
+	xxx_hidden_User       *User                  `protobuf:"bytes,2,opt,name=user,proto3"`
+	xxx_hidden_InviteCode string                 `protobuf:"bytes,3,opt,name=invite_code,json=inviteCode,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[16]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1395,7 +2001,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[16]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,6 +2026,13 @@ func (x *CreateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *CreateUserRequest) GetInviteCode() string {
+	if x != nil {
+		return x.xxx_hidden_InviteCode
+	}
+	return ""
+}
+
 func (x *CreateUserRequest) SetId(v string) {
 	x.xxx_hidden_Id = v
 }
@@ -1428,6 +2041,10 @@ func (x *CreateUserRequest) SetUser(v *User) {
 	x.xxx_hidden_User = v
 }
 
+func (x *CreateUserRequest) SetInviteCode(v string) {
+	x.xxx_hidden_InviteCode = v
+}
+
 func (x *CreateUserRequest) HasUser() bool {
 	if x == nil {
 		return false
@@ -1448,6 +2065,9 @@ type CreateUserRequest_builder struct {
 	Id string
 	// The user to create.
 	User *User
+	// The code of an invite, allowing users that are not admins to create a
+	// user. The invite is redeemed by creating the user.
+	InviteCode string
 }
 
 func (b0 CreateUserRequest_builder) Build() *CreateUserRequest {
@@ -1456,6 +2076,7 @@ func (b0 CreateUserRequest_builder) Build() *CreateUserRequest {
 	_, _ = b, x
 	x.xxx_hidden_Id = b.Id
 	x.xxx_hidden_User = b.User
+	x.xxx_hidden_InviteCode = b.InviteCode
 	return m0
 }
 
@@ -1474,7 +2095,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[17]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1486,7 +2107,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[17]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1535,7 +2156,8 @@ type ListUsersRequest_builder struct {
 
 	// Boolean CEL expression to filter user results.
 	//
-	// The variable `this` refers to a User.
+	// The variable `this` refers to a User and `user` to the authenticated user.
+	// See ListEntriesRequest.filter for the functions available to filters.
 	Filter string
 	// The maximum size of the page.
 	MaxPageSize int32
@@ -1558,13 +2180,14 @@ type ListUsersResponse struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Results       *[]*User               `protobuf:"bytes,1,rep,name=results,proto3"`
 	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[18]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1576,7 +2199,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[18]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1603,6 +2226,13 @@ func (x *ListUsersResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListUsersResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
 func (x *ListUsersResponse) SetResults(v []*User) {
 	x.xxx_hidden_Results = &v
 }
@@ -1611,6 +2241,10 @@ func (x *ListUsersResponse) SetNextPageToken(v string) {
 	x.xxx_hidden_NextPageToken = v
 }
 
+func (x *ListUsersResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
 type ListUsersResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1618,6 +2252,8 @@ type ListUsersResponse_builder struct {
 	Results []*User
 	// The opaque page token indicating the ending point of this response.
 	NextPageToken string
+	// The number of users matching the filter across every page.
+	TotalSize int32
 }
 
 func (b0 ListUsersResponse_builder) Build() *ListUsersResponse {
@@ -1626,6 +2262,7 @@ func (b0 ListUsersResponse_builder) Build() *ListUsersResponse {
 	_, _ = b, x
 	x.xxx_hidden_Results = &b.Results
 	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
 	return m0
 }
 
@@ -1639,7 +2276,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[19]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1651,7 +2288,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[19]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1700,7 +2337,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[20]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1712,7 +2349,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[20]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1811,7 +2448,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[21]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1823,7 +2460,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[21]
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1860,58 +2497,1287 @@ func (b0 DeleteUserRequest_builder) Build() *DeleteUserRequest {
 	return m0
 }
 
-var File_stolasapp_erato_v1_archive_proto protoreflect.FileDescriptor
+// RenameUser Request
+type RenameUserRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path  string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	xxx_hidden_NewId string                 `protobuf:"bytes,2,opt,name=new_id,json=newId,proto3"`
+	xxx_hidden_Etag  string                 `protobuf:"bytes,3,opt,name=etag,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_stolasapp_erato_v1_archive_proto_rawDesc = "" +
-	"\n" +
-	" stolasapp/erato/v1/archive.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a!stolasapp/erato/v1/category.proto\x1a stolasapp/erato/v1/chapter.proto\x1a\x1estolasapp/erato/v1/entry.proto\x1a\x1dstolasapp/erato/v1/user.proto\"\x9b\x01\n" +
-	"\x15ListCategoriesRequest\x12\x1e\n" +
-	"\x06filter\x18\x01 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
-	"\rmax_page_size\x18\x02 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
-	"\n" +
-	"page_token\x18\x03 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"x\n" +
-	"\x16ListCategoriesResponse\x126\n" +
-	"\aresults\x18\x01 \x03(\v2\x1c.stolasapp.erato.v1.CategoryR\aresults\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"Q\n" +
-	"\x12GetCategoryRequest\x12;\n" +
-	"\x04path\x18\x01 \x01(\tB'\xbaH\x03\xc8\x01\x01\x8aO\x1e\x12\x19erato.stolas.app/category\x1a\x01\x02R\x04path\"\xe9\x01\n" +
-	"\x15UpdateCategoryRequest\x12;\n" +
-	"\x04path\x18\x01 \x01(\tB'\xbaH\x03\xc8\x01\x01\x8aO\x1e\x12\x19erato.stolas.app/category\x1a\x01\x02R\x04path\x12F\n" +
-	"\bcategory\x18\x02 \x01(\v2\x1c.stolasapp.erato.v1.CategoryB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\bcategory\x12K\n" +
-	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x0e\xbaH\v\xe2\x01\b\x12\x06hiddenR\n" +
-	"updateMask\"\xd6\x01\n" +
-	"\x12ListEntriesRequest\x12<\n" +
-	"\x06parent\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x1a\x01\x02\"\x16erato.stolas.app/entryR\x06parent\x12\x1e\n" +
-	"\x06filter\x18\x02 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
-	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
-	"\n" +
-	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"r\n" +
-	"\x13ListEntriesResponse\x123\n" +
-	"\aresults\x18\x01 \x03(\v2\x19.stolasapp.erato.v1.EntryR\aresults\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"K\n" +
-	"\x0fGetEntryRequest\x128\n" +
-	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\"\xf9\x01\n" +
-	"\x12UpdateEntryRequest\x128\n" +
-	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\x12=\n" +
-	"\x05entry\x18\x02 \x01(\v2\x19.stolasapp.erato.v1.EntryB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x05entry\x12j\n" +
-	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB-\xbaH*\xe2\x01'\x12\x06hidden\x12\astarred\x12\tview_time\x12\tread_timeR\n" +
-	"updateMask\"\xb9\x01\n" +
-	"\x13ListChaptersRequest\x12>\n" +
-	"\x06parent\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x1a\x01\x02\"\x18erato.stolas.app/chapterR\x06parent\x123\n" +
-	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
-	"\n" +
-	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"u\n" +
-	"\x14ListChaptersResponse\x125\n" +
-	"\aresults\x18\x01 \x03(\v2\x1b.stolasapp.erato.v1.ChapterR\aresults\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"O\n" +
-	"\x11GetChapterRequest\x12:\n" +
-	"\x04path\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x12\x18erato.stolas.app/chapter\x1a\x01\x02R\x04path\"\xf2\x01\n" +
-	"\x14UpdateChapterRequest\x12:\n" +
-	"\x04path\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x12\x18erato.stolas.app/chapter\x1a\x01\x02R\x04path\x12C\n" +
-	"\achapter\x18\x02 \x01(\v2\x1b.stolasapp.erato.v1.ChapterB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\achapter\x12Y\n" +
-	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x1c\xbaH\x19\xe2\x01\x16\x12\tview_time\x12\tread_timeR\n" +
-	"updateMask\"\xea\x01\n" +
-	"\x10ReadEntryRequest\x128\n" +
+func (x *RenameUserRequest) Reset() {
+	*x = RenameUserRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameUserRequest) ProtoMessage() {}
+
+func (x *RenameUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RenameUserRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) GetNewId() string {
+	if x != nil {
+		return x.xxx_hidden_NewId
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *RenameUserRequest) SetNewId(v string) {
+	x.xxx_hidden_NewId = v
+}
+
+func (x *RenameUserRequest) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+type RenameUserRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the user.
+	Path string
+	// The new unique identifier for the user. Used as the user's name.
+	//
+	// Must be 3-64 characters, alphanumeric and underscores only.
+	NewId string
+	// The etag of the user. If provided, the rename is rejected if the user has
+	// been modified since the etag was read.
+	Etag string
+}
+
+func (b0 RenameUserRequest_builder) Build() *RenameUserRequest {
+	m0 := &RenameUserRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_NewId = b.NewId
+	x.xxx_hidden_Etag = b.Etag
+	return m0
+}
+
+// CreateAccessToken Request
+type CreateAccessTokenRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Parent      string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_AccessToken *AccessToken           `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenRequest) Reset() {
+	*x = CreateAccessTokenRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreateAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateAccessTokenRequest) GetParent() string {
+	if x != nil {
+		return x.xxx_hidden_Parent
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenRequest) GetAccessToken() *AccessToken {
+	if x != nil {
+		return x.xxx_hidden_AccessToken
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
+}
+
+func (x *CreateAccessTokenRequest) SetAccessToken(v *AccessToken) {
+	x.xxx_hidden_AccessToken = v
+}
+
+func (x *CreateAccessTokenRequest) HasAccessToken() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_AccessToken != nil
+}
+
+func (x *CreateAccessTokenRequest) ClearAccessToken() {
+	x.xxx_hidden_AccessToken = nil
+}
+
+type CreateAccessTokenRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The user that owns the access token.
+	Parent string
+	// The access token to create.
+	AccessToken *AccessToken
+}
+
+func (b0 CreateAccessTokenRequest_builder) Build() *CreateAccessTokenRequest {
+	m0 := &CreateAccessTokenRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_AccessToken = b.AccessToken
+	return m0
+}
+
+// ListAccessTokens Request.
+type ListAccessTokensRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Parent      string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_Filter      string                 `protobuf:"bytes,2,opt,name=filter,proto3"`
+	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,3,opt,name=max_page_size,json=maxPageSize,proto3"`
+	xxx_hidden_PageToken   string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensRequest) Reset() {
+	*x = ListAccessTokensRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensRequest) ProtoMessage() {}
+
+func (x *ListAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAccessTokensRequest) GetParent() string {
+	if x != nil {
+		return x.xxx_hidden_Parent
+	}
+	return ""
+}
+
+func (x *ListAccessTokensRequest) GetFilter() string {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return ""
+}
+
+func (x *ListAccessTokensRequest) GetMaxPageSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxPageSize
+	}
+	return 0
+}
+
+func (x *ListAccessTokensRequest) GetPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_PageToken
+	}
+	return ""
+}
+
+func (x *ListAccessTokensRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
+}
+
+func (x *ListAccessTokensRequest) SetFilter(v string) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *ListAccessTokensRequest) SetMaxPageSize(v int32) {
+	x.xxx_hidden_MaxPageSize = v
+}
+
+func (x *ListAccessTokensRequest) SetPageToken(v string) {
+	x.xxx_hidden_PageToken = v
+}
+
+type ListAccessTokensRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The user that owns the access tokens.
+	Parent string
+	// Boolean CEL expression to filter access token results.
+	//
+	// The variable `this` refers to an AccessToken and `user` to the authenticated user.
+	// See ListEntriesRequest.filter for the functions available to filters.
+	Filter string
+	// The maximum size of the page.
+	MaxPageSize int32
+	// The opaque page token to request.
+	PageToken string
+}
+
+func (b0 ListAccessTokensRequest_builder) Build() *ListAccessTokensRequest {
+	m0 := &ListAccessTokensRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_Filter = b.Filter
+	x.xxx_hidden_MaxPageSize = b.MaxPageSize
+	x.xxx_hidden_PageToken = b.PageToken
+	return m0
+}
+
+// ListAccessTokens Response
+type ListAccessTokensResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results       *[]*AccessToken        `protobuf:"bytes,1,rep,name=results,proto3"`
+	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensResponse) Reset() {
+	*x = ListAccessTokensResponse{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensResponse) ProtoMessage() {}
+
+func (x *ListAccessTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAccessTokensResponse) GetResults() []*AccessToken {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *ListAccessTokensResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAccessTokensResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *ListAccessTokensResponse) SetResults(v []*AccessToken) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *ListAccessTokensResponse) SetNextPageToken(v string) {
+	x.xxx_hidden_NextPageToken = v
+}
+
+func (x *ListAccessTokensResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
+type ListAccessTokensResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The access tokens, in order of creation.
+	Results []*AccessToken
+	// The opaque page token indicating the ending point of this response.
+	NextPageToken string
+	// The number of access tokens matching the filter across every page.
+	TotalSize int32
+}
+
+func (b0 ListAccessTokensResponse_builder) Build() *ListAccessTokensResponse {
+	m0 := &ListAccessTokensResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
+	return m0
+}
+
+// DeleteAccessToken Request
+type DeleteAccessTokenRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteAccessTokenRequest) Reset() {
+	*x = DeleteAccessTokenRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccessTokenRequest) ProtoMessage() {}
+
+func (x *DeleteAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteAccessTokenRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *DeleteAccessTokenRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+type DeleteAccessTokenRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the access token.
+	Path string
+}
+
+func (b0 DeleteAccessTokenRequest_builder) Build() *DeleteAccessTokenRequest {
+	m0 := &DeleteAccessTokenRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	return m0
+}
+
+// CreateSavedView Request
+type CreateSavedViewRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Parent    string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_SavedView *SavedView             `protobuf:"bytes,2,opt,name=saved_view,json=savedView,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateSavedViewRequest) Reset() {
+	*x = CreateSavedViewRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedViewRequest) ProtoMessage() {}
+
+func (x *CreateSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateSavedViewRequest) GetParent() string {
+	if x != nil {
+		return x.xxx_hidden_Parent
+	}
+	return ""
+}
+
+func (x *CreateSavedViewRequest) GetSavedView() *SavedView {
+	if x != nil {
+		return x.xxx_hidden_SavedView
+	}
+	return nil
+}
+
+func (x *CreateSavedViewRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
+}
+
+func (x *CreateSavedViewRequest) SetSavedView(v *SavedView) {
+	x.xxx_hidden_SavedView = v
+}
+
+func (x *CreateSavedViewRequest) HasSavedView() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_SavedView != nil
+}
+
+func (x *CreateSavedViewRequest) ClearSavedView() {
+	x.xxx_hidden_SavedView = nil
+}
+
+type CreateSavedViewRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The user that owns the saved view.
+	Parent string
+	// The saved view to create, which must have a display_name.
+	SavedView *SavedView
+}
+
+func (b0 CreateSavedViewRequest_builder) Build() *CreateSavedViewRequest {
+	m0 := &CreateSavedViewRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_SavedView = b.SavedView
+	return m0
+}
+
+// ListSavedViews Request.
+type ListSavedViewsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Parent      string                 `protobuf:"bytes,1,opt,name=parent,proto3"`
+	xxx_hidden_Filter      string                 `protobuf:"bytes,2,opt,name=filter,proto3"`
+	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,3,opt,name=max_page_size,json=maxPageSize,proto3"`
+	xxx_hidden_PageToken   string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListSavedViewsRequest) Reset() {
+	*x = ListSavedViewsRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsRequest) ProtoMessage() {}
+
+func (x *ListSavedViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSavedViewsRequest) GetParent() string {
+	if x != nil {
+		return x.xxx_hidden_Parent
+	}
+	return ""
+}
+
+func (x *ListSavedViewsRequest) GetFilter() string {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return ""
+}
+
+func (x *ListSavedViewsRequest) GetMaxPageSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxPageSize
+	}
+	return 0
+}
+
+func (x *ListSavedViewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_PageToken
+	}
+	return ""
+}
+
+func (x *ListSavedViewsRequest) SetParent(v string) {
+	x.xxx_hidden_Parent = v
+}
+
+func (x *ListSavedViewsRequest) SetFilter(v string) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *ListSavedViewsRequest) SetMaxPageSize(v int32) {
+	x.xxx_hidden_MaxPageSize = v
+}
+
+func (x *ListSavedViewsRequest) SetPageToken(v string) {
+	x.xxx_hidden_PageToken = v
+}
+
+type ListSavedViewsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The user that owns the saved views.
+	Parent string
+	// Boolean CEL expression to filter saved view results.
+	//
+	// The variable `this` refers to a SavedView and `user` to the authenticated
+	// user. See ListEntriesRequest.filter for the functions available to
+	// filters.
+	Filter string
+	// The maximum size of the page.
+	MaxPageSize int32
+	// The opaque page token to request.
+	PageToken string
+}
+
+func (b0 ListSavedViewsRequest_builder) Build() *ListSavedViewsRequest {
+	m0 := &ListSavedViewsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parent = b.Parent
+	x.xxx_hidden_Filter = b.Filter
+	x.xxx_hidden_MaxPageSize = b.MaxPageSize
+	x.xxx_hidden_PageToken = b.PageToken
+	return m0
+}
+
+// ListSavedViews Response
+type ListSavedViewsResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results       *[]*SavedView          `protobuf:"bytes,1,rep,name=results,proto3"`
+	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ListSavedViewsResponse) Reset() {
+	*x = ListSavedViewsResponse{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsResponse) ProtoMessage() {}
+
+func (x *ListSavedViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSavedViewsResponse) GetResults() []*SavedView {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *ListSavedViewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_NextPageToken
+	}
+	return ""
+}
+
+func (x *ListSavedViewsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *ListSavedViewsResponse) SetResults(v []*SavedView) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *ListSavedViewsResponse) SetNextPageToken(v string) {
+	x.xxx_hidden_NextPageToken = v
+}
+
+func (x *ListSavedViewsResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
+type ListSavedViewsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The saved views, in order of creation.
+	Results []*SavedView
+	// The opaque page token indicating the ending point of this response.
+	NextPageToken string
+	// The number of saved views matching the filter across every page.
+	TotalSize int32
+}
+
+func (b0 ListSavedViewsResponse_builder) Build() *ListSavedViewsResponse {
+	m0 := &ListSavedViewsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
+	return m0
+}
+
+// GetSavedView Request
+type GetSavedViewRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetSavedViewRequest) Reset() {
+	*x = GetSavedViewRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedViewRequest) ProtoMessage() {}
+
+func (x *GetSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSavedViewRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *GetSavedViewRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+type GetSavedViewRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the saved view.
+	Path string
+}
+
+func (b0 GetSavedViewRequest_builder) Build() *GetSavedViewRequest {
+	m0 := &GetSavedViewRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	return m0
+}
+
+// UpdateSavedView Request
+type UpdateSavedViewRequest struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path       string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	xxx_hidden_SavedView  *SavedView             `protobuf:"bytes,2,opt,name=saved_view,json=savedView,proto3"`
+	xxx_hidden_UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UpdateSavedViewRequest) Reset() {
+	*x = UpdateSavedViewRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedViewRequest) ProtoMessage() {}
+
+func (x *UpdateSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateSavedViewRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *UpdateSavedViewRequest) GetSavedView() *SavedView {
+	if x != nil {
+		return x.xxx_hidden_SavedView
+	}
+	return nil
+}
+
+func (x *UpdateSavedViewRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.xxx_hidden_UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateSavedViewRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *UpdateSavedViewRequest) SetSavedView(v *SavedView) {
+	x.xxx_hidden_SavedView = v
+}
+
+func (x *UpdateSavedViewRequest) SetUpdateMask(v *fieldmaskpb.FieldMask) {
+	x.xxx_hidden_UpdateMask = v
+}
+
+func (x *UpdateSavedViewRequest) HasSavedView() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_SavedView != nil
+}
+
+func (x *UpdateSavedViewRequest) HasUpdateMask() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdateMask != nil
+}
+
+func (x *UpdateSavedViewRequest) ClearSavedView() {
+	x.xxx_hidden_SavedView = nil
+}
+
+func (x *UpdateSavedViewRequest) ClearUpdateMask() {
+	x.xxx_hidden_UpdateMask = nil
+}
+
+type UpdateSavedViewRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the saved view.
+	Path string
+	// The updates to apply to the saved view.
+	SavedView *SavedView
+	// The update mask for the saved view.
+	//
+	// Valid paths: display_name, filter, order_by, category
+	UpdateMask *fieldmaskpb.FieldMask
+}
+
+func (b0 UpdateSavedViewRequest_builder) Build() *UpdateSavedViewRequest {
+	m0 := &UpdateSavedViewRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_SavedView = b.SavedView
+	x.xxx_hidden_UpdateMask = b.UpdateMask
+	return m0
+}
+
+// DeleteSavedView Request
+type DeleteSavedViewRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteSavedViewRequest) Reset() {
+	*x = DeleteSavedViewRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedViewRequest) ProtoMessage() {}
+
+func (x *DeleteSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteSavedViewRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *DeleteSavedViewRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+type DeleteSavedViewRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The globally unique identifier for the saved view.
+	Path string
+}
+
+func (b0 DeleteSavedViewRequest_builder) Build() *DeleteSavedViewRequest {
+	m0 := &DeleteSavedViewRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	return m0
+}
+
+// CreateInvite Request
+type CreateInviteRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Invite *Invite                `protobuf:"bytes,2,opt,name=invite,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateInviteRequest) GetInvite() *Invite {
+	if x != nil {
+		return x.xxx_hidden_Invite
+	}
+	return nil
+}
+
+func (x *CreateInviteRequest) SetInvite(v *Invite) {
+	x.xxx_hidden_Invite = v
+}
+
+func (x *CreateInviteRequest) HasInvite() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Invite != nil
+}
+
+func (x *CreateInviteRequest) ClearInvite() {
+	x.xxx_hidden_Invite = nil
+}
+
+type CreateInviteRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The invite to create.
+	Invite *Invite
+}
+
+func (b0 CreateInviteRequest_builder) Build() *CreateInviteRequest {
+	m0 := &CreateInviteRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Invite = b.Invite
+	return m0
+}
+
+// ListAuditEvents Request.
+//
+// TODO: linter bug not skipping parent field when none exists
+// buf:lint:ignore AEP_0132_REQUEST_PARENT_REQUIRED
+type ListAuditEventsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filter      string                 `protobuf:"bytes,1,opt,name=filter,proto3"`
+	xxx_hidden_MaxPageSize int32                  `protobuf:"varint,2,opt,name=max_page_size,json=maxPageSize,proto3"`
+	xxx_hidden_PageToken   string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditEventsRequest) GetFilter() string {
+	if x != nil {
+		return x.xxx_hidden_Filter
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetMaxPageSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxPageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_PageToken
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) SetFilter(v string) {
+	x.xxx_hidden_Filter = v
+}
+
+func (x *ListAuditEventsRequest) SetMaxPageSize(v int32) {
+	x.xxx_hidden_MaxPageSize = v
+}
+
+func (x *ListAuditEventsRequest) SetPageToken(v string) {
+	x.xxx_hidden_PageToken = v
+}
+
+type ListAuditEventsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Boolean CEL expression to filter audit event results.
+	//
+	// The variable `this` refers to an AuditEvent and `user` to the authenticated user.
+	// See ListEntriesRequest.filter for the functions available to filters.
+	Filter string
+	// The maximum size of the page. Defaults to 50 if unset.
+	MaxPageSize int32
+	// The opaque page token to request.
+	PageToken string
+}
+
+func (b0 ListAuditEventsRequest_builder) Build() *ListAuditEventsRequest {
+	m0 := &ListAuditEventsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Filter = b.Filter
+	x.xxx_hidden_MaxPageSize = b.MaxPageSize
+	x.xxx_hidden_PageToken = b.PageToken
+	return m0
+}
+
+// ListAuditEvents Response
+type ListAuditEventsResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results       *[]*AuditEvent         `protobuf:"bytes,1,rep,name=results,proto3"`
+	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	xxx_hidden_TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_archive_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditEventsResponse) GetResults() []*AuditEvent {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse) SetResults(v []*AuditEvent) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *ListAuditEventsResponse) SetNextPageToken(v string) {
+	x.xxx_hidden_NextPageToken = v
+}
+
+func (x *ListAuditEventsResponse) SetTotalSize(v int32) {
+	x.xxx_hidden_TotalSize = v
+}
+
+type ListAuditEventsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The audit events, newest first.
+	Results []*AuditEvent
+	// The opaque page token indicating the ending point of this response.
+	NextPageToken string
+	// The number of audit events across every page. This is only set if there
+	// is no filter, as filtered listings stop reading once the page is full.
+	TotalSize int32
+}
+
+func (b0 ListAuditEventsResponse_builder) Build() *ListAuditEventsResponse {
+	m0 := &ListAuditEventsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_NextPageToken = b.NextPageToken
+	x.xxx_hidden_TotalSize = b.TotalSize
+	return m0
+}
+
+var File_stolasapp_erato_v1_archive_proto protoreflect.FileDescriptor
+
+const file_stolasapp_erato_v1_archive_proto_rawDesc = "" +
+	"\n" +
+	" stolasapp/erato/v1/archive.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a%stolasapp/erato/v1/access_token.proto\x1a$stolasapp/erato/v1/audit_event.proto\x1a!stolasapp/erato/v1/category.proto\x1a stolasapp/erato/v1/chapter.proto\x1a\x1estolasapp/erato/v1/entry.proto\x1a\x1fstolasapp/erato/v1/invite.proto\x1a#stolasapp/erato/v1/saved_view.proto\x1a\x1dstolasapp/erato/v1/user.proto\"\xc6\x01\n" +
+	"\x15ListCategoriesRequest\x12\x1e\n" +
+	"\x06filter\x18\x01 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x02 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\x12)\n" +
+	"\border_by\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80\x02\x8aO\x03\x1a\x01\x01R\aorderBy\"\x97\x01\n" +
+	"\x16ListCategoriesResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.stolasapp.erato.v1.CategoryR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"Q\n" +
+	"\x12GetCategoryRequest\x12;\n" +
+	"\x04path\x18\x01 \x01(\tB'\xbaH\x03\xc8\x01\x01\x8aO\x1e\x12\x19erato.stolas.app/category\x1a\x01\x02R\x04path\"\xe9\x01\n" +
+	"\x15UpdateCategoryRequest\x12;\n" +
+	"\x04path\x18\x01 \x01(\tB'\xbaH\x03\xc8\x01\x01\x8aO\x1e\x12\x19erato.stolas.app/category\x1a\x01\x02R\x04path\x12F\n" +
+	"\bcategory\x18\x02 \x01(\v2\x1c.stolasapp.erato.v1.CategoryB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\bcategory\x12K\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x0e\xbaH\v\xe2\x01\b\x12\x06hiddenR\n" +
+	"updateMask\"\x81\x02\n" +
+	"\x12ListEntriesRequest\x12<\n" +
+	"\x06parent\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x1a\x01\x02\"\x16erato.stolas.app/entryR\x06parent\x12\x1e\n" +
+	"\x06filter\x18\x02 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\x12)\n" +
+	"\border_by\x18\x05 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80\x02\x8aO\x03\x1a\x01\x01R\aorderBy\"\xa4\x02\n" +
+	"\x13ListEntriesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.stolasapp.erato.v1.EntryR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\x120\n" +
+	"\x14total_size_estimated\x18\x04 \x01(\bR\x12totalSizeEstimated\x12+\n" +
+	"\x11results_truncated\x18\x05 \x01(\bR\x10resultsTruncated\x122\n" +
+	"\x15unfiltered_total_size\x18\x06 \x01(\x05R\x13unfilteredTotalSize\"K\n" +
+	"\x0fGetEntryRequest\x128\n" +
+	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\"\xf9\x01\n" +
+	"\x12UpdateEntryRequest\x128\n" +
+	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\x12=\n" +
+	"\x05entry\x18\x02 \x01(\v2\x19.stolasapp.erato.v1.EntryB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x05entry\x12j\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB-\xbaH*\xe2\x01'\x12\x06hidden\x12\astarred\x12\tview_time\x12\tread_timeR\n" +
+	"updateMask\"\xaf\x01\n" +
+	"\x19BatchUpdateEntriesRequest\x12<\n" +
+	"\x06parent\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x1a\x01\x02\"\x16erato.stolas.app/entryR\x06parent\x12T\n" +
+	"\brequests\x18\x02 \x03(\v2&.stolasapp.erato.v1.UpdateEntryRequestB\x10\xbaH\a\x92\x01\x04\b\x01\x10d\x8aO\x03\x1a\x01\x02R\brequests\"Q\n" +
+	"\x1aBatchUpdateEntriesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.stolasapp.erato.v1.EntryR\aresults\"\x84\x02\n" +
+	"\x13ListChaptersRequest\x12>\n" +
+	"\x06parent\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x1a\x01\x02\"\x18erato.stolas.app/chapterR\x06parent\x12\x1e\n" +
+	"\x06filter\x18\x02 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\x12)\n" +
+	"\border_by\x18\x05 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80\x02\x8aO\x03\x1a\x01\x01R\aorderBy\"\x94\x01\n" +
+	"\x14ListChaptersResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.stolasapp.erato.v1.ChapterR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"O\n" +
+	"\x11GetChapterRequest\x12:\n" +
+	"\x04path\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x12\x18erato.stolas.app/chapter\x1a\x01\x02R\x04path\"\xf2\x01\n" +
+	"\x14UpdateChapterRequest\x12:\n" +
+	"\x04path\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x12\x18erato.stolas.app/chapter\x1a\x01\x02R\x04path\x12C\n" +
+	"\achapter\x18\x02 \x01(\v2\x1b.stolasapp.erato.v1.ChapterB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\achapter\x12Y\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x1c\xbaH\x19\xe2\x01\x16\x12\tview_time\x12\tread_timeR\n" +
+	"updateMask\"\xb4\x01\n" +
+	"\x1aBatchUpdateChaptersRequest\x12>\n" +
+	"\x06parent\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x1a\x01\x02\"\x18erato.stolas.app/chapterR\x06parent\x12V\n" +
+	"\brequests\x18\x02 \x03(\v2(.stolasapp.erato.v1.UpdateChapterRequestB\x10\xbaH\a\x92\x01\x04\b\x01\x10d\x8aO\x03\x1a\x01\x02R\brequests\"T\n" +
+	"\x1bBatchUpdateChaptersResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.stolasapp.erato.v1.ChapterR\aresults\"\xea\x01\n" +
+	"\x10ReadEntryRequest\x128\n" +
 	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\x12]\n" +
 	"\tmime_type\x18\x02 \x01(\x0e2-.stolasapp.erato.v1.ReadEntryRequest.MimeTypeB\x11\xbaH\b\xc8\x01\x01\x82\x01\x02\x10\x01\x8aO\x03\x1a\x01\x02R\bmimeType\"=\n" +
 	"\bMimeType\x12\x19\n" +
@@ -1924,19 +3790,29 @@ const file_stolasapp_erato_v1_archive_proto_rawDesc = "" +
 	"\x04path\x18\x01 \x01(\tB&\xbaH\x03\xc8\x01\x01\x8aO\x1d\x12\x18erato.stolas.app/chapter\x1a\x01\x02R\x04path\x12]\n" +
 	"\tmime_type\x18\x02 \x01(\x0e2-.stolasapp.erato.v1.ReadEntryRequest.MimeTypeB\x11\xbaH\b\xc8\x01\x01\x82\x01\x02\x10\x01\x8aO\x03\x1a\x01\x02R\bmimeType\"/\n" +
 	"\x13ReadChapterResponse\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\"\x97\x02\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\xd4\x01\n" +
+	"\x12ExportEntryRequest\x128\n" +
+	"\x04path\x18\x01 \x01(\tB$\xbaH\x03\xc8\x01\x01\x8aO\x1b\x12\x16erato.stolas.app/entry\x1a\x01\x02R\x04path\x12X\n" +
+	"\x06format\x18\x02 \x01(\x0e2-.stolasapp.erato.v1.ExportEntryRequest.FormatB\x11\xbaH\b\xc8\x01\x01\x82\x01\x02\x10\x01\x8aO\x03\x1a\x01\x02R\x06format\"*\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04EPUB\x10\x01\"\xc7\x02\n" +
 	"\x11CreateUserRequest\x12\xc5\x01\n" +
 	"\x02id\x18\x01 \x01(\tB\xb4\x01\xbaH\xaa\x01\xba\x01\xa6\x01\n" +
 	"\x0estring.user_id\x12:must be 3-64 characters, alphanumeric and underscores only\x1aXthis == '' || (this.size() >= 3 && this.size() <= 64 && this.matches('^[a-zA-Z0-9_]+$'))\x8aO\x03\x1a\x01\x01R\x02id\x12:\n" +
-	"\x04user\x18\x02 \x01(\v2\x18.stolasapp.erato.v1.UserB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x04user\"\x96\x01\n" +
+	"\x04user\x18\x02 \x01(\v2\x18.stolasapp.erato.v1.UserB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x04user\x12.\n" +
+	"\vinvite_code\x18\x03 \x01(\tB\r\xbaH\x04r\x02\x18@\x8aO\x03\x1a\x01\x01R\n" +
+	"inviteCode\"\x96\x01\n" +
 	"\x10ListUsersRequest\x12\x1e\n" +
 	"\x06filter\x18\x01 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
 	"\rmax_page_size\x18\x02 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
 	"\n" +
-	"page_token\x18\x03 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"o\n" +
+	"page_token\x18\x03 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"\x8e\x01\n" +
 	"\x11ListUsersResponse\x122\n" +
 	"\aresults\x18\x01 \x03(\v2\x18.stolasapp.erato.v1.UserR\aresults\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"I\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"I\n" +
 	"\x0eGetUserRequest\x127\n" +
 	"\x04path\x18\x01 \x01(\tB#\xbaH\x03\xc8\x01\x01\x8aO\x1a\x12\x15erato.stolas.app/user\x1a\x01\x02R\x04path\"\xd7\x01\n" +
 	"\x11UpdateUserRequest\x127\n" +
@@ -1946,20 +3822,82 @@ const file_stolasapp_erato_v1_archive_proto_rawDesc = "" +
 	"\x12\bpasswordR\n" +
 	"updateMask\"L\n" +
 	"\x11DeleteUserRequest\x127\n" +
-	"\x04path\x18\x01 \x01(\tB#\xbaH\x03\xc8\x01\x01\x8aO\x1a\x12\x15erato.stolas.app/user\x1a\x01\x02R\x04path2\xd0\x11\n" +
+	"\x04path\x18\x01 \x01(\tB#\xbaH\x03\xc8\x01\x01\x8aO\x1a\x12\x15erato.stolas.app/user\x1a\x01\x02R\x04path\"\xaa\x02\n" +
+	"\x11RenameUserRequest\x127\n" +
+	"\x04path\x18\x01 \x01(\tB#\xbaH\x03\xc8\x01\x01\x8aO\x1a\x12\x15erato.stolas.app/user\x1a\x01\x02R\x04path\x12\xbf\x01\n" +
+	"\x06new_id\x18\x02 \x01(\tB\xa7\x01\xbaH\x9d\x01\xba\x01\x96\x01\n" +
+	"\x0estring.user_id\x12:must be 3-64 characters, alphanumeric and underscores only\x1aHthis.size() >= 3 && this.size() <= 64 && this.matches('^[a-zA-Z0-9_]+$')\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x05newId\x12\x1a\n" +
+	"\x04etag\x18\x03 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x04etag\"\xb1\x01\n" +
+	"\x18CreateAccessTokenRequest\x12C\n" +
+	"\x06parent\x18\x01 \x01(\tB+\xbaH\x03\xc8\x01\x01\x8aO\"\x1a\x01\x02\"\x1derato.stolas.app/access-tokenR\x06parent\x12P\n" +
+	"\faccess_token\x18\x02 \x01(\v2\x1f.stolasapp.erato.v1.AccessTokenB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\vaccessToken\"\xe2\x01\n" +
+	"\x17ListAccessTokensRequest\x12C\n" +
+	"\x06parent\x18\x01 \x01(\tB+\xbaH\x03\xc8\x01\x01\x8aO\"\x1a\x01\x02\"\x1derato.stolas.app/access-tokenR\x06parent\x12\x1e\n" +
+	"\x06filter\x18\x02 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"\x9c\x01\n" +
+	"\x18ListAccessTokensResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.stolasapp.erato.v1.AccessTokenR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"[\n" +
+	"\x18DeleteAccessTokenRequest\x12?\n" +
+	"\x04path\x18\x01 \x01(\tB+\xbaH\x03\xc8\x01\x01\x8aO\"\x12\x1derato.stolas.app/access-token\x1a\x01\x02R\x04path\"\xf7\x01\n" +
+	"\x16CreateSavedViewRequest\x12A\n" +
+	"\x06parent\x18\x01 \x01(\tB)\xbaH\x03\xc8\x01\x01\x8aO \x1a\x01\x02\"\x1berato.stolas.app/saved-viewR\x06parent\x12\x99\x01\n" +
+	"\n" +
+	"saved_view\x18\x02 \x01(\v2\x1d.stolasapp.erato.v1.SavedViewB[\xbaHR\xba\x01L\n" +
+	"\x17saved_view.display_name\x12\x18display_name is required\x1a\x17this.display_name != ''\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\tsavedView\"\xde\x01\n" +
+	"\x15ListSavedViewsRequest\x12A\n" +
+	"\x06parent\x18\x01 \x01(\tB)\xbaH\x03\xc8\x01\x01\x8aO \x1a\x01\x02\"\x1berato.stolas.app/saved-viewR\x06parent\x12\x1e\n" +
+	"\x06filter\x18\x02 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x03 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"\x98\x01\n" +
+	"\x16ListSavedViewsResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.stolasapp.erato.v1.SavedViewR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"T\n" +
+	"\x13GetSavedViewRequest\x12=\n" +
+	"\x04path\x18\x01 \x01(\tB)\xbaH\x03\xc8\x01\x01\x8aO \x12\x1berato.stolas.app/saved-view\x1a\x01\x02R\x04path\"\x92\x02\n" +
+	"\x16UpdateSavedViewRequest\x12=\n" +
+	"\x04path\x18\x01 \x01(\tB)\xbaH\x03\xc8\x01\x01\x8aO \x12\x1berato.stolas.app/saved-view\x1a\x01\x02R\x04path\x12J\n" +
+	"\n" +
+	"saved_view\x18\x02 \x01(\v2\x1d.stolasapp.erato.v1.SavedViewB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\tsavedView\x12m\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB0\xbaH-\xe2\x01*\x12\fdisplay_name\x12\x06filter\x12\border_by\x12\bcategoryR\n" +
+	"updateMask\"W\n" +
+	"\x16DeleteSavedViewRequest\x12=\n" +
+	"\x04path\x18\x01 \x01(\tB)\xbaH\x03\xc8\x01\x01\x8aO \x12\x1berato.stolas.app/saved-view\x1a\x01\x02R\x04path\"W\n" +
+	"\x13CreateInviteRequest\x12@\n" +
+	"\x06invite\x18\x02 \x01(\v2\x1a.stolasapp.erato.v1.InviteB\f\xbaH\x03\xc8\x01\x01\x8aO\x03\x1a\x01\x02R\x06invite\"\x9c\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x1e\n" +
+	"\x06filter\x18\x01 \x01(\tB\x06\x8aO\x03\x1a\x01\x01R\x06filter\x123\n" +
+	"\rmax_page_size\x18\x02 \x01(\x05B\x0f\xbaH\x06\x1a\x04\x18d(\x00\x8aO\x03\x1a\x01\x01R\vmaxPageSize\x12-\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\x0e\xbaH\x05r\x03\x18\x80 \x8aO\x03\x1a\x01\x01R\tpageToken\"\x9a\x01\n" +
+	"\x17ListAuditEventsResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.stolasapp.erato.v1.AuditEventR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize2\xcf\"\n" +
 	"\x0eArchiveService\x12\x85\x01\n" +
 	"\x0eListCategories\x12).stolasapp.erato.v1.ListCategoriesRequest\x1a*.stolasapp.erato.v1.ListCategoriesResponse\"\x1c\xdaA\x00\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/categories\x90\x02\x01\x12~\n" +
 	"\vGetCategory\x12&.stolasapp.erato.v1.GetCategoryRequest\x1a\x1c.stolasapp.erato.v1.Category\")\xdaA\x04path\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/{path=categories/*}\x90\x02\x01\x12\x9b\x01\n" +
 	"\x0eUpdateCategory\x12).stolasapp.erato.v1.UpdateCategoryRequest\x1a\x1c.stolasapp.erato.v1.Category\"@\xdaA\x14category,update_mask\x82\xd3\xe4\x93\x02#:\bcategory2\x17/v1/{path=categories/*}\x12\x95\x01\n" +
 	"\vListEntries\x12&.stolasapp.erato.v1.ListEntriesRequest\x1a'.stolasapp.erato.v1.ListEntriesResponse\"5\xdaA\x06parent\x82\xd3\xe4\x93\x02#\x12!/v1/{parent=categories/*}/entries\x90\x02\x01\x12\x7f\n" +
 	"\bGetEntry\x12#.stolasapp.erato.v1.GetEntryRequest\x1a\x19.stolasapp.erato.v1.Entry\"3\xdaA\x04path\x82\xd3\xe4\x93\x02#\x12!/v1/{path=categories/*/entries/*}\x90\x02\x01\x12\x96\x01\n" +
-	"\vUpdateEntry\x12&.stolasapp.erato.v1.UpdateEntryRequest\x1a\x19.stolasapp.erato.v1.Entry\"D\xdaA\x11entry,update_mask\x82\xd3\xe4\x93\x02*:\x05entry2!/v1/{path=categories/*/entries/*}\x12\xa3\x01\n" +
+	"\vUpdateEntry\x12&.stolasapp.erato.v1.UpdateEntryRequest\x1a\x19.stolasapp.erato.v1.Entry\"D\xdaA\x11entry,update_mask\x82\xd3\xe4\x93\x02*:\x05entry2!/v1/{path=categories/*/entries/*}\x12\xad\x01\n" +
+	"\x12BatchUpdateEntries\x12-.stolasapp.erato.v1.BatchUpdateEntriesRequest\x1a..stolasapp.erato.v1.BatchUpdateEntriesResponse\"8\x82\xd3\xe4\x93\x022:\x01*\"-/v1/{parent=categories/*}/entries:batchUpdate\x12\xa3\x01\n" +
 	"\fListChapters\x12'.stolasapp.erato.v1.ListChaptersRequest\x1a(.stolasapp.erato.v1.ListChaptersResponse\"@\xdaA\x06parent\x82\xd3\xe4\x93\x02.\x12,/v1/{parent=categories/*/entries/*}/chapters\x90\x02\x01\x12\x90\x01\n" +
 	"\n" +
 	"GetChapter\x12%.stolasapp.erato.v1.GetChapterRequest\x1a\x1b.stolasapp.erato.v1.Chapter\">\xdaA\x04path\x82\xd3\xe4\x93\x02.\x12,/v1/{path=categories/*/entries/*/chapters/*}\x90\x02\x01\x12\xab\x01\n" +
-	"\rUpdateChapter\x12(.stolasapp.erato.v1.UpdateChapterRequest\x1a\x1b.stolasapp.erato.v1.Chapter\"S\xdaA\x13chapter,update_mask\x82\xd3\xe4\x93\x027:\achapter2,/v1/{path=categories/*/entries/*/chapters/*}\x12\x92\x01\n" +
+	"\rUpdateChapter\x12(.stolasapp.erato.v1.UpdateChapterRequest\x1a\x1b.stolasapp.erato.v1.Chapter\"S\xdaA\x13chapter,update_mask\x82\xd3\xe4\x93\x027:\achapter2,/v1/{path=categories/*/entries/*/chapters/*}\x12\xbb\x01\n" +
+	"\x13BatchUpdateChapters\x12..stolasapp.erato.v1.BatchUpdateChaptersRequest\x1a/.stolasapp.erato.v1.BatchUpdateChaptersResponse\"C\x82\xd3\xe4\x93\x02=:\x01*\"8/v1/{parent=categories/*/entries/*}/chapters:batchUpdate\x12\x92\x01\n" +
 	"\tReadEntry\x12$.stolasapp.erato.v1.ReadEntryRequest\x1a%.stolasapp.erato.v1.ReadEntryResponse\"8\xdaA\x04path\x82\xd3\xe4\x93\x02(\x12&/v1/{path=categories/*/entries/*}:read\x90\x02\x01\x12\xa3\x01\n" +
-	"\vReadChapter\x12&.stolasapp.erato.v1.ReadChapterRequest\x1a'.stolasapp.erato.v1.ReadChapterResponse\"C\xdaA\x04path\x82\xd3\xe4\x93\x023\x121/v1/{path=categories/*/entries/*/chapters/*}:read\x90\x02\x01\x12m\n" +
+	"\vReadChapter\x12&.stolasapp.erato.v1.ReadChapterRequest\x1a'.stolasapp.erato.v1.ReadChapterResponse\"C\xdaA\x04path\x82\xd3\xe4\x93\x023\x121/v1/{path=categories/*/entries/*/chapters/*}:read\x90\x02\x01\x12\x89\x01\n" +
+	"\vExportEntry\x12&.stolasapp.erato.v1.ExportEntryRequest\x1a\x14.google.api.HttpBody\":\xdaA\x04path\x82\xd3\xe4\x93\x02*\x12(/v1/{path=categories/*/entries/*}:export\x90\x02\x010\x01\x12m\n" +
 	"\n" +
 	"CreateUser\x12%.stolasapp.erato.v1.CreateUserRequest\x1a\x18.stolasapp.erato.v1.User\"\x1e\xdaA\x04user\x82\xd3\xe4\x93\x02\x11:\x04user\"\t/v1/users\x12q\n" +
 	"\tListUsers\x12$.stolasapp.erato.v1.ListUsersRequest\x1a%.stolasapp.erato.v1.ListUsersResponse\"\x17\xdaA\x00\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x90\x02\x01\x12m\n" +
@@ -1967,95 +3905,175 @@ const file_stolasapp_erato_v1_archive_proto_rawDesc = "" +
 	"\n" +
 	"UpdateUser\x12%.stolasapp.erato.v1.UpdateUserRequest\x1a\x18.stolasapp.erato.v1.User\"3\xdaA\x10user,update_mask\x82\xd3\xe4\x93\x02\x1a:\x04user2\x12/v1/{path=users/*}\x12n\n" +
 	"\n" +
-	"DeleteUser\x12%.stolasapp.erato.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\"!\xdaA\x04path\x82\xd3\xe4\x93\x02\x14*\x12/v1/{path=users/*}B\xd4\x01\n" +
+	"DeleteUser\x12%.stolasapp.erato.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\"!\xdaA\x04path\x82\xd3\xe4\x93\x02\x14*\x12/v1/{path=users/*}\x12\x81\x01\n" +
+	"\n" +
+	"RenameUser\x12%.stolasapp.erato.v1.RenameUserRequest\x1a\x18.stolasapp.erato.v1.User\"2\xdaA\vpath,new_id\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/{path=users/*}:rename\x12\xb2\x01\n" +
+	"\x11CreateAccessToken\x12,.stolasapp.erato.v1.CreateAccessTokenRequest\x1a\x1f.stolasapp.erato.v1.AccessToken\"N\xdaA\x13parent,access_token\x82\xd3\xe4\x93\x022:\faccess_token\"\"/v1/{parent=users/*}/access-tokens\x12\xa5\x01\n" +
+	"\x10ListAccessTokens\x12+.stolasapp.erato.v1.ListAccessTokensRequest\x1a,.stolasapp.erato.v1.ListAccessTokensResponse\"6\xdaA\x06parent\x82\xd3\xe4\x93\x02$\x12\"/v1/{parent=users/*}/access-tokens\x90\x02\x01\x12\x8c\x01\n" +
+	"\x11DeleteAccessToken\x12,.stolasapp.erato.v1.DeleteAccessTokenRequest\x1a\x16.google.protobuf.Empty\"1\xdaA\x04path\x82\xd3\xe4\x93\x02$*\"/v1/{path=users/*/access-tokens/*}\x12\xa6\x01\n" +
+	"\x0fCreateSavedView\x12*.stolasapp.erato.v1.CreateSavedViewRequest\x1a\x1d.stolasapp.erato.v1.SavedView\"H\xdaA\x11parent,saved_view\x82\xd3\xe4\x93\x02.:\n" +
+	"saved_view\" /v1/{parent=users/*}/saved-views\x12\x9d\x01\n" +
+	"\x0eListSavedViews\x12).stolasapp.erato.v1.ListSavedViewsRequest\x1a*.stolasapp.erato.v1.ListSavedViewsResponse\"4\xdaA\x06parent\x82\xd3\xe4\x93\x02\"\x12 /v1/{parent=users/*}/saved-views\x90\x02\x01\x12\x8a\x01\n" +
+	"\fGetSavedView\x12'.stolasapp.erato.v1.GetSavedViewRequest\x1a\x1d.stolasapp.erato.v1.SavedView\"2\xdaA\x04path\x82\xd3\xe4\x93\x02\"\x12 /v1/{path=users/*/saved-views/*}\x90\x02\x01\x12\xab\x01\n" +
+	"\x0fUpdateSavedView\x12*.stolasapp.erato.v1.UpdateSavedViewRequest\x1a\x1d.stolasapp.erato.v1.SavedView\"M\xdaA\x16saved_view,update_mask\x82\xd3\xe4\x93\x02.:\n" +
+	"saved_view2 /v1/{path=users/*/saved-views/*}\x12\x86\x01\n" +
+	"\x0fDeleteSavedView\x12*.stolasapp.erato.v1.DeleteSavedViewRequest\x1a\x16.google.protobuf.Empty\"/\xdaA\x04path\x82\xd3\xe4\x93\x02\"* /v1/{path=users/*/saved-views/*}\x12y\n" +
+	"\fCreateInvite\x12'.stolasapp.erato.v1.CreateInviteRequest\x1a\x1a.stolasapp.erato.v1.Invite\"$\xdaA\x06invite\x82\xd3\xe4\x93\x02\x15:\x06invite\"\v/v1/invites\x12\x8a\x01\n" +
+	"\x0fListAuditEvents\x12*.stolasapp.erato.v1.ListAuditEventsRequest\x1a+.stolasapp.erato.v1.ListAuditEventsResponse\"\x1e\xdaA\x00\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit-events\x90\x02\x01B\xd4\x01\n" +
 	"\x16com.stolasapp.erato.v1B\fArchiveProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
 
-var file_stolasapp_erato_v1_archive_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_stolasapp_erato_v1_archive_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_stolasapp_erato_v1_archive_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_stolasapp_erato_v1_archive_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_stolasapp_erato_v1_archive_proto_goTypes = []any{
-	(ReadEntryRequest_MimeType)(0), // 0: stolasapp.erato.v1.ReadEntryRequest.MimeType
-	(*ListCategoriesRequest)(nil),  // 1: stolasapp.erato.v1.ListCategoriesRequest
-	(*ListCategoriesResponse)(nil), // 2: stolasapp.erato.v1.ListCategoriesResponse
-	(*GetCategoryRequest)(nil),     // 3: stolasapp.erato.v1.GetCategoryRequest
-	(*UpdateCategoryRequest)(nil),  // 4: stolasapp.erato.v1.UpdateCategoryRequest
-	(*ListEntriesRequest)(nil),     // 5: stolasapp.erato.v1.ListEntriesRequest
-	(*ListEntriesResponse)(nil),    // 6: stolasapp.erato.v1.ListEntriesResponse
-	(*GetEntryRequest)(nil),        // 7: stolasapp.erato.v1.GetEntryRequest
-	(*UpdateEntryRequest)(nil),     // 8: stolasapp.erato.v1.UpdateEntryRequest
-	(*ListChaptersRequest)(nil),    // 9: stolasapp.erato.v1.ListChaptersRequest
-	(*ListChaptersResponse)(nil),   // 10: stolasapp.erato.v1.ListChaptersResponse
-	(*GetChapterRequest)(nil),      // 11: stolasapp.erato.v1.GetChapterRequest
-	(*UpdateChapterRequest)(nil),   // 12: stolasapp.erato.v1.UpdateChapterRequest
-	(*ReadEntryRequest)(nil),       // 13: stolasapp.erato.v1.ReadEntryRequest
-	(*ReadEntryResponse)(nil),      // 14: stolasapp.erato.v1.ReadEntryResponse
-	(*ReadChapterRequest)(nil),     // 15: stolasapp.erato.v1.ReadChapterRequest
-	(*ReadChapterResponse)(nil),    // 16: stolasapp.erato.v1.ReadChapterResponse
-	(*CreateUserRequest)(nil),      // 17: stolasapp.erato.v1.CreateUserRequest
-	(*ListUsersRequest)(nil),       // 18: stolasapp.erato.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),      // 19: stolasapp.erato.v1.ListUsersResponse
-	(*GetUserRequest)(nil),         // 20: stolasapp.erato.v1.GetUserRequest
-	(*UpdateUserRequest)(nil),      // 21: stolasapp.erato.v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 22: stolasapp.erato.v1.DeleteUserRequest
-	(*Category)(nil),               // 23: stolasapp.erato.v1.Category
-	(*fieldmaskpb.FieldMask)(nil),  // 24: google.protobuf.FieldMask
-	(*Entry)(nil),                  // 25: stolasapp.erato.v1.Entry
-	(*Chapter)(nil),                // 26: stolasapp.erato.v1.Chapter
-	(*User)(nil),                   // 27: stolasapp.erato.v1.User
-	(*emptypb.Empty)(nil),          // 28: google.protobuf.Empty
+	(ReadEntryRequest_MimeType)(0),      // 0: stolasapp.erato.v1.ReadEntryRequest.MimeType
+	(ExportEntryRequest_Format)(0),      // 1: stolasapp.erato.v1.ExportEntryRequest.Format
+	(*ListCategoriesRequest)(nil),       // 2: stolasapp.erato.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),      // 3: stolasapp.erato.v1.ListCategoriesResponse
+	(*GetCategoryRequest)(nil),          // 4: stolasapp.erato.v1.GetCategoryRequest
+	(*UpdateCategoryRequest)(nil),       // 5: stolasapp.erato.v1.UpdateCategoryRequest
+	(*ListEntriesRequest)(nil),          // 6: stolasapp.erato.v1.ListEntriesRequest
+	(*ListEntriesResponse)(nil),         // 7: stolasapp.erato.v1.ListEntriesResponse
+	(*GetEntryRequest)(nil),             // 8: stolasapp.erato.v1.GetEntryRequest
+	(*UpdateEntryRequest)(nil),          // 9: stolasapp.erato.v1.UpdateEntryRequest
+	(*BatchUpdateEntriesRequest)(nil),   // 10: stolasapp.erato.v1.BatchUpdateEntriesRequest
+	(*BatchUpdateEntriesResponse)(nil),  // 11: stolasapp.erato.v1.BatchUpdateEntriesResponse
+	(*ListChaptersRequest)(nil),         // 12: stolasapp.erato.v1.ListChaptersRequest
+	(*ListChaptersResponse)(nil),        // 13: stolasapp.erato.v1.ListChaptersResponse
+	(*GetChapterRequest)(nil),           // 14: stolasapp.erato.v1.GetChapterRequest
+	(*UpdateChapterRequest)(nil),        // 15: stolasapp.erato.v1.UpdateChapterRequest
+	(*BatchUpdateChaptersRequest)(nil),  // 16: stolasapp.erato.v1.BatchUpdateChaptersRequest
+	(*BatchUpdateChaptersResponse)(nil), // 17: stolasapp.erato.v1.BatchUpdateChaptersResponse
+	(*ReadEntryRequest)(nil),            // 18: stolasapp.erato.v1.ReadEntryRequest
+	(*ReadEntryResponse)(nil),           // 19: stolasapp.erato.v1.ReadEntryResponse
+	(*ReadChapterRequest)(nil),          // 20: stolasapp.erato.v1.ReadChapterRequest
+	(*ReadChapterResponse)(nil),         // 21: stolasapp.erato.v1.ReadChapterResponse
+	(*ExportEntryRequest)(nil),          // 22: stolasapp.erato.v1.ExportEntryRequest
+	(*CreateUserRequest)(nil),           // 23: stolasapp.erato.v1.CreateUserRequest
+	(*ListUsersRequest)(nil),            // 24: stolasapp.erato.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),           // 25: stolasapp.erato.v1.ListUsersResponse
+	(*GetUserRequest)(nil),              // 26: stolasapp.erato.v1.GetUserRequest
+	(*UpdateUserRequest)(nil),           // 27: stolasapp.erato.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),           // 28: stolasapp.erato.v1.DeleteUserRequest
+	(*RenameUserRequest)(nil),           // 29: stolasapp.erato.v1.RenameUserRequest
+	(*CreateAccessTokenRequest)(nil),    // 30: stolasapp.erato.v1.CreateAccessTokenRequest
+	(*ListAccessTokensRequest)(nil),     // 31: stolasapp.erato.v1.ListAccessTokensRequest
+	(*ListAccessTokensResponse)(nil),    // 32: stolasapp.erato.v1.ListAccessTokensResponse
+	(*DeleteAccessTokenRequest)(nil),    // 33: stolasapp.erato.v1.DeleteAccessTokenRequest
+	(*CreateSavedViewRequest)(nil),      // 34: stolasapp.erato.v1.CreateSavedViewRequest
+	(*ListSavedViewsRequest)(nil),       // 35: stolasapp.erato.v1.ListSavedViewsRequest
+	(*ListSavedViewsResponse)(nil),      // 36: stolasapp.erato.v1.ListSavedViewsResponse
+	(*GetSavedViewRequest)(nil),         // 37: stolasapp.erato.v1.GetSavedViewRequest
+	(*UpdateSavedViewRequest)(nil),      // 38: stolasapp.erato.v1.UpdateSavedViewRequest
+	(*DeleteSavedViewRequest)(nil),      // 39: stolasapp.erato.v1.DeleteSavedViewRequest
+	(*CreateInviteRequest)(nil),         // 40: stolasapp.erato.v1.CreateInviteRequest
+	(*ListAuditEventsRequest)(nil),      // 41: stolasapp.erato.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),     // 42: stolasapp.erato.v1.ListAuditEventsResponse
+	(*Category)(nil),                    // 43: stolasapp.erato.v1.Category
+	(*fieldmaskpb.FieldMask)(nil),       // 44: google.protobuf.FieldMask
+	(*Entry)(nil),                       // 45: stolasapp.erato.v1.Entry
+	(*Chapter)(nil),                     // 46: stolasapp.erato.v1.Chapter
+	(*User)(nil),                        // 47: stolasapp.erato.v1.User
+	(*AccessToken)(nil),                 // 48: stolasapp.erato.v1.AccessToken
+	(*SavedView)(nil),                   // 49: stolasapp.erato.v1.SavedView
+	(*Invite)(nil),                      // 50: stolasapp.erato.v1.Invite
+	(*AuditEvent)(nil),                  // 51: stolasapp.erato.v1.AuditEvent
+	(*httpbody.HttpBody)(nil),           // 52: google.api.HttpBody
+	(*emptypb.Empty)(nil),               // 53: google.protobuf.Empty
 }
 var file_stolasapp_erato_v1_archive_proto_depIdxs = []int32{
-	23, // 0: stolasapp.erato.v1.ListCategoriesResponse.results:type_name -> stolasapp.erato.v1.Category
-	23, // 1: stolasapp.erato.v1.UpdateCategoryRequest.category:type_name -> stolasapp.erato.v1.Category
-	24, // 2: stolasapp.erato.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
-	25, // 3: stolasapp.erato.v1.ListEntriesResponse.results:type_name -> stolasapp.erato.v1.Entry
-	25, // 4: stolasapp.erato.v1.UpdateEntryRequest.entry:type_name -> stolasapp.erato.v1.Entry
-	24, // 5: stolasapp.erato.v1.UpdateEntryRequest.update_mask:type_name -> google.protobuf.FieldMask
-	26, // 6: stolasapp.erato.v1.ListChaptersResponse.results:type_name -> stolasapp.erato.v1.Chapter
-	26, // 7: stolasapp.erato.v1.UpdateChapterRequest.chapter:type_name -> stolasapp.erato.v1.Chapter
-	24, // 8: stolasapp.erato.v1.UpdateChapterRequest.update_mask:type_name -> google.protobuf.FieldMask
-	0,  // 9: stolasapp.erato.v1.ReadEntryRequest.mime_type:type_name -> stolasapp.erato.v1.ReadEntryRequest.MimeType
-	0,  // 10: stolasapp.erato.v1.ReadChapterRequest.mime_type:type_name -> stolasapp.erato.v1.ReadEntryRequest.MimeType
-	27, // 11: stolasapp.erato.v1.CreateUserRequest.user:type_name -> stolasapp.erato.v1.User
-	27, // 12: stolasapp.erato.v1.ListUsersResponse.results:type_name -> stolasapp.erato.v1.User
-	27, // 13: stolasapp.erato.v1.UpdateUserRequest.user:type_name -> stolasapp.erato.v1.User
-	24, // 14: stolasapp.erato.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 15: stolasapp.erato.v1.ArchiveService.ListCategories:input_type -> stolasapp.erato.v1.ListCategoriesRequest
-	3,  // 16: stolasapp.erato.v1.ArchiveService.GetCategory:input_type -> stolasapp.erato.v1.GetCategoryRequest
-	4,  // 17: stolasapp.erato.v1.ArchiveService.UpdateCategory:input_type -> stolasapp.erato.v1.UpdateCategoryRequest
-	5,  // 18: stolasapp.erato.v1.ArchiveService.ListEntries:input_type -> stolasapp.erato.v1.ListEntriesRequest
-	7,  // 19: stolasapp.erato.v1.ArchiveService.GetEntry:input_type -> stolasapp.erato.v1.GetEntryRequest
-	8,  // 20: stolasapp.erato.v1.ArchiveService.UpdateEntry:input_type -> stolasapp.erato.v1.UpdateEntryRequest
-	9,  // 21: stolasapp.erato.v1.ArchiveService.ListChapters:input_type -> stolasapp.erato.v1.ListChaptersRequest
-	11, // 22: stolasapp.erato.v1.ArchiveService.GetChapter:input_type -> stolasapp.erato.v1.GetChapterRequest
-	12, // 23: stolasapp.erato.v1.ArchiveService.UpdateChapter:input_type -> stolasapp.erato.v1.UpdateChapterRequest
-	13, // 24: stolasapp.erato.v1.ArchiveService.ReadEntry:input_type -> stolasapp.erato.v1.ReadEntryRequest
-	15, // 25: stolasapp.erato.v1.ArchiveService.ReadChapter:input_type -> stolasapp.erato.v1.ReadChapterRequest
-	17, // 26: stolasapp.erato.v1.ArchiveService.CreateUser:input_type -> stolasapp.erato.v1.CreateUserRequest
-	18, // 27: stolasapp.erato.v1.ArchiveService.ListUsers:input_type -> stolasapp.erato.v1.ListUsersRequest
-	20, // 28: stolasapp.erato.v1.ArchiveService.GetUser:input_type -> stolasapp.erato.v1.GetUserRequest
-	21, // 29: stolasapp.erato.v1.ArchiveService.UpdateUser:input_type -> stolasapp.erato.v1.UpdateUserRequest
-	22, // 30: stolasapp.erato.v1.ArchiveService.DeleteUser:input_type -> stolasapp.erato.v1.DeleteUserRequest
-	2,  // 31: stolasapp.erato.v1.ArchiveService.ListCategories:output_type -> stolasapp.erato.v1.ListCategoriesResponse
-	23, // 32: stolasapp.erato.v1.ArchiveService.GetCategory:output_type -> stolasapp.erato.v1.Category
-	23, // 33: stolasapp.erato.v1.ArchiveService.UpdateCategory:output_type -> stolasapp.erato.v1.Category
-	6,  // 34: stolasapp.erato.v1.ArchiveService.ListEntries:output_type -> stolasapp.erato.v1.ListEntriesResponse
-	25, // 35: stolasapp.erato.v1.ArchiveService.GetEntry:output_type -> stolasapp.erato.v1.Entry
-	25, // 36: stolasapp.erato.v1.ArchiveService.UpdateEntry:output_type -> stolasapp.erato.v1.Entry
-	10, // 37: stolasapp.erato.v1.ArchiveService.ListChapters:output_type -> stolasapp.erato.v1.ListChaptersResponse
-	26, // 38: stolasapp.erato.v1.ArchiveService.GetChapter:output_type -> stolasapp.erato.v1.Chapter
-	26, // 39: stolasapp.erato.v1.ArchiveService.UpdateChapter:output_type -> stolasapp.erato.v1.Chapter
-	14, // 40: stolasapp.erato.v1.ArchiveService.ReadEntry:output_type -> stolasapp.erato.v1.ReadEntryResponse
-	16, // 41: stolasapp.erato.v1.ArchiveService.ReadChapter:output_type -> stolasapp.erato.v1.ReadChapterResponse
-	27, // 42: stolasapp.erato.v1.ArchiveService.CreateUser:output_type -> stolasapp.erato.v1.User
-	19, // 43: stolasapp.erato.v1.ArchiveService.ListUsers:output_type -> stolasapp.erato.v1.ListUsersResponse
-	27, // 44: stolasapp.erato.v1.ArchiveService.GetUser:output_type -> stolasapp.erato.v1.User
-	27, // 45: stolasapp.erato.v1.ArchiveService.UpdateUser:output_type -> stolasapp.erato.v1.User
-	28, // 46: stolasapp.erato.v1.ArchiveService.DeleteUser:output_type -> google.protobuf.Empty
-	31, // [31:47] is the sub-list for method output_type
-	15, // [15:31] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	43, // 0: stolasapp.erato.v1.ListCategoriesResponse.results:type_name -> stolasapp.erato.v1.Category
+	43, // 1: stolasapp.erato.v1.UpdateCategoryRequest.category:type_name -> stolasapp.erato.v1.Category
+	44, // 2: stolasapp.erato.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
+	45, // 3: stolasapp.erato.v1.ListEntriesResponse.results:type_name -> stolasapp.erato.v1.Entry
+	45, // 4: stolasapp.erato.v1.UpdateEntryRequest.entry:type_name -> stolasapp.erato.v1.Entry
+	44, // 5: stolasapp.erato.v1.UpdateEntryRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 6: stolasapp.erato.v1.BatchUpdateEntriesRequest.requests:type_name -> stolasapp.erato.v1.UpdateEntryRequest
+	45, // 7: stolasapp.erato.v1.BatchUpdateEntriesResponse.results:type_name -> stolasapp.erato.v1.Entry
+	46, // 8: stolasapp.erato.v1.ListChaptersResponse.results:type_name -> stolasapp.erato.v1.Chapter
+	46, // 9: stolasapp.erato.v1.UpdateChapterRequest.chapter:type_name -> stolasapp.erato.v1.Chapter
+	44, // 10: stolasapp.erato.v1.UpdateChapterRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 11: stolasapp.erato.v1.BatchUpdateChaptersRequest.requests:type_name -> stolasapp.erato.v1.UpdateChapterRequest
+	46, // 12: stolasapp.erato.v1.BatchUpdateChaptersResponse.results:type_name -> stolasapp.erato.v1.Chapter
+	0,  // 13: stolasapp.erato.v1.ReadEntryRequest.mime_type:type_name -> stolasapp.erato.v1.ReadEntryRequest.MimeType
+	0,  // 14: stolasapp.erato.v1.ReadChapterRequest.mime_type:type_name -> stolasapp.erato.v1.ReadEntryRequest.MimeType
+	1,  // 15: stolasapp.erato.v1.ExportEntryRequest.format:type_name -> stolasapp.erato.v1.ExportEntryRequest.Format
+	47, // 16: stolasapp.erato.v1.CreateUserRequest.user:type_name -> stolasapp.erato.v1.User
+	47, // 17: stolasapp.erato.v1.ListUsersResponse.results:type_name -> stolasapp.erato.v1.User
+	47, // 18: stolasapp.erato.v1.UpdateUserRequest.user:type_name -> stolasapp.erato.v1.User
+	44, // 19: stolasapp.erato.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	48, // 20: stolasapp.erato.v1.CreateAccessTokenRequest.access_token:type_name -> stolasapp.erato.v1.AccessToken
+	48, // 21: stolasapp.erato.v1.ListAccessTokensResponse.results:type_name -> stolasapp.erato.v1.AccessToken
+	49, // 22: stolasapp.erato.v1.CreateSavedViewRequest.saved_view:type_name -> stolasapp.erato.v1.SavedView
+	49, // 23: stolasapp.erato.v1.ListSavedViewsResponse.results:type_name -> stolasapp.erato.v1.SavedView
+	49, // 24: stolasapp.erato.v1.UpdateSavedViewRequest.saved_view:type_name -> stolasapp.erato.v1.SavedView
+	44, // 25: stolasapp.erato.v1.UpdateSavedViewRequest.update_mask:type_name -> google.protobuf.FieldMask
+	50, // 26: stolasapp.erato.v1.CreateInviteRequest.invite:type_name -> stolasapp.erato.v1.Invite
+	51, // 27: stolasapp.erato.v1.ListAuditEventsResponse.results:type_name -> stolasapp.erato.v1.AuditEvent
+	2,  // 28: stolasapp.erato.v1.ArchiveService.ListCategories:input_type -> stolasapp.erato.v1.ListCategoriesRequest
+	4,  // 29: stolasapp.erato.v1.ArchiveService.GetCategory:input_type -> stolasapp.erato.v1.GetCategoryRequest
+	5,  // 30: stolasapp.erato.v1.ArchiveService.UpdateCategory:input_type -> stolasapp.erato.v1.UpdateCategoryRequest
+	6,  // 31: stolasapp.erato.v1.ArchiveService.ListEntries:input_type -> stolasapp.erato.v1.ListEntriesRequest
+	8,  // 32: stolasapp.erato.v1.ArchiveService.GetEntry:input_type -> stolasapp.erato.v1.GetEntryRequest
+	9,  // 33: stolasapp.erato.v1.ArchiveService.UpdateEntry:input_type -> stolasapp.erato.v1.UpdateEntryRequest
+	10, // 34: stolasapp.erato.v1.ArchiveService.BatchUpdateEntries:input_type -> stolasapp.erato.v1.BatchUpdateEntriesRequest
+	12, // 35: stolasapp.erato.v1.ArchiveService.ListChapters:input_type -> stolasapp.erato.v1.ListChaptersRequest
+	14, // 36: stolasapp.erato.v1.ArchiveService.GetChapter:input_type -> stolasapp.erato.v1.GetChapterRequest
+	15, // 37: stolasapp.erato.v1.ArchiveService.UpdateChapter:input_type -> stolasapp.erato.v1.UpdateChapterRequest
+	16, // 38: stolasapp.erato.v1.ArchiveService.BatchUpdateChapters:input_type -> stolasapp.erato.v1.BatchUpdateChaptersRequest
+	18, // 39: stolasapp.erato.v1.ArchiveService.ReadEntry:input_type -> stolasapp.erato.v1.ReadEntryRequest
+	20, // 40: stolasapp.erato.v1.ArchiveService.ReadChapter:input_type -> stolasapp.erato.v1.ReadChapterRequest
+	22, // 41: stolasapp.erato.v1.ArchiveService.ExportEntry:input_type -> stolasapp.erato.v1.ExportEntryRequest
+	23, // 42: stolasapp.erato.v1.ArchiveService.CreateUser:input_type -> stolasapp.erato.v1.CreateUserRequest
+	24, // 43: stolasapp.erato.v1.ArchiveService.ListUsers:input_type -> stolasapp.erato.v1.ListUsersRequest
+	26, // 44: stolasapp.erato.v1.ArchiveService.GetUser:input_type -> stolasapp.erato.v1.GetUserRequest
+	27, // 45: stolasapp.erato.v1.ArchiveService.UpdateUser:input_type -> stolasapp.erato.v1.UpdateUserRequest
+	28, // 46: stolasapp.erato.v1.ArchiveService.DeleteUser:input_type -> stolasapp.erato.v1.DeleteUserRequest
+	29, // 47: stolasapp.erato.v1.ArchiveService.RenameUser:input_type -> stolasapp.erato.v1.RenameUserRequest
+	30, // 48: stolasapp.erato.v1.ArchiveService.CreateAccessToken:input_type -> stolasapp.erato.v1.CreateAccessTokenRequest
+	31, // 49: stolasapp.erato.v1.ArchiveService.ListAccessTokens:input_type -> stolasapp.erato.v1.ListAccessTokensRequest
+	33, // 50: stolasapp.erato.v1.ArchiveService.DeleteAccessToken:input_type -> stolasapp.erato.v1.DeleteAccessTokenRequest
+	34, // 51: stolasapp.erato.v1.ArchiveService.CreateSavedView:input_type -> stolasapp.erato.v1.CreateSavedViewRequest
+	35, // 52: stolasapp.erato.v1.ArchiveService.ListSavedViews:input_type -> stolasapp.erato.v1.ListSavedViewsRequest
+	37, // 53: stolasapp.erato.v1.ArchiveService.GetSavedView:input_type -> stolasapp.erato.v1.GetSavedViewRequest
+	38, // 54: stolasapp.erato.v1.ArchiveService.UpdateSavedView:input_type -> stolasapp.erato.v1.UpdateSavedViewRequest
+	39, // 55: stolasapp.erato.v1.ArchiveService.DeleteSavedView:input_type -> stolasapp.erato.v1.DeleteSavedViewRequest
+	40, // 56: stolasapp.erato.v1.ArchiveService.CreateInvite:input_type -> stolasapp.erato.v1.CreateInviteRequest
+	41, // 57: stolasapp.erato.v1.ArchiveService.ListAuditEvents:input_type -> stolasapp.erato.v1.ListAuditEventsRequest
+	3,  // 58: stolasapp.erato.v1.ArchiveService.ListCategories:output_type -> stolasapp.erato.v1.ListCategoriesResponse
+	43, // 59: stolasapp.erato.v1.ArchiveService.GetCategory:output_type -> stolasapp.erato.v1.Category
+	43, // 60: stolasapp.erato.v1.ArchiveService.UpdateCategory:output_type -> stolasapp.erato.v1.Category
+	7,  // 61: stolasapp.erato.v1.ArchiveService.ListEntries:output_type -> stolasapp.erato.v1.ListEntriesResponse
+	45, // 62: stolasapp.erato.v1.ArchiveService.GetEntry:output_type -> stolasapp.erato.v1.Entry
+	45, // 63: stolasapp.erato.v1.ArchiveService.UpdateEntry:output_type -> stolasapp.erato.v1.Entry
+	11, // 64: stolasapp.erato.v1.ArchiveService.BatchUpdateEntries:output_type -> stolasapp.erato.v1.BatchUpdateEntriesResponse
+	13, // 65: stolasapp.erato.v1.ArchiveService.ListChapters:output_type -> stolasapp.erato.v1.ListChaptersResponse
+	46, // 66: stolasapp.erato.v1.ArchiveService.GetChapter:output_type -> stolasapp.erato.v1.Chapter
+	46, // 67: stolasapp.erato.v1.ArchiveService.UpdateChapter:output_type -> stolasapp.erato.v1.Chapter
+	17, // 68: stolasapp.erato.v1.ArchiveService.BatchUpdateChapters:output_type -> stolasapp.erato.v1.BatchUpdateChaptersResponse
+	19, // 69: stolasapp.erato.v1.ArchiveService.ReadEntry:output_type -> stolasapp.erato.v1.ReadEntryResponse
+	21, // 70: stolasapp.erato.v1.ArchiveService.ReadChapter:output_type -> stolasapp.erato.v1.ReadChapterResponse
+	52, // 71: stolasapp.erato.v1.ArchiveService.ExportEntry:output_type -> google.api.HttpBody
+	47, // 72: stolasapp.erato.v1.ArchiveService.CreateUser:output_type -> stolasapp.erato.v1.User
+	25, // 73: stolasapp.erato.v1.ArchiveService.ListUsers:output_type -> stolasapp.erato.v1.ListUsersResponse
+	47, // 74: stolasapp.erato.v1.ArchiveService.GetUser:output_type -> stolasapp.erato.v1.User
+	47, // 75: stolasapp.erato.v1.ArchiveService.UpdateUser:output_type -> stolasapp.erato.v1.User
+	53, // 76: stolasapp.erato.v1.ArchiveService.DeleteUser:output_type -> google.protobuf.Empty
+	47, // 77: stolasapp.erato.v1.ArchiveService.RenameUser:output_type -> stolasapp.erato.v1.User
+	48, // 78: stolasapp.erato.v1.ArchiveService.CreateAccessToken:output_type -> stolasapp.erato.v1.AccessToken
+	32, // 79: stolasapp.erato.v1.ArchiveService.ListAccessTokens:output_type -> stolasapp.erato.v1.ListAccessTokensResponse
+	53, // 80: stolasapp.erato.v1.ArchiveService.DeleteAccessToken:output_type -> google.protobuf.Empty
+	49, // 81: stolasapp.erato.v1.ArchiveService.CreateSavedView:output_type -> stolasapp.erato.v1.SavedView
+	36, // 82: stolasapp.erato.v1.ArchiveService.ListSavedViews:output_type -> stolasapp.erato.v1.ListSavedViewsResponse
+	49, // 83: stolasapp.erato.v1.ArchiveService.GetSavedView:output_type -> stolasapp.erato.v1.SavedView
+	49, // 84: stolasapp.erato.v1.ArchiveService.UpdateSavedView:output_type -> stolasapp.erato.v1.SavedView
+	53, // 85: stolasapp.erato.v1.ArchiveService.DeleteSavedView:output_type -> google.protobuf.Empty
+	50, // 86: stolasapp.erato.v1.ArchiveService.CreateInvite:output_type -> stolasapp.erato.v1.Invite
+	42, // 87: stolasapp.erato.v1.ArchiveService.ListAuditEvents:output_type -> stolasapp.erato.v1.ListAuditEventsResponse
+	58, // [58:88] is the sub-list for method output_type
+	28, // [28:58] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_stolasapp_erato_v1_archive_proto_init() }
@@ -2063,17 +4081,21 @@ func file_stolasapp_erato_v1_archive_proto_init() {
 	if File_stolasapp_erato_v1_archive_proto != nil {
 		return
 	}
+	file_stolasapp_erato_v1_access_token_proto_init()
+	file_stolasapp_erato_v1_audit_event_proto_init()
 	file_stolasapp_erato_v1_category_proto_init()
 	file_stolasapp_erato_v1_chapter_proto_init()
 	file_stolasapp_erato_v1_entry_proto_init()
+	file_stolasapp_erato_v1_invite_proto_init()
+	file_stolasapp_erato_v1_saved_view_proto_init()
 	file_stolasapp_erato_v1_user_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stolasapp_erato_v1_archive_proto_rawDesc), len(file_stolasapp_erato_v1_archive_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      2,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
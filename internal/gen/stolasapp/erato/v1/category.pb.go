@@ -29,6 +29,7 @@ type Category struct {
 	xxx_hidden_DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3"`
 	xxx_hidden_Description string                 `protobuf:"bytes,3,opt,name=description,proto3"`
 	xxx_hidden_Hidden      bool                   `protobuf:"varint,4,opt,name=hidden,proto3"`
+	xxx_hidden_Etag        string                 `protobuf:"bytes,5,opt,name=etag,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -86,6 +87,13 @@ func (x *Category) GetHidden() bool {
 	return false
 }
 
+func (x *Category) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
 func (x *Category) SetPath(v string) {
 	x.xxx_hidden_Path = v
 }
@@ -102,6 +110,10 @@ func (x *Category) SetHidden(v bool) {
 	x.xxx_hidden_Hidden = v
 }
 
+func (x *Category) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
 type Category_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -115,6 +127,10 @@ type Category_builder struct {
 	Description string
 	// Has the user hidden the category?
 	Hidden bool
+	// An opaque version of the category, used for optimistic concurrency control.
+	// Updates that provide an etag are rejected if the category has been modified
+	// since the etag was read.
+	Etag string
 }
 
 func (b0 Category_builder) Build() *Category {
@@ -125,6 +141,7 @@ func (b0 Category_builder) Build() *Category {
 	x.xxx_hidden_DisplayName = b.DisplayName
 	x.xxx_hidden_Description = b.Description
 	x.xxx_hidden_Hidden = b.Hidden
+	x.xxx_hidden_Etag = b.Etag
 	return m0
 }
 
@@ -132,12 +149,13 @@ var File_stolasapp_erato_v1_category_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_category_proto_rawDesc = "" +
 	"\n" +
-	"!stolasapp/erato/v1/category.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\"\xe4\x01\n" +
+	"!stolasapp/erato/v1/category.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\"\xf8\x01\n" +
 	"\bCategory\x12\x18\n" +
 	"\x04path\x18\xa2N \x01(\tB\x03\xe0A\bR\x04path\x12,\n" +
 	"\fdisplay_name\x18\x02 \x01(\tB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\vdisplayName\x12+\n" +
 	"\vdescription\x18\x03 \x01(\tB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\vdescription\x12\x16\n" +
-	"\x06hidden\x18\x04 \x01(\bR\x06hidden:K\x92OH\n" +
+	"\x06hidden\x18\x04 \x01(\bR\x06hidden\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag:K\x92OH\n" +
 	"\x19erato.stolas.app/category\x12\x15categories/{category}\x1a\bcategory\"\n" +
 	"categoriesB\xd5\x01\n" +
 	"\x16com.stolasapp.erato.v1B\rCategoryProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
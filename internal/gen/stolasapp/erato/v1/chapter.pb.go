@@ -31,6 +31,8 @@ type Chapter struct {
 	xxx_hidden_UpdateTime  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3"`
 	xxx_hidden_ViewTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=view_time,json=viewTime,proto3"`
 	xxx_hidden_ReadTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=read_time,json=readTime,proto3"`
+	xxx_hidden_Number      int32                  `protobuf:"varint,8,opt,name=number,proto3"`
+	xxx_hidden_Etag        string                 `protobuf:"bytes,7,opt,name=etag,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -95,6 +97,20 @@ func (x *Chapter) GetReadTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Chapter) GetNumber() int32 {
+	if x != nil {
+		return x.xxx_hidden_Number
+	}
+	return 0
+}
+
+func (x *Chapter) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
 func (x *Chapter) SetPath(v string) {
 	x.xxx_hidden_Path = v
 }
@@ -115,6 +131,14 @@ func (x *Chapter) SetReadTime(v *timestamppb.Timestamp) {
 	x.xxx_hidden_ReadTime = v
 }
 
+func (x *Chapter) SetNumber(v int32) {
+	x.xxx_hidden_Number = v
+}
+
+func (x *Chapter) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
 func (x *Chapter) HasUpdateTime() bool {
 	if x == nil {
 		return false
@@ -164,6 +188,14 @@ type Chapter_builder struct {
 	ViewTime *timestamppb.Timestamp
 	// When was the chapter marked as read by the user?
 	ReadTime *timestamppb.Timestamp
+	// The chapter number parsed from the start of its ID, such as 12 for
+	// `chapter-12-the-end`, or 0 if the chapter is not numbered. Numbered
+	// chapters are listed by number by default.
+	Number int32
+	// An opaque version of the chapter, used for optimistic concurrency control.
+	// Updates that provide an etag are rejected if the chapter has been modified
+	// since the etag was read.
+	Etag string
 }
 
 func (b0 Chapter_builder) Build() *Chapter {
@@ -175,6 +207,8 @@ func (b0 Chapter_builder) Build() *Chapter {
 	x.xxx_hidden_UpdateTime = b.UpdateTime
 	x.xxx_hidden_ViewTime = b.ViewTime
 	x.xxx_hidden_ReadTime = b.ReadTime
+	x.xxx_hidden_Number = b.Number
+	x.xxx_hidden_Etag = b.Etag
 	return m0
 }
 
@@ -182,14 +216,16 @@ var File_stolasapp_erato_v1_chapter_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_chapter_proto_rawDesc = "" +
 	"\n" +
-	" stolasapp/erato/v1/chapter.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf7\x02\n" +
+	" stolasapp/erato/v1/chapter.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x03\n" +
 	"\aChapter\x12\x18\n" +
 	"\x04path\x18\xa2N \x01(\tB\x03\xe0A\bR\x04path\x12,\n" +
 	"\fdisplay_name\x18\x03 \x01(\tB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\vdisplayName\x12F\n" +
 	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\n" +
 	"updateTime\x127\n" +
 	"\tview_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bviewTime\x127\n" +
-	"\tread_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\breadTime:j\x92Og\n" +
+	"\tread_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\breadTime\x12!\n" +
+	"\x06number\x18\b \x01(\x05B\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\x06number\x12\x12\n" +
+	"\x04etag\x18\a \x01(\tR\x04etag:j\x92Og\n" +
 	"\x18erato.stolas.app/chapter\x128categories/{category}/entries/{entry}/chapters/{chapter}\x1a\achapter\"\bchaptersB\xd4\x01\n" +
 	"\x16com.stolasapp.erato.v1B\fChapterProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
 
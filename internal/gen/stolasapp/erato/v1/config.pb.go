@@ -10,6 +10,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -81,17 +82,26 @@ func (x Config_LogLevel) Number() protoreflect.EnumNumber {
 //
 // Default location is `$XDG_CONFIG_HOME/erato.yaml`
 type Config struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_LogLevel    Config_LogLevel        `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=stolasapp.erato.v1.Config_LogLevel"`
-	xxx_hidden_RpcAddress  *string                `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3,oneof"`
-	xxx_hidden_WebAddress  *string                `protobuf:"bytes,3,opt,name=web_address,json=webAddress,proto3,oneof"`
-	xxx_hidden_DbFilepath  string                 `protobuf:"bytes,4,opt,name=db_filepath,json=dbFilepath,proto3"`
-	xxx_hidden_RootUri     string                 `protobuf:"bytes,5,opt,name=root_uri,json=rootUri,proto3"`
-	xxx_hidden_DevMode     bool                   `protobuf:"varint,6,opt,name=dev_mode,json=devMode,proto3"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_LogLevel            Config_LogLevel        `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=stolasapp.erato.v1.Config_LogLevel"`
+	xxx_hidden_RpcAddress          *string                `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3,oneof"`
+	xxx_hidden_WebAddress          *string                `protobuf:"bytes,3,opt,name=web_address,json=webAddress,proto3,oneof"`
+	xxx_hidden_DbFilepath          string                 `protobuf:"bytes,4,opt,name=db_filepath,json=dbFilepath,proto3"`
+	xxx_hidden_RootUri             string                 `protobuf:"bytes,5,opt,name=root_uri,json=rootUri,proto3"`
+	xxx_hidden_DevMode             bool                   `protobuf:"varint,6,opt,name=dev_mode,json=devMode,proto3"`
+	xxx_hidden_ManualMigrations    bool                   `protobuf:"varint,7,opt,name=manual_migrations,json=manualMigrations,proto3"`
+	xxx_hidden_AutoReadAnthologies bool                   `protobuf:"varint,8,opt,name=auto_read_anthologies,json=autoReadAnthologies,proto3"`
+	xxx_hidden_SessionIdleTimeout  *durationpb.Duration   `protobuf:"bytes,9,opt,name=session_idle_timeout,json=sessionIdleTimeout,proto3"`
+	xxx_hidden_SessionMaxLifetime  *durationpb.Duration   `protobuf:"bytes,10,opt,name=session_max_lifetime,json=sessionMaxLifetime,proto3"`
+	xxx_hidden_Oidc                *Config_Oidc           `protobuf:"bytes,11,opt,name=oidc,proto3"`
+	xxx_hidden_ProxyAuth           *Config_ProxyAuth      `protobuf:"bytes,12,opt,name=proxy_auth,json=proxyAuth,proto3"`
+	xxx_hidden_DevUsers            []string               `protobuf:"bytes,13,rep,name=dev_users,json=devUsers,proto3"`
+	xxx_hidden_PageTokenKey        []byte                 `protobuf:"bytes,14,opt,name=page_token_key,json=pageTokenKey,proto3"`
+	xxx_hidden_PageTokenTtl        *durationpb.Duration   `protobuf:"bytes,15,opt,name=page_token_ttl,json=pageTokenTtl,proto3"`
+	XXX_raceDetectHookData         protoimpl.RaceDetectHookData
+	XXX_presence                   [1]uint32
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *Config) Reset() {
@@ -167,18 +177,81 @@ func (x *Config) GetDevMode() bool {
 	return false
 }
 
+func (x *Config) GetManualMigrations() bool {
+	if x != nil {
+		return x.xxx_hidden_ManualMigrations
+	}
+	return false
+}
+
+func (x *Config) GetAutoReadAnthologies() bool {
+	if x != nil {
+		return x.xxx_hidden_AutoReadAnthologies
+	}
+	return false
+}
+
+func (x *Config) GetSessionIdleTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_SessionIdleTimeout
+	}
+	return nil
+}
+
+func (x *Config) GetSessionMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_SessionMaxLifetime
+	}
+	return nil
+}
+
+func (x *Config) GetOidc() *Config_Oidc {
+	if x != nil {
+		return x.xxx_hidden_Oidc
+	}
+	return nil
+}
+
+func (x *Config) GetProxyAuth() *Config_ProxyAuth {
+	if x != nil {
+		return x.xxx_hidden_ProxyAuth
+	}
+	return nil
+}
+
+func (x *Config) GetDevUsers() []string {
+	if x != nil {
+		return x.xxx_hidden_DevUsers
+	}
+	return nil
+}
+
+func (x *Config) GetPageTokenKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_PageTokenKey
+	}
+	return nil
+}
+
+func (x *Config) GetPageTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_PageTokenTtl
+	}
+	return nil
+}
+
 func (x *Config) SetLogLevel(v Config_LogLevel) {
 	x.xxx_hidden_LogLevel = v
 }
 
 func (x *Config) SetRpcAddress(v string) {
 	x.xxx_hidden_RpcAddress = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 15)
 }
 
 func (x *Config) SetWebAddress(v string) {
 	x.xxx_hidden_WebAddress = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 15)
 }
 
 func (x *Config) SetDbFilepath(v string) {
@@ -193,6 +266,45 @@ func (x *Config) SetDevMode(v bool) {
 	x.xxx_hidden_DevMode = v
 }
 
+func (x *Config) SetManualMigrations(v bool) {
+	x.xxx_hidden_ManualMigrations = v
+}
+
+func (x *Config) SetAutoReadAnthologies(v bool) {
+	x.xxx_hidden_AutoReadAnthologies = v
+}
+
+func (x *Config) SetSessionIdleTimeout(v *durationpb.Duration) {
+	x.xxx_hidden_SessionIdleTimeout = v
+}
+
+func (x *Config) SetSessionMaxLifetime(v *durationpb.Duration) {
+	x.xxx_hidden_SessionMaxLifetime = v
+}
+
+func (x *Config) SetOidc(v *Config_Oidc) {
+	x.xxx_hidden_Oidc = v
+}
+
+func (x *Config) SetProxyAuth(v *Config_ProxyAuth) {
+	x.xxx_hidden_ProxyAuth = v
+}
+
+func (x *Config) SetDevUsers(v []string) {
+	x.xxx_hidden_DevUsers = v
+}
+
+func (x *Config) SetPageTokenKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_PageTokenKey = v
+}
+
+func (x *Config) SetPageTokenTtl(v *durationpb.Duration) {
+	x.xxx_hidden_PageTokenTtl = v
+}
+
 func (x *Config) HasRpcAddress() bool {
 	if x == nil {
 		return false
@@ -207,6 +319,41 @@ func (x *Config) HasWebAddress() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
+func (x *Config) HasSessionIdleTimeout() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_SessionIdleTimeout != nil
+}
+
+func (x *Config) HasSessionMaxLifetime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_SessionMaxLifetime != nil
+}
+
+func (x *Config) HasOidc() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Oidc != nil
+}
+
+func (x *Config) HasProxyAuth() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ProxyAuth != nil
+}
+
+func (x *Config) HasPageTokenTtl() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PageTokenTtl != nil
+}
+
 func (x *Config) ClearRpcAddress() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
 	x.xxx_hidden_RpcAddress = nil
@@ -217,6 +364,26 @@ func (x *Config) ClearWebAddress() {
 	x.xxx_hidden_WebAddress = nil
 }
 
+func (x *Config) ClearSessionIdleTimeout() {
+	x.xxx_hidden_SessionIdleTimeout = nil
+}
+
+func (x *Config) ClearSessionMaxLifetime() {
+	x.xxx_hidden_SessionMaxLifetime = nil
+}
+
+func (x *Config) ClearOidc() {
+	x.xxx_hidden_Oidc = nil
+}
+
+func (x *Config) ClearProxyAuth() {
+	x.xxx_hidden_ProxyAuth = nil
+}
+
+func (x *Config) ClearPageTokenTtl() {
+	x.xxx_hidden_PageTokenTtl = nil
+}
+
 type Config_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -224,7 +391,8 @@ type Config_builder struct {
 	//
 	// Defaults to `INFO`.
 	LogLevel Config_LogLevel
-	// The host:port pair to listen on for Connect RPC endpoints.
+	// The host:port pair to listen on for Connect RPC endpoints. Admins may
+	// also read the server's expvar metrics as JSON at `/debug/vars`.
 	//
 	// Defaults to `localhost:9998`.
 	RpcAddress *string
@@ -239,7 +407,63 @@ type Config_builder struct {
 	// Root upstream URL for the archive.
 	RootUri string
 	// Enable developer mode.
+	//
+	// Serves a fake upstream archive and skips logging in: every request is
+	// authenticated as one of the `dev_users`.
 	DevMode bool
+	// Require database migrations to be applied manually.
+	//
+	// When set, erato refuses to start if the database schema is behind instead
+	// of migrating it automatically, so upgrades can be staged and applied with
+	// `erato db migrate`. Defaults to `false`.
+	ManualMigrations bool
+	// Automatically mark anthologies as read.
+	//
+	// When set, an anthology is marked as read once every one of its chapters
+	// has been marked as read. Defaults to `false`.
+	AutoReadAnthologies bool
+	// How long a web app session may go unused before it expires.
+	//
+	// Defaults to `168h` (7 days).
+	SessionIdleTimeout *durationpb.Duration
+	// How long a web app session lasts, regardless of activity.
+	//
+	// Defaults to `720h` (30 days).
+	SessionMaxLifetime *durationpb.Duration
+	// Sign in with an OpenID Connect identity provider.
+	//
+	// When set, the web app offers login through the provider and the RPC
+	// server accepts the provider's ID tokens as Bearer tokens. Users are
+	// created on their first login. Disabled by default.
+	Oidc *Config_Oidc
+	// Trust an authenticating reverse proxy to identify users.
+	//
+	// When set, requests from a trusted proxy naming a user in the configured
+	// header are authenticated as that user, in both the web app and the RPC
+	// server. The first time a name is seen, it is linked to the user of that
+	// name, who is created if they do not exist, so users renamed afterwards
+	// keep the name the proxy knows them by. Disabled by default.
+	ProxyAuth *Config_ProxyAuth
+	// The users seeded in developer mode.
+	//
+	// Users are created at startup if they do not already exist. Requests are
+	// authenticated as the first, who is an admin, unless they impersonate
+	// another with the web app's user switcher or the `X-Erato-Dev-User` RPC
+	// header. The others are members. Only used when `dev_mode` is set.
+	//
+	// Defaults to `dev`.
+	DevUsers []string
+	// The key used to sign pagination tokens, base64-encoded in YAML. Must be at
+	// least 32 bytes. Tokens signed with a different key are rejected, so
+	// servers sharing a database should share a key.
+	//
+	// Defaults to a random key generated on first start and stored in the
+	// database.
+	PageTokenKey []byte
+	// How long a pagination token may be used after it is issued.
+	//
+	// Defaults to `24h`.
+	PageTokenTtl *durationpb.Duration
 }
 
 func (b0 Config_builder) Build() *Config {
@@ -248,16 +472,273 @@ func (b0 Config_builder) Build() *Config {
 	_, _ = b, x
 	x.xxx_hidden_LogLevel = b.LogLevel
 	if b.RpcAddress != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 15)
 		x.xxx_hidden_RpcAddress = b.RpcAddress
 	}
 	if b.WebAddress != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 15)
 		x.xxx_hidden_WebAddress = b.WebAddress
 	}
 	x.xxx_hidden_DbFilepath = b.DbFilepath
 	x.xxx_hidden_RootUri = b.RootUri
 	x.xxx_hidden_DevMode = b.DevMode
+	x.xxx_hidden_ManualMigrations = b.ManualMigrations
+	x.xxx_hidden_AutoReadAnthologies = b.AutoReadAnthologies
+	x.xxx_hidden_SessionIdleTimeout = b.SessionIdleTimeout
+	x.xxx_hidden_SessionMaxLifetime = b.SessionMaxLifetime
+	x.xxx_hidden_Oidc = b.Oidc
+	x.xxx_hidden_ProxyAuth = b.ProxyAuth
+	x.xxx_hidden_DevUsers = b.DevUsers
+	x.xxx_hidden_PageTokenKey = b.PageTokenKey
+	x.xxx_hidden_PageTokenTtl = b.PageTokenTtl
+	return m0
+}
+
+// OpenID Connect identity provider settings.
+type Config_Oidc struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Issuer        string                 `protobuf:"bytes,1,opt,name=issuer,proto3"`
+	xxx_hidden_ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3"`
+	xxx_hidden_ClientSecret  string                 `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3"`
+	xxx_hidden_RedirectUrl   string                 `protobuf:"bytes,4,opt,name=redirect_url,json=redirectUrl,proto3"`
+	xxx_hidden_Scopes        []string               `protobuf:"bytes,5,rep,name=scopes,proto3"`
+	xxx_hidden_UsernameClaim string                 `protobuf:"bytes,6,opt,name=username_claim,json=usernameClaim,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Config_Oidc) Reset() {
+	*x = Config_Oidc{}
+	mi := &file_stolasapp_erato_v1_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config_Oidc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config_Oidc) ProtoMessage() {}
+
+func (x *Config_Oidc) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Config_Oidc) GetIssuer() string {
+	if x != nil {
+		return x.xxx_hidden_Issuer
+	}
+	return ""
+}
+
+func (x *Config_Oidc) GetClientId() string {
+	if x != nil {
+		return x.xxx_hidden_ClientId
+	}
+	return ""
+}
+
+func (x *Config_Oidc) GetClientSecret() string {
+	if x != nil {
+		return x.xxx_hidden_ClientSecret
+	}
+	return ""
+}
+
+func (x *Config_Oidc) GetRedirectUrl() string {
+	if x != nil {
+		return x.xxx_hidden_RedirectUrl
+	}
+	return ""
+}
+
+func (x *Config_Oidc) GetScopes() []string {
+	if x != nil {
+		return x.xxx_hidden_Scopes
+	}
+	return nil
+}
+
+func (x *Config_Oidc) GetUsernameClaim() string {
+	if x != nil {
+		return x.xxx_hidden_UsernameClaim
+	}
+	return ""
+}
+
+func (x *Config_Oidc) SetIssuer(v string) {
+	x.xxx_hidden_Issuer = v
+}
+
+func (x *Config_Oidc) SetClientId(v string) {
+	x.xxx_hidden_ClientId = v
+}
+
+func (x *Config_Oidc) SetClientSecret(v string) {
+	x.xxx_hidden_ClientSecret = v
+}
+
+func (x *Config_Oidc) SetRedirectUrl(v string) {
+	x.xxx_hidden_RedirectUrl = v
+}
+
+func (x *Config_Oidc) SetScopes(v []string) {
+	x.xxx_hidden_Scopes = v
+}
+
+func (x *Config_Oidc) SetUsernameClaim(v string) {
+	x.xxx_hidden_UsernameClaim = v
+}
+
+type Config_Oidc_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The issuer URL of the provider, used to discover its endpoints and
+	// signing keys (e.g., `https://accounts.example.com`).
+	Issuer string
+	// The client ID registered with the provider. ID tokens must be issued to
+	// this client.
+	ClientId string
+	// The client secret registered with the provider, if any. Public clients
+	// rely on PKCE alone.
+	ClientSecret string
+	// The web app URL the provider returns the user to after logging in. Must
+	// be the web app's `/login/oidc/callback` path as seen by the browser
+	// (e.g., `https://erato.example.com/login/oidc/callback`).
+	RedirectUrl string
+	// The scopes to request when logging in.
+	//
+	// Defaults to `openid` and `profile`.
+	Scopes []string
+	// The ID token claim naming new users. Users are identified by the
+	// issuer and subject of their ID tokens, and are created on their first
+	// login, named by this claim with any characters usernames may not
+	// contain replaced by underscores. Logins are refused if the name is
+	// taken, as existing users are only linked to an identity by an admin
+	// with `erato user link`.
+	//
+	// Defaults to `sub`.
+	UsernameClaim string
+}
+
+func (b0 Config_Oidc_builder) Build() *Config_Oidc {
+	m0 := &Config_Oidc{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Issuer = b.Issuer
+	x.xxx_hidden_ClientId = b.ClientId
+	x.xxx_hidden_ClientSecret = b.ClientSecret
+	x.xxx_hidden_RedirectUrl = b.RedirectUrl
+	x.xxx_hidden_Scopes = b.Scopes
+	x.xxx_hidden_UsernameClaim = b.UsernameClaim
+	return m0
+}
+
+// Reverse proxy authentication settings.
+type Config_ProxyAuth struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Header         *string                `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+	xxx_hidden_TrustedProxies []string               `protobuf:"bytes,2,rep,name=trusted_proxies,json=trustedProxies,proto3"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Config_ProxyAuth) Reset() {
+	*x = Config_ProxyAuth{}
+	mi := &file_stolasapp_erato_v1_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config_ProxyAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config_ProxyAuth) ProtoMessage() {}
+
+func (x *Config_ProxyAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Config_ProxyAuth) GetHeader() string {
+	if x != nil {
+		if x.xxx_hidden_Header != nil {
+			return *x.xxx_hidden_Header
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Config_ProxyAuth) GetTrustedProxies() []string {
+	if x != nil {
+		return x.xxx_hidden_TrustedProxies
+	}
+	return nil
+}
+
+func (x *Config_ProxyAuth) SetHeader(v string) {
+	x.xxx_hidden_Header = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *Config_ProxyAuth) SetTrustedProxies(v []string) {
+	x.xxx_hidden_TrustedProxies = v
+}
+
+func (x *Config_ProxyAuth) HasHeader() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Config_ProxyAuth) ClearHeader() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Header = nil
+}
+
+type Config_ProxyAuth_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The request header holding the username.
+	//
+	// Defaults to `Remote-User`.
+	Header *string
+	// The addresses of the trusted proxies as CIDR prefixes (e.g.,
+	// `10.0.0.0/8` or `::1/128`). The header is ignored on requests from any
+	// other address. Clients must not be able to reach erato directly from
+	// these addresses, or they could set the header themselves.
+	TrustedProxies []string
+}
+
+func (b0 Config_ProxyAuth_builder) Build() *Config_ProxyAuth {
+	m0 := &Config_ProxyAuth{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Header != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Header = b.Header
+	}
+	x.xxx_hidden_TrustedProxies = b.TrustedProxies
 	return m0
 }
 
@@ -265,7 +746,7 @@ var File_stolasapp_erato_v1_config_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_config_proto_rawDesc = "" +
 	"\n" +
-	"\x1fstolasapp/erato/v1/config.proto\x12\x12stolasapp.erato.v1\x1a\x1bbuf/validate/validate.proto\"\x93\x03\n" +
+	"\x1fstolasapp/erato/v1/config.proto\x12\x12stolasapp.erato.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1egoogle/protobuf/duration.proto\"\x8d\v\n" +
 	"\x06Config\x12J\n" +
 	"\tlog_level\x18\x01 \x01(\x0e2#.stolasapp.erato.v1.Config.LogLevelB\b\xbaH\x05\x82\x01\x02\x10\x01R\blogLevel\x12.\n" +
 	"\vrpc_address\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x80\x02\x01H\x00R\n" +
@@ -275,7 +756,30 @@ const file_stolasapp_erato_v1_config_proto_rawDesc = "" +
 	"\vdb_filepath\x18\x04 \x01(\tR\n" +
 	"dbFilepath\x12#\n" +
 	"\broot_uri\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\arootUri\x12\x19\n" +
-	"\bdev_mode\x18\x06 \x01(\bR\adevMode\"\\\n" +
+	"\bdev_mode\x18\x06 \x01(\bR\adevMode\x12+\n" +
+	"\x11manual_migrations\x18\a \x01(\bR\x10manualMigrations\x122\n" +
+	"\x15auto_read_anthologies\x18\b \x01(\bR\x13autoReadAnthologies\x12U\n" +
+	"\x14session_idle_timeout\x18\t \x01(\v2\x19.google.protobuf.DurationB\b\xbaH\x05\xaa\x01\x02*\x00R\x12sessionIdleTimeout\x12U\n" +
+	"\x14session_max_lifetime\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationB\b\xbaH\x05\xaa\x01\x02*\x00R\x12sessionMaxLifetime\x123\n" +
+	"\x04oidc\x18\v \x01(\v2\x1f.stolasapp.erato.v1.Config.OidcR\x04oidc\x12C\n" +
+	"\n" +
+	"proxy_auth\x18\f \x01(\v2$.stolasapp.erato.v1.Config.ProxyAuthR\tproxyAuth\x12>\n" +
+	"\tdev_users\x18\r \x03(\tB!\xbaH\x1e\x92\x01\x1b\x18\x01\"\x17r\x15\x10\x03\x18@2\x0f^[a-zA-Z0-9_]+$R\bdevUsers\x12\x83\x01\n" +
+	"\x0epage_token_key\x18\x0e \x01(\fB]\xbaHZ\xba\x01W\n" +
+	"\x13page_token_key.size\x12\x19must be at least 32 bytes\x1a%this.size() == 0 || this.size() >= 32R\fpageTokenKey\x12I\n" +
+	"\x0epage_token_ttl\x18\x0f \x01(\v2\x19.google.protobuf.DurationB\b\xbaH\x05\xaa\x01\x02*\x00R\fpageTokenTtl\x1a\xe4\x01\n" +
+	"\x04Oidc\x12#\n" +
+	"\x06issuer\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\x06issuer\x12#\n" +
+	"\tclient_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bclientId\x12#\n" +
+	"\rclient_secret\x18\x03 \x01(\tR\fclientSecret\x12.\n" +
+	"\fredirect_url\x18\x04 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\vredirectUrl\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12%\n" +
+	"\x0eusername_claim\x18\x06 \x01(\tR\rusernameClaim\x1aw\n" +
+	"\tProxyAuth\x12%\n" +
+	"\x06header\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xc0\x01\x01H\x00R\x06header\x88\x01\x01\x128\n" +
+	"\x0ftrusted_proxies\x18\x02 \x03(\tB\x0f\xbaH\f\x92\x01\t\b\x01\"\x05r\x03\xe8\x01\x01R\x0etrustedProxiesB\t\n" +
+	"\a_header\"\\\n" +
 	"\bLogLevel\x12\x19\n" +
 	"\x15LOG_LEVEL_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x05DEBUG\x10\xfc\xff\xff\xff\xff\xff\xff\xff\xff\x01\x12\b\n" +
@@ -287,18 +791,26 @@ const file_stolasapp_erato_v1_config_proto_rawDesc = "" +
 	"\x16com.stolasapp.erato.v1B\vConfigProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
 
 var file_stolasapp_erato_v1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_stolasapp_erato_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_stolasapp_erato_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_stolasapp_erato_v1_config_proto_goTypes = []any{
-	(Config_LogLevel)(0), // 0: stolasapp.erato.v1.Config.LogLevel
-	(*Config)(nil),       // 1: stolasapp.erato.v1.Config
+	(Config_LogLevel)(0),        // 0: stolasapp.erato.v1.Config.LogLevel
+	(*Config)(nil),              // 1: stolasapp.erato.v1.Config
+	(*Config_Oidc)(nil),         // 2: stolasapp.erato.v1.Config.Oidc
+	(*Config_ProxyAuth)(nil),    // 3: stolasapp.erato.v1.Config.ProxyAuth
+	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
 }
 var file_stolasapp_erato_v1_config_proto_depIdxs = []int32{
 	0, // 0: stolasapp.erato.v1.Config.log_level:type_name -> stolasapp.erato.v1.Config.LogLevel
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 1: stolasapp.erato.v1.Config.session_idle_timeout:type_name -> google.protobuf.Duration
+	4, // 2: stolasapp.erato.v1.Config.session_max_lifetime:type_name -> google.protobuf.Duration
+	2, // 3: stolasapp.erato.v1.Config.oidc:type_name -> stolasapp.erato.v1.Config.Oidc
+	3, // 4: stolasapp.erato.v1.Config.proxy_auth:type_name -> stolasapp.erato.v1.Config.ProxyAuth
+	4, // 5: stolasapp.erato.v1.Config.page_token_ttl:type_name -> google.protobuf.Duration
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_stolasapp_erato_v1_config_proto_init() }
@@ -307,13 +819,14 @@ func file_stolasapp_erato_v1_config_proto_init() {
 		return
 	}
 	file_stolasapp_erato_v1_config_proto_msgTypes[0].OneofWrappers = []any{}
+	file_stolasapp_erato_v1_config_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stolasapp_erato_v1_config_proto_rawDesc), len(file_stolasapp_erato_v1_config_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -75,17 +75,21 @@ func (x Entry_Kind) Number() protoreflect.EnumNumber {
 // A single item within a category. May be a one-shot story or a multi-chapter
 // anthology.
 type Entry struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Path        string                 `protobuf:"bytes,10018,opt,name=path,proto3"`
-	xxx_hidden_DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3"`
-	xxx_hidden_Kind        Entry_Kind             `protobuf:"varint,3,opt,name=kind,proto3,enum=stolasapp.erato.v1.Entry_Kind"`
-	xxx_hidden_Hidden      bool                   `protobuf:"varint,4,opt,name=hidden,proto3"`
-	xxx_hidden_Starred     bool                   `protobuf:"varint,5,opt,name=starred,proto3"`
-	xxx_hidden_UpdateTime  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3"`
-	xxx_hidden_ViewTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=view_time,json=viewTime,proto3"`
-	xxx_hidden_ReadTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=read_time,json=readTime,proto3"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path               string                 `protobuf:"bytes,10018,opt,name=path,proto3"`
+	xxx_hidden_DisplayName        string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3"`
+	xxx_hidden_Kind               Entry_Kind             `protobuf:"varint,3,opt,name=kind,proto3,enum=stolasapp.erato.v1.Entry_Kind"`
+	xxx_hidden_Hidden             bool                   `protobuf:"varint,4,opt,name=hidden,proto3"`
+	xxx_hidden_Starred            bool                   `protobuf:"varint,5,opt,name=starred,proto3"`
+	xxx_hidden_UpdateTime         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3"`
+	xxx_hidden_ViewTime           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=view_time,json=viewTime,proto3"`
+	xxx_hidden_ReadTime           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=read_time,json=readTime,proto3"`
+	xxx_hidden_ChapterCount       int32                  `protobuf:"varint,9,opt,name=chapter_count,json=chapterCount,proto3"`
+	xxx_hidden_ReadChapterCount   int32                  `protobuf:"varint,10,opt,name=read_chapter_count,json=readChapterCount,proto3"`
+	xxx_hidden_UnreadChapterCount int32                  `protobuf:"varint,11,opt,name=unread_chapter_count,json=unreadChapterCount,proto3"`
+	xxx_hidden_Etag               string                 `protobuf:"bytes,12,opt,name=etag,proto3"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
 }
 
 func (x *Entry) Reset() {
@@ -169,6 +173,34 @@ func (x *Entry) GetReadTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Entry) GetChapterCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_ChapterCount
+	}
+	return 0
+}
+
+func (x *Entry) GetReadChapterCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_ReadChapterCount
+	}
+	return 0
+}
+
+func (x *Entry) GetUnreadChapterCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_UnreadChapterCount
+	}
+	return 0
+}
+
+func (x *Entry) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
 func (x *Entry) SetPath(v string) {
 	x.xxx_hidden_Path = v
 }
@@ -201,6 +233,22 @@ func (x *Entry) SetReadTime(v *timestamppb.Timestamp) {
 	x.xxx_hidden_ReadTime = v
 }
 
+func (x *Entry) SetChapterCount(v int32) {
+	x.xxx_hidden_ChapterCount = v
+}
+
+func (x *Entry) SetReadChapterCount(v int32) {
+	x.xxx_hidden_ReadChapterCount = v
+}
+
+func (x *Entry) SetUnreadChapterCount(v int32) {
+	x.xxx_hidden_UnreadChapterCount = v
+}
+
+func (x *Entry) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
 func (x *Entry) HasUpdateTime() bool {
 	if x == nil {
 		return false
@@ -256,6 +304,18 @@ type Entry_builder struct {
 	ViewTime *timestamppb.Timestamp
 	// When was the entry marked as read by the user?
 	ReadTime *timestamppb.Timestamp
+	// The number of chapters in an anthology, as of the last time it or its
+	// chapters were loaded from the archive by any user. Zero for stories and
+	// anthologies that have not been loaded yet.
+	ChapterCount int32
+	// The number of chapters in an anthology marked as read by the user.
+	ReadChapterCount int32
+	// The number of chapters in an anthology not yet marked as read by the user.
+	UnreadChapterCount int32
+	// An opaque version of the entry, used for optimistic concurrency control.
+	// Updates that provide an etag are rejected if the entry has been modified
+	// since the etag was read.
+	Etag string
 }
 
 func (b0 Entry_builder) Build() *Entry {
@@ -270,6 +330,10 @@ func (b0 Entry_builder) Build() *Entry {
 	x.xxx_hidden_UpdateTime = b.UpdateTime
 	x.xxx_hidden_ViewTime = b.ViewTime
 	x.xxx_hidden_ReadTime = b.ReadTime
+	x.xxx_hidden_ChapterCount = b.ChapterCount
+	x.xxx_hidden_ReadChapterCount = b.ReadChapterCount
+	x.xxx_hidden_UnreadChapterCount = b.UnreadChapterCount
+	x.xxx_hidden_Etag = b.Etag
 	return m0
 }
 
@@ -277,7 +341,7 @@ var File_stolasapp_erato_v1_entry_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_entry_proto_rawDesc = "" +
 	"\n" +
-	"\x1estolasapp/erato/v1/entry.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x04\n" +
+	"\x1estolasapp/erato/v1/entry.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\x05\n" +
 	"\x05Entry\x12\x18\n" +
 	"\x04path\x18\xa2N \x01(\tB\x03\xe0A\bR\x04path\x12,\n" +
 	"\fdisplay_name\x18\x02 \x01(\tB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\vdisplayName\x12E\n" +
@@ -287,7 +351,12 @@ const file_stolasapp_erato_v1_entry_proto_rawDesc = "" +
 	"\vupdate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\n" +
 	"updateTime\x127\n" +
 	"\tview_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bviewTime\x127\n" +
-	"\tread_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\breadTime\"6\n" +
+	"\tread_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\breadTime\x12.\n" +
+	"\rchapter_count\x18\t \x01(\x05B\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\fchapterCount\x127\n" +
+	"\x12read_chapter_count\x18\n" +
+	" \x01(\x05B\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\x10readChapterCount\x12;\n" +
+	"\x14unread_chapter_count\x18\v \x01(\x05B\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\x12unreadChapterCount\x12\x12\n" +
+	"\x04etag\x18\f \x01(\tR\x04etag\"6\n" +
 	"\x04Kind\x12\x14\n" +
 	"\x10KIND_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05STORY\x10\x01\x12\r\n" +
@@ -9,6 +9,7 @@ import (
 	context "context"
 	errors "errors"
 	v1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	http "net/http"
 	strings "strings"
@@ -51,6 +52,9 @@ const (
 	// ArchiveServiceUpdateEntryProcedure is the fully-qualified name of the ArchiveService's
 	// UpdateEntry RPC.
 	ArchiveServiceUpdateEntryProcedure = "/stolasapp.erato.v1.ArchiveService/UpdateEntry"
+	// ArchiveServiceBatchUpdateEntriesProcedure is the fully-qualified name of the ArchiveService's
+	// BatchUpdateEntries RPC.
+	ArchiveServiceBatchUpdateEntriesProcedure = "/stolasapp.erato.v1.ArchiveService/BatchUpdateEntries"
 	// ArchiveServiceListChaptersProcedure is the fully-qualified name of the ArchiveService's
 	// ListChapters RPC.
 	ArchiveServiceListChaptersProcedure = "/stolasapp.erato.v1.ArchiveService/ListChapters"
@@ -60,12 +64,18 @@ const (
 	// ArchiveServiceUpdateChapterProcedure is the fully-qualified name of the ArchiveService's
 	// UpdateChapter RPC.
 	ArchiveServiceUpdateChapterProcedure = "/stolasapp.erato.v1.ArchiveService/UpdateChapter"
+	// ArchiveServiceBatchUpdateChaptersProcedure is the fully-qualified name of the ArchiveService's
+	// BatchUpdateChapters RPC.
+	ArchiveServiceBatchUpdateChaptersProcedure = "/stolasapp.erato.v1.ArchiveService/BatchUpdateChapters"
 	// ArchiveServiceReadEntryProcedure is the fully-qualified name of the ArchiveService's ReadEntry
 	// RPC.
 	ArchiveServiceReadEntryProcedure = "/stolasapp.erato.v1.ArchiveService/ReadEntry"
 	// ArchiveServiceReadChapterProcedure is the fully-qualified name of the ArchiveService's
 	// ReadChapter RPC.
 	ArchiveServiceReadChapterProcedure = "/stolasapp.erato.v1.ArchiveService/ReadChapter"
+	// ArchiveServiceExportEntryProcedure is the fully-qualified name of the ArchiveService's
+	// ExportEntry RPC.
+	ArchiveServiceExportEntryProcedure = "/stolasapp.erato.v1.ArchiveService/ExportEntry"
 	// ArchiveServiceCreateUserProcedure is the fully-qualified name of the ArchiveService's CreateUser
 	// RPC.
 	ArchiveServiceCreateUserProcedure = "/stolasapp.erato.v1.ArchiveService/CreateUser"
@@ -80,6 +90,39 @@ const (
 	// ArchiveServiceDeleteUserProcedure is the fully-qualified name of the ArchiveService's DeleteUser
 	// RPC.
 	ArchiveServiceDeleteUserProcedure = "/stolasapp.erato.v1.ArchiveService/DeleteUser"
+	// ArchiveServiceRenameUserProcedure is the fully-qualified name of the ArchiveService's RenameUser
+	// RPC.
+	ArchiveServiceRenameUserProcedure = "/stolasapp.erato.v1.ArchiveService/RenameUser"
+	// ArchiveServiceCreateAccessTokenProcedure is the fully-qualified name of the ArchiveService's
+	// CreateAccessToken RPC.
+	ArchiveServiceCreateAccessTokenProcedure = "/stolasapp.erato.v1.ArchiveService/CreateAccessToken"
+	// ArchiveServiceListAccessTokensProcedure is the fully-qualified name of the ArchiveService's
+	// ListAccessTokens RPC.
+	ArchiveServiceListAccessTokensProcedure = "/stolasapp.erato.v1.ArchiveService/ListAccessTokens"
+	// ArchiveServiceDeleteAccessTokenProcedure is the fully-qualified name of the ArchiveService's
+	// DeleteAccessToken RPC.
+	ArchiveServiceDeleteAccessTokenProcedure = "/stolasapp.erato.v1.ArchiveService/DeleteAccessToken"
+	// ArchiveServiceCreateSavedViewProcedure is the fully-qualified name of the ArchiveService's
+	// CreateSavedView RPC.
+	ArchiveServiceCreateSavedViewProcedure = "/stolasapp.erato.v1.ArchiveService/CreateSavedView"
+	// ArchiveServiceListSavedViewsProcedure is the fully-qualified name of the ArchiveService's
+	// ListSavedViews RPC.
+	ArchiveServiceListSavedViewsProcedure = "/stolasapp.erato.v1.ArchiveService/ListSavedViews"
+	// ArchiveServiceGetSavedViewProcedure is the fully-qualified name of the ArchiveService's
+	// GetSavedView RPC.
+	ArchiveServiceGetSavedViewProcedure = "/stolasapp.erato.v1.ArchiveService/GetSavedView"
+	// ArchiveServiceUpdateSavedViewProcedure is the fully-qualified name of the ArchiveService's
+	// UpdateSavedView RPC.
+	ArchiveServiceUpdateSavedViewProcedure = "/stolasapp.erato.v1.ArchiveService/UpdateSavedView"
+	// ArchiveServiceDeleteSavedViewProcedure is the fully-qualified name of the ArchiveService's
+	// DeleteSavedView RPC.
+	ArchiveServiceDeleteSavedViewProcedure = "/stolasapp.erato.v1.ArchiveService/DeleteSavedView"
+	// ArchiveServiceCreateInviteProcedure is the fully-qualified name of the ArchiveService's
+	// CreateInvite RPC.
+	ArchiveServiceCreateInviteProcedure = "/stolasapp.erato.v1.ArchiveService/CreateInvite"
+	// ArchiveServiceListAuditEventsProcedure is the fully-qualified name of the ArchiveService's
+	// ListAuditEvents RPC.
+	ArchiveServiceListAuditEventsProcedure = "/stolasapp.erato.v1.ArchiveService/ListAuditEvents"
 )
 
 // ArchiveServiceClient is a client for the stolasapp.erato.v1.ArchiveService service.
@@ -96,28 +139,67 @@ type ArchiveServiceClient interface {
 	GetEntry(context.Context, *connect.Request[v1.GetEntryRequest]) (*connect.Response[v1.Entry], error)
 	// Modify a single entry in the archive.
 	UpdateEntry(context.Context, *connect.Request[v1.UpdateEntryRequest]) (*connect.Response[v1.Entry], error)
+	// Modify multiple entries of a category in the archive at once.
+	BatchUpdateEntries(context.Context, *connect.Request[v1.BatchUpdateEntriesRequest]) (*connect.Response[v1.BatchUpdateEntriesResponse], error)
 	// Fetch the chapters for an anthology entry.
 	ListChapters(context.Context, *connect.Request[v1.ListChaptersRequest]) (*connect.Response[v1.ListChaptersResponse], error)
 	// Fetch a single chapter from an anthology entry.
 	GetChapter(context.Context, *connect.Request[v1.GetChapterRequest]) (*connect.Response[v1.Chapter], error)
 	// Modify a single chapter in the archive.
 	UpdateChapter(context.Context, *connect.Request[v1.UpdateChapterRequest]) (*connect.Response[v1.Chapter], error)
+	// Modify multiple chapters of an anthology entry in the archive at once.
+	BatchUpdateChapters(context.Context, *connect.Request[v1.BatchUpdateChaptersRequest]) (*connect.Response[v1.BatchUpdateChaptersResponse], error)
 	// Fetch content for a story entry.
 	// buf:lint:ignore AEP_0131_SYNONYMS
 	ReadEntry(context.Context, *connect.Request[v1.ReadEntryRequest]) (*connect.Response[v1.ReadEntryResponse], error)
 	// Fetch content for an anthology chapter.
 	// buf:lint:ignore AEP_0131_SYNONYMS
 	ReadChapter(context.Context, *connect.Request[v1.ReadChapterRequest]) (*connect.Response[v1.ReadChapterResponse], error)
-	// Creates a new user with access to the archive.
+	// Export a story or anthology entry as a file, such as an EPUB for
+	// e-readers. The file is streamed in chunks as it is built; the first chunk
+	// carries its content type.
+	ExportEntry(context.Context, *connect.Request[v1.ExportEntryRequest]) (*connect.ServerStreamForClient[httpbody.HttpBody], error)
+	// Creates a new user with access to the archive. Only admins may create
+	// users, unless an unused invite code is provided.
 	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error)
-	// Fetch the users with access to the archive.
+	// Fetch the users with access to the archive. Only admins may list users.
 	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
-	// Fetch a single user with access to the archive.
+	// Fetch a single user with access to the archive. Users may only fetch
+	// themselves unless they are an admin.
 	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error)
-	// Modify a single user with access to the archive.
+	// Modify a single user with access to the archive. Users may only modify
+	// themselves unless they are an admin.
 	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error)
-	// Deletes a user and their data from the archive.
+	// Deletes a user and their data from the archive. Users may only delete
+	// themselves unless they are an admin.
 	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error)
+	// Renames a user, changing their path. Their data is kept. Only admins may
+	// rename users.
+	RenameUser(context.Context, *connect.Request[v1.RenameUserRequest]) (*connect.Response[v1.User], error)
+	// Creates a new personal access token for a user. The token's secret value
+	// is only returned in this response.
+	CreateAccessToken(context.Context, *connect.Request[v1.CreateAccessTokenRequest]) (*connect.Response[v1.AccessToken], error)
+	// Fetch the personal access tokens of a user.
+	ListAccessTokens(context.Context, *connect.Request[v1.ListAccessTokensRequest]) (*connect.Response[v1.ListAccessTokensResponse], error)
+	// Revokes a personal access token.
+	DeleteAccessToken(context.Context, *connect.Request[v1.DeleteAccessTokenRequest]) (*connect.Response[emptypb.Empty], error)
+	// Creates a saved view for a user.
+	CreateSavedView(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Fetch the saved views of a user.
+	ListSavedViews(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error)
+	// Fetch a single saved view of a user.
+	GetSavedView(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Modify a saved view.
+	UpdateSavedView(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Deletes a saved view.
+	DeleteSavedView(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[emptypb.Empty], error)
+	// Creates a single-use invite, allowing someone to register as a new user.
+	// The invite's code is only returned in this response. Only admins may
+	// create invites.
+	CreateInvite(context.Context, *connect.Request[v1.CreateInviteRequest]) (*connect.Response[v1.Invite], error)
+	// Fetch the audit log of authentication attempts and user account changes,
+	// newest first. Only admins may read the audit log.
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 }
 
 // NewArchiveServiceClient constructs a client for the stolasapp.erato.v1.ArchiveService service. By
@@ -171,6 +253,12 @@ func NewArchiveServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(archiveServiceMethods.ByName("UpdateEntry")),
 			connect.WithClientOptions(opts...),
 		),
+		batchUpdateEntries: connect.NewClient[v1.BatchUpdateEntriesRequest, v1.BatchUpdateEntriesResponse](
+			httpClient,
+			baseURL+ArchiveServiceBatchUpdateEntriesProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("BatchUpdateEntries")),
+			connect.WithClientOptions(opts...),
+		),
 		listChapters: connect.NewClient[v1.ListChaptersRequest, v1.ListChaptersResponse](
 			httpClient,
 			baseURL+ArchiveServiceListChaptersProcedure,
@@ -191,6 +279,12 @@ func NewArchiveServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(archiveServiceMethods.ByName("UpdateChapter")),
 			connect.WithClientOptions(opts...),
 		),
+		batchUpdateChapters: connect.NewClient[v1.BatchUpdateChaptersRequest, v1.BatchUpdateChaptersResponse](
+			httpClient,
+			baseURL+ArchiveServiceBatchUpdateChaptersProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("BatchUpdateChapters")),
+			connect.WithClientOptions(opts...),
+		),
 		readEntry: connect.NewClient[v1.ReadEntryRequest, v1.ReadEntryResponse](
 			httpClient,
 			baseURL+ArchiveServiceReadEntryProcedure,
@@ -205,6 +299,13 @@ func NewArchiveServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		exportEntry: connect.NewClient[v1.ExportEntryRequest, httpbody.HttpBody](
+			httpClient,
+			baseURL+ArchiveServiceExportEntryProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("ExportEntry")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 		createUser: connect.NewClient[v1.CreateUserRequest, v1.User](
 			httpClient,
 			baseURL+ArchiveServiceCreateUserProcedure,
@@ -237,27 +338,111 @@ func NewArchiveServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(archiveServiceMethods.ByName("DeleteUser")),
 			connect.WithClientOptions(opts...),
 		),
+		renameUser: connect.NewClient[v1.RenameUserRequest, v1.User](
+			httpClient,
+			baseURL+ArchiveServiceRenameUserProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("RenameUser")),
+			connect.WithClientOptions(opts...),
+		),
+		createAccessToken: connect.NewClient[v1.CreateAccessTokenRequest, v1.AccessToken](
+			httpClient,
+			baseURL+ArchiveServiceCreateAccessTokenProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("CreateAccessToken")),
+			connect.WithClientOptions(opts...),
+		),
+		listAccessTokens: connect.NewClient[v1.ListAccessTokensRequest, v1.ListAccessTokensResponse](
+			httpClient,
+			baseURL+ArchiveServiceListAccessTokensProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("ListAccessTokens")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAccessToken: connect.NewClient[v1.DeleteAccessTokenRequest, emptypb.Empty](
+			httpClient,
+			baseURL+ArchiveServiceDeleteAccessTokenProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("DeleteAccessToken")),
+			connect.WithClientOptions(opts...),
+		),
+		createSavedView: connect.NewClient[v1.CreateSavedViewRequest, v1.SavedView](
+			httpClient,
+			baseURL+ArchiveServiceCreateSavedViewProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("CreateSavedView")),
+			connect.WithClientOptions(opts...),
+		),
+		listSavedViews: connect.NewClient[v1.ListSavedViewsRequest, v1.ListSavedViewsResponse](
+			httpClient,
+			baseURL+ArchiveServiceListSavedViewsProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("ListSavedViews")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getSavedView: connect.NewClient[v1.GetSavedViewRequest, v1.SavedView](
+			httpClient,
+			baseURL+ArchiveServiceGetSavedViewProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("GetSavedView")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		updateSavedView: connect.NewClient[v1.UpdateSavedViewRequest, v1.SavedView](
+			httpClient,
+			baseURL+ArchiveServiceUpdateSavedViewProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("UpdateSavedView")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSavedView: connect.NewClient[v1.DeleteSavedViewRequest, emptypb.Empty](
+			httpClient,
+			baseURL+ArchiveServiceDeleteSavedViewProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("DeleteSavedView")),
+			connect.WithClientOptions(opts...),
+		),
+		createInvite: connect.NewClient[v1.CreateInviteRequest, v1.Invite](
+			httpClient,
+			baseURL+ArchiveServiceCreateInviteProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("CreateInvite")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+ArchiveServiceListAuditEventsProcedure,
+			connect.WithSchema(archiveServiceMethods.ByName("ListAuditEvents")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // archiveServiceClient implements ArchiveServiceClient.
 type archiveServiceClient struct {
-	listCategories *connect.Client[v1.ListCategoriesRequest, v1.ListCategoriesResponse]
-	getCategory    *connect.Client[v1.GetCategoryRequest, v1.Category]
-	updateCategory *connect.Client[v1.UpdateCategoryRequest, v1.Category]
-	listEntries    *connect.Client[v1.ListEntriesRequest, v1.ListEntriesResponse]
-	getEntry       *connect.Client[v1.GetEntryRequest, v1.Entry]
-	updateEntry    *connect.Client[v1.UpdateEntryRequest, v1.Entry]
-	listChapters   *connect.Client[v1.ListChaptersRequest, v1.ListChaptersResponse]
-	getChapter     *connect.Client[v1.GetChapterRequest, v1.Chapter]
-	updateChapter  *connect.Client[v1.UpdateChapterRequest, v1.Chapter]
-	readEntry      *connect.Client[v1.ReadEntryRequest, v1.ReadEntryResponse]
-	readChapter    *connect.Client[v1.ReadChapterRequest, v1.ReadChapterResponse]
-	createUser     *connect.Client[v1.CreateUserRequest, v1.User]
-	listUsers      *connect.Client[v1.ListUsersRequest, v1.ListUsersResponse]
-	getUser        *connect.Client[v1.GetUserRequest, v1.User]
-	updateUser     *connect.Client[v1.UpdateUserRequest, v1.User]
-	deleteUser     *connect.Client[v1.DeleteUserRequest, emptypb.Empty]
+	listCategories      *connect.Client[v1.ListCategoriesRequest, v1.ListCategoriesResponse]
+	getCategory         *connect.Client[v1.GetCategoryRequest, v1.Category]
+	updateCategory      *connect.Client[v1.UpdateCategoryRequest, v1.Category]
+	listEntries         *connect.Client[v1.ListEntriesRequest, v1.ListEntriesResponse]
+	getEntry            *connect.Client[v1.GetEntryRequest, v1.Entry]
+	updateEntry         *connect.Client[v1.UpdateEntryRequest, v1.Entry]
+	batchUpdateEntries  *connect.Client[v1.BatchUpdateEntriesRequest, v1.BatchUpdateEntriesResponse]
+	listChapters        *connect.Client[v1.ListChaptersRequest, v1.ListChaptersResponse]
+	getChapter          *connect.Client[v1.GetChapterRequest, v1.Chapter]
+	updateChapter       *connect.Client[v1.UpdateChapterRequest, v1.Chapter]
+	batchUpdateChapters *connect.Client[v1.BatchUpdateChaptersRequest, v1.BatchUpdateChaptersResponse]
+	readEntry           *connect.Client[v1.ReadEntryRequest, v1.ReadEntryResponse]
+	readChapter         *connect.Client[v1.ReadChapterRequest, v1.ReadChapterResponse]
+	exportEntry         *connect.Client[v1.ExportEntryRequest, httpbody.HttpBody]
+	createUser          *connect.Client[v1.CreateUserRequest, v1.User]
+	listUsers           *connect.Client[v1.ListUsersRequest, v1.ListUsersResponse]
+	getUser             *connect.Client[v1.GetUserRequest, v1.User]
+	updateUser          *connect.Client[v1.UpdateUserRequest, v1.User]
+	deleteUser          *connect.Client[v1.DeleteUserRequest, emptypb.Empty]
+	renameUser          *connect.Client[v1.RenameUserRequest, v1.User]
+	createAccessToken   *connect.Client[v1.CreateAccessTokenRequest, v1.AccessToken]
+	listAccessTokens    *connect.Client[v1.ListAccessTokensRequest, v1.ListAccessTokensResponse]
+	deleteAccessToken   *connect.Client[v1.DeleteAccessTokenRequest, emptypb.Empty]
+	createSavedView     *connect.Client[v1.CreateSavedViewRequest, v1.SavedView]
+	listSavedViews      *connect.Client[v1.ListSavedViewsRequest, v1.ListSavedViewsResponse]
+	getSavedView        *connect.Client[v1.GetSavedViewRequest, v1.SavedView]
+	updateSavedView     *connect.Client[v1.UpdateSavedViewRequest, v1.SavedView]
+	deleteSavedView     *connect.Client[v1.DeleteSavedViewRequest, emptypb.Empty]
+	createInvite        *connect.Client[v1.CreateInviteRequest, v1.Invite]
+	listAuditEvents     *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
 }
 
 // ListCategories calls stolasapp.erato.v1.ArchiveService.ListCategories.
@@ -290,6 +475,11 @@ func (c *archiveServiceClient) UpdateEntry(ctx context.Context, req *connect.Req
 	return c.updateEntry.CallUnary(ctx, req)
 }
 
+// BatchUpdateEntries calls stolasapp.erato.v1.ArchiveService.BatchUpdateEntries.
+func (c *archiveServiceClient) BatchUpdateEntries(ctx context.Context, req *connect.Request[v1.BatchUpdateEntriesRequest]) (*connect.Response[v1.BatchUpdateEntriesResponse], error) {
+	return c.batchUpdateEntries.CallUnary(ctx, req)
+}
+
 // ListChapters calls stolasapp.erato.v1.ArchiveService.ListChapters.
 func (c *archiveServiceClient) ListChapters(ctx context.Context, req *connect.Request[v1.ListChaptersRequest]) (*connect.Response[v1.ListChaptersResponse], error) {
 	return c.listChapters.CallUnary(ctx, req)
@@ -305,6 +495,11 @@ func (c *archiveServiceClient) UpdateChapter(ctx context.Context, req *connect.R
 	return c.updateChapter.CallUnary(ctx, req)
 }
 
+// BatchUpdateChapters calls stolasapp.erato.v1.ArchiveService.BatchUpdateChapters.
+func (c *archiveServiceClient) BatchUpdateChapters(ctx context.Context, req *connect.Request[v1.BatchUpdateChaptersRequest]) (*connect.Response[v1.BatchUpdateChaptersResponse], error) {
+	return c.batchUpdateChapters.CallUnary(ctx, req)
+}
+
 // ReadEntry calls stolasapp.erato.v1.ArchiveService.ReadEntry.
 func (c *archiveServiceClient) ReadEntry(ctx context.Context, req *connect.Request[v1.ReadEntryRequest]) (*connect.Response[v1.ReadEntryResponse], error) {
 	return c.readEntry.CallUnary(ctx, req)
@@ -315,6 +510,11 @@ func (c *archiveServiceClient) ReadChapter(ctx context.Context, req *connect.Req
 	return c.readChapter.CallUnary(ctx, req)
 }
 
+// ExportEntry calls stolasapp.erato.v1.ArchiveService.ExportEntry.
+func (c *archiveServiceClient) ExportEntry(ctx context.Context, req *connect.Request[v1.ExportEntryRequest]) (*connect.ServerStreamForClient[httpbody.HttpBody], error) {
+	return c.exportEntry.CallServerStream(ctx, req)
+}
+
 // CreateUser calls stolasapp.erato.v1.ArchiveService.CreateUser.
 func (c *archiveServiceClient) CreateUser(ctx context.Context, req *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error) {
 	return c.createUser.CallUnary(ctx, req)
@@ -340,6 +540,61 @@ func (c *archiveServiceClient) DeleteUser(ctx context.Context, req *connect.Requ
 	return c.deleteUser.CallUnary(ctx, req)
 }
 
+// RenameUser calls stolasapp.erato.v1.ArchiveService.RenameUser.
+func (c *archiveServiceClient) RenameUser(ctx context.Context, req *connect.Request[v1.RenameUserRequest]) (*connect.Response[v1.User], error) {
+	return c.renameUser.CallUnary(ctx, req)
+}
+
+// CreateAccessToken calls stolasapp.erato.v1.ArchiveService.CreateAccessToken.
+func (c *archiveServiceClient) CreateAccessToken(ctx context.Context, req *connect.Request[v1.CreateAccessTokenRequest]) (*connect.Response[v1.AccessToken], error) {
+	return c.createAccessToken.CallUnary(ctx, req)
+}
+
+// ListAccessTokens calls stolasapp.erato.v1.ArchiveService.ListAccessTokens.
+func (c *archiveServiceClient) ListAccessTokens(ctx context.Context, req *connect.Request[v1.ListAccessTokensRequest]) (*connect.Response[v1.ListAccessTokensResponse], error) {
+	return c.listAccessTokens.CallUnary(ctx, req)
+}
+
+// DeleteAccessToken calls stolasapp.erato.v1.ArchiveService.DeleteAccessToken.
+func (c *archiveServiceClient) DeleteAccessToken(ctx context.Context, req *connect.Request[v1.DeleteAccessTokenRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteAccessToken.CallUnary(ctx, req)
+}
+
+// CreateSavedView calls stolasapp.erato.v1.ArchiveService.CreateSavedView.
+func (c *archiveServiceClient) CreateSavedView(ctx context.Context, req *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return c.createSavedView.CallUnary(ctx, req)
+}
+
+// ListSavedViews calls stolasapp.erato.v1.ArchiveService.ListSavedViews.
+func (c *archiveServiceClient) ListSavedViews(ctx context.Context, req *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error) {
+	return c.listSavedViews.CallUnary(ctx, req)
+}
+
+// GetSavedView calls stolasapp.erato.v1.ArchiveService.GetSavedView.
+func (c *archiveServiceClient) GetSavedView(ctx context.Context, req *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return c.getSavedView.CallUnary(ctx, req)
+}
+
+// UpdateSavedView calls stolasapp.erato.v1.ArchiveService.UpdateSavedView.
+func (c *archiveServiceClient) UpdateSavedView(ctx context.Context, req *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return c.updateSavedView.CallUnary(ctx, req)
+}
+
+// DeleteSavedView calls stolasapp.erato.v1.ArchiveService.DeleteSavedView.
+func (c *archiveServiceClient) DeleteSavedView(ctx context.Context, req *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteSavedView.CallUnary(ctx, req)
+}
+
+// CreateInvite calls stolasapp.erato.v1.ArchiveService.CreateInvite.
+func (c *archiveServiceClient) CreateInvite(ctx context.Context, req *connect.Request[v1.CreateInviteRequest]) (*connect.Response[v1.Invite], error) {
+	return c.createInvite.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls stolasapp.erato.v1.ArchiveService.ListAuditEvents.
+func (c *archiveServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
 // ArchiveServiceHandler is an implementation of the stolasapp.erato.v1.ArchiveService service.
 type ArchiveServiceHandler interface {
 	// Fetches the categories of an archive.
@@ -354,28 +609,67 @@ type ArchiveServiceHandler interface {
 	GetEntry(context.Context, *connect.Request[v1.GetEntryRequest]) (*connect.Response[v1.Entry], error)
 	// Modify a single entry in the archive.
 	UpdateEntry(context.Context, *connect.Request[v1.UpdateEntryRequest]) (*connect.Response[v1.Entry], error)
+	// Modify multiple entries of a category in the archive at once.
+	BatchUpdateEntries(context.Context, *connect.Request[v1.BatchUpdateEntriesRequest]) (*connect.Response[v1.BatchUpdateEntriesResponse], error)
 	// Fetch the chapters for an anthology entry.
 	ListChapters(context.Context, *connect.Request[v1.ListChaptersRequest]) (*connect.Response[v1.ListChaptersResponse], error)
 	// Fetch a single chapter from an anthology entry.
 	GetChapter(context.Context, *connect.Request[v1.GetChapterRequest]) (*connect.Response[v1.Chapter], error)
 	// Modify a single chapter in the archive.
 	UpdateChapter(context.Context, *connect.Request[v1.UpdateChapterRequest]) (*connect.Response[v1.Chapter], error)
+	// Modify multiple chapters of an anthology entry in the archive at once.
+	BatchUpdateChapters(context.Context, *connect.Request[v1.BatchUpdateChaptersRequest]) (*connect.Response[v1.BatchUpdateChaptersResponse], error)
 	// Fetch content for a story entry.
 	// buf:lint:ignore AEP_0131_SYNONYMS
 	ReadEntry(context.Context, *connect.Request[v1.ReadEntryRequest]) (*connect.Response[v1.ReadEntryResponse], error)
 	// Fetch content for an anthology chapter.
 	// buf:lint:ignore AEP_0131_SYNONYMS
 	ReadChapter(context.Context, *connect.Request[v1.ReadChapterRequest]) (*connect.Response[v1.ReadChapterResponse], error)
-	// Creates a new user with access to the archive.
+	// Export a story or anthology entry as a file, such as an EPUB for
+	// e-readers. The file is streamed in chunks as it is built; the first chunk
+	// carries its content type.
+	ExportEntry(context.Context, *connect.Request[v1.ExportEntryRequest], *connect.ServerStream[httpbody.HttpBody]) error
+	// Creates a new user with access to the archive. Only admins may create
+	// users, unless an unused invite code is provided.
 	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error)
-	// Fetch the users with access to the archive.
+	// Fetch the users with access to the archive. Only admins may list users.
 	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
-	// Fetch a single user with access to the archive.
+	// Fetch a single user with access to the archive. Users may only fetch
+	// themselves unless they are an admin.
 	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.User], error)
-	// Modify a single user with access to the archive.
+	// Modify a single user with access to the archive. Users may only modify
+	// themselves unless they are an admin.
 	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.User], error)
-	// Deletes a user and their data from the archive.
+	// Deletes a user and their data from the archive. Users may only delete
+	// themselves unless they are an admin.
 	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error)
+	// Renames a user, changing their path. Their data is kept. Only admins may
+	// rename users.
+	RenameUser(context.Context, *connect.Request[v1.RenameUserRequest]) (*connect.Response[v1.User], error)
+	// Creates a new personal access token for a user. The token's secret value
+	// is only returned in this response.
+	CreateAccessToken(context.Context, *connect.Request[v1.CreateAccessTokenRequest]) (*connect.Response[v1.AccessToken], error)
+	// Fetch the personal access tokens of a user.
+	ListAccessTokens(context.Context, *connect.Request[v1.ListAccessTokensRequest]) (*connect.Response[v1.ListAccessTokensResponse], error)
+	// Revokes a personal access token.
+	DeleteAccessToken(context.Context, *connect.Request[v1.DeleteAccessTokenRequest]) (*connect.Response[emptypb.Empty], error)
+	// Creates a saved view for a user.
+	CreateSavedView(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Fetch the saved views of a user.
+	ListSavedViews(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error)
+	// Fetch a single saved view of a user.
+	GetSavedView(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Modify a saved view.
+	UpdateSavedView(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.SavedView], error)
+	// Deletes a saved view.
+	DeleteSavedView(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[emptypb.Empty], error)
+	// Creates a single-use invite, allowing someone to register as a new user.
+	// The invite's code is only returned in this response. Only admins may
+	// create invites.
+	CreateInvite(context.Context, *connect.Request[v1.CreateInviteRequest]) (*connect.Response[v1.Invite], error)
+	// Fetch the audit log of authentication attempts and user account changes,
+	// newest first. Only admins may read the audit log.
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 }
 
 // NewArchiveServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -425,6 +719,12 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 		connect.WithSchema(archiveServiceMethods.ByName("UpdateEntry")),
 		connect.WithHandlerOptions(opts...),
 	)
+	archiveServiceBatchUpdateEntriesHandler := connect.NewUnaryHandler(
+		ArchiveServiceBatchUpdateEntriesProcedure,
+		svc.BatchUpdateEntries,
+		connect.WithSchema(archiveServiceMethods.ByName("BatchUpdateEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
 	archiveServiceListChaptersHandler := connect.NewUnaryHandler(
 		ArchiveServiceListChaptersProcedure,
 		svc.ListChapters,
@@ -445,6 +745,12 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 		connect.WithSchema(archiveServiceMethods.ByName("UpdateChapter")),
 		connect.WithHandlerOptions(opts...),
 	)
+	archiveServiceBatchUpdateChaptersHandler := connect.NewUnaryHandler(
+		ArchiveServiceBatchUpdateChaptersProcedure,
+		svc.BatchUpdateChapters,
+		connect.WithSchema(archiveServiceMethods.ByName("BatchUpdateChapters")),
+		connect.WithHandlerOptions(opts...),
+	)
 	archiveServiceReadEntryHandler := connect.NewUnaryHandler(
 		ArchiveServiceReadEntryProcedure,
 		svc.ReadEntry,
@@ -459,6 +765,13 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	archiveServiceExportEntryHandler := connect.NewServerStreamHandler(
+		ArchiveServiceExportEntryProcedure,
+		svc.ExportEntry,
+		connect.WithSchema(archiveServiceMethods.ByName("ExportEntry")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	archiveServiceCreateUserHandler := connect.NewUnaryHandler(
 		ArchiveServiceCreateUserProcedure,
 		svc.CreateUser,
@@ -491,6 +804,76 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 		connect.WithSchema(archiveServiceMethods.ByName("DeleteUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	archiveServiceRenameUserHandler := connect.NewUnaryHandler(
+		ArchiveServiceRenameUserProcedure,
+		svc.RenameUser,
+		connect.WithSchema(archiveServiceMethods.ByName("RenameUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceCreateAccessTokenHandler := connect.NewUnaryHandler(
+		ArchiveServiceCreateAccessTokenProcedure,
+		svc.CreateAccessToken,
+		connect.WithSchema(archiveServiceMethods.ByName("CreateAccessToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceListAccessTokensHandler := connect.NewUnaryHandler(
+		ArchiveServiceListAccessTokensProcedure,
+		svc.ListAccessTokens,
+		connect.WithSchema(archiveServiceMethods.ByName("ListAccessTokens")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceDeleteAccessTokenHandler := connect.NewUnaryHandler(
+		ArchiveServiceDeleteAccessTokenProcedure,
+		svc.DeleteAccessToken,
+		connect.WithSchema(archiveServiceMethods.ByName("DeleteAccessToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceCreateSavedViewHandler := connect.NewUnaryHandler(
+		ArchiveServiceCreateSavedViewProcedure,
+		svc.CreateSavedView,
+		connect.WithSchema(archiveServiceMethods.ByName("CreateSavedView")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceListSavedViewsHandler := connect.NewUnaryHandler(
+		ArchiveServiceListSavedViewsProcedure,
+		svc.ListSavedViews,
+		connect.WithSchema(archiveServiceMethods.ByName("ListSavedViews")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceGetSavedViewHandler := connect.NewUnaryHandler(
+		ArchiveServiceGetSavedViewProcedure,
+		svc.GetSavedView,
+		connect.WithSchema(archiveServiceMethods.ByName("GetSavedView")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceUpdateSavedViewHandler := connect.NewUnaryHandler(
+		ArchiveServiceUpdateSavedViewProcedure,
+		svc.UpdateSavedView,
+		connect.WithSchema(archiveServiceMethods.ByName("UpdateSavedView")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceDeleteSavedViewHandler := connect.NewUnaryHandler(
+		ArchiveServiceDeleteSavedViewProcedure,
+		svc.DeleteSavedView,
+		connect.WithSchema(archiveServiceMethods.ByName("DeleteSavedView")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceCreateInviteHandler := connect.NewUnaryHandler(
+		ArchiveServiceCreateInviteProcedure,
+		svc.CreateInvite,
+		connect.WithSchema(archiveServiceMethods.ByName("CreateInvite")),
+		connect.WithHandlerOptions(opts...),
+	)
+	archiveServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		ArchiveServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(archiveServiceMethods.ByName("ListAuditEvents")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/stolasapp.erato.v1.ArchiveService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ArchiveServiceListCategoriesProcedure:
@@ -505,16 +888,22 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 			archiveServiceGetEntryHandler.ServeHTTP(w, r)
 		case ArchiveServiceUpdateEntryProcedure:
 			archiveServiceUpdateEntryHandler.ServeHTTP(w, r)
+		case ArchiveServiceBatchUpdateEntriesProcedure:
+			archiveServiceBatchUpdateEntriesHandler.ServeHTTP(w, r)
 		case ArchiveServiceListChaptersProcedure:
 			archiveServiceListChaptersHandler.ServeHTTP(w, r)
 		case ArchiveServiceGetChapterProcedure:
 			archiveServiceGetChapterHandler.ServeHTTP(w, r)
 		case ArchiveServiceUpdateChapterProcedure:
 			archiveServiceUpdateChapterHandler.ServeHTTP(w, r)
+		case ArchiveServiceBatchUpdateChaptersProcedure:
+			archiveServiceBatchUpdateChaptersHandler.ServeHTTP(w, r)
 		case ArchiveServiceReadEntryProcedure:
 			archiveServiceReadEntryHandler.ServeHTTP(w, r)
 		case ArchiveServiceReadChapterProcedure:
 			archiveServiceReadChapterHandler.ServeHTTP(w, r)
+		case ArchiveServiceExportEntryProcedure:
+			archiveServiceExportEntryHandler.ServeHTTP(w, r)
 		case ArchiveServiceCreateUserProcedure:
 			archiveServiceCreateUserHandler.ServeHTTP(w, r)
 		case ArchiveServiceListUsersProcedure:
@@ -525,6 +914,28 @@ func NewArchiveServiceHandler(svc ArchiveServiceHandler, opts ...connect.Handler
 			archiveServiceUpdateUserHandler.ServeHTTP(w, r)
 		case ArchiveServiceDeleteUserProcedure:
 			archiveServiceDeleteUserHandler.ServeHTTP(w, r)
+		case ArchiveServiceRenameUserProcedure:
+			archiveServiceRenameUserHandler.ServeHTTP(w, r)
+		case ArchiveServiceCreateAccessTokenProcedure:
+			archiveServiceCreateAccessTokenHandler.ServeHTTP(w, r)
+		case ArchiveServiceListAccessTokensProcedure:
+			archiveServiceListAccessTokensHandler.ServeHTTP(w, r)
+		case ArchiveServiceDeleteAccessTokenProcedure:
+			archiveServiceDeleteAccessTokenHandler.ServeHTTP(w, r)
+		case ArchiveServiceCreateSavedViewProcedure:
+			archiveServiceCreateSavedViewHandler.ServeHTTP(w, r)
+		case ArchiveServiceListSavedViewsProcedure:
+			archiveServiceListSavedViewsHandler.ServeHTTP(w, r)
+		case ArchiveServiceGetSavedViewProcedure:
+			archiveServiceGetSavedViewHandler.ServeHTTP(w, r)
+		case ArchiveServiceUpdateSavedViewProcedure:
+			archiveServiceUpdateSavedViewHandler.ServeHTTP(w, r)
+		case ArchiveServiceDeleteSavedViewProcedure:
+			archiveServiceDeleteSavedViewHandler.ServeHTTP(w, r)
+		case ArchiveServiceCreateInviteProcedure:
+			archiveServiceCreateInviteHandler.ServeHTTP(w, r)
+		case ArchiveServiceListAuditEventsProcedure:
+			archiveServiceListAuditEventsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -558,6 +969,10 @@ func (UnimplementedArchiveServiceHandler) UpdateEntry(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.UpdateEntry is not implemented"))
 }
 
+func (UnimplementedArchiveServiceHandler) BatchUpdateEntries(context.Context, *connect.Request[v1.BatchUpdateEntriesRequest]) (*connect.Response[v1.BatchUpdateEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.BatchUpdateEntries is not implemented"))
+}
+
 func (UnimplementedArchiveServiceHandler) ListChapters(context.Context, *connect.Request[v1.ListChaptersRequest]) (*connect.Response[v1.ListChaptersResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ListChapters is not implemented"))
 }
@@ -570,6 +985,10 @@ func (UnimplementedArchiveServiceHandler) UpdateChapter(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.UpdateChapter is not implemented"))
 }
 
+func (UnimplementedArchiveServiceHandler) BatchUpdateChapters(context.Context, *connect.Request[v1.BatchUpdateChaptersRequest]) (*connect.Response[v1.BatchUpdateChaptersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.BatchUpdateChapters is not implemented"))
+}
+
 func (UnimplementedArchiveServiceHandler) ReadEntry(context.Context, *connect.Request[v1.ReadEntryRequest]) (*connect.Response[v1.ReadEntryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ReadEntry is not implemented"))
 }
@@ -578,6 +997,10 @@ func (UnimplementedArchiveServiceHandler) ReadChapter(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ReadChapter is not implemented"))
 }
 
+func (UnimplementedArchiveServiceHandler) ExportEntry(context.Context, *connect.Request[v1.ExportEntryRequest], *connect.ServerStream[httpbody.HttpBody]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ExportEntry is not implemented"))
+}
+
 func (UnimplementedArchiveServiceHandler) CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.User], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.CreateUser is not implemented"))
 }
@@ -597,3 +1020,47 @@ func (UnimplementedArchiveServiceHandler) UpdateUser(context.Context, *connect.R
 func (UnimplementedArchiveServiceHandler) DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.DeleteUser is not implemented"))
 }
+
+func (UnimplementedArchiveServiceHandler) RenameUser(context.Context, *connect.Request[v1.RenameUserRequest]) (*connect.Response[v1.User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.RenameUser is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) CreateAccessToken(context.Context, *connect.Request[v1.CreateAccessTokenRequest]) (*connect.Response[v1.AccessToken], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.CreateAccessToken is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) ListAccessTokens(context.Context, *connect.Request[v1.ListAccessTokensRequest]) (*connect.Response[v1.ListAccessTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ListAccessTokens is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) DeleteAccessToken(context.Context, *connect.Request[v1.DeleteAccessTokenRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.DeleteAccessToken is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) CreateSavedView(context.Context, *connect.Request[v1.CreateSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.CreateSavedView is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) ListSavedViews(context.Context, *connect.Request[v1.ListSavedViewsRequest]) (*connect.Response[v1.ListSavedViewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ListSavedViews is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) GetSavedView(context.Context, *connect.Request[v1.GetSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.GetSavedView is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) UpdateSavedView(context.Context, *connect.Request[v1.UpdateSavedViewRequest]) (*connect.Response[v1.SavedView], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.UpdateSavedView is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) DeleteSavedView(context.Context, *connect.Request[v1.DeleteSavedViewRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.DeleteSavedView is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) CreateInvite(context.Context, *connect.Request[v1.CreateInviteRequest]) (*connect.Response[v1.Invite], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.CreateInvite is not implemented"))
+}
+
+func (UnimplementedArchiveServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stolasapp.erato.v1.ArchiveService.ListAuditEvents is not implemented"))
+}
@@ -22,18 +22,127 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Signed wrapper of every pagination token, binding it to the request it was
+// issued for. This message should not be used and is not considered stable.
+type PageTokenEnvelope struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Token         []byte                 `protobuf:"bytes,1,opt,name=token,proto3"`
+	xxx_hidden_RequestDigest []byte                 `protobuf:"bytes,2,opt,name=request_digest,json=requestDigest,proto3"`
+	xxx_hidden_ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expire_time,json=expireTime,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *PageTokenEnvelope) Reset() {
+	*x = PageTokenEnvelope{}
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageTokenEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageTokenEnvelope) ProtoMessage() {}
+
+func (x *PageTokenEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PageTokenEnvelope) GetToken() []byte {
+	if x != nil {
+		return x.xxx_hidden_Token
+	}
+	return nil
+}
+
+func (x *PageTokenEnvelope) GetRequestDigest() []byte {
+	if x != nil {
+		return x.xxx_hidden_RequestDigest
+	}
+	return nil
+}
+
+func (x *PageTokenEnvelope) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpireTime
+	}
+	return nil
+}
+
+func (x *PageTokenEnvelope) SetToken(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Token = v
+}
+
+func (x *PageTokenEnvelope) SetRequestDigest(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_RequestDigest = v
+}
+
+func (x *PageTokenEnvelope) SetExpireTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpireTime = v
+}
+
+func (x *PageTokenEnvelope) HasExpireTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpireTime != nil
+}
+
+func (x *PageTokenEnvelope) ClearExpireTime() {
+	x.xxx_hidden_ExpireTime = nil
+}
+
+type PageTokenEnvelope_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The serialized list-specific pagination token.
+	Token []byte
+	// Digest of the request fields that must not change between pages.
+	RequestDigest []byte
+	// When the token stops being accepted.
+	ExpireTime *timestamppb.Timestamp
+}
+
+func (b0 PageTokenEnvelope_builder) Build() *PageTokenEnvelope {
+	m0 := &PageTokenEnvelope{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Token = b.Token
+	x.xxx_hidden_RequestDigest = b.RequestDigest
+	x.xxx_hidden_ExpireTime = b.ExpireTime
+	return m0
+}
+
 // Opaque pagination token used by ListCategories RPC. This message should not
 // be used and is not considered stable.
 type ListCategoriesPaginationToken struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_AfterCategory string                 `protobuf:"bytes,1,opt,name=after_category,json=afterCategory,proto3"`
+	xxx_hidden_OrderBy       string                 `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3"`
+	xxx_hidden_AfterKey      *Category              `protobuf:"bytes,3,opt,name=after_key,json=afterKey,proto3"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ListCategoriesPaginationToken) Reset() {
 	*x = ListCategoriesPaginationToken{}
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[0]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +154,7 @@ func (x *ListCategoriesPaginationToken) String() string {
 func (*ListCategoriesPaginationToken) ProtoMessage() {}
 
 func (x *ListCategoriesPaginationToken) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[0]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -63,15 +172,55 @@ func (x *ListCategoriesPaginationToken) GetAfterCategory() string {
 	return ""
 }
 
+func (x *ListCategoriesPaginationToken) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
+func (x *ListCategoriesPaginationToken) GetAfterKey() *Category {
+	if x != nil {
+		return x.xxx_hidden_AfterKey
+	}
+	return nil
+}
+
 func (x *ListCategoriesPaginationToken) SetAfterCategory(v string) {
 	x.xxx_hidden_AfterCategory = v
 }
 
+func (x *ListCategoriesPaginationToken) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
+func (x *ListCategoriesPaginationToken) SetAfterKey(v *Category) {
+	x.xxx_hidden_AfterKey = v
+}
+
+func (x *ListCategoriesPaginationToken) HasAfterKey() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_AfterKey != nil
+}
+
+func (x *ListCategoriesPaginationToken) ClearAfterKey() {
+	x.xxx_hidden_AfterKey = nil
+}
+
 type ListCategoriesPaginationToken_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// Resource path to the category to start with, exclusively.
 	AfterCategory string
+	// The normalized order_by of the request, which must not change between
+	// pages.
+	OrderBy string
+	// When order_by is set, the category to start after, with only its path and
+	// the ordered fields set. If the category has since moved or been removed,
+	// results resume where it would have been.
+	AfterKey *Category
 }
 
 func (b0 ListCategoriesPaginationToken_builder) Build() *ListCategoriesPaginationToken {
@@ -79,6 +228,8 @@ func (b0 ListCategoriesPaginationToken_builder) Build() *ListCategoriesPaginatio
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_AfterCategory = b.AfterCategory
+	x.xxx_hidden_OrderBy = b.OrderBy
+	x.xxx_hidden_AfterKey = b.AfterKey
 	return m0
 }
 
@@ -89,13 +240,15 @@ type ListEntriesPaginationToken struct {
 	xxx_hidden_Page            uint32                 `protobuf:"varint,1,opt,name=page,proto3"`
 	xxx_hidden_AfterEntry      string                 `protobuf:"bytes,2,opt,name=after_entry,json=afterEntry,proto3"`
 	xxx_hidden_StartUpdateTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_update_time,json=startUpdateTime,proto3"`
+	xxx_hidden_OrderBy         string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3"`
+	xxx_hidden_AfterKey        *Entry                 `protobuf:"bytes,5,opt,name=after_key,json=afterKey,proto3"`
 	unknownFields              protoimpl.UnknownFields
 	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *ListEntriesPaginationToken) Reset() {
 	*x = ListEntriesPaginationToken{}
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[1]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -107,7 +260,7 @@ func (x *ListEntriesPaginationToken) String() string {
 func (*ListEntriesPaginationToken) ProtoMessage() {}
 
 func (x *ListEntriesPaginationToken) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[1]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,6 +292,20 @@ func (x *ListEntriesPaginationToken) GetStartUpdateTime() *timestamppb.Timestamp
 	return nil
 }
 
+func (x *ListEntriesPaginationToken) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
+func (x *ListEntriesPaginationToken) GetAfterKey() *Entry {
+	if x != nil {
+		return x.xxx_hidden_AfterKey
+	}
+	return nil
+}
+
 func (x *ListEntriesPaginationToken) SetPage(v uint32) {
 	x.xxx_hidden_Page = v
 }
@@ -151,6 +318,14 @@ func (x *ListEntriesPaginationToken) SetStartUpdateTime(v *timestamppb.Timestamp
 	x.xxx_hidden_StartUpdateTime = v
 }
 
+func (x *ListEntriesPaginationToken) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
+func (x *ListEntriesPaginationToken) SetAfterKey(v *Entry) {
+	x.xxx_hidden_AfterKey = v
+}
+
 func (x *ListEntriesPaginationToken) HasStartUpdateTime() bool {
 	if x == nil {
 		return false
@@ -158,10 +333,21 @@ func (x *ListEntriesPaginationToken) HasStartUpdateTime() bool {
 	return x.xxx_hidden_StartUpdateTime != nil
 }
 
+func (x *ListEntriesPaginationToken) HasAfterKey() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_AfterKey != nil
+}
+
 func (x *ListEntriesPaginationToken) ClearStartUpdateTime() {
 	x.xxx_hidden_StartUpdateTime = nil
 }
 
+func (x *ListEntriesPaginationToken) ClearAfterKey() {
+	x.xxx_hidden_AfterKey = nil
+}
+
 type ListEntriesPaginationToken_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -177,6 +363,13 @@ type ListEntriesPaginationToken_builder struct {
 	// page at this timestamp, which may result in duplicates in some unlikely
 	// edge cases.
 	StartUpdateTime *timestamppb.Timestamp
+	// The normalized order_by of the request, which must not change between
+	// pages.
+	OrderBy string
+	// When order_by is set, the entry to start after, with only its path and
+	// the ordered fields set. If the entry has since moved or been removed,
+	// results resume where it would have been.
+	AfterKey *Entry
 }
 
 func (b0 ListEntriesPaginationToken_builder) Build() *ListEntriesPaginationToken {
@@ -186,6 +379,8 @@ func (b0 ListEntriesPaginationToken_builder) Build() *ListEntriesPaginationToken
 	x.xxx_hidden_Page = b.Page
 	x.xxx_hidden_AfterEntry = b.AfterEntry
 	x.xxx_hidden_StartUpdateTime = b.StartUpdateTime
+	x.xxx_hidden_OrderBy = b.OrderBy
+	x.xxx_hidden_AfterKey = b.AfterKey
 	return m0
 }
 
@@ -194,13 +389,15 @@ func (b0 ListEntriesPaginationToken_builder) Build() *ListEntriesPaginationToken
 type ListChaptersPaginationToken struct {
 	state                   protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_AfterChapter string                 `protobuf:"bytes,1,opt,name=after_chapter,json=afterChapter,proto3"`
+	xxx_hidden_OrderBy      string                 `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3"`
+	xxx_hidden_AfterKey     *Chapter               `protobuf:"bytes,3,opt,name=after_key,json=afterKey,proto3"`
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
 
 func (x *ListChaptersPaginationToken) Reset() {
 	*x = ListChaptersPaginationToken{}
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[2]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -212,7 +409,7 @@ func (x *ListChaptersPaginationToken) String() string {
 func (*ListChaptersPaginationToken) ProtoMessage() {}
 
 func (x *ListChaptersPaginationToken) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[2]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,15 +427,55 @@ func (x *ListChaptersPaginationToken) GetAfterChapter() string {
 	return ""
 }
 
+func (x *ListChaptersPaginationToken) GetOrderBy() string {
+	if x != nil {
+		return x.xxx_hidden_OrderBy
+	}
+	return ""
+}
+
+func (x *ListChaptersPaginationToken) GetAfterKey() *Chapter {
+	if x != nil {
+		return x.xxx_hidden_AfterKey
+	}
+	return nil
+}
+
 func (x *ListChaptersPaginationToken) SetAfterChapter(v string) {
 	x.xxx_hidden_AfterChapter = v
 }
 
+func (x *ListChaptersPaginationToken) SetOrderBy(v string) {
+	x.xxx_hidden_OrderBy = v
+}
+
+func (x *ListChaptersPaginationToken) SetAfterKey(v *Chapter) {
+	x.xxx_hidden_AfterKey = v
+}
+
+func (x *ListChaptersPaginationToken) HasAfterKey() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_AfterKey != nil
+}
+
+func (x *ListChaptersPaginationToken) ClearAfterKey() {
+	x.xxx_hidden_AfterKey = nil
+}
+
 type ListChaptersPaginationToken_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// Resource path to the chapter to start with, exclusively.
 	AfterChapter string
+	// The normalized order_by of the request, which must not change between
+	// pages.
+	OrderBy string
+	// When order_by is set, the chapter to start after, with only its path and
+	// the ordered fields set. If the chapter has since moved or been removed,
+	// results resume where it would have been.
+	AfterKey *Chapter
 }
 
 func (b0 ListChaptersPaginationToken_builder) Build() *ListChaptersPaginationToken {
@@ -246,6 +483,8 @@ func (b0 ListChaptersPaginationToken_builder) Build() *ListChaptersPaginationTok
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_AfterChapter = b.AfterChapter
+	x.xxx_hidden_OrderBy = b.OrderBy
+	x.xxx_hidden_AfterKey = b.AfterKey
 	return m0
 }
 
@@ -260,7 +499,7 @@ type ListUsersPaginationToken struct {
 
 func (x *ListUsersPaginationToken) Reset() {
 	*x = ListUsersPaginationToken{}
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[3]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -272,7 +511,7 @@ func (x *ListUsersPaginationToken) String() string {
 func (*ListUsersPaginationToken) ProtoMessage() {}
 
 func (x *ListUsersPaginationToken) ProtoReflect() protoreflect.Message {
-	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[3]
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,40 +548,248 @@ func (b0 ListUsersPaginationToken_builder) Build() *ListUsersPaginationToken {
 	return m0
 }
 
+// Opaque pagination token used by ListAccessTokens RPC. This message should
+// not be used and is not considered stable.
+type ListAccessTokensPaginationToken struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AfterAccessToken string                 `protobuf:"bytes,1,opt,name=after_access_token,json=afterAccessToken,proto3"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensPaginationToken) Reset() {
+	*x = ListAccessTokensPaginationToken{}
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensPaginationToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensPaginationToken) ProtoMessage() {}
+
+func (x *ListAccessTokensPaginationToken) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAccessTokensPaginationToken) GetAfterAccessToken() string {
+	if x != nil {
+		return x.xxx_hidden_AfterAccessToken
+	}
+	return ""
+}
+
+func (x *ListAccessTokensPaginationToken) SetAfterAccessToken(v string) {
+	x.xxx_hidden_AfterAccessToken = v
+}
+
+type ListAccessTokensPaginationToken_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Resource path to the access token to start with, exclusively.
+	AfterAccessToken string
+}
+
+func (b0 ListAccessTokensPaginationToken_builder) Build() *ListAccessTokensPaginationToken {
+	m0 := &ListAccessTokensPaginationToken{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_AfterAccessToken = b.AfterAccessToken
+	return m0
+}
+
+// Opaque pagination token used by ListSavedViews RPC. This message should
+// not be used and is not considered stable.
+type ListSavedViewsPaginationToken struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AfterSavedView string                 `protobuf:"bytes,1,opt,name=after_saved_view,json=afterSavedView,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListSavedViewsPaginationToken) Reset() {
+	*x = ListSavedViewsPaginationToken{}
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedViewsPaginationToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsPaginationToken) ProtoMessage() {}
+
+func (x *ListSavedViewsPaginationToken) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSavedViewsPaginationToken) GetAfterSavedView() string {
+	if x != nil {
+		return x.xxx_hidden_AfterSavedView
+	}
+	return ""
+}
+
+func (x *ListSavedViewsPaginationToken) SetAfterSavedView(v string) {
+	x.xxx_hidden_AfterSavedView = v
+}
+
+type ListSavedViewsPaginationToken_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Resource path to the saved view to start with, exclusively.
+	AfterSavedView string
+}
+
+func (b0 ListSavedViewsPaginationToken_builder) Build() *ListSavedViewsPaginationToken {
+	m0 := &ListSavedViewsPaginationToken{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_AfterSavedView = b.AfterSavedView
+	return m0
+}
+
+// Opaque pagination token used by ListAuditEvents RPC. This message should
+// not be used and is not considered stable.
+type ListAuditEventsPaginationToken struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AfterAuditEvent string                 `protobuf:"bytes,1,opt,name=after_audit_event,json=afterAuditEvent,proto3"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsPaginationToken) Reset() {
+	*x = ListAuditEventsPaginationToken{}
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsPaginationToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsPaginationToken) ProtoMessage() {}
+
+func (x *ListAuditEventsPaginationToken) ProtoReflect() protoreflect.Message {
+	mi := &file_stolasapp_erato_v1_pagination_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditEventsPaginationToken) GetAfterAuditEvent() string {
+	if x != nil {
+		return x.xxx_hidden_AfterAuditEvent
+	}
+	return ""
+}
+
+func (x *ListAuditEventsPaginationToken) SetAfterAuditEvent(v string) {
+	x.xxx_hidden_AfterAuditEvent = v
+}
+
+type ListAuditEventsPaginationToken_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Resource path to the audit event to start with, exclusively.
+	AfterAuditEvent string
+}
+
+func (b0 ListAuditEventsPaginationToken_builder) Build() *ListAuditEventsPaginationToken {
+	m0 := &ListAuditEventsPaginationToken{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_AfterAuditEvent = b.AfterAuditEvent
+	return m0
+}
+
 var File_stolasapp_erato_v1_pagination_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_pagination_proto_rawDesc = "" +
 	"\n" +
-	"#stolasapp/erato/v1/pagination.proto\x12\x12stolasapp.erato.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"N\n" +
+	"#stolasapp/erato/v1/pagination.proto\x12\x12stolasapp.erato.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!stolasapp/erato/v1/category.proto\x1a stolasapp/erato/v1/chapter.proto\x1a\x1estolasapp/erato/v1/entry.proto\"\xa5\x01\n" +
+	"\x11PageTokenEnvelope\x12\x1c\n" +
+	"\x05token\x18\x01 \x01(\fB\x06\xbaH\x03\xc8\x01\x01R\x05token\x12-\n" +
+	"\x0erequest_digest\x18\x02 \x01(\fB\x06\xbaH\x03\xc8\x01\x01R\rrequestDigest\x12C\n" +
+	"\vexpire_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"expireTime\"\xa4\x01\n" +
 	"\x1dListCategoriesPaginationToken\x12-\n" +
-	"\x0eafter_category\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\rafterCategory\"\xb1\x01\n" +
+	"\x0eafter_category\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\rafterCategory\x12\x19\n" +
+	"\border_by\x18\x02 \x01(\tR\aorderBy\x129\n" +
+	"\tafter_key\x18\x03 \x01(\v2\x1c.stolasapp.erato.v1.CategoryR\bafterKey\"\x84\x02\n" +
 	"\x1aListEntriesPaginationToken\x12\x1a\n" +
 	"\x04page\x18\x01 \x01(\rB\x06\xbaH\x03\xc8\x01\x01R\x04page\x12'\n" +
 	"\vafter_entry\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
 	"afterEntry\x12N\n" +
-	"\x11start_update_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\x0fstartUpdateTime\"J\n" +
+	"\x11start_update_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\x0fstartUpdateTime\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x126\n" +
+	"\tafter_key\x18\x05 \x01(\v2\x19.stolasapp.erato.v1.EntryR\bafterKey\"\x9f\x01\n" +
 	"\x1bListChaptersPaginationToken\x12+\n" +
-	"\rafter_chapter\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\fafterChapter\"A\n" +
+	"\rafter_chapter\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\fafterChapter\x12\x19\n" +
+	"\border_by\x18\x02 \x01(\tR\aorderBy\x128\n" +
+	"\tafter_key\x18\x03 \x01(\v2\x1b.stolasapp.erato.v1.ChapterR\bafterKey\"A\n" +
 	"\x18ListUsersPaginationToken\x12%\n" +
 	"\n" +
-	"after_user\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tafterUserB\xd7\x01\n" +
+	"after_user\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tafterUser\"W\n" +
+	"\x1fListAccessTokensPaginationToken\x124\n" +
+	"\x12after_access_token\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x10afterAccessToken\"Q\n" +
+	"\x1dListSavedViewsPaginationToken\x120\n" +
+	"\x10after_saved_view\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x0eafterSavedView\"T\n" +
+	"\x1eListAuditEventsPaginationToken\x122\n" +
+	"\x11after_audit_event\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x0fafterAuditEventB\xd7\x01\n" +
 	"\x16com.stolasapp.erato.v1B\x0fPaginationProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
 
-var file_stolasapp_erato_v1_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stolasapp_erato_v1_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_stolasapp_erato_v1_pagination_proto_goTypes = []any{
-	(*ListCategoriesPaginationToken)(nil), // 0: stolasapp.erato.v1.ListCategoriesPaginationToken
-	(*ListEntriesPaginationToken)(nil),    // 1: stolasapp.erato.v1.ListEntriesPaginationToken
-	(*ListChaptersPaginationToken)(nil),   // 2: stolasapp.erato.v1.ListChaptersPaginationToken
-	(*ListUsersPaginationToken)(nil),      // 3: stolasapp.erato.v1.ListUsersPaginationToken
-	(*timestamppb.Timestamp)(nil),         // 4: google.protobuf.Timestamp
+	(*PageTokenEnvelope)(nil),               // 0: stolasapp.erato.v1.PageTokenEnvelope
+	(*ListCategoriesPaginationToken)(nil),   // 1: stolasapp.erato.v1.ListCategoriesPaginationToken
+	(*ListEntriesPaginationToken)(nil),      // 2: stolasapp.erato.v1.ListEntriesPaginationToken
+	(*ListChaptersPaginationToken)(nil),     // 3: stolasapp.erato.v1.ListChaptersPaginationToken
+	(*ListUsersPaginationToken)(nil),        // 4: stolasapp.erato.v1.ListUsersPaginationToken
+	(*ListAccessTokensPaginationToken)(nil), // 5: stolasapp.erato.v1.ListAccessTokensPaginationToken
+	(*ListSavedViewsPaginationToken)(nil),   // 6: stolasapp.erato.v1.ListSavedViewsPaginationToken
+	(*ListAuditEventsPaginationToken)(nil),  // 7: stolasapp.erato.v1.ListAuditEventsPaginationToken
+	(*timestamppb.Timestamp)(nil),           // 8: google.protobuf.Timestamp
+	(*Category)(nil),                        // 9: stolasapp.erato.v1.Category
+	(*Entry)(nil),                           // 10: stolasapp.erato.v1.Entry
+	(*Chapter)(nil),                         // 11: stolasapp.erato.v1.Chapter
 }
 var file_stolasapp_erato_v1_pagination_proto_depIdxs = []int32{
-	4, // 0: stolasapp.erato.v1.ListEntriesPaginationToken.start_update_time:type_name -> google.protobuf.Timestamp
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	8,  // 0: stolasapp.erato.v1.PageTokenEnvelope.expire_time:type_name -> google.protobuf.Timestamp
+	9,  // 1: stolasapp.erato.v1.ListCategoriesPaginationToken.after_key:type_name -> stolasapp.erato.v1.Category
+	8,  // 2: stolasapp.erato.v1.ListEntriesPaginationToken.start_update_time:type_name -> google.protobuf.Timestamp
+	10, // 3: stolasapp.erato.v1.ListEntriesPaginationToken.after_key:type_name -> stolasapp.erato.v1.Entry
+	11, // 4: stolasapp.erato.v1.ListChaptersPaginationToken.after_key:type_name -> stolasapp.erato.v1.Chapter
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_stolasapp_erato_v1_pagination_proto_init() }
@@ -350,13 +797,16 @@ func file_stolasapp_erato_v1_pagination_proto_init() {
 	if File_stolasapp_erato_v1_pagination_proto != nil {
 		return
 	}
+	file_stolasapp_erato_v1_category_proto_init()
+	file_stolasapp_erato_v1_chapter_proto_init()
+	file_stolasapp_erato_v1_entry_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stolasapp_erato_v1_pagination_proto_rawDesc), len(file_stolasapp_erato_v1_pagination_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -23,12 +23,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// The roles a user may have.
+type User_Role int32
+
+const (
+	// Unknown role.
+	User_ROLE_UNSPECIFIED User_Role = 0
+	// May only access and manage their own data.
+	User_MEMBER User_Role = 1
+	// May additionally list, get, reset the password of and delete other
+	// users.
+	User_ADMIN User_Role = 2
+)
+
+// Enum value maps for User_Role.
+var (
+	User_Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "MEMBER",
+		2: "ADMIN",
+	}
+	User_Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"MEMBER":           1,
+		"ADMIN":            2,
+	}
+)
+
+func (x User_Role) Enum() *User_Role {
+	p := new(User_Role)
+	*p = x
+	return p
+}
+
+func (x User_Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (User_Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_stolasapp_erato_v1_user_proto_enumTypes[0].Descriptor()
+}
+
+func (User_Role) Type() protoreflect.EnumType {
+	return &file_stolasapp_erato_v1_user_proto_enumTypes[0]
+}
+
+func (x User_Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 // A user with access to the archive.
 type User struct {
 	state               protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Path     string                 `protobuf:"bytes,10018,opt,name=path,proto3"`
 	xxx_hidden_Id       string                 `protobuf:"bytes,2,opt,name=id,proto3"`
 	xxx_hidden_Password string                 `protobuf:"bytes,3,opt,name=password,proto3"`
+	xxx_hidden_Etag     string                 `protobuf:"bytes,4,opt,name=etag,proto3"`
+	xxx_hidden_Role     User_Role              `protobuf:"varint,5,opt,name=role,proto3,enum=stolasapp.erato.v1.User_Role"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -79,6 +130,20 @@ func (x *User) GetPassword() string {
 	return ""
 }
 
+func (x *User) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *User) GetRole() User_Role {
+	if x != nil {
+		return x.xxx_hidden_Role
+	}
+	return User_ROLE_UNSPECIFIED
+}
+
 func (x *User) SetPath(v string) {
 	x.xxx_hidden_Path = v
 }
@@ -91,6 +156,14 @@ func (x *User) SetPassword(v string) {
 	x.xxx_hidden_Password = v
 }
 
+func (x *User) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+func (x *User) SetRole(v User_Role) {
+	x.xxx_hidden_Role = v
+}
+
 type User_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -98,12 +171,20 @@ type User_builder struct {
 	//
 	// Format: users/{user_id}
 	Path string
-	// The user's unique name, used when logging in.
+	// The user's unique name, used when logging in. Admins may change it with
+	// RenameUser.
 	Id string
 	// The password of the user, stored as a one-way hash.
 	//
-	// Must be 8-72 characters (bcrypt compatible).
+	// Must be 8-1024 characters.
 	Password string
+	// An opaque version of the user, used for optimistic concurrency control.
+	// Updates that provide an etag are rejected if the user has been modified
+	// since the etag was read.
+	Etag string
+	// The user's role, determining what they may access. Roles are managed with
+	// the `erato user promote` and `erato user demote` commands.
+	Role User_Role
 }
 
 func (b0 User_builder) Build() *User {
@@ -113,6 +194,8 @@ func (b0 User_builder) Build() *User {
 	x.xxx_hidden_Path = b.Path
 	x.xxx_hidden_Id = b.Id
 	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_Etag = b.Etag
+	x.xxx_hidden_Role = b.Role
 	return m0
 }
 
@@ -120,25 +203,35 @@ var File_stolasapp_erato_v1_user_proto protoreflect.FileDescriptor
 
 const file_stolasapp_erato_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x1dstolasapp/erato/v1/user.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/api/field_behavior.proto\"\x84\x02\n" +
+	"\x1dstolasapp/erato/v1/user.proto\x12\x12stolasapp.erato.v1\x1a\x18aep/api/field_info.proto\x1a\x16aep/api/resource.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/api/field_behavior.proto\"\x8f\x03\n" +
 	"\x04User\x12\x18\n" +
 	"\x04path\x18\xa2N \x01(\tB\x03\xe0A\bR\x04path\x12\x19\n" +
-	"\x02id\x18\x02 \x01(\tB\t\xe0A\x05\x8aO\x03\x1a\x01\x05R\x02id\x12\x8c\x01\n" +
-	"\bpassword\x18\x03 \x01(\tBp\xe0A\x04\xbaHd\xba\x01a\n" +
-	"\x0fstring.password\x12\x17must be 8-72 characters\x1a5this == '' || (this.size() >= 8 && this.size() <= 72)\x8aO\x03\x1a\x01\x04R\bpassword:8\x92O5\n" +
+	"\x02id\x18\x02 \x01(\tB\t\xe0A\x05\x8aO\x03\x1a\x01\x05R\x02id\x12\x90\x01\n" +
+	"\bpassword\x18\x03 \x01(\tBt\xe0A\x04\xbaHh\xba\x01e\n" +
+	"\x0fstring.password\x12\x19must be 8-1024 characters\x1a7this == '' || (this.size() >= 8 && this.size() <= 1024)\x8aO\x03\x1a\x01\x04R\bpassword\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etag\x12<\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x1d.stolasapp.erato.v1.User.RoleB\t\xe0A\x03\x8aO\x03\x1a\x01\x03R\x04role\"3\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06MEMBER\x10\x01\x12\t\n" +
+	"\x05ADMIN\x10\x02:8\x92O5\n" +
 	"\x15erato.stolas.app/user\x12\x0fusers/{user_id}\x1a\x04user\"\x05usersB\xd1\x01\n" +
 	"\x16com.stolasapp.erato.v1B\tUserProtoP\x01ZBgithub.com/stolasapp/erato/internal/gen/stolasapp/erato/v1;eratov1\xa2\x02\x03SEX\xaa\x02\x12Stolasapp.Erato.V1\xca\x02\x12Stolasapp\\Erato\\V1\xe2\x02\x1eStolasapp\\Erato\\V1\\GPBMetadata\xea\x02\x14Stolasapp::Erato::V1b\x06proto3"
 
+var file_stolasapp_erato_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_stolasapp_erato_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_stolasapp_erato_v1_user_proto_goTypes = []any{
-	(*User)(nil), // 0: stolasapp.erato.v1.User
+	(User_Role)(0), // 0: stolasapp.erato.v1.User.Role
+	(*User)(nil),   // 1: stolasapp.erato.v1.User
 }
 var file_stolasapp_erato_v1_user_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: stolasapp.erato.v1.User.role:type_name -> stolasapp.erato.v1.User.Role
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_stolasapp_erato_v1_user_proto_init() }
@@ -151,13 +244,14 @@ func file_stolasapp_erato_v1_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stolasapp_erato_v1_user_proto_rawDesc), len(file_stolasapp_erato_v1_user_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_stolasapp_erato_v1_user_proto_goTypes,
 		DependencyIndexes: file_stolasapp_erato_v1_user_proto_depIdxs,
+		EnumInfos:         file_stolasapp_erato_v1_user_proto_enumTypes,
 		MessageInfos:      file_stolasapp_erato_v1_user_proto_msgTypes,
 	}.Build()
 	File_stolasapp_erato_v1_user_proto = out.File
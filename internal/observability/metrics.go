@@ -0,0 +1,17 @@
+package observability
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// MetricsPath is the path of the metrics published with expvar, such as the
+// usage of the archive's filter program cache.
+const MetricsPath = "/debug/vars"
+
+// MetricsHandler serves the metrics published with expvar as JSON. They
+// include the command line and memory statistics of the process, so the
+// handler should only be reachable by admins.
+func MetricsHandler() http.Handler {
+	return expvar.Handler()
+}
@@ -1,4 +1,4 @@
-// Package observability provides logging initialization.
+// Package observability provides logging initialization and metrics.
 package observability
 
 import (
@@ -2,23 +2,57 @@
 package pagination
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 )
 
+// MinKeySize is the minimum size of a key used to sign tokens.
+const MinKeySize = 32
+
 var tokenEncoding = base64.RawURLEncoding
 
+var (
+	// ErrTokenInvalid is the reason for a [TokenError] when a token is
+	// malformed or has been modified.
+	ErrTokenInvalid = errors.New("invalid pagination token")
+
+	// ErrTokenExpired is the reason for a [TokenError] when a token is used
+	// after it has expired.
+	ErrTokenExpired = errors.New("pagination token has expired")
+
+	// ErrTokenMismatch is the reason for a [TokenError] when a token is used
+	// with a different request than it was issued for.
+	ErrTokenMismatch = errors.New("pagination token does not match the request: parent, filter and max_page_size must not change between pages")
+)
+
 // TokenError is an opaque error related to pagination tokens. The error message
-// does not reveal internal details; use [errors.Unwrap] to access the cause.
+// is one of the reasons above and does not reveal internal details; use
+// [errors.Is] to check the reason and [errors.Unwrap] to access the cause.
 type TokenError struct {
-	cause error
+	reason error
+	cause  error
 }
 
 // Error satisfies [error].
 func (terr TokenError) Error() string {
-	return "invalid pagination token"
+	return terr.reason.Error()
+}
+
+// Is reports whether target is the reason for the token error.
+func (terr TokenError) Is(target error) bool {
+	return target == terr.reason
 }
 
 // Unwrap returns the underlying cause of the token error.
@@ -26,31 +60,123 @@ func (terr TokenError) Unwrap() error {
 	return terr.cause
 }
 
-// FromToken decodes an opaque pagination token into the provided proto message.
-// Returns a [TokenError] if decoding or validation fails.
-func FromToken(tkn string, msg proto.Message) error {
+// Request is a list request that page tokens are bound to. Tokens are issued
+// for the request's max_page_size and, if the request has them, its parent and
+// filter; they are only accepted for requests with the same values.
+type Request interface {
+	GetMaxPageSize() int32
+}
+
+// Codec signs and verifies pagination tokens with HMAC-SHA256, so tokens cannot
+// be modified, used after they expire, or used with another request.
+type Codec struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewCodec creates a Codec that signs tokens with key, which must be at least
+// [MinKeySize] bytes, and accepts them for ttl after they are issued.
+func NewCodec(key []byte, ttl time.Duration) (*Codec, error) {
+	if len(key) < MinKeySize {
+		return nil, fmt.Errorf("page token key must be at least %d bytes, got %d", MinKeySize, len(key))
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("page token ttl must be positive, got %v", ttl)
+	}
+	return &Codec{
+		key: key,
+		ttl: ttl,
+		now: time.Now,
+	}, nil
+}
+
+// FromToken verifies an opaque pagination token issued for req and decodes it
+// into the provided proto message. Returns a [TokenError] if the token is
+// invalid, expired, issued for another request, or fails validation.
+func (c *Codec) FromToken(req Request, tkn string, msg proto.Message) error {
 	data, err := tokenEncoding.DecodeString(tkn)
 	if err != nil {
-		return TokenError{cause: err}
+		return TokenError{reason: ErrTokenInvalid, cause: err}
+	}
+	if len(data) < sha256.Size {
+		return TokenError{reason: ErrTokenInvalid, cause: errors.New("token is too short")}
 	}
-	if err = proto.Unmarshal(data, msg); err != nil {
-		return TokenError{cause: err}
+	envelopeData, mac := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(envelopeData)) {
+		return TokenError{reason: ErrTokenInvalid, cause: errors.New("signature mismatch")}
+	}
+
+	envelope := &eratov1.PageTokenEnvelope{}
+	if err = proto.Unmarshal(envelopeData, envelope); err != nil {
+		return TokenError{reason: ErrTokenInvalid, cause: err}
+	}
+	if err = protovalidate.Validate(envelope); err != nil {
+		return TokenError{reason: ErrTokenInvalid, cause: err}
+	}
+	if expireTime := envelope.GetExpireTime().AsTime(); !c.now().Before(expireTime) {
+		return TokenError{reason: ErrTokenExpired, cause: fmt.Errorf("expired at %v", expireTime)}
+	}
+	if !hmac.Equal(envelope.GetRequestDigest(), Digest(req)) {
+		return TokenError{reason: ErrTokenMismatch, cause: errors.New("request digest mismatch")}
+	}
+
+	if err = proto.Unmarshal(envelope.GetToken(), msg); err != nil {
+		return TokenError{reason: ErrTokenInvalid, cause: err}
 	}
 	if err = protovalidate.Validate(msg); err != nil {
-		return TokenError{cause: err}
+		return TokenError{reason: ErrTokenInvalid, cause: err}
 	}
 	return nil
 }
 
-// ToToken encodes a proto message into an opaque pagination token. Returns a
-// [TokenError] if validation or encoding fails.
-func ToToken(msg proto.Message) (string, error) {
+// ToToken encodes a proto message into an opaque pagination token for req.
+// Returns a [TokenError] if validation or encoding fails.
+func (c *Codec) ToToken(req Request, msg proto.Message) (string, error) {
 	if err := protovalidate.Validate(msg); err != nil {
-		return "", TokenError{cause: err}
+		return "", TokenError{reason: ErrTokenInvalid, cause: err}
 	}
 	data, err := proto.Marshal(msg)
 	if err != nil {
-		return "", TokenError{cause: err}
+		return "", TokenError{reason: ErrTokenInvalid, cause: err}
+	}
+	envelopeData, err := proto.Marshal(eratov1.PageTokenEnvelope_builder{
+		Token:         data,
+		RequestDigest: Digest(req),
+		ExpireTime:    timestamppb.New(c.now().Add(c.ttl)),
+	}.Build())
+	if err != nil {
+		return "", TokenError{reason: ErrTokenInvalid, cause: err}
 	}
-	return tokenEncoding.EncodeToString(data), nil
+	return tokenEncoding.EncodeToString(append(envelopeData, c.sign(envelopeData)...)), nil
+}
+
+func (c *Codec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Digest hashes the fields of req that must not change between pages, which
+// tokens issued for req are bound to.
+func Digest(req Request) []byte {
+	var parent, filter string
+	if parented, ok := req.(interface{ GetParent() string }); ok {
+		parent = parented.GetParent()
+	}
+	if filtered, ok := req.(interface{ GetFilter() string }); ok {
+		filter = filtered.GetFilter()
+	}
+	digest := sha256.New()
+	writeField(digest, parent)
+	writeField(digest, filter)
+	_ = binary.Write(digest, binary.BigEndian, req.GetMaxPageSize())
+	return digest.Sum(nil)
+}
+
+// writeField writes a length-prefixed field, so adjacent fields cannot be
+// confused for one another.
+func writeField(digest hash.Hash, field string) {
+	_ = binary.Write(digest, binary.BigEndian, uint64(len(field)))
+	_, _ = digest.Write([]byte(field))
 }
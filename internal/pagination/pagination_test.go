@@ -3,17 +3,40 @@ package pagination
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 )
 
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testCodec(t *testing.T) *Codec {
+	t.Helper()
+	codec, err := NewCodec(testKey, time.Hour)
+	require.NoError(t, err)
+	return codec
+}
+
+func TestNewCodec(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCodec(testKey[:MinKeySize-1], time.Hour)
+	require.Error(t, err, "short key")
+	_, err = NewCodec(testKey, 0)
+	require.Error(t, err, "zero ttl")
+}
+
 func TestToToken(t *testing.T) {
 	t.Parallel()
 
+	codec := testCodec(t)
+	req := eratov1.ListCategoriesRequest_builder{MaxPageSize: 10}.Build()
+
 	tests := []struct {
 		name    string
 		msg     proto.Message
@@ -36,7 +59,7 @@ func TestToToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			tkn, err := ToToken(tt.msg)
+			tkn, err := codec.ToToken(req, tt.msg)
 			if tt.wantErr {
 				var tokenErr TokenError
 				require.ErrorAs(t, err, &tokenErr)
@@ -52,48 +75,131 @@ func TestToToken(t *testing.T) {
 func TestFromToken(t *testing.T) {
 	t.Parallel()
 
-	// Create a valid token for testing
+	codec := testCodec(t)
+	req := eratov1.ListEntriesRequest_builder{
+		Parent:      "categories/foo",
+		Filter:      "this.starred",
+		MaxPageSize: 10,
+	}.Build()
+
 	validMsg := eratov1.ListCategoriesPaginationToken_builder{
 		AfterCategory: "categories/foo",
 	}.Build()
-	validToken, err := ToToken(validMsg)
+	validToken, err := codec.ToToken(req, validMsg)
 	require.NoError(t, err)
 
-	// Create a token with valid base64/proto but missing required fields
-	emptyMsg := &eratov1.ListCategoriesPaginationToken{}
-	emptyProtoBytes, err := proto.Marshal(emptyMsg)
+	// a token that is signed correctly but with a key other than the codec's
+	otherCodec, err := NewCodec([]byte("fedcba9876543210fedcba9876543210"), time.Hour)
 	require.NoError(t, err)
-	invalidValidationToken := tokenEncoding.EncodeToString(emptyProtoBytes)
+	otherKeyToken, err := otherCodec.ToToken(req, validMsg)
+	require.NoError(t, err)
+
+	// a token that expired an hour ago
+	expiredCodec := testCodec(t)
+	expiredCodec.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	expiredToken, err := expiredCodec.ToToken(req, validMsg)
+	require.NoError(t, err)
+
+	// an unsigned token, as issued before tokens were signed
+	unsignedData, err := proto.Marshal(validMsg)
+	require.NoError(t, err)
+	unsignedToken := tokenEncoding.EncodeToString(unsignedData)
+
+	// a correctly signed envelope around a message that fails validation
+	invalidData, err := proto.Marshal(eratov1.PageTokenEnvelope_builder{
+		Token:         []byte{},
+		RequestDigest: Digest(req),
+		ExpireTime:    timestamppb.New(time.Now().Add(time.Hour)),
+	}.Build())
+	require.NoError(t, err)
+	invalidToken := tokenEncoding.EncodeToString(append(invalidData, codec.sign(invalidData)...))
+
+	tampered, err := tokenEncoding.DecodeString(validToken)
+	require.NoError(t, err)
+	tampered[0] ^= 0xff
+	tamperedToken := tokenEncoding.EncodeToString(tampered)
 
 	tests := []struct {
-		name    string
-		token   string
-		wantErr bool
+		name   string
+		req    Request
+		token  string
+		reason error
 	}{
 		{
-			name:    "valid token",
-			token:   validToken,
-			wantErr: false,
+			name:  "valid token",
+			req:   req,
+			token: validToken,
 		},
 		{
-			name:    "empty token",
-			token:   "",
-			wantErr: true,
+			name:   "empty token",
+			req:    req,
+			token:  "",
+			reason: ErrTokenInvalid,
 		},
 		{
-			name:    "invalid base64",
-			token:   "not-valid-base64!!!",
-			wantErr: true,
+			name:   "invalid base64",
+			req:    req,
+			token:  "not-valid-base64!!!",
+			reason: ErrTokenInvalid,
 		},
 		{
-			name:    "valid base64 invalid proto",
-			token:   tokenEncoding.EncodeToString([]byte("not a protobuf")),
-			wantErr: true,
+			name:   "unsigned",
+			req:    req,
+			token:  unsignedToken,
+			reason: ErrTokenInvalid,
 		},
 		{
-			name:    "valid proto fails validation",
-			token:   invalidValidationToken,
-			wantErr: true,
+			name:   "tampered",
+			req:    req,
+			token:  tamperedToken,
+			reason: ErrTokenInvalid,
+		},
+		{
+			name:   "other key",
+			req:    req,
+			token:  otherKeyToken,
+			reason: ErrTokenInvalid,
+		},
+		{
+			name:   "fails validation",
+			req:    req,
+			token:  invalidToken,
+			reason: ErrTokenInvalid,
+		},
+		{
+			name:   "expired",
+			req:    req,
+			token:  expiredToken,
+			reason: ErrTokenExpired,
+		},
+		{
+			name: "parent changed",
+			req: eratov1.ListEntriesRequest_builder{
+				Parent:      "categories/bar",
+				Filter:      req.GetFilter(),
+				MaxPageSize: req.GetMaxPageSize(),
+			}.Build(),
+			token:  validToken,
+			reason: ErrTokenMismatch,
+		},
+		{
+			name: "filter changed",
+			req: eratov1.ListEntriesRequest_builder{
+				Parent:      req.GetParent(),
+				MaxPageSize: req.GetMaxPageSize(),
+			}.Build(),
+			token:  validToken,
+			reason: ErrTokenMismatch,
+		},
+		{
+			name: "page size changed",
+			req: eratov1.ListEntriesRequest_builder{
+				Parent:      req.GetParent(),
+				Filter:      req.GetFilter(),
+				MaxPageSize: 20,
+			}.Build(),
+			token:  validToken,
+			reason: ErrTokenMismatch,
 		},
 	}
 
@@ -101,10 +207,12 @@ func TestFromToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			out := &eratov1.ListCategoriesPaginationToken{}
-			err := FromToken(tt.token, out)
-			if tt.wantErr {
+			err := codec.FromToken(tt.req, tt.token, out)
+			if tt.reason != nil {
 				var tokenErr TokenError
 				require.ErrorAs(t, err, &tokenErr)
+				require.ErrorIs(t, err, tt.reason)
+				assert.EqualError(t, err, tt.reason.Error())
 			} else {
 				require.NoError(t, err)
 				assert.True(t, proto.Equal(validMsg, out))
@@ -116,15 +224,17 @@ func TestFromToken(t *testing.T) {
 func TestTokenRoundTrip(t *testing.T) {
 	t.Parallel()
 
+	codec := testCodec(t)
+	req := eratov1.ListCategoriesRequest_builder{MaxPageSize: 10}.Build()
 	msg := eratov1.ListCategoriesPaginationToken_builder{
 		AfterCategory: "categories/bar",
 	}.Build()
 
-	tkn, err := ToToken(msg)
+	tkn, err := codec.ToToken(req, msg)
 	require.NoError(t, err)
 
 	out := &eratov1.ListCategoriesPaginationToken{}
-	err = FromToken(tkn, out)
+	err = codec.FromToken(req, tkn, out)
 	require.NoError(t, err)
 
 	assert.True(t, proto.Equal(msg, out), "expected %v, got %v", msg, out)
@@ -133,7 +243,9 @@ func TestTokenRoundTrip(t *testing.T) {
 func TestTokenErrorMessage(t *testing.T) {
 	t.Parallel()
 
-	err := TokenError{cause: errors.New("underlying cause")}
-	assert.Equal(t, "invalid pagination token", err.Error())
+	err := TokenError{reason: ErrTokenExpired, cause: errors.New("underlying cause")}
+	assert.Equal(t, "pagination token has expired", err.Error())
+	require.ErrorIs(t, err, ErrTokenExpired)
+	assert.NotErrorIs(t, err, ErrTokenInvalid)
 	assert.EqualError(t, errors.Unwrap(err), "underlying cause")
 }
@@ -0,0 +1,158 @@
+package sec
+
+import (
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const (
+	// loginRecordInterval is how often the use of a credential presented on
+	// every request is recorded as a login.
+	loginRecordInterval = time.Hour
+
+	// maxRecordedCredentials bounds the number of credentials whose last
+	// recorded login is remembered.
+	maxRecordedCredentials = 4096
+)
+
+// Auditor records authentication attempts and user account changes to the
+// append-only audit log. The actor is the authenticated user of the context,
+// and the client is the one stored by [ClientMiddleware] or [WithClient].
+type Auditor struct {
+	store  storage.AuditEvents
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	logins *lru.Cache[[sha256.Size]byte, time.Time] // credential digests to their last recorded login
+}
+
+// NewAuditor creates an auditor writing to store. Events that cannot be
+// written are logged to logger.
+func NewAuditor(store storage.AuditEvents, logger *slog.Logger) *Auditor {
+	logins, _ := lru.New[[sha256.Size]byte, time.Time](maxRecordedCredentials) // only fails for a non-positive size
+	return &Auditor{
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+		logins: logins,
+	}
+}
+
+// Record appends an event for action on the subject user, which failed if err
+// is non-nil. The subject may only have a name if they do not exist. Failing
+// to write the event does not fail the action, so errors are logged rather
+// than returned. A nil Auditor records nothing.
+func (a *Auditor) Record(ctx context.Context, action eratov1.AuditEvent_Action, subject db.User, err error) {
+	a.record(ctx, action, subject, nil, err)
+}
+
+// RecordUpdate appends an [eratov1.AuditEvent_UPDATE_USER] event for the
+// fields changed on the subject user, which failed if err is non-nil.
+func (a *Auditor) RecordUpdate(ctx context.Context, subject db.User, fields []string, err error) {
+	a.record(ctx, eratov1.AuditEvent_UPDATE_USER, subject, fields, err)
+}
+
+// RecordLogin appends a successful [eratov1.AuditEvent_LOGIN] event for the
+// user authenticated by credential, such as an access token or Basic Auth
+// header. Those credentials are presented on every request and establish no
+// session, so each is only recorded the first time it is used every
+// [loginRecordInterval], as if that were its session.
+func (a *Auditor) RecordLogin(ctx context.Context, credential string, user db.User) {
+	if a == nil {
+		return
+	}
+	key, now := sha256.Sum256([]byte(credential)), a.now()
+	a.mu.Lock()
+	last, ok := a.logins.Get(key)
+	recorded := ok && now.Sub(last) < loginRecordInterval
+	if !recorded {
+		a.logins.Add(key, now)
+	}
+	a.mu.Unlock()
+	if !recorded {
+		a.record(SetAuthenticatedUser(ctx, user), eratov1.AuditEvent_LOGIN, user, nil, nil)
+	}
+}
+
+func (a *Auditor) record(
+	ctx context.Context,
+	action eratov1.AuditEvent_Action,
+	subject db.User,
+	fields []string,
+	err error,
+) {
+	if a == nil {
+		return
+	}
+	outcome := eratov1.AuditEvent_SUCCESS
+	if err != nil {
+		outcome = eratov1.AuditEvent_FAILURE
+	}
+	client, _ := ctx.Value(clientKey{}).(clientInfo)
+	actor := GetAuthenticatedUser(ctx)
+	event := db.AuditEvent{
+		CreateTime: a.now().UTC(),
+		Actor:      actor.Name,
+		ActorID:    actor.ID,
+		Subject:    subject.Name,
+		SubjectID:  subject.ID,
+		Action:     int64(action),
+		Outcome:    int64(outcome),
+		Fields:     strings.Join(fields, ","),
+		IpAddress:  client.addr,
+		UserAgent:  client.userAgent,
+	}
+	// the action already happened, so record it even if the request is canceled
+	if err := a.store.CreateAuditEvent(context.WithoutCancel(ctx), event); err != nil {
+		a.logger.ErrorContext(ctx, "failed to write audit event",
+			slog.String("action", action.String()),
+			slog.String("subject", subject.Name),
+			slog.String("outcome", outcome.String()),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// clientKey is the context key for the [clientInfo] of a request.
+type clientKey struct{}
+
+// clientInfo identifies the client making a request in the audit log.
+type clientInfo struct {
+	addr      string
+	userAgent string
+}
+
+// WithClient stores the client's address and User-Agent in ctx for the
+// [Auditor]. A port on addr is removed.
+func WithClient(ctx context.Context, addr, userAgent string) context.Context {
+	if addrPort, err := netip.ParseAddrPort(addr); err == nil {
+		addr = addrPort.Addr().Unmap().String()
+	} else if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return context.WithValue(ctx, clientKey{}, clientInfo{addr: addr, userAgent: userAgent})
+}
+
+// ClientMiddleware stores the remote address and User-Agent of each request
+// in its context for the [Auditor]. It must wrap any handler that records
+// audit events, including the authentication middleware.
+func ClientMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := WithClient(req.Context(), req.RemoteAddr, req.UserAgent())
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,173 @@
+package sec
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestAuditor(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(t *testing.T) *storage.DB {
+		t.Helper()
+		cfg := eratov1.Config_builder{
+			DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+		}.Build()
+		store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+
+	t.Run("records actor and client", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		audit := NewAuditor(store, slog.Default())
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = "[::ffff:192.0.2.1]:1234"
+		req.Header.Set("User-Agent", "test-agent")
+		ClientMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			ctx := SetAuthenticatedUser(req.Context(), db.User{ID: 1, Name: "admin"})
+			audit.Record(ctx, eratov1.AuditEvent_DELETE_USER, db.User{ID: 2, Name: "alice"}, nil)
+			audit.Record(ctx, eratov1.AuditEvent_DELETE_USER, db.User{Name: "bob"}, errors.New("oops"))
+		})).ServeHTTP(httptest.NewRecorder(), req)
+
+		events, err := store.ListAuditEvents(t.Context(), storage.AuditEventFilter{}, math.MaxInt64, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, "bob", events[0].Subject, "newest first")
+		assert.Zero(t, events[0].SubjectID)
+		assert.Equal(t, int64(eratov1.AuditEvent_FAILURE), events[0].Outcome)
+
+		event := events[1]
+		assert.Equal(t, "admin", event.Actor)
+		assert.Equal(t, uint64(1), event.ActorID)
+		assert.Equal(t, "alice", event.Subject)
+		assert.Equal(t, uint64(2), event.SubjectID)
+		assert.Equal(t, int64(eratov1.AuditEvent_DELETE_USER), event.Action)
+		assert.Equal(t, int64(eratov1.AuditEvent_SUCCESS), event.Outcome)
+		assert.Equal(t, "192.0.2.1", event.IpAddress)
+		assert.Equal(t, "test-agent", event.UserAgent)
+		assert.False(t, event.CreateTime.IsZero())
+	})
+
+	t.Run("records rejected credentials", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		audit := NewAuditor(store, slog.Default())
+
+		authenticate := func(authorization string) {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", authorization)
+			_, _, err = Authenticate(t.Context(), req, store, nil, audit, nil, nil)
+			require.Error(t, err)
+		}
+		authenticate("")
+		authenticate("Bearer " + AccessTokenPrefix + "unknown")
+		authenticate(basicAuth(t, "mallory", "guess"))
+
+		events, err := store.ListAuditEvents(t.Context(), storage.AuditEventFilter{}, math.MaxInt64, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2, "requests without credentials are not recorded")
+		assert.Equal(t, "mallory", events[0].Subject)
+		assert.Empty(t, events[1].Subject)
+		for _, event := range events {
+			assert.Equal(t, int64(eratov1.AuditEvent_LOGIN), event.Action)
+			assert.Equal(t, int64(eratov1.AuditEvent_FAILURE), event.Outcome)
+		}
+	})
+
+	t.Run("records logins once per interval", func(t *testing.T) {
+		t.Parallel()
+		store := newStore(t)
+		audit := NewAuditor(store, slog.Default())
+		now := time.Now()
+		audit.now = func() time.Time { return now }
+
+		hash, err := HashPassword("password")
+		require.NoError(t, err)
+		require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: "alice", PasswordHash: hash}))
+		authenticate := func(authorization string) {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", authorization)
+			_, _, err = Authenticate(t.Context(), req, store, nil, audit, nil, nil)
+			require.NoError(t, err)
+		}
+		count := func() int {
+			events, err := store.ListAuditEvents(t.Context(), storage.AuditEventFilter{
+				Action:  int64(eratov1.AuditEvent_LOGIN),
+				Outcome: int64(eratov1.AuditEvent_SUCCESS),
+			}, math.MaxInt64, 10)
+			require.NoError(t, err)
+			return len(events)
+		}
+
+		authenticate(basicAuth(t, "alice", "password"))
+		authenticate(basicAuth(t, "alice", "password"))
+		assert.Equal(t, 1, count(), "repeated credentials are recorded once")
+
+		user, err := store.GetUserByName(t.Context(), "alice")
+		require.NoError(t, err)
+		audit.RecordLogin(t.Context(), "other", user)
+		assert.Equal(t, 2, count(), "other credentials are recorded")
+
+		now = now.Add(loginRecordInterval)
+		authenticate(basicAuth(t, "alice", "password"))
+		assert.Equal(t, 3, count(), "credentials are recorded again after the interval")
+
+		events, err := store.ListAuditEvents(t.Context(), storage.AuditEventFilter{}, math.MaxInt64, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, user.ID, events[0].ActorID)
+		assert.Equal(t, user.ID, events[0].SubjectID)
+		assert.Equal(t, "alice", events[0].ActorName)
+	})
+
+	t.Run("nil auditor", func(t *testing.T) {
+		t.Parallel()
+		var audit *Auditor
+		audit.Record(t.Context(), eratov1.AuditEvent_LOGIN, db.User{Name: "alice"}, nil)
+		audit.RecordLogin(t.Context(), "credential", db.User{Name: "alice"})
+	})
+}
+
+func TestWithClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{"192.0.2.1:1234", "192.0.2.1"},
+		{"[2001:db8::1]:1234", "2001:db8::1"},
+		{"localhost:1234", "localhost"},
+		{"192.0.2.1", "192.0.2.1"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr, func(t *testing.T) {
+			t.Parallel()
+			ctx := WithClient(t.Context(), test.addr, "agent")
+			client, ok := ctx.Value(clientKey{}).(clientInfo)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, client.addr)
+		})
+	}
+}
@@ -2,51 +2,164 @@ package sec
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
 
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
 	"github.com/stolasapp/erato/internal/storage"
 	"github.com/stolasapp/erato/internal/storage/db"
 )
 
-// Authenticate resolves the logged in user from req. If the information is
-// invalid, a ConnectRPC error is returned.
-func Authenticate(ctx context.Context, req *http.Request, store storage.Users) (user db.User, err error) {
+// Authenticate resolves the logged in user from req. Requests from a trusted
+// reverse proxy are identified by its header if proxy is non-nil. Otherwise,
+// either a Bearer token or Basic Auth credentials are used. Bearer tokens are
+// personal access tokens, or ID tokens from the provider if oidc is non-nil.
+// Basic Auth attempts are limited by throttle.
+// The returned scope limits the RPCs the request may call; all but access
+// tokens grant the full [eratov1.AccessToken_USER_ADMIN] scope. If the
+// information is invalid, a ConnectRPC error is returned.
+// Rejected credentials are recorded by audit, as are accepted ones the first
+// time they are used each hour (see [Auditor.RecordLogin]), since they are
+// presented on every request.
+func Authenticate(
+	ctx context.Context,
+	req *http.Request,
+	store AuthStore,
+	throttle *Throttle,
+	audit *Auditor,
+	proxy *ProxyAuth,
+	oidc *OIDC,
+) (user db.User, scope eratov1.AccessToken_Scope, err error) {
+	subject, credential := db.User{}, req.Header.Get("Authorization")
+	defer func() {
+		if err != nil && credential != "" {
+			audit.Record(ctx, eratov1.AuditEvent_LOGIN, subject, err)
+		} else if err == nil {
+			audit.RecordLogin(ctx, credential, user)
+		}
+	}()
+	if proxy != nil {
+		if user, ok, err := proxy.Authenticate(ctx, req, store); ok || err != nil {
+			credential = proxy.Credential(req)
+			return user, eratov1.AccessToken_USER_ADMIN, err
+		}
+	}
+	if token, ok := bearerToken(req); ok {
+		if oidc != nil && !strings.HasPrefix(token, AccessTokenPrefix) {
+			user, err = oidc.AuthenticateIDToken(ctx, store, token)
+			return user, eratov1.AccessToken_USER_ADMIN, err
+		}
+		return AuthenticateAccessToken(ctx, store, token)
+	}
 	username, password, ok := req.BasicAuth()
 	if !ok {
-		return user, authn.Errorf("invalid authorization header")
+		// no credentials were presented, so there is nothing to audit
+		credential = ""
+		return user, scope, authn.Errorf("invalid authorization header")
 	}
+	subject = db.User{Name: username}
+	user, err = throttle.VerifyCredentials(ctx, store, req.RemoteAddr, username, password)
+	return user, eratov1.AccessToken_USER_ADMIN, err
+}
+
+// VerifyCredentials resolves the user with the given username and password.
+// If either is invalid, a ConnectRPC error is returned. Passwords hashed with
+// an outdated algorithm or parameters are transparently rehashed.
+func VerifyCredentials(ctx context.Context, store storage.Users, username, password string) (user db.User, err error) {
 	if user, err = store.GetUserByName(ctx, username); err != nil {
 		return user, authn.Errorf("invalid username or password")
 	}
 	if err = ComparePassword(password, user.PasswordHash); err != nil {
 		return user, authn.Errorf("invalid username or password")
 	}
+	if NeedsRehash(user.PasswordHash) {
+		rehashed := user
+		if rehashed.PasswordHash, err = HashPassword(password); err != nil {
+			return user, err
+		}
+		// a conflicting update wins; the rehash is retried on the next login
+		if err = store.UpsertUser(ctx, rehashed); err == nil {
+			rehashed.Version++
+			user = rehashed
+		} else if !errors.Is(err, storage.ErrConflict) {
+			return user, err
+		}
+	}
 	return user, nil
 }
 
-// NewConnectAuthMiddleware returns a new authentication middleware for ConnectRPC.
-func NewConnectAuthMiddleware(store storage.Users, opts ...connect.HandlerOption) *authn.Middleware {
+// NewConnectAuthMiddleware returns a new authentication middleware for
+// ConnectRPC. The throttle, audit and optional proxy and oidc authenticators
+// are passed to [Authenticate].
+func NewConnectAuthMiddleware(
+	store AuthStore,
+	throttle *Throttle,
+	audit *Auditor,
+	proxy *ProxyAuth,
+	oidc *OIDC,
+	opts ...connect.HandlerOption,
+) *authn.Middleware {
 	return authn.NewMiddleware(func(ctx context.Context, req *http.Request) (any, error) {
-		return Authenticate(ctx, req, store)
+		user, scope, err := Authenticate(ctx, req, store, throttle, audit, proxy, oidc)
+		if err != nil {
+			return nil, err
+		}
+		return identity{user: user, scope: scope}, nil
 	}, opts...)
 }
 
+// identity is the information stored in the context of an authenticated
+// request.
+type identity struct {
+	user  db.User
+	scope eratov1.AccessToken_Scope
+}
+
 // GetAuthenticatedUser returns the user information for the authenticated user.
 // Returns a zero-value User if the context has no authenticated user or if
-// the stored value is not a User (should only happen if middleware is misconfigured).
+// the stored value is not an identity (should only happen if middleware is
+// misconfigured).
 func GetAuthenticatedUser(ctx context.Context) db.User {
-	if user, ok := authn.GetInfo(ctx).(db.User); ok {
-		return user
+	if id, ok := authn.GetInfo(ctx).(identity); ok {
+		return id.user
 	}
 	return db.User{}
 }
 
-// SetAuthenticatedUser sets the user information for an authenticated user. The
+// GetAuthenticatedScope returns the access token scope of the authenticated
+// request. Returns [eratov1.AccessToken_SCOPE_UNSPECIFIED] if the context has
+// no authenticated user.
+func GetAuthenticatedScope(ctx context.Context) eratov1.AccessToken_Scope {
+	if id, ok := authn.GetInfo(ctx).(identity); ok {
+		return id.scope
+	}
+	return eratov1.AccessToken_SCOPE_UNSPECIFIED
+}
+
+// SetAuthenticatedUser sets the user information for an authenticated user,
+// granting the full [eratov1.AccessToken_USER_ADMIN] scope. The
 // authn.Middleware automatically injects this information; this function is
-// provided as a convenience for testing.
+// provided for authentication outside of ConnectRPC and for testing.
 func SetAuthenticatedUser(ctx context.Context, user db.User) context.Context {
-	return authn.SetInfo(ctx, user)
+	return SetAuthenticatedToken(ctx, user, eratov1.AccessToken_USER_ADMIN)
+}
+
+// SetAuthenticatedToken is like [SetAuthenticatedUser], but limits the request
+// to the given access token scope.
+func SetAuthenticatedToken(ctx context.Context, user db.User, scope eratov1.AccessToken_Scope) context.Context {
+	return authn.SetInfo(ctx, identity{user: user, scope: scope})
+}
+
+// bearerToken returns the token from the Authorization header of req if it
+// uses the Bearer scheme.
+func bearerToken(req *http.Request) (token string, ok bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return token, token != ""
 }
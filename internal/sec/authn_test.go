@@ -0,0 +1,63 @@
+package sec
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	t.Run("rehashes bcrypt on login", func(t *testing.T) {
+		t.Parallel()
+		hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+		require.NoError(t, err)
+		require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: "legacy", PasswordHash: hash}))
+
+		_, err = VerifyCredentials(t.Context(), store, "legacy", "wrong")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		stored, err := store.GetUserByName(t.Context(), "legacy")
+		require.NoError(t, err)
+		assert.Equal(t, hash, stored.PasswordHash, "failed logins do not rehash")
+
+		user, err := VerifyCredentials(t.Context(), store, "legacy", "password")
+		require.NoError(t, err)
+		assert.False(t, NeedsRehash(user.PasswordHash))
+
+		stored, err = store.GetUserByName(t.Context(), "legacy")
+		require.NoError(t, err)
+		assert.Equal(t, user, stored)
+		assert.False(t, NeedsRehash(stored.PasswordHash))
+
+		_, err = VerifyCredentials(t.Context(), store, "legacy", "password")
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps current hash", func(t *testing.T) {
+		t.Parallel()
+		hash, err := HashPassword("password")
+		require.NoError(t, err)
+		require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: "current", PasswordHash: hash}))
+
+		user, err := VerifyCredentials(t.Context(), store, "current", "password")
+		require.NoError(t, err)
+		assert.Equal(t, hash, user.PasswordHash)
+	})
+}
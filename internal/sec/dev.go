@@ -0,0 +1,99 @@
+package sec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// DefaultDevUser is the user seeded in dev mode if none are configured.
+const DefaultDevUser = "dev"
+
+const (
+	// DevUserHeader is the request header naming the seeded user an RPC
+	// request impersonates in dev mode.
+	DevUserHeader = "X-Erato-Dev-User"
+
+	// DevUserCookieName is the name of the cookie holding the seeded user the
+	// web app impersonates in dev mode.
+	DevUserCookieName = "erato_dev_user"
+)
+
+// DevAuth authenticates every request as one of the users seeded at startup,
+// so per-user features work in dev mode without logging in. The first user is
+// an admin and is used by default; requests may impersonate any of the others
+// by naming them in the [DevUserHeader] header or [DevUserCookieName] cookie.
+type DevAuth struct {
+	store storage.Users
+	names []string
+}
+
+// NewDevAuth seeds the dev users configured in cfg, creating those that do
+// not already exist. Seeded users have no password.
+func NewDevAuth(ctx context.Context, cfg *eratov1.Config, store storage.Users) (*DevAuth, error) {
+	names := cfg.GetDevUsers()
+	if len(names) == 0 {
+		names = []string{DefaultDevUser}
+	}
+	for i, name := range names {
+		role := db.RoleMember
+		if i == 0 {
+			role = db.RoleAdmin
+		}
+		_, err := store.GetUserByName(ctx, name)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = store.UpsertUser(ctx, db.User{Name: name, PasswordHash: []byte{}, Role: role})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed dev user %q: %w", name, err)
+		}
+	}
+	return &DevAuth{store: store, names: slices.Clone(names)}, nil
+}
+
+// Users returns the names of the seeded users, starting with the default.
+func (d *DevAuth) Users() []string {
+	return slices.Clone(d.names)
+}
+
+// Middleware authenticates each request as the seeded user it names, falling
+// back to the default user if it names none or one that was not seeded.
+func (d *DevAuth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		name := req.Header.Get(DevUserHeader)
+		if cookie, err := req.Cookie(DevUserCookieName); name == "" && err == nil {
+			name = cookie.Value
+		}
+		if !slices.Contains(d.names, name) {
+			name = d.names[0]
+		}
+
+		ctx := req.Context()
+		user, err := d.store.GetUserByName(ctx, name)
+		if err != nil {
+			// renamed or deleted since startup; restarting seeds them again
+			http.Error(w, fmt.Sprintf("dev user %q not found: %v", name, err), http.StatusInternalServerError)
+			return
+		}
+		ctx = context.WithValue(SetAuthenticatedUser(ctx, user), devUsersKey{}, d.names)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// devUsersKey is the context key holding the names of the seeded users in
+// dev mode.
+type devUsersKey struct{}
+
+// GetDevUsers returns the names of the seeded users a request may
+// impersonate in dev mode, starting with the default. Returns nil outside of
+// dev mode.
+func GetDevUsers(ctx context.Context) []string {
+	names, _ := ctx.Value(devUsersKey{}).([]string)
+	return names
+}
@@ -0,0 +1,93 @@
+package sec
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestDevAuth(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+		DevMode:    true,
+		DevUsers:   []string{"alice", "bob", "carol"},
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	// existing users are reused as is
+	require.NoError(t, store.UpsertUser(t.Context(), db.User{ID: 123, Name: "carol", PasswordHash: []byte{}, Role: db.RoleAdmin}))
+
+	dev, err := NewDevAuth(t.Context(), cfg, store)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, dev.Users())
+
+	// seeding again reuses the users created the first time
+	_, err = NewDevAuth(t.Context(), cfg, store)
+	require.NoError(t, err)
+	users, err := store.ListUsers(t.Context(), "", 10)
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	tests := []struct {
+		name     string
+		header   string
+		cookie   string
+		expected string
+		role     string
+	}{
+		{name: "default", expected: "alice", role: db.RoleAdmin},
+		{name: "header", header: "bob", expected: "bob", role: db.RoleMember},
+		{name: "cookie", cookie: "carol", expected: "carol", role: db.RoleAdmin},
+		{name: "header over cookie", header: "bob", cookie: "carol", expected: "bob", role: db.RoleMember},
+		{name: "not seeded", header: "mallory", expected: "alice", role: db.RoleAdmin},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", http.NoBody)
+			if test.header != "" {
+				req.Header.Set(DevUserHeader, test.header)
+			}
+			if test.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: DevUserCookieName, Value: test.cookie})
+			}
+			var user db.User
+			var devUsers []string
+			dev.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				user = GetAuthenticatedUser(req.Context())
+				devUsers = GetDevUsers(req.Context())
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, test.expected, user.Name)
+			assert.Equal(t, test.role, user.Role)
+			assert.Equal(t, dev.Users(), devUsers)
+		})
+	}
+
+	t.Run("default user", func(t *testing.T) {
+		t.Parallel()
+		cfg := eratov1.Config_builder{DevMode: true}.Build()
+		dev, err := NewDevAuth(t.Context(), cfg, store)
+		require.NoError(t, err)
+		assert.Equal(t, []string{DefaultDevUser}, dev.Users())
+	})
+
+	t.Run("not dev mode", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, GetDevUsers(t.Context()))
+	})
+}
@@ -0,0 +1,25 @@
+package sec
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// DefaultInviteTTL is how long invites are valid for unless an expiry is
+// given when they are created.
+const DefaultInviteTTL = 7 * 24 * time.Hour
+
+// NewInviteCode generates a new invite code, returning the code to give to
+// the invitee and the hash to store.
+func NewInviteCode() (code string, hash []byte) {
+	code = rand.Text()
+	return code, HashInviteCode(code)
+}
+
+// HashInviteCode returns the hash an invite code is stored by. Codes are
+// likely to be typed or pasted by hand, so surrounding whitespace and case are
+// ignored.
+func HashInviteCode(code string) []byte {
+	return hashToken(strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,175 @@
+package sec
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/authn"
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// ErrOIDCLogin is returned when an OpenID Connect login cannot be completed,
+// such as when the provider rejects the login or its ID token is invalid.
+var ErrOIDCLogin = errors.New("OpenID Connect login failed")
+
+// defaultUsernameClaim is the ID token claim naming provisioned users if none
+// is configured.
+const defaultUsernameClaim = "sub"
+
+// defaultOIDCScopes are the scopes requested at login if none are configured.
+var defaultOIDCScopes = []string{gooidc.ScopeOpenID, "profile"}
+
+// OIDC authenticates users with an OpenID Connect identity provider. The web
+// app uses the authorization code flow with PKCE, while RPC clients present
+// ID tokens issued to the same client as Bearer tokens. Users are identified
+// by the issuer and subject of their ID tokens, and are provisioned on their
+// first login, named by the configured username claim.
+type OIDC struct {
+	oauth         oauth2.Config
+	usernameClaim string
+	client        *http.Client
+	verifier      *gooidc.IDTokenVerifier
+}
+
+// OIDCLogin is the state of an in-progress login, which must be kept by the
+// browser until the provider returns the user to the web app.
+type OIDCLogin struct {
+	State    string // binds the callback to the browser that started the login
+	Verifier string // the PKCE code verifier
+	Nonce    string // binds the ID token to the login
+}
+
+// NewOIDC discovers the configuration of the provider in cfg. Requests to the
+// provider are made with client.
+func NewOIDC(ctx context.Context, cfg *eratov1.Config_Oidc, client *http.Client) (*OIDC, error) {
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(ctx, client), cfg.GetIssuer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenID Connect provider: %w", err)
+	}
+
+	scopes := cfg.GetScopes()
+	if len(scopes) == 0 {
+		scopes = defaultOIDCScopes
+	}
+	usernameClaim := cfg.GetUsernameClaim()
+	if usernameClaim == "" {
+		usernameClaim = defaultUsernameClaim
+	}
+	return &OIDC{
+		oauth: oauth2.Config{
+			ClientID:     cfg.GetClientId(),
+			ClientSecret: cfg.GetClientSecret(),
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  cfg.GetRedirectUrl(),
+			Scopes:       scopes,
+		},
+		usernameClaim: usernameClaim,
+		client:        client,
+		verifier:      provider.Verifier(&gooidc.Config{ClientID: cfg.GetClientId()}),
+	}, nil
+}
+
+// StartLogin begins a login, returning its state and the provider URL to send
+// the user to.
+func (o *OIDC) StartLogin() (login OIDCLogin, authURL string) {
+	login = OIDCLogin{
+		State:    rand.Text(),
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    rand.Text(),
+	}
+	authURL = o.oauth.AuthCodeURL(login.State,
+		oauth2.S256ChallengeOption(login.Verifier),
+		gooidc.Nonce(login.Nonce),
+	)
+	return login, authURL
+}
+
+// FinishLogin completes a login once the provider returns the user with the
+// given state and authorization code, resolving the user from the ID token.
+// An [ErrOIDCLogin] is returned if the login is not valid.
+func (o *OIDC) FinishLogin(
+	ctx context.Context,
+	store IdentityStore,
+	login OIDCLogin,
+	state, code string,
+) (user db.User, err error) {
+	if login.State == "" || subtle.ConstantTimeCompare([]byte(login.State), []byte(state)) != 1 {
+		return user, fmt.Errorf("%w: state mismatch", ErrOIDCLogin)
+	}
+
+	token, err := o.oauth.Exchange(
+		context.WithValue(ctx, oauth2.HTTPClient, o.client),
+		code,
+		oauth2.VerifierOption(login.Verifier),
+	)
+	if err != nil {
+		return user, fmt.Errorf("%w: %w", ErrOIDCLogin, err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return user, fmt.Errorf("%w: no ID token in response", ErrOIDCLogin)
+	}
+
+	idToken, err := o.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return user, fmt.Errorf("%w: %w", ErrOIDCLogin, err)
+	} else if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(login.Nonce)) != 1 {
+		return user, fmt.Errorf("%w: nonce mismatch", ErrOIDCLogin)
+	}
+	return o.provision(ctx, store, idToken)
+}
+
+// AuthenticateIDToken resolves the user from an ID token presented by an RPC
+// client. If the token is invalid, a ConnectRPC error is returned.
+func (o *OIDC) AuthenticateIDToken(ctx context.Context, store IdentityStore, rawIDToken string) (db.User, error) {
+	idToken, err := o.verifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return db.User{}, authn.Errorf("invalid ID token")
+	}
+	user, err := o.provision(ctx, store, idToken)
+	if errors.Is(err, ErrOIDCLogin) {
+		return user, authn.Errorf("invalid ID token")
+	}
+	return user, err
+}
+
+// verifyIDToken checks that the ID token was signed by the provider for this
+// client and is currently valid.
+func (o *OIDC) verifyIDToken(ctx context.Context, raw string) (*gooidc.IDToken, error) {
+	return o.verifier.Verify(gooidc.ClientContext(ctx, o.client), raw)
+}
+
+// provision returns the user linked to the token's subject, creating them,
+// named by the username claim, on their first login. Subjects are never linked
+// to existing users automatically, as the claim may name a different person;
+// an admin must link them instead. An [ErrOIDCLogin] is returned if the user
+// cannot be provisioned.
+func (o *OIDC) provision(ctx context.Context, store IdentityStore, idToken *gooidc.IDToken) (db.User, error) {
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return db.User{}, fmt.Errorf("%w: %w", ErrOIDCLogin, err)
+	}
+	claim, _ := claims[o.usernameClaim].(string)
+	if claim == "" {
+		return db.User{}, fmt.Errorf("%w: missing %q claim", ErrOIDCLogin, o.usernameClaim)
+	}
+
+	user, err := provisionIdentity(ctx, store, idToken.Issuer, idToken.Subject, toUsername(claim))
+	switch {
+	case errors.Is(err, storage.ErrInvalidUsername):
+		return user, fmt.Errorf("%w: %q claim is not a valid username: %w", ErrOIDCLogin, o.usernameClaim, err)
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return user, fmt.Errorf("%w: user %q is not linked to subject %q; an admin must link them",
+			ErrOIDCLogin, toUsername(claim), idToken.Subject)
+	}
+	return user, err
+}
@@ -0,0 +1,216 @@
+package sec
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec/oidctest"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestOIDC(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	existing := db.User{ID: 123, Name: "existing", PasswordHash: []byte{}, Role: db.RoleAdmin}
+	require.NoError(t, store.UpsertUser(t.Context(), existing))
+
+	provider := oidctest.NewProvider(t, map[string]any{"sub": "sso_user"})
+	oidc, err := NewOIDC(t.Context(), provider.Config("https://erato.test/login/oidc/callback"), provider.Client())
+	require.NoError(t, err)
+	require.NoError(t, store.LinkIdentity(t.Context(), existing.ID, provider.URL, "linked"))
+
+	other := oidctest.NewProvider(t, nil)
+
+	t.Run("ID tokens", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			token string
+			user  string
+		}{
+			{"provisions user", provider.IDToken(t, map[string]any{"sub": "new_user"}), "new_user"},
+			{"linked user", provider.IDToken(t, map[string]any{"sub": "linked"}), "existing"},
+			{"sanitizes username", provider.IDToken(t, map[string]any{"sub": "b8a2-41f0"}), "b8a2_41f0"},
+			{"existing username", provider.IDToken(t, map[string]any{"sub": "existing"}), ""},
+			{"audience list", provider.IDToken(t, map[string]any{
+				"sub": "linked",
+				"aud": []string{"another", oidctest.ClientID},
+			}), "existing"},
+			{"wrong audience", provider.IDToken(t, map[string]any{
+				"sub": "linked",
+				"aud": "another",
+			}), ""},
+			{"wrong issuer", provider.IDToken(t, map[string]any{
+				"sub": "linked",
+				"iss": other.URL,
+			}), ""},
+			{"expired", provider.IDToken(t, map[string]any{
+				"sub": "linked",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			}), ""},
+			{"not yet valid", provider.IDToken(t, map[string]any{
+				"sub": "linked",
+				"nbf": time.Now().Add(time.Hour).Unix(),
+			}), ""},
+			{"invalid username", provider.IDToken(t, map[string]any{"sub": "x"}), ""},
+			{"untrusted signer", other.IDToken(t, map[string]any{
+				"sub": "linked",
+				"iss": provider.URL,
+			}), ""},
+			{"malformed", "not.a.jwt", ""},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+
+				user, err := oidc.AuthenticateIDToken(t.Context(), store, test.token)
+				if test.user == "" {
+					assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, test.user, user.Name)
+				assert.NotZero(t, user.ID)
+			})
+		}
+	})
+
+	t.Run("provisioned users", func(t *testing.T) {
+		t.Parallel()
+
+		token := provider.IDToken(t, map[string]any{"sub": "provisioned"})
+		first, err := oidc.AuthenticateIDToken(t.Context(), store, token)
+		require.NoError(t, err)
+		assert.Equal(t, db.RoleMember, first.Role)
+		require.Error(t, ComparePassword("", first.PasswordHash), "provisioned users cannot use passwords")
+
+		second, err := oidc.AuthenticateIDToken(t.Context(), store, token)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+
+		// users are bound to their subject, not to the name they were given
+		first.Name = "renamed"
+		require.NoError(t, store.UpsertUser(t.Context(), first))
+		renamed, err := oidc.AuthenticateIDToken(t.Context(), store, token)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, renamed.ID)
+		assert.Equal(t, "renamed", renamed.Name)
+	})
+
+	t.Run("username claim", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := provider.Config("https://erato.test/login/oidc/callback")
+		cfg.SetUsernameClaim("preferred_username")
+		named, err := NewOIDC(t.Context(), cfg, provider.Client())
+		require.NoError(t, err)
+
+		user, err := named.AuthenticateIDToken(t.Context(), store, provider.IDToken(t, map[string]any{
+			"preferred_username": "named",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "named", user.Name)
+
+		_, err = named.AuthenticateIDToken(t.Context(), store, provider.IDToken(t, map[string]any{
+			"preferred_username": existing.Name,
+		}))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "claims cannot take over existing users")
+
+		_, err = named.AuthenticateIDToken(t.Context(), store, provider.IDToken(t, nil))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "missing claim")
+	})
+
+	t.Run("bearer authentication", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+provider.IDToken(t, map[string]any{"sub": "linked"}))
+
+		user, scope, err := Authenticate(t.Context(), req, store, nil, nil, nil, oidc)
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, user.ID)
+		assert.Equal(t, eratov1.AccessToken_USER_ADMIN, scope)
+
+		_, _, err = Authenticate(t.Context(), req, store, nil, nil, nil, nil)
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "ID tokens require OIDC")
+	})
+
+	t.Run("login", func(t *testing.T) {
+		t.Parallel()
+
+		login, authURL := oidc.StartLogin()
+		callback := authorize(t, provider, authURL)
+		assert.Equal(t, login.State, callback.Get("state"))
+
+		_, err := oidc.FinishLogin(t.Context(), store, login, "forged", callback.Get("code"))
+		require.ErrorIs(t, err, ErrOIDCLogin)
+
+		wrongVerifier := login
+		wrongVerifier.Verifier = "wrong"
+		_, err = oidc.FinishLogin(t.Context(), store, wrongVerifier, callback.Get("state"), callback.Get("code"))
+		require.ErrorIs(t, err, ErrOIDCLogin)
+
+		// codes are single use, so a new login is required
+		login, authURL = oidc.StartLogin()
+		callback = authorize(t, provider, authURL)
+		wrongNonce := login
+		wrongNonce.Nonce = "wrong"
+		_, err = oidc.FinishLogin(t.Context(), store, wrongNonce, callback.Get("state"), callback.Get("code"))
+		require.ErrorIs(t, err, ErrOIDCLogin)
+
+		login, authURL = oidc.StartLogin()
+		callback = authorize(t, provider, authURL)
+		user, err := oidc.FinishLogin(t.Context(), store, login, callback.Get("state"), callback.Get("code"))
+		require.NoError(t, err)
+		assert.Equal(t, "sso_user", user.Name)
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := provider.Config("https://erato.test/login/oidc/callback")
+		cfg.SetIssuer(provider.URL + "/")
+		_, err := NewOIDC(t.Context(), cfg, provider.Client())
+		require.Error(t, err)
+	})
+}
+
+// authorize sends the user to the provider's authorization URL, returning the
+// query parameters of the callback the provider redirects them to.
+func authorize(t *testing.T, provider *oidctest.Provider, authURL string) url.Values {
+	t.Helper()
+	client := *provider.Client() // copied, as the client is shared
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, authURL, http.NoBody)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusFound, res.StatusCode)
+
+	location, err := res.Location()
+	require.NoError(t, err)
+	return location.Query()
+}
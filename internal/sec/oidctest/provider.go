@@ -0,0 +1,241 @@
+// Package oidctest provides an in-process OpenID Connect provider for testing
+// logins without network access.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+)
+
+const (
+	// ClientID is the only client registered with the provider.
+	ClientID = "erato"
+
+	// ClientSecret is the secret of the registered client.
+	ClientSecret = "erato-secret"
+
+	keyID = "test-key"
+
+	// tokenLifetime is how long the issued ID tokens are valid.
+	tokenLifetime = time.Hour
+)
+
+// Provider is an OpenID Connect provider served over TLS. Logins are approved
+// without prompting, issuing ID tokens with the configured claims. Use the
+// server's Client to make requests to it.
+type Provider struct {
+	*httptest.Server
+
+	key *rsa.PrivateKey
+
+	claims map[string]any
+
+	mu     sync.Mutex
+	grants map[string]grant // authorization codes to the logins they grant
+}
+
+type grant struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	claims      map[string]any
+}
+
+// NewProvider starts a provider that logs users in with the given ID token
+// claims (e.g., sub). The provider is closed when the test
+// completes.
+func NewProvider(tb testing.TB, claims map[string]any) *Provider {
+	tb.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048) //nolint:mnd // minimum recommended RSA key size
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	provider := &Provider{
+		key:    key,
+		claims: claims,
+		grants: map[string]grant{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("GET /jwks", provider.jwks)
+	mux.HandleFunc("GET /authorize", provider.authorize)
+	mux.HandleFunc("POST /token", provider.token)
+	provider.Server = httptest.NewTLSServer(mux)
+	tb.Cleanup(provider.Close)
+	return provider
+}
+
+// Config returns the provider settings for a client returning users to
+// redirectURL.
+func (p *Provider) Config(redirectURL string) *eratov1.Config_Oidc {
+	return eratov1.Config_Oidc_builder{
+		Issuer:       p.URL,
+		ClientId:     ClientID,
+		ClientSecret: ClientSecret,
+		RedirectUrl:  redirectURL,
+	}.Build()
+}
+
+// IDToken returns an ID token for the registered client signed by the
+// provider. The standard claims may be overridden by claims.
+func (p *Provider) IDToken(tb testing.TB, claims map[string]any) string {
+	tb.Helper()
+	token := p.standardClaims()
+	maps.Copy(token, claims)
+	signed, err := p.sign(token)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return signed
+}
+
+// standardClaims returns the claims of a new ID token for the registered
+// client.
+func (p *Provider) standardClaims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss": p.URL,
+		"sub": rand.Text(),
+		"aud": ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(tokenLifetime).Unix(),
+	}
+}
+
+func (p *Provider) sign(claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": keyID, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                 p.URL,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize approves the login immediately, returning the user to the client
+// with an authorization code.
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	switch {
+	case err != nil || !redirectURI.IsAbs():
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	case query.Get("client_id") != ClientID,
+		query.Get("response_type") != "code",
+		query.Get("code_challenge_method") != "S256",
+		query.Get("code_challenge") == "":
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	p.mu.Lock()
+	p.grants[code] = grant{
+		redirectURI: redirectURI.String(),
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		claims:      p.claims,
+	}
+	p.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// token exchanges an authorization code for an ID token, checking the PKCE
+// code verifier against the challenge from the authorization request.
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientID != ClientID || clientSecret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	p.mu.Lock()
+	grant, ok := p.grants[code]
+	delete(p.grants, code) // codes are single use
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != grant.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := p.standardClaims()
+	claims["nonce"] = grant.nonce
+	maps.Copy(claims, grant.claims)
+	idToken, err := p.sign(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenLifetime.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
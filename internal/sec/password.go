@@ -1,15 +1,107 @@
 package sec
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2idPrefix identifies argon2id hashes, which are encoded in the PHC
+// string format (e.g., `$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>`). Other
+// hashes are bcrypt's, which are similarly self-describing.
+const argon2idPrefix = "$argon2id$"
+
+// argon2Params are the cost parameters of an argon2id hash.
+type argon2Params struct {
+	memory  uint32 // in KiB
+	time    uint32
+	threads uint8
+}
+
+// Parameters for new hashes, from the second recommended option of RFC 9106.
+// Existing hashes with other parameters are rehashed on login.
+//
+//nolint:mnd // see RFC 9106
+var (
+	argon2Current = argon2Params{memory: 64 * 1024, time: 3, threads: 4}
+	argon2SaltLen = 16
+	argon2KeyLen  = uint32(32)
+)
+
+// errInvalidHash is returned when comparing against a malformed hash.
+var errInvalidHash = errors.New("invalid password hash")
 
 // ComparePassword returns an error if the provided password does not resolve to
-// the given hash.
+// the given hash. Both argon2id and bcrypt hashes are supported.
 func ComparePassword[T ~string | ~[]byte](password T, hash []byte) error {
-	return bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if !bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		return bcrypt.CompareHashAndPassword(hash, []byte(password))
+	}
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+	actual := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return bcrypt.ErrMismatchedHashAndPassword
+	}
+	return nil
 }
 
-// HashPassword generates the hash for a given password. It errors if the
-// password is longer than 72 bytes.
+// HashPassword generates the argon2id hash for a given password.
 func HashPassword[T ~string | ~[]byte](password T) ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	p := argon2Current
+	key := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, argon2KeyLen)
+	return fmt.Appendf(nil, "%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// NeedsRehash reports whether hash was generated with a different algorithm
+// or parameters than [HashPassword] currently uses.
+func NeedsRehash(hash []byte) bool {
+	params, salt, key, err := decodeArgon2id(hash)
+	return err != nil ||
+		params != argon2Current ||
+		len(salt) != argon2SaltLen ||
+		len(key) != int(argon2KeyLen)
+}
+
+// decodeArgon2id parses an argon2id hash in the PHC string format.
+func decodeArgon2id(hash []byte) (params argon2Params, salt, key []byte, err error) {
+	rest, ok := bytes.CutPrefix(hash, []byte(argon2idPrefix))
+	if !ok {
+		return params, nil, nil, errInvalidHash
+	}
+	var version int
+	var salt64, key64 string
+	_, err = fmt.Sscanf(
+		string(bytes.ReplaceAll(rest, []byte("$"), []byte(" "))),
+		"v=%d m=%d,t=%d,p=%d %s %s",
+		&version, &params.memory, &params.time, &params.threads, &salt64, &key64,
+	)
+	if err != nil || version != argon2.Version || params.time == 0 || params.threads == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(salt64); err != nil {
+		return params, nil, nil, errInvalidHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(key64); err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	return params, salt, key, nil
 }
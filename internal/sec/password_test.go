@@ -1,10 +1,14 @@
 package sec
 
 import (
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHashPassword(t *testing.T) {
@@ -51,3 +55,48 @@ func TestComparePassword(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPasswordHashFormats(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("long password ", 20)
+	argon2Hash, err := HashPassword(long)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(argon2Hash), "$argon2id$v=19$m=65536,t=3,p=4$"))
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	salt := []byte("saltsaltsaltsalt")
+	weakHash := []byte("$argon2id$v=19$m=1024,t=1,p=1$" +
+		base64.RawStdEncoding.EncodeToString(salt) + "$" +
+		base64.RawStdEncoding.EncodeToString(argon2.IDKey([]byte("password"), salt, 1, 1024, 1, 32)))
+
+	tests := []struct {
+		name     string
+		password string
+		hash     []byte
+		valid    bool
+		rehash   bool
+	}{
+		{"argon2id longer than bcrypt allows", long, argon2Hash, true, false},
+		{"argon2id truncated password", long[:72], argon2Hash, false, false},
+		{"bcrypt", "password", bcryptHash, true, true},
+		{"bcrypt mismatch", "wrong", bcryptHash, false, true},
+		{"argon2id outdated parameters", "password", weakHash, true, true},
+		{"argon2id mismatch", "wrong", weakHash, false, true},
+		{"malformed argon2id", "password", []byte("$argon2id$v=19$m=1024$c2FsdA$a2V5"), false, true},
+		{"unknown version", "password", []byte("$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5"), false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := ComparePassword(test.password, test.hash)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, test.rehash, NeedsRehash(test.hash))
+		})
+	}
+}
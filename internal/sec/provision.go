@@ -0,0 +1,96 @@
+package sec
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// maxProvisionedUsernameLen is the length provisioned usernames are truncated
+// to, which is the longest valid username.
+const maxProvisionedUsernameLen = 64
+
+// IdentityStore is the storage required to resolve users from the identities
+// they are known by to external authorities.
+type IdentityStore interface {
+	storage.Users
+	storage.Identities
+}
+
+// provisionUser returns the user linked to the subject of the issuer. If the
+// identity is not linked yet, it is linked to the user with the given name,
+// who is created if they do not exist. It is used when an external authority
+// vouches for the user's name, so provisioned users have no password and can
+// only log in through it. Once linked, the user keeps the identity if they are
+// renamed. A [storage.ErrInvalidUsername] is returned if the name is not a
+// valid username.
+func provisionUser(ctx context.Context, store IdentityStore, issuer, subject, name string) (db.User, error) {
+	user, err := store.GetUserByIdentity(ctx, issuer, subject)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return user, err
+	}
+
+	user, err = store.GetUserByName(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		user, err = provisionIdentity(ctx, store, issuer, subject, name)
+		if !errors.Is(err, storage.ErrAlreadyExists) {
+			return user, err
+		}
+		// the name was taken concurrently, so link its user instead
+		user, err = store.GetUserByName(ctx, name)
+	}
+	if err != nil {
+		return user, err
+	}
+
+	err = store.LinkIdentity(ctx, user.ID, issuer, subject)
+	if errors.Is(err, storage.ErrAlreadyExists) { // linked concurrently
+		return store.GetUserByIdentity(ctx, issuer, subject)
+	}
+	return user, err
+}
+
+// provisionIdentity returns the user linked to the subject of the issuer,
+// creating them with the given name if the identity is not linked yet. Like
+// provisionUser, provisioned users have no password. The identity is never
+// linked to an existing user, so a [storage.ErrAlreadyExists] is returned if
+// the name is taken, or a [storage.ErrInvalidUsername] if it is not valid.
+func provisionIdentity(ctx context.Context, store IdentityStore, issuer, subject, name string) (db.User, error) {
+	user, err := store.GetUserByIdentity(ctx, issuer, subject)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return user, err
+	}
+
+	user, err = store.ProvisionUser(ctx,
+		db.User{Name: name, PasswordHash: []byte{}, Role: db.RoleMember},
+		issuer, subject,
+	)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		// the identity may have been provisioned concurrently
+		if linked, lookupErr := store.GetUserByIdentity(ctx, issuer, subject); lookupErr == nil {
+			return linked, nil
+		}
+	}
+	return user, err
+}
+
+// toUsername converts a name given by an external authority to a username,
+// replacing the characters usernames may not contain with underscores and
+// truncating it to the maximum length.
+func toUsername(name string) string {
+	username := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if len(username) > maxProvisionedUsernameLen {
+		username = username[:maxProvisionedUsernameLen]
+	}
+	return username
+}
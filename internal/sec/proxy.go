@@ -0,0 +1,98 @@
+package sec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"connectrpc.com/authn"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const (
+	// defaultProxyAuthHeader is the header holding the username if none is
+	// configured.
+	defaultProxyAuthHeader = "Remote-User"
+
+	// proxyIssuer is the issuer of the identities vouched for by the proxy.
+	// Unlike OpenID Connect issuers, it is not a URL, so they cannot clash.
+	proxyIssuer = "proxy"
+)
+
+// ProxyAuth authenticates requests by a header set by a trusted reverse
+// proxy that has already authenticated the user. The header is only trusted
+// on requests coming directly from the configured proxy addresses. The first
+// time a name is seen, it is linked to the user of that name, who is
+// provisioned if they do not exist, so renamed users keep logging in with the
+// name the proxy knows them by.
+type ProxyAuth struct {
+	header  string
+	trusted []netip.Prefix
+}
+
+// NewProxyAuth creates a proxy authenticator with the settings in cfg.
+func NewProxyAuth(cfg *eratov1.Config_ProxyAuth) (*ProxyAuth, error) {
+	trusted := make([]netip.Prefix, len(cfg.GetTrustedProxies()))
+	for i, proxy := range cfg.GetTrustedProxies() {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		trusted[i] = prefix
+	}
+	header := defaultProxyAuthHeader
+	if cfg.HasHeader() {
+		header = cfg.GetHeader()
+	}
+	return &ProxyAuth{
+		header:  http.CanonicalHeaderKey(header),
+		trusted: trusted,
+	}, nil
+}
+
+// Authenticate resolves the user named by the header of req. If req did not
+// come from a trusted proxy or does not have the header, ok is false and the
+// request should be authenticated by other means. If the header does not
+// name a valid username, a ConnectRPC error is returned.
+func (p *ProxyAuth) Authenticate(
+	ctx context.Context,
+	req *http.Request,
+	store IdentityStore,
+) (user db.User, ok bool, err error) {
+	name := req.Header.Get(p.header)
+	if name == "" || !p.isTrusted(req.RemoteAddr) {
+		return user, false, nil
+	}
+	user, err = provisionUser(ctx, store, proxyIssuer, name, name)
+	if errors.Is(err, storage.ErrInvalidUsername) {
+		return user, false, authn.Errorf("invalid %s header", p.header)
+	}
+	return user, err == nil, err
+}
+
+// Credential returns the credential of a request authenticated by the proxy
+// for [Auditor.RecordLogin], which is the user it names.
+func (p *ProxyAuth) Credential(req *http.Request) string {
+	return proxyIssuer + ":" + req.Header.Get(p.header)
+}
+
+// isTrusted reports whether the remote address of a request is a trusted
+// proxy.
+func (p *ProxyAuth) isTrusted(remoteAddr string) bool {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap() // IPv4 clients of an IPv6 listener
+	for _, prefix := range p.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package sec
+
+import (
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestProxyAuth(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	hash, err := HashPassword("password")
+	require.NoError(t, err)
+	existing := db.User{ID: 123, Name: "existing", PasswordHash: hash}
+	require.NoError(t, store.UpsertUser(t.Context(), existing))
+
+	proxy, err := NewProxyAuth(eratov1.Config_ProxyAuth_builder{
+		TrustedProxies: []string{"10.0.0.0/8", "::1/128"},
+	}.Build())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     string
+		user       string
+		invalid    bool
+	}{
+		{"existing user", "10.1.2.3:1234", "existing", "existing", false},
+		{"provisions user", "10.1.2.3:1234", "proxied", "proxied", false},
+		{"IPv6 proxy", "[::1]:1234", "existing", "existing", false},
+		{"IPv4-mapped proxy", "[::ffff:10.1.2.3]:1234", "existing", "existing", false},
+		{"untrusted address", "192.0.2.1:1234", "existing", "", false},
+		{"missing header", "10.1.2.3:1234", "", "", false},
+		{"invalid username", "10.1.2.3:1234", "not valid", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+			require.NoError(t, err)
+			req.RemoteAddr = test.remoteAddr
+			req.Header.Set("Remote-User", test.header)
+
+			user, ok, err := proxy.Authenticate(t.Context(), req, store)
+			if test.invalid {
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.user != "", ok)
+			assert.Equal(t, test.user, user.Name)
+		})
+	}
+
+	t.Run("renamed user", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+		require.NoError(t, err)
+		req.RemoteAddr = "10.1.2.3:1234"
+		req.Header.Set("Remote-User", "before_rename")
+
+		user, ok, err := proxy.Authenticate(t.Context(), req, store)
+		require.NoError(t, err)
+		require.True(t, ok)
+		user.Name = "after_rename"
+		require.NoError(t, store.UpsertUser(t.Context(), user))
+
+		renamed, ok, err := proxy.Authenticate(t.Context(), req, store)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, user.ID, renamed.ID)
+		assert.Equal(t, "after_rename", renamed.Name)
+		_, err = store.GetUserByName(t.Context(), "before_rename")
+		require.ErrorIs(t, err, storage.ErrNotFound, "no user is provisioned for the old name")
+	})
+
+	t.Run("custom header", func(t *testing.T) {
+		t.Parallel()
+		proxy, err := NewProxyAuth(eratov1.Config_ProxyAuth_builder{
+			Header:         proto.String("x-forwarded-user"),
+			TrustedProxies: []string{"10.0.0.0/8"},
+		}.Build())
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+		require.NoError(t, err)
+		req.RemoteAddr = "10.1.2.3:1234"
+		req.Header.Set("Remote-User", "ignored")
+		req.Header.Set("X-Forwarded-User", "existing")
+
+		user, ok, err := proxy.Authenticate(t.Context(), req, store)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, existing.ID, user.ID)
+	})
+
+	t.Run("falls back to credentials", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+		require.NoError(t, err)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("Remote-User", "proxied")
+		req.SetBasicAuth("existing", "password")
+
+		user, scope, err := Authenticate(t.Context(), req, store, nil, nil, proxy, nil)
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, user.ID)
+		assert.Equal(t, eratov1.AccessToken_USER_ADMIN, scope)
+	})
+}
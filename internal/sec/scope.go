@@ -0,0 +1,95 @@
+package sec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+)
+
+// procedureScopes is the minimum access token scope required by each RPC.
+// Procedures missing from this map require [eratov1.AccessToken_USER_ADMIN].
+var procedureScopes = map[string]eratov1.AccessToken_Scope{
+	eratov1connect.ArchiveServiceListCategoriesProcedure:      eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceGetCategoryProcedure:         eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceUpdateCategoryProcedure:      eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceListEntriesProcedure:         eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceGetEntryProcedure:            eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceUpdateEntryProcedure:         eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceBatchUpdateEntriesProcedure:  eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceListChaptersProcedure:        eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceGetChapterProcedure:          eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceUpdateChapterProcedure:       eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceBatchUpdateChaptersProcedure: eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceReadEntryProcedure:           eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceReadChapterProcedure:         eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceExportEntryProcedure:         eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceGetUserProcedure:             eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceCreateSavedViewProcedure:     eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceListSavedViewsProcedure:      eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceGetSavedViewProcedure:        eratov1.AccessToken_READ_ONLY,
+	eratov1connect.ArchiveServiceUpdateSavedViewProcedure:     eratov1.AccessToken_READ_WRITE,
+	eratov1connect.ArchiveServiceDeleteSavedViewProcedure:     eratov1.AccessToken_READ_WRITE,
+}
+
+// CheckScope returns a PermissionDenied error if the scope of the
+// authenticated request does not permit calling procedure.
+func CheckScope(ctx context.Context, procedure string) error {
+	required, ok := procedureScopes[procedure]
+	if !ok {
+		required = eratov1.AccessToken_USER_ADMIN
+	}
+	if scope := GetAuthenticatedScope(ctx); scope < required {
+		return connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("access token scope %s does not permit %s", scope, procedure))
+	}
+	return nil
+}
+
+// RequireAdmin wraps next, a handler served alongside the RPCs, so only admins
+// may call it, and only with the full [eratov1.AccessToken_USER_ADMIN] scope.
+// Other requests are forbidden.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if !GetAuthenticatedUser(ctx).IsAdmin() || GetAuthenticatedScope(ctx) < eratov1.AccessToken_USER_ADMIN {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// NewScopeInterceptor returns a ConnectRPC interceptor that enforces the
+// access token scope of each request, unary or streaming, with [CheckScope].
+func NewScopeInterceptor() connect.Interceptor {
+	return scopeInterceptor{}
+}
+
+type scopeInterceptor struct{}
+
+func (scopeInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := CheckScope(ctx, req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (scopeInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (scopeInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := CheckScope(ctx, conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
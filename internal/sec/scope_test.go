@@ -0,0 +1,82 @@
+package sec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1/eratov1connect"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestCheckScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		scope     eratov1.AccessToken_Scope
+		procedure string
+		allowed   bool
+	}{
+		{"read only can read", eratov1.AccessToken_READ_ONLY, eratov1connect.ArchiveServiceGetEntryProcedure, true},
+		{"read only cannot write", eratov1.AccessToken_READ_ONLY, eratov1connect.ArchiveServiceUpdateEntryProcedure, false},
+		{"read write can write", eratov1.AccessToken_READ_WRITE, eratov1connect.ArchiveServiceBatchUpdateChaptersProcedure, true},
+		{"read only cannot save views", eratov1.AccessToken_READ_ONLY, eratov1connect.ArchiveServiceCreateSavedViewProcedure, false},
+		{"read write can save views", eratov1.AccessToken_READ_WRITE, eratov1connect.ArchiveServiceCreateSavedViewProcedure, true},
+		{"read write cannot manage tokens", eratov1.AccessToken_READ_WRITE, eratov1connect.ArchiveServiceCreateAccessTokenProcedure, false},
+		{"user admin can manage tokens", eratov1.AccessToken_USER_ADMIN, eratov1connect.ArchiveServiceDeleteAccessTokenProcedure, true},
+		{"read only can export", eratov1.AccessToken_READ_ONLY, eratov1connect.ArchiveServiceExportEntryProcedure, true},
+		{"user admin can read", eratov1.AccessToken_USER_ADMIN, eratov1connect.ArchiveServiceListEntriesProcedure, true},
+		{"unknown procedures require user admin", eratov1.AccessToken_READ_WRITE, "/unknown/Procedure", false},
+		{"unauthenticated", eratov1.AccessToken_SCOPE_UNSPECIFIED, eratov1connect.ArchiveServiceGetEntryProcedure, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := SetAuthenticatedToken(t.Context(), db.User{ID: 123}, test.scope)
+			err := CheckScope(ctx, test.procedure)
+			if test.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	admin := db.User{ID: 1, Name: "admin", Role: db.RoleAdmin}
+	member := db.User{ID: 2, Name: "member", Role: db.RoleMember}
+	tests := []struct {
+		name     string
+		user     db.User
+		scope    eratov1.AccessToken_Scope
+		expected int
+	}{
+		{"admin", admin, eratov1.AccessToken_USER_ADMIN, http.StatusOK},
+		{"admin with a limited token", admin, eratov1.AccessToken_READ_WRITE, http.StatusForbidden},
+		{"member", member, eratov1.AccessToken_USER_ADMIN, http.StatusForbidden},
+		{"unauthenticated", db.User{}, eratov1.AccessToken_SCOPE_UNSPECIFIED, http.StatusForbidden},
+	}
+
+	handler := RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := SetAuthenticatedToken(t.Context(), test.user, test.scope)
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/debug/vars", http.NoBody)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, test.expected, rec.Code)
+		})
+	}
+}
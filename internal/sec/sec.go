@@ -3,16 +3,36 @@
 //
 // # Authentication
 //
-// Authentication uses HTTP Basic Auth via the connectrpc.com/authn middleware.
-// Credentials are validated against bcrypt password hashes stored in the database.
+// The ConnectRPC service uses HTTP Basic Auth or personal access tokens via the
+// connectrpc.com/authn middleware. Access tokens are presented as Bearer tokens
+// and carry a scope limiting the RPCs they may call. The web app instead uses a
+// login form backed by server-side sessions, identified by a cookie holding a
+// random token. Optionally, users may log in with an OpenID Connect provider:
+// the web app uses the authorization code flow with PKCE, and RPC clients may
+// present the provider's ID tokens as Bearer tokens. Both may also trust a
+// header naming the user set by an authenticating reverse proxy. Credentials
+// are validated against argon2id password hashes stored in the database, or
+// bcrypt hashes from earlier versions, which are upgraded on login. Session
+// and access tokens, and invite codes, are stored as SHA-256 hashes.
 //
-// IMPORTANT: Basic Auth transmits credentials in base64 encoding (not encrypted).
-// TLS must be used in production to protect credentials in transit.
+// IMPORTANT: Basic Auth transmits credentials in base64 encoding (not encrypted)
+// and session cookies are marked Secure. TLS must be used in production to
+// protect credentials in transit.
 //
 // # Components
 //
-//   - [Authenticate]: Validates Basic Auth credentials against the user store
+//   - [Authenticate]: Validates proxy headers, Basic Auth credentials, access tokens or ID tokens
+//   - [NewAccessToken], [AuthenticateAccessToken]: Personal access token utilities
+//   - [NewInviteCode], [HashInviteCode]: Invite code utilities for self-registration
+//   - [CheckScope], [NewScopeInterceptor]: Enforce access token scopes per RPC
+//   - [VerifyCredentials]: Validates a username and password against the user store
+//   - [Throttle]: Locks out repeated password and invite failures and limits concurrent password hashing
+//   - [Auditor], [ClientMiddleware]: Record logins and user account changes to the audit log
+//   - [Sessions]: Creates, validates and revokes web login sessions
+//   - [OIDC]: Logs users in with an OpenID Connect provider, provisioning them on first login
+//   - [ProxyAuth]: Trusts a reverse proxy header naming the user, provisioning them on first sight
 //   - [NewConnectAuthMiddleware]: Creates ConnectRPC middleware for authentication
 //   - [GetAuthenticatedUser], [SetAuthenticatedUser]: Context accessors for user info
-//   - [HashPassword], [ComparePassword]: bcrypt password hashing utilities
+//   - [GetAuthenticatedScope], [SetAuthenticatedToken]: Context accessors for token scopes
+//   - [HashPassword], [ComparePassword], [NeedsRehash]: argon2id and bcrypt password hashing utilities
 package sec
@@ -0,0 +1,150 @@
+package sec
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"time"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// SessionCookieName is the name of the cookie holding the web session token.
+const SessionCookieName = "erato_session"
+
+// ErrInvalidSession is returned when a session token is unknown or has expired.
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// sessionTouchInterval limits how often a session's access time is persisted,
+// avoiding a write on every request.
+const sessionTouchInterval = time.Minute
+
+// SessionStore is the storage required by [Sessions].
+type SessionStore interface {
+	storage.Users
+	storage.Sessions
+}
+
+// Sessions manages web login sessions. Sessions expire after going unused for
+// the configured idle timeout, or once they reach their maximum lifetime.
+type Sessions struct {
+	store       SessionStore
+	idleTimeout time.Duration
+	maxLifetime time.Duration
+	now         func() time.Time
+}
+
+// NewSessions creates a session manager backed by store, with the expiry
+// settings from cfg.
+func NewSessions(cfg *eratov1.Config, store SessionStore) *Sessions {
+	return &Sessions{
+		store:       store,
+		idleTimeout: cfg.GetSessionIdleTimeout().AsDuration(),
+		maxLifetime: cfg.GetSessionMaxLifetime().AsDuration(),
+		now:         time.Now,
+	}
+}
+
+// Create starts a new session for the user, returning its token. Any of the
+// user's expired sessions are removed.
+func (s *Sessions) Create(ctx context.Context, user db.User, userAgent string) (token string, err error) {
+	now := s.now().UTC()
+	if err = s.store.DeleteStaleSessions(ctx,
+		user.ID,
+		now.Add(-s.idleTimeout),
+		now.Add(-s.maxLifetime),
+	); err != nil {
+		return "", err
+	}
+
+	token = rand.Text()
+	err = s.store.CreateSession(ctx, db.Session{
+		TokenHash:  hashToken(token),
+		User:       user.ID,
+		CreateTime: now,
+		AccessTime: now,
+		UserAgent:  userAgent,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Authenticate resolves the user for a session token, extending the session's
+// idle expiry. An [ErrInvalidSession] is returned if the session does not
+// exist or has expired.
+func (s *Sessions) Authenticate(ctx context.Context, token string) (user db.User, err error) {
+	hash := hashToken(token)
+	session, err := s.store.GetSession(ctx, hash)
+	if errors.Is(err, storage.ErrNotFound) {
+		return user, ErrInvalidSession
+	} else if err != nil {
+		return user, err
+	}
+
+	now := s.now().UTC()
+	if now.Sub(session.AccessTime) > s.idleTimeout || now.Sub(session.CreateTime) > s.maxLifetime {
+		return user, errors.Join(ErrInvalidSession, s.store.DeleteSession(ctx, hash))
+	}
+
+	user, err = s.store.GetUser(ctx, session.User)
+	if errors.Is(err, storage.ErrNotFound) {
+		// the user has been deleted
+		return user, errors.Join(ErrInvalidSession, s.store.DeleteSession(ctx, hash))
+	} else if err != nil {
+		return user, err
+	}
+
+	if now.Sub(session.AccessTime) > sessionTouchInterval {
+		if err = s.store.TouchSession(ctx, hash, now); err != nil {
+			return user, err
+		}
+	}
+	return user, nil
+}
+
+// Revoke ends the session with the given token.
+func (s *Sessions) Revoke(ctx context.Context, token string) error {
+	return s.store.DeleteSession(ctx, hashToken(token))
+}
+
+// RevokeOthers ends all the user's sessions except the one with the given
+// token.
+func (s *Sessions) RevokeOthers(ctx context.Context, userID uint64, token string) error {
+	return s.store.DeleteUserSessions(ctx, userID, hashToken(token))
+}
+
+// Cookie returns the cookie storing the session token. The cookie is only sent
+// over HTTPS, is inaccessible to scripts and is withheld from cross-site
+// subrequests.
+func (s *Sessions) Cookie(token string) *http.Cookie {
+	return &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(s.maxLifetime.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ClearCookie returns a cookie that removes the session token from the
+// browser.
+func (s *Sessions) ClearCookie() *http.Cookie {
+	cookie := s.Cookie("")
+	cookie.MaxAge = -1
+	return cookie
+}
+
+// hashToken derives the stored identifier of a session from its token, so a
+// leaked database cannot be used to hijack sessions.
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,118 @@
+package sec
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath:         filepath.Join(t.TempDir(), "db.sqlite"),
+		SessionIdleTimeout: durationpb.New(time.Hour),
+		SessionMaxLifetime: durationpb.New(24 * time.Hour),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 123, Name: "test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+
+	// each subtest advances its own clock
+	newSessions := func() (*Sessions, *time.Time) {
+		now := time.Now()
+		sessions := NewSessions(cfg, store)
+		sessions.now = func() time.Time { return now }
+		return sessions, &now
+	}
+
+	t.Run("authenticate", func(t *testing.T) {
+		t.Parallel()
+		sessions, _ := newSessions()
+
+		token, err := sessions.Create(t.Context(), user, "test")
+		require.NoError(t, err)
+
+		actual, err := sessions.Authenticate(t.Context(), token)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, actual.ID)
+
+		_, err = sessions.Authenticate(t.Context(), "unknown")
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("idle timeout", func(t *testing.T) {
+		t.Parallel()
+		sessions, now := newSessions()
+
+		token, err := sessions.Create(t.Context(), user, "test")
+		require.NoError(t, err)
+
+		// activity keeps the session alive past the idle timeout
+		for range 3 {
+			*now = now.Add(45 * time.Minute)
+			_, err = sessions.Authenticate(t.Context(), token)
+			require.NoError(t, err)
+		}
+
+		*now = now.Add(2 * time.Hour)
+		_, err = sessions.Authenticate(t.Context(), token)
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("max lifetime", func(t *testing.T) {
+		t.Parallel()
+		sessions, now := newSessions()
+
+		token, err := sessions.Create(t.Context(), user, "test")
+		require.NoError(t, err)
+
+		for range 25 {
+			*now = now.Add(time.Hour - time.Minute)
+			_, err = sessions.Authenticate(t.Context(), token)
+			if err != nil {
+				break
+			}
+		}
+		require.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		t.Parallel()
+		sessions, _ := newSessions()
+
+		other := db.User{Name: "revoke_test", PasswordHash: []byte{}}
+		require.NoError(t, store.UpsertUser(t.Context(), other))
+		other, err := store.GetUserByName(t.Context(), other.Name)
+		require.NoError(t, err)
+
+		current, err := sessions.Create(t.Context(), other, "current")
+		require.NoError(t, err)
+		first, err := sessions.Create(t.Context(), other, "first")
+		require.NoError(t, err)
+		second, err := sessions.Create(t.Context(), other, "second")
+		require.NoError(t, err)
+
+		require.NoError(t, sessions.Revoke(t.Context(), first))
+		_, err = sessions.Authenticate(t.Context(), first)
+		require.ErrorIs(t, err, ErrInvalidSession)
+
+		require.NoError(t, sessions.RevokeOthers(t.Context(), other.ID, current))
+		_, err = sessions.Authenticate(t.Context(), second)
+		require.ErrorIs(t, err, ErrInvalidSession)
+		_, err = sessions.Authenticate(t.Context(), current)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,276 @@
+package sec
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net/netip"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+const (
+	// lockoutThreshold is the number of failed attempts allowed for a
+	// username or client address before it is locked out.
+	lockoutThreshold = 5
+
+	// lockoutBase is the duration of the first lockout, which doubles with
+	// each further failure up to lockoutMax.
+	lockoutBase = time.Second
+	lockoutMax  = 15 * time.Minute
+
+	// failureWindow is how long failures are remembered. A username or
+	// address without failures in this window starts afresh.
+	failureWindow = time.Hour
+
+	// compareWait bounds how long an attempt waits for a password comparison
+	// slot before it is throttled.
+	compareWait = time.Second
+
+	// ipv6ThrottlePrefix groups IPv6 clients by network, since a single
+	// client typically controls a whole /64.
+	ipv6ThrottlePrefix = 64
+)
+
+// Throttle slows down password and invite code guessing. Usernames and client
+// addresses are each locked out for exponentially increasing durations after
+// repeated failures, and the number of concurrent password comparisons and
+// hashes is limited so guessing cannot exhaust the CPU or memory. Attempts
+// rejected by the throttle return a [connect.CodeResourceExhausted] error with
+// a Retry-After header.
+//
+// Client addresses are taken from the connection, so clients behind a
+// non-authenticating reverse proxy share the proxy's address.
+type Throttle struct {
+	logger *slog.Logger
+	slots  chan struct{}
+	now    func() time.Time
+
+	mu       sync.Mutex
+	failures map[throttleKey]*failureRecord
+	swept    time.Time
+}
+
+// throttleKey identifies what failures are counted against, either a
+// username or a client address.
+type throttleKey struct {
+	kind  string // the log attribute naming the value
+	value string
+}
+
+type failureRecord struct {
+	count       int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// NewThrottle creates a throttle which logs lockouts to logger. Password
+// comparisons are limited to one per CPU.
+func NewThrottle(logger *slog.Logger) *Throttle {
+	return &Throttle{
+		logger:   logger,
+		slots:    make(chan struct{}, runtime.GOMAXPROCS(0)),
+		now:      time.Now,
+		failures: map[throttleKey]*failureRecord{},
+	}
+}
+
+// VerifyCredentials is like the package-level [VerifyCredentials], but
+// rejects attempts for a locked out username or from a locked out client
+// address, recording the outcome of the others. A nil Throttle verifies
+// credentials without limits.
+func (t *Throttle) VerifyCredentials(
+	ctx context.Context,
+	store storage.Users,
+	remoteAddr, username, password string,
+) (user db.User, err error) {
+	if t == nil {
+		return VerifyCredentials(ctx, store, username, password)
+	}
+	keys := []throttleKey{{kind: "username", value: username}}
+	if addr, ok := clientAddr(remoteAddr); ok {
+		keys = append(keys, throttleKey{kind: "address", value: addr})
+	}
+	if retryAfter := t.lockedOut(keys); retryAfter > 0 {
+		return user, throttledError(retryAfter)
+	}
+
+	release, err := t.acquire(ctx)
+	if err != nil {
+		return user, err
+	}
+	defer release()
+
+	user, err = VerifyCredentials(ctx, store, username, password)
+	if err == nil {
+		// only the username is cleared, otherwise a client could reset its
+		// address by logging in to its own account between guesses
+		t.succeed(keys[0])
+		return user, nil
+	}
+	if connect.CodeOf(err) == connect.CodeUnauthenticated {
+		t.fail(ctx, keys)
+	}
+	return user, err
+}
+
+// HashPassword is like the package-level [HashPassword], but waits for a
+// password comparison slot first, so hashing shares the limit on concurrent
+// comparisons. A nil Throttle hashes without limits.
+func (t *Throttle) HashPassword(ctx context.Context, password string) ([]byte, error) {
+	if t == nil {
+		return HashPassword(password)
+	}
+	release, err := t.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return HashPassword(password)
+}
+
+// Register rejects registrations from a locked out client address, otherwise
+// calling register. Registrations rejected for an invalid invite code, with a
+// [connect.CodePermissionDenied] error, count as failures against the address
+// like failed logins. A nil Throttle calls register without limits.
+func (t *Throttle) Register(ctx context.Context, remoteAddr string, register func() error) error {
+	if t == nil {
+		return register()
+	}
+	addr, ok := clientAddr(remoteAddr)
+	if !ok {
+		return register()
+	}
+	keys := []throttleKey{{kind: "address", value: addr}}
+	if retryAfter := t.lockedOut(keys); retryAfter > 0 {
+		return throttledError(retryAfter)
+	}
+	err := register()
+	if connect.CodeOf(err) == connect.CodePermissionDenied {
+		t.fail(ctx, keys)
+	}
+	return err
+}
+
+// acquire waits for a password comparison slot, returning a function that
+// releases it.
+func (t *Throttle) acquire(ctx context.Context) (release func(), err error) {
+	timer := time.NewTimer(compareWait)
+	defer timer.Stop()
+	select {
+	case t.slots <- struct{}{}:
+		return func() { <-t.slots }, nil
+	case <-timer.C:
+		return nil, throttledError(compareWait)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lockedOut returns how long until none of keys are locked out.
+func (t *Throttle) lockedOut(keys []throttleKey) (retryAfter time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for _, key := range keys {
+		if record, ok := t.failures[key]; ok {
+			retryAfter = max(retryAfter, record.lockedUntil.Sub(now))
+		}
+	}
+	return retryAfter
+}
+
+// fail records a failed attempt against keys, locking out any that reach the
+// threshold.
+func (t *Throttle) fail(ctx context.Context, keys []throttleKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+	for _, key := range keys {
+		record, ok := t.failures[key]
+		if !ok || now.Sub(record.last) > failureWindow {
+			record = &failureRecord{}
+			t.failures[key] = record
+		}
+		record.count++
+		record.last = now
+		if record.count < lockoutThreshold {
+			continue
+		}
+
+		duration := lockoutMax
+		if shift := record.count - lockoutThreshold; shift < 32 { //nolint:mnd // avoids overflow
+			duration = min(lockoutBase<<shift, lockoutMax)
+		}
+		record.lockedUntil = now.Add(duration)
+		t.logger.WarnContext(ctx, "authentication locked out",
+			slog.String(key.kind, key.value),
+			slog.Int("failures", record.count),
+			slog.Duration("duration", duration),
+		)
+	}
+}
+
+func (t *Throttle) succeed(key throttleKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.failures, key)
+}
+
+// sweep forgets failures outside the window, bounding the memory used by
+// guesses for many usernames. It runs at most once per window.
+func (t *Throttle) sweep(now time.Time) {
+	if now.Sub(t.swept) < failureWindow {
+		return
+	}
+	for key, record := range t.failures {
+		if now.Sub(record.last) > failureWindow && now.After(record.lockedUntil) {
+			delete(t.failures, key)
+		}
+	}
+	t.swept = now
+}
+
+// clientAddr returns the address failures are counted against for a
+// connection's remote address.
+func clientAddr(remoteAddr string) (string, bool) {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return "", false
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.Is6() {
+		prefix, _ := addr.Prefix(ipv6ThrottlePrefix)
+		return prefix.String(), true
+	}
+	return addr.String(), true
+}
+
+// throttledError returns the error for an attempt rejected by the throttle.
+func throttledError(retryAfter time.Duration) *connect.Error {
+	err := connect.NewError(connect.CodeResourceExhausted, errors.New("too many authentication attempts"))
+	err.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return err
+}
+
+// RetryAfter returns the Retry-After header value of an error returned by
+// the [Throttle], or false if err was not caused by throttling.
+func RetryAfter(err error) (string, bool) {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+		return "", false
+	}
+	retryAfter := connectErr.Meta().Get("Retry-After")
+	return retryAfter, retryAfter != ""
+}
@@ -0,0 +1,295 @@
+package sec
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	hash, err := HashPassword("password")
+	require.NoError(t, err)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, store.UpsertUser(t.Context(), db.User{Name: name, PasswordHash: hash}))
+	}
+
+	newThrottle := func(t *testing.T) (*Throttle, *fakeClock, *syncBuffer) {
+		t.Helper()
+		logs := &syncBuffer{}
+		throttle := NewThrottle(slog.New(slog.NewTextHandler(logs, nil)))
+		clock := &fakeClock{now: time.Now()}
+		throttle.now = clock.Now
+		return throttle, clock, logs
+	}
+
+	// attempt logs in, returning the error code, zero on success, and the
+	// Retry-After header
+	attempt := func(t *testing.T, throttle *Throttle, addr, username, password string) (connect.Code, string) {
+		t.Helper()
+		_, err := throttle.VerifyCredentials(t.Context(), store, addr, username, password)
+		retryAfter, _ := RetryAfter(err)
+		if err == nil {
+			return 0, retryAfter
+		}
+		return connect.CodeOf(err), retryAfter
+	}
+
+	t.Run("locks out username", func(t *testing.T) {
+		t.Parallel()
+		throttle, clock, logs := newThrottle(t)
+
+		for i := range lockoutThreshold {
+			code, _ := attempt(t, throttle, "192.0.2.1:1234", "alice", "wrong")
+			assert.Equal(t, connect.CodeUnauthenticated, code, "attempt %d", i)
+			clock.Advance(time.Minute)
+		}
+		assert.Contains(t, logs.String(), "authentication locked out")
+		assert.Contains(t, logs.String(), "username=alice")
+
+		// the first lockout has expired, so this failure locks out for twice as long
+		code, _ := attempt(t, throttle, "192.0.2.2:1234", "alice", "wrong")
+		assert.Equal(t, connect.CodeUnauthenticated, code)
+
+		code, retryAfter := attempt(t, throttle, "192.0.2.3:1234", "alice", "password")
+		assert.Equal(t, connect.CodeResourceExhausted, code, "correct password is rejected while locked out")
+		assert.Equal(t, "2", retryAfter)
+
+		code, _ = attempt(t, throttle, "192.0.2.3:1234", "bob", "password")
+		assert.Zero(t, code, "other users are unaffected")
+
+		clock.Advance(2 * time.Second)
+		code, _ = attempt(t, throttle, "192.0.2.3:1234", "alice", "password")
+		assert.Zero(t, code)
+
+		// success resets the username
+		code, _ = attempt(t, throttle, "192.0.2.3:1234", "alice", "wrong")
+		assert.Equal(t, connect.CodeUnauthenticated, code)
+		code, _ = attempt(t, throttle, "192.0.2.3:1234", "alice", "password")
+		assert.Zero(t, code)
+	})
+
+	t.Run("lockout grows exponentially", func(t *testing.T) {
+		t.Parallel()
+		throttle, clock, _ := newThrottle(t)
+
+		// unknown users skip the comparison, but still count as failures
+		for range lockoutThreshold - 1 {
+			attempt(t, throttle, "", "nobody", "wrong")
+		}
+		for _, expected := range []string{"1", "2", "4", "8", "16"} {
+			attempt(t, throttle, "", "nobody", "wrong")
+			code, retryAfter := attempt(t, throttle, "", "nobody", "wrong")
+			require.Equal(t, connect.CodeResourceExhausted, code)
+			assert.Equal(t, expected, retryAfter)
+			clock.Advance(time.Minute)
+		}
+	})
+
+	t.Run("lockout is capped", func(t *testing.T) {
+		t.Parallel()
+		throttle, clock, _ := newThrottle(t)
+
+		for range 100 {
+			attempt(t, throttle, "", "nobody", "wrong")
+			clock.Advance(lockoutMax)
+		}
+		clock.Advance(-lockoutMax)
+		_, retryAfter := attempt(t, throttle, "", "nobody", "wrong")
+		assert.Equal(t, "900", retryAfter)
+	})
+
+	t.Run("failures expire", func(t *testing.T) {
+		t.Parallel()
+		throttle, clock, _ := newThrottle(t)
+
+		for range lockoutThreshold - 1 {
+			attempt(t, throttle, "", "alice", "wrong")
+		}
+		clock.Advance(failureWindow + time.Second)
+		attempt(t, throttle, "", "alice", "wrong")
+		code, _ := attempt(t, throttle, "", "alice", "password")
+		assert.Zero(t, code)
+	})
+
+	t.Run("locks out address", func(t *testing.T) {
+		t.Parallel()
+		throttle, _, logs := newThrottle(t)
+
+		for _, name := range []string{"alice", "bob", "carol", "dave", "erin"} {
+			code, _ := attempt(t, throttle, "[2001:db8::1]:1234", name, "wrong")
+			assert.Equal(t, connect.CodeUnauthenticated, code)
+		}
+		assert.Contains(t, logs.String(), "address=2001:db8::/64")
+
+		code, _ := attempt(t, throttle, "[2001:db8::2]:1234", "bob", "password")
+		assert.Equal(t, connect.CodeResourceExhausted, code, "address's network is locked out")
+
+		code, _ = attempt(t, throttle, "[2001:db8:1::1]:1234", "bob", "password")
+		assert.Zero(t, code, "other networks are unaffected")
+
+		// logging in does not reset the address
+		code, _ = attempt(t, throttle, "[2001:db8::2]:1234", "bob", "password")
+		assert.Equal(t, connect.CodeResourceExhausted, code)
+	})
+
+	t.Run("limits concurrent comparisons", func(t *testing.T) {
+		t.Parallel()
+		if testing.Short() {
+			t.Skip("waits for a comparison slot")
+		}
+		throttle, _, _ := newThrottle(t)
+
+		for range cap(throttle.slots) {
+			throttle.slots <- struct{}{}
+		}
+		code, retryAfter := attempt(t, throttle, "", "alice", "password")
+		assert.Equal(t, connect.CodeResourceExhausted, code)
+		assert.Equal(t, "1", retryAfter)
+
+		<-throttle.slots
+		code, _ = attempt(t, throttle, "", "alice", "password")
+		assert.Zero(t, code)
+	})
+
+	t.Run("limits concurrent hashes", func(t *testing.T) {
+		t.Parallel()
+		if testing.Short() {
+			t.Skip("waits for a comparison slot")
+		}
+		throttle, _, _ := newThrottle(t)
+
+		for range cap(throttle.slots) {
+			throttle.slots <- struct{}{}
+		}
+		_, err := throttle.HashPassword(t.Context(), "password")
+		assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+		<-throttle.slots
+		hash, err := throttle.HashPassword(t.Context(), "password")
+		require.NoError(t, err)
+		assert.NoError(t, ComparePassword("password", hash))
+	})
+
+	t.Run("locks out invalid invite codes", func(t *testing.T) {
+		t.Parallel()
+		throttle, clock, logs := newThrottle(t)
+
+		register := func(addr string, err error) connect.Code {
+			var called bool
+			err = throttle.Register(t.Context(), addr, func() error {
+				called = true
+				return err
+			})
+			if connect.CodeOf(err) != connect.CodeResourceExhausted {
+				assert.True(t, called, "register called unless throttled")
+			}
+			if err == nil {
+				return 0
+			}
+			return connect.CodeOf(err)
+		}
+
+		invalid := connect.NewError(connect.CodePermissionDenied, nil)
+		taken := connect.NewError(connect.CodeAlreadyExists, nil)
+		for range lockoutThreshold {
+			assert.Equal(t, connect.CodeAlreadyExists, register("192.0.2.1:1234", taken), "not a guess")
+			assert.Equal(t, connect.CodePermissionDenied, register("192.0.2.1:1234", invalid))
+		}
+		assert.Contains(t, logs.String(), "address=192.0.2.1")
+		assert.Equal(t, connect.CodeResourceExhausted, register("192.0.2.1:5678", nil))
+		assert.Zero(t, register("192.0.2.2:1234", nil), "other addresses are unaffected")
+
+		clock.Advance(time.Second)
+		assert.Zero(t, register("192.0.2.1:1234", nil))
+	})
+
+	t.Run("nil throttle", func(t *testing.T) {
+		t.Parallel()
+		var throttle *Throttle
+		for range lockoutThreshold * 2 {
+			code, _ := attempt(t, throttle, "", "alice", "wrong")
+			assert.Equal(t, connect.CodeUnauthenticated, code)
+		}
+		code, _ := attempt(t, throttle, "", "alice", "password")
+		assert.Zero(t, code)
+	})
+}
+
+func TestClientAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		remoteAddr string
+		expected   string
+	}{
+		{"192.0.2.1:1234", "192.0.2.1"},
+		{"[::ffff:192.0.2.1]:1234", "192.0.2.1"},
+		{"[2001:db8::1]:1234", "2001:db8::/64"},
+		{"[2001:db8::1%eth0]:1234", "2001:db8::/64"},
+		{"invalid", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.remoteAddr, func(t *testing.T) {
+			t.Parallel()
+			actual, ok := clientAddr(test.remoteAddr)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// syncBuffer is a [bytes.Buffer] safe for concurrent logging.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
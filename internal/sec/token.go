@@ -0,0 +1,67 @@
+package sec
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"connectrpc.com/authn"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+// AccessTokenPrefix is prepended to all personal access tokens, making them
+// distinguishable from other credentials (e.g., by secret scanners).
+const AccessTokenPrefix = "erato_pat_"
+
+// accessTokenTouchInterval limits how often a token's last used time is
+// persisted, avoiding a write on every request.
+const accessTokenTouchInterval = time.Minute
+
+// AuthStore is the storage required to authenticate RPC requests.
+type AuthStore interface {
+	IdentityStore
+	storage.AccessTokens
+}
+
+// NewAccessToken generates a new personal access token, returning the token
+// to give to the user and the hash to store.
+func NewAccessToken() (token string, hash []byte) {
+	token = AccessTokenPrefix + rand.Text()
+	return token, hashToken(token)
+}
+
+// AuthenticateAccessToken resolves the user and scope of a personal access
+// token, recording its use. If the token is invalid, a ConnectRPC error is
+// returned.
+func AuthenticateAccessToken(
+	ctx context.Context,
+	store AuthStore,
+	token string,
+) (user db.User, scope eratov1.AccessToken_Scope, err error) {
+	accessToken, err := store.GetAccessTokenByHash(ctx, hashToken(token))
+	if errors.Is(err, storage.ErrNotFound) {
+		return user, scope, authn.Errorf("invalid access token")
+	} else if err != nil {
+		return user, scope, err
+	}
+
+	user, err = store.GetUser(ctx, accessToken.User)
+	if errors.Is(err, storage.ErrNotFound) {
+		// the user has been deleted
+		return user, scope, authn.Errorf("invalid access token")
+	} else if err != nil {
+		return user, scope, err
+	}
+
+	now := time.Now().UTC()
+	if lastUsed := accessToken.LastUsedTime; !lastUsed.Valid || now.Sub(lastUsed.Time) > accessTokenTouchInterval {
+		if err = store.TouchAccessToken(ctx, accessToken.ID, now); err != nil {
+			return user, scope, err
+		}
+	}
+	return user, eratov1.AccessToken_Scope(accessToken.Scope), nil //nolint:gosec // stored from a valid enum
+}
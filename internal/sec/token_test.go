@@ -0,0 +1,104 @@
+package sec
+
+import (
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/storage"
+	"github.com/stolasapp/erato/internal/storage/db"
+)
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := storage.NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	hash, err := HashPassword("password")
+	require.NoError(t, err)
+	user := db.User{ID: 123, Name: "test", PasswordHash: hash}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+
+	token, tokenHash := NewAccessToken()
+	tokenID, err := store.CreateAccessToken(t.Context(), db.AccessToken{
+		User:       user.ID,
+		TokenHash:  tokenHash,
+		Scope:      int64(eratov1.AccessToken_READ_WRITE),
+		CreateTime: time.Now(),
+	})
+	require.NoError(t, err)
+
+	orphan, orphanHash := NewAccessToken()
+	_, err = store.CreateAccessToken(t.Context(), db.AccessToken{
+		User:       user.ID + 1,
+		TokenHash:  orphanHash,
+		Scope:      int64(eratov1.AccessToken_READ_WRITE),
+		CreateTime: time.Now(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		scope         eratov1.AccessToken_Scope
+	}{
+		{"bearer token", "Bearer " + token, eratov1.AccessToken_READ_WRITE},
+		{"case insensitive scheme", "bearer " + token, eratov1.AccessToken_READ_WRITE},
+		{"basic auth", basicAuth(t, "test", "password"), eratov1.AccessToken_USER_ADMIN},
+		{"unknown token", "Bearer " + AccessTokenPrefix + "unknown", eratov1.AccessToken_SCOPE_UNSPECIFIED},
+		{"deleted user", "Bearer " + orphan, eratov1.AccessToken_SCOPE_UNSPECIFIED},
+		{"wrong password", basicAuth(t, "test", "wrong"), eratov1.AccessToken_SCOPE_UNSPECIFIED},
+		{"empty bearer", "Bearer ", eratov1.AccessToken_SCOPE_UNSPECIFIED},
+		{"missing", "", eratov1.AccessToken_SCOPE_UNSPECIFIED},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", test.authorization)
+
+			actual, scope, err := Authenticate(t.Context(), req, store, nil, nil, nil, nil)
+			if test.scope == eratov1.AccessToken_SCOPE_UNSPECIFIED {
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, actual.ID)
+			assert.Equal(t, test.scope, scope)
+		})
+	}
+
+	t.Run("records last use", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := AuthenticateAccessToken(t.Context(), store, token)
+		require.NoError(t, err)
+
+		tokens, err := store.ListAccessTokens(t.Context(), user.ID)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, tokenID, tokens[0].ID)
+		assert.True(t, tokens[0].LastUsedTime.Valid)
+	})
+}
+
+func basicAuth(t *testing.T, username, password string) string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "/", http.NoBody)
+	require.NoError(t, err)
+	req.SetBasicAuth(username, password)
+	return req.Header.Get("Authorization")
+}
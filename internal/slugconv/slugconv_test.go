@@ -231,3 +231,59 @@ func TestToTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestToChapterNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		slug string
+		want int32
+	}{
+		{
+			name: "chapter prefix",
+			slug: "chapter-12-the-end",
+			want: 12,
+		},
+		{
+			name: "chapter path",
+			slug: "categories/foo/entries/bar/chapters/chapter-2",
+			want: 2,
+		},
+		{
+			name: "short prefix",
+			slug: "Ch3-beginnings",
+			want: 3,
+		},
+		{
+			name: "bare number",
+			slug: "07.html",
+			want: 7,
+		},
+		{
+			name: "unnumbered",
+			slug: "prologue",
+			want: 0,
+		},
+		{
+			name: "number not at the start",
+			slug: "the-12-days",
+			want: 0,
+		},
+		{
+			name: "number followed by letters",
+			slug: "chapter-1a",
+			want: 0,
+		},
+		{
+			name: "overflow",
+			slug: "chapter-99999999999",
+			want: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, ToChapterNumber(test.slug))
+		})
+	}
+}
@@ -2,10 +2,16 @@ package slugconv
 
 import (
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
+// chapterNumber matches a slug's base name starting with a chapter number,
+// optionally prefixed, such as chapter-12-the-end, ch3 or 07.html.
+var chapterNumber = regexp.MustCompile(`(?i)^(?:chapter|chap|ch|part|pt)?-?(\d+)(?:[-.]|$)`)
+
 // ToTitle converts a slug into a rough approximation of title case. It extracts
 // the base name, strips any .html suffix, replaces hyphens with spaces, and
 // capitalizes the first letter of each word.
@@ -16,6 +22,21 @@ func ToTitle(slug string) string {
 	return titleCase(name)
 }
 
+// ToChapterNumber extracts the chapter number from the start of a slug's base
+// name, such as 12 from chapter-12-the-end. Returns 0 if the slug is not
+// numbered.
+func ToChapterNumber(slug string) int32 {
+	match := chapterNumber.FindStringSubmatch(path.Base(slug))
+	if match == nil {
+		return 0
+	}
+	number, err := strconv.ParseInt(match[1], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(number)
+}
+
 // titleCase capitalizes the first rune of each space-separated word.
 func titleCase(s string) string {
 	words := strings.Fields(s)
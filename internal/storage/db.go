@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"regexp"
+	"time"
 
 	"github.com/influxdata/influxdb/pkg/snowflake"
 
@@ -22,6 +23,10 @@ const (
 
 var usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 
+// sqliteTimeLayout is the format times are stored in, as configured by the
+// _time_format=sqlite connection parameter.
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
 // validateUsername validates that a username meets the requirements:
 // 3-64 characters, alphanumeric and underscores only.
 func validateUsername(name string) bool {
@@ -37,9 +42,11 @@ type DB struct {
 	queries *db.Queries
 }
 
-// NewDB initializes a DB with the given config and logger.
+// NewDB initializes a DB with the given config and logger. Pending migrations
+// are applied unless the config requires manual migrations, in which case a
+// [db.ErrPendingMigrations] error is returned if the schema is out of date.
 func NewDB(ctx context.Context, cfg *eratov1.Config, logger *slog.Logger) (*DB, error) {
-	handle, err := db.Open(ctx, logger, cfg.GetDbFilepath())
+	handle, err := db.Open(ctx, logger, cfg.GetDbFilepath(), !cfg.GetManualMigrations())
 	if err != nil {
 		return nil, err
 	}
@@ -77,10 +84,63 @@ func (d *DB) GetResource(ctx context.Context, userID uint64, path string) (db.Re
 
 // UpsertResource satisfies the [Resources] interface.
 func (d *DB) UpsertResource(ctx context.Context, resource db.Resource) error {
-	_, err := d.queries.UpsertResource(ctx, db.UpsertResourceParams(resource))
+	return upsertResource(ctx, d.queries, resource)
+}
+
+// BatchUpsertResources satisfies the [Resources] interface.
+func (d *DB) BatchUpsertResources(ctx context.Context, resources ...db.Resource) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	queries := d.queries.WithTx(tx)
+	for _, resource := range resources {
+		if err = upsertResource(ctx, queries, resource); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func upsertResource(ctx context.Context, queries *db.Queries, resource db.Resource) error {
+	resource.Version++
+	_, err := queries.UpsertResource(ctx, db.UpsertResourceParams(resource))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrConflict
+	}
 	return err
 }
 
+// GetProgress satisfies the [Resources] interface.
+func (d *DB) GetProgress(ctx context.Context, userID uint64, entryPaths ...string) (map[string]Progress, error) {
+	rows, err := d.queries.GetProgress(ctx, db.GetProgressParams{
+		User:  userID,
+		Paths: entryPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+	progress := make(map[string]Progress, len(rows))
+	for _, row := range rows {
+		progress[row.Path] = Progress{Chapters: row.ChapterCount, Read: row.ReadCount}
+	}
+	return progress, nil
+}
+
+// SetChapterCount satisfies the [Resources] interface.
+func (d *DB) SetChapterCount(ctx context.Context, entryPath string, count int64) error {
+	return d.queries.SetChapterCount(ctx, db.SetChapterCountParams{
+		Path:         entryPath,
+		ChapterCount: count,
+	})
+}
+
 // ListUsers satisfies the [Users] interface.
 func (d *DB) ListUsers(ctx context.Context, afterName string, limit int32) ([]db.User, error) {
 	return d.queries.GetUsers(ctx, db.GetUsersParams{
@@ -109,23 +169,463 @@ func (d *DB) GetUserByName(ctx context.Context, name string) (db.User, error) {
 
 // UpsertUser satisfies the [Users] interface.
 func (d *DB) UpsertUser(ctx context.Context, user db.User) error {
+	return d.upsertUser(ctx, d.queries, user)
+}
+
+func (d *DB) upsertUser(ctx context.Context, queries *db.Queries, user db.User) error {
 	if !validateUsername(user.Name) {
 		return ErrInvalidUsername
 	}
 	if user.ID == 0 {
 		user.ID = d.ids.Next()
 	}
-	switch _, err := d.queries.UpsertUser(ctx, db.UpsertUserParams(user)); {
-	case errors.Is(err, sql.ErrNoRows):
-		return ErrAlreadyExists
-	default:
+	if user.Role == "" {
+		user.Role = db.RoleMember
+	}
+	if !user.CreateTime.Valid {
+		// only stored when the user is created
+		user.CreateTime = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	if user.PasswordHash == nil {
+		// users without a password, such as those provisioned by single sign-on,
+		// are read back with a nil hash
+		user.PasswordHash = []byte{}
+	}
+	user.Version++
+	_, err := queries.UpsertUser(ctx, db.UpsertUserParams(user))
+	if !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
+	// the upsert is skipped if either the name is taken or the version is stale
+	if existing, err := queries.GetUserByName(ctx, user.Name); err == nil && existing.ID != user.ID {
+		return ErrAlreadyExists
+	}
+	return ErrConflict
 }
 
 // DeleteUser satisfies the [Users] interface.
-func (d *DB) DeleteUser(ctx context.Context, userID uint64) error {
-	return d.queries.DeleteUser(ctx, userID)
+func (d *DB) DeleteUser(ctx context.Context, userID uint64) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	// the user's rows are removed explicitly, as foreign keys are not enforced
+	queries := d.queries.WithTx(tx)
+	for _, deleteRows := range []func(context.Context, uint64) error{
+		queries.DeleteUserIdentities,
+		queries.DeleteAllUserSessions,
+		queries.DeleteUserAccessTokens,
+		queries.DeleteUserSavedViews,
+		queries.DeleteUserResources,
+		queries.DeleteUser,
+	} {
+		if err = deleteRows(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetUserByIdentity satisfies the [Identities] interface.
+func (d *DB) GetUserByIdentity(ctx context.Context, issuer, subject string) (db.User, error) {
+	row, err := d.queries.GetUserByIdentity(ctx, db.GetUserByIdentityParams{
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return row.User, ErrNotFound
+	}
+	return row.User, err
+}
+
+// ProvisionUser satisfies the [Identities] interface.
+func (d *DB) ProvisionUser(ctx context.Context, user db.User, issuer, subject string) (_ db.User, err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return user, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	queries := d.queries.WithTx(tx)
+	if user.ID == 0 {
+		user.ID = d.ids.Next()
+	}
+	if err = d.upsertUser(ctx, queries, user); err != nil {
+		return user, err
+	} else if err = linkIdentity(ctx, queries, user.ID, issuer, subject); err != nil {
+		return user, err
+	}
+	if user, err = queries.GetUser(ctx, user.ID); err != nil {
+		return user, err
+	}
+	return user, tx.Commit()
+}
+
+// LinkIdentity satisfies the [Identities] interface.
+func (d *DB) LinkIdentity(ctx context.Context, userID uint64, issuer, subject string) error {
+	return linkIdentity(ctx, d.queries, userID, issuer, subject)
+}
+
+func linkIdentity(ctx context.Context, queries *db.Queries, userID uint64, issuer, subject string) error {
+	rows, err := queries.CreateIdentity(ctx, db.CreateIdentityParams{
+		Issuer:     issuer,
+		Subject:    subject,
+		User:       userID,
+		CreateTime: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// UnlinkIdentity satisfies the [Identities] interface.
+func (d *DB) UnlinkIdentity(ctx context.Context, userID uint64, issuer, subject string) error {
+	rows, err := d.queries.DeleteIdentity(ctx, db.DeleteIdentityParams{
+		User:    userID,
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListIdentities satisfies the [Identities] interface.
+func (d *DB) ListIdentities(ctx context.Context, userID uint64) ([]db.Identity, error) {
+	return d.queries.ListIdentities(ctx, userID)
+}
+
+// GetUserActivity satisfies the [Users] interface.
+func (d *DB) GetUserActivity(ctx context.Context, userID uint64) (UserActivity, error) {
+	row, err := d.queries.GetUserActivity(ctx, userID)
+	if err != nil {
+		return UserActivity{}, err
+	}
+	activity := UserActivity{
+		Resources: row.Resources,
+		Read:      row.Read,
+		Starred:   row.Starred,
+		Hidden:    row.Hidden,
+	}
+	// each table's times are compared in Go, as they may be in different zones
+	for _, value := range []string{row.LastViewTime, row.LastReadTime, row.LastSessionTime, row.LastTokenTime} {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(sqliteTimeLayout, value)
+		if err != nil {
+			return UserActivity{}, err
+		}
+		if !activity.LastActiveTime.Valid || t.After(activity.LastActiveTime.Time) {
+			activity.LastActiveTime = sql.NullTime{Time: t, Valid: true}
+		}
+	}
+	return activity, nil
+}
+
+// CreateSession satisfies the [Sessions] interface.
+func (d *DB) CreateSession(ctx context.Context, session db.Session) error {
+	return d.queries.CreateSession(ctx, db.CreateSessionParams(session))
+}
+
+// GetSession satisfies the [Sessions] interface.
+func (d *DB) GetSession(ctx context.Context, tokenHash []byte) (db.Session, error) {
+	session, err := d.queries.GetSession(ctx, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrNotFound
+	}
+	return session, err
+}
+
+// TouchSession satisfies the [Sessions] interface.
+func (d *DB) TouchSession(ctx context.Context, tokenHash []byte, accessTime time.Time) error {
+	return d.queries.TouchSession(ctx, db.TouchSessionParams{
+		TokenHash:  tokenHash,
+		AccessTime: accessTime,
+	})
+}
+
+// DeleteSession satisfies the [Sessions] interface.
+func (d *DB) DeleteSession(ctx context.Context, tokenHash []byte) error {
+	return d.queries.DeleteSession(ctx, tokenHash)
+}
+
+// DeleteUserSessions satisfies the [Sessions] interface.
+func (d *DB) DeleteUserSessions(ctx context.Context, userID uint64, exceptTokenHash []byte) error {
+	if exceptTokenHash == nil {
+		// a NULL comparison would never match, so no sessions would be deleted
+		exceptTokenHash = []byte{}
+	}
+	return d.queries.DeleteUserSessions(ctx, db.DeleteUserSessionsParams{
+		User:            userID,
+		ExceptTokenHash: exceptTokenHash,
+	})
+}
+
+// DeleteStaleSessions satisfies the [Sessions] interface.
+func (d *DB) DeleteStaleSessions(ctx context.Context, userID uint64, accessBefore, createBefore time.Time) error {
+	return d.queries.DeleteStaleSessions(ctx, db.DeleteStaleSessionsParams{
+		User:         userID,
+		AccessBefore: accessBefore,
+		CreateBefore: createBefore,
+	})
+}
+
+// CreateAccessToken satisfies the [AccessTokens] interface.
+func (d *DB) CreateAccessToken(ctx context.Context, token db.AccessToken) (int64, error) {
+	return d.queries.CreateAccessToken(ctx, db.CreateAccessTokenParams{
+		User:        token.User,
+		TokenHash:   token.TokenHash,
+		DisplayName: token.DisplayName,
+		Scope:       token.Scope,
+		CreateTime:  token.CreateTime,
+	})
+}
+
+// GetAccessTokenByHash satisfies the [AccessTokens] interface.
+func (d *DB) GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (db.AccessToken, error) {
+	token, err := d.queries.GetAccessTokenByHash(ctx, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return token, ErrNotFound
+	}
+	return token, err
+}
+
+// ListAccessTokens satisfies the [AccessTokens] interface.
+func (d *DB) ListAccessTokens(ctx context.Context, userID uint64) ([]db.AccessToken, error) {
+	return d.queries.ListAccessTokens(ctx, userID)
+}
+
+// TouchAccessToken satisfies the [AccessTokens] interface.
+func (d *DB) TouchAccessToken(ctx context.Context, tokenID int64, lastUsedTime time.Time) error {
+	return d.queries.TouchAccessToken(ctx, db.TouchAccessTokenParams{
+		ID:           tokenID,
+		LastUsedTime: sql.NullTime{Time: lastUsedTime, Valid: true},
+	})
+}
+
+// DeleteAccessToken satisfies the [AccessTokens] interface.
+func (d *DB) DeleteAccessToken(ctx context.Context, userID uint64, tokenID int64) error {
+	rows, err := d.queries.DeleteAccessToken(ctx, db.DeleteAccessTokenParams{
+		User: userID,
+		ID:   tokenID,
+	})
+	if err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CreateSavedView satisfies the [SavedViews] interface.
+func (d *DB) CreateSavedView(ctx context.Context, view db.SavedView) (int64, error) {
+	return d.queries.CreateSavedView(ctx, db.CreateSavedViewParams{
+		User:        view.User,
+		DisplayName: view.DisplayName,
+		Filter:      view.Filter,
+		OrderBy:     view.OrderBy,
+		Category:    view.Category,
+		Version:     1,
+		CreateTime:  view.CreateTime,
+		UpdateTime:  view.UpdateTime,
+	})
+}
+
+// GetSavedView satisfies the [SavedViews] interface.
+func (d *DB) GetSavedView(ctx context.Context, userID uint64, viewID int64) (db.SavedView, error) {
+	view, err := d.queries.GetSavedView(ctx, db.GetSavedViewParams{
+		User: userID,
+		ID:   viewID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return view, ErrNotFound
+	}
+	return view, err
+}
+
+// ListSavedViews satisfies the [SavedViews] interface.
+func (d *DB) ListSavedViews(ctx context.Context, userID uint64) ([]db.SavedView, error) {
+	return d.queries.ListSavedViews(ctx, userID)
+}
+
+// UpdateSavedView satisfies the [SavedViews] interface.
+func (d *DB) UpdateSavedView(ctx context.Context, view db.SavedView) error {
+	rows, err := d.queries.UpdateSavedView(ctx, db.UpdateSavedViewParams{
+		User:        view.User,
+		ID:          view.ID,
+		DisplayName: view.DisplayName,
+		Filter:      view.Filter,
+		OrderBy:     view.OrderBy,
+		Category:    view.Category,
+		Version:     view.Version + 1,
+		UpdateTime:  view.UpdateTime,
+	})
+	if err != nil || rows > 0 {
+		return err
+	}
+	// the update is skipped if either the view is missing or the version is stale
+	if _, err = d.GetSavedView(ctx, view.User, view.ID); err != nil {
+		return err
+	}
+	return ErrConflict
+}
+
+// DeleteSavedView satisfies the [SavedViews] interface.
+func (d *DB) DeleteSavedView(ctx context.Context, userID uint64, viewID int64) error {
+	rows, err := d.queries.DeleteSavedView(ctx, db.DeleteSavedViewParams{
+		User: userID,
+		ID:   viewID,
+	})
+	if err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CreateAuditEvent satisfies the [AuditEvents] interface.
+func (d *DB) CreateAuditEvent(ctx context.Context, event db.AuditEvent) error {
+	return d.queries.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		CreateTime: event.CreateTime,
+		Actor:      event.Actor,
+		ActorID:    event.ActorID,
+		Subject:    event.Subject,
+		SubjectID:  event.SubjectID,
+		Action:     event.Action,
+		Outcome:    event.Outcome,
+		Fields:     event.Fields,
+		IpAddress:  event.IpAddress,
+		UserAgent:  event.UserAgent,
+	})
+}
+
+// ListAuditEvents satisfies the [AuditEvents] interface.
+func (d *DB) ListAuditEvents(
+	ctx context.Context,
+	filter AuditEventFilter,
+	beforeID int64,
+	limit int32,
+) ([]AuditEvent, error) {
+	rows, err := d.queries.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		BeforeID: beforeID,
+		Action:   filter.Action,
+		Outcome:  filter.Outcome,
+		Limit:    int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]AuditEvent, len(rows))
+	for i, row := range rows {
+		events[i] = AuditEvent{AuditEvent: row.AuditEvent, ActorName: row.ActorName, SubjectName: row.SubjectName}
+	}
+	return events, nil
+}
+
+// ListAuditEventsAfter satisfies the [AuditEvents] interface.
+func (d *DB) ListAuditEventsAfter(ctx context.Context, afterID int64, limit int32) ([]AuditEvent, error) {
+	rows, err := d.queries.ListAuditEventsAfter(ctx, db.ListAuditEventsAfterParams{
+		AfterID: afterID,
+		Limit:   int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]AuditEvent, len(rows))
+	for i, row := range rows {
+		events[i] = AuditEvent{AuditEvent: row.AuditEvent, ActorName: row.ActorName, SubjectName: row.SubjectName}
+	}
+	return events, nil
+}
+
+// CountAuditEvents satisfies the [AuditEvents] interface.
+func (d *DB) CountAuditEvents(ctx context.Context) (int64, error) {
+	return d.queries.CountAuditEvents(ctx)
+}
+
+// GetOrCreateSecret satisfies the [Secrets] interface.
+func (d *DB) GetOrCreateSecret(ctx context.Context, name string, value []byte) ([]byte, error) {
+	err := d.queries.CreateSecret(ctx, db.CreateSecretParams{
+		Name:       name,
+		Value:      value,
+		CreateTime: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.queries.GetSecret(ctx, name)
+}
+
+// CreateInvite satisfies the [Invites] interface.
+func (d *DB) CreateInvite(ctx context.Context, invite db.Invite) (int64, error) {
+	return d.queries.CreateInvite(ctx, db.CreateInviteParams{
+		CodeHash:   invite.CodeHash,
+		Creator:    invite.Creator,
+		CreateTime: invite.CreateTime,
+		ExpireTime: invite.ExpireTime,
+	})
+}
+
+// CheckInvite satisfies the [Invites] interface.
+func (d *DB) CheckInvite(ctx context.Context, codeHash []byte, now time.Time) error {
+	count, err := d.queries.CountRedeemableInvites(ctx, db.CountRedeemableInvitesParams{
+		CodeHash: codeHash,
+		Now:      now.UTC(),
+	})
+	if err != nil {
+		return err
+	} else if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RedeemInvite satisfies the [Invites] interface. The invite is claimed
+// before the user is created in the same transaction, so concurrent
+// redemptions of the same invite cannot both succeed.
+func (d *DB) RedeemInvite(ctx context.Context, codeHash []byte, now time.Time, user db.User) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	queries := d.queries.WithTx(tx)
+	rows, err := queries.RedeemInvite(ctx, db.RedeemInviteParams{
+		RedeemTime: sql.NullTime{Time: now.UTC(), Valid: true},
+		CodeHash:   codeHash,
+	})
+	if err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNotFound
+	}
+	if err = d.upsertUser(ctx, queries, user); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 var _ Store = (*DB)(nil)
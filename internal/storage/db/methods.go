@@ -1,7 +1,20 @@
 package db
 
+// The roles a user may have, stored in the users.role column.
+const (
+	// RoleMember users may only access and manage their own data.
+	RoleMember = "member"
+	// RoleAdmin users may additionally manage other users.
+	RoleAdmin = "admin"
+)
+
 // Path returns the path to a user resource, used by the archive connect
 // service.
 func (u User) Path() string {
 	return "users/" + u.Name
 }
+
+// IsAdmin reports whether the user has the admin role.
+func (u User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
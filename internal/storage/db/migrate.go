@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// NewMigrator returns a migration provider for the embedded migrations, bound
+// to the given database handle.
+func NewMigrator(handle *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve migrations: %w", err)
+	}
+	migrator, err := goose.NewProvider(goose.DialectSQLite3, handle, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+	return migrator, nil
+}
+
+// LogMigrationResult logs the outcome of applying or rolling back a single
+// migration.
+func LogMigrationResult(ctx context.Context, logger *slog.Logger, result *goose.MigrationResult) {
+	logger.InfoContext(ctx, "migration "+result.Direction,
+		slog.Int64("version", result.Source.Version),
+		slog.String("source", filepath.Base(result.Source.Path)),
+		slog.Duration("duration", result.Duration),
+	)
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS chapter_counts
+(
+    path          TEXT    NOT NULL PRIMARY KEY,
+    chapter_count INTEGER NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chapter_counts;
+-- +goose StatementEnd
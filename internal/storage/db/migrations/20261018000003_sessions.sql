@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sessions
+(
+    token_hash  BLOB      NOT NULL PRIMARY KEY,
+    user        BIGINT    NOT NULL,
+    create_time TIMESTAMP NOT NULL,
+    access_time TIMESTAMP NOT NULL,
+    user_agent  TEXT      NOT NULL DEFAULT '',
+    FOREIGN KEY(user)
+      REFERENCES users(id)
+      ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user ON sessions(user);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS access_tokens
+(
+    id             INTEGER   NOT NULL PRIMARY KEY AUTOINCREMENT,
+    user           BIGINT    NOT NULL,
+    token_hash     BLOB      NOT NULL UNIQUE,
+    display_name   TEXT      NOT NULL DEFAULT '',
+    scope          INTEGER   NOT NULL,
+    create_time    TIMESTAMP NOT NULL,
+    last_used_time TIMESTAMP,
+    FOREIGN KEY(user)
+      REFERENCES users(id)
+      ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS access_tokens_user ON access_tokens(user);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS access_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- existing users have no create time, as SQLite cannot add a column with a
+-- non-constant default
+ALTER TABLE users ADD COLUMN create_time TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN create_time;
+-- +goose StatementEnd
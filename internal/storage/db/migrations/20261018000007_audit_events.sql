@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          INTEGER   NOT NULL PRIMARY KEY AUTOINCREMENT,
+    create_time TIMESTAMP NOT NULL,
+    actor       TEXT      NOT NULL DEFAULT '',
+    subject     TEXT      NOT NULL DEFAULT '',
+    action      INTEGER   NOT NULL,
+    outcome     INTEGER   NOT NULL,
+    ip_address  TEXT      NOT NULL DEFAULT '',
+    user_agent  TEXT      NOT NULL DEFAULT ''
+);
+
+-- users are referenced by name rather than ID, so events outlive the users
+-- they name, and the log is append-only
+CREATE TRIGGER IF NOT EXISTS audit_events_no_update
+    BEFORE UPDATE
+    ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_events_no_delete
+    BEFORE DELETE
+    ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invites
+(
+    id          INTEGER   NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code_hash   BLOB      NOT NULL UNIQUE,
+    creator     TEXT      NOT NULL DEFAULT '',
+    create_time TIMESTAMP NOT NULL,
+    expire_time TIMESTAMP NOT NULL,
+    redeem_time TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invites;
+-- +goose StatementEnd
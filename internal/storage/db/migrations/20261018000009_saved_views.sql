@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS saved_views
+(
+    id           INTEGER   NOT NULL PRIMARY KEY AUTOINCREMENT,
+    user         BIGINT    NOT NULL,
+    display_name TEXT      NOT NULL,
+    filter       TEXT      NOT NULL DEFAULT '',
+    order_by     TEXT      NOT NULL DEFAULT '',
+    category     TEXT      NOT NULL DEFAULT '',
+    version      INTEGER   NOT NULL DEFAULT 0,
+    create_time  TIMESTAMP NOT NULL,
+    update_time  TIMESTAMP NOT NULL,
+    FOREIGN KEY(user)
+      REFERENCES users(id)
+      ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS saved_views_user ON saved_views(user);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saved_views;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS secrets
+(
+    name        TEXT      NOT NULL PRIMARY KEY,
+    value       BLOB      NOT NULL,
+    create_time TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS secrets;
+-- +goose StatementEnd
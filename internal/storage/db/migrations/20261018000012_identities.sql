@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS identities
+(
+    issuer      TEXT      NOT NULL,
+    subject     TEXT      NOT NULL,
+    user        BIGINT    NOT NULL,
+    create_time TIMESTAMP NOT NULL,
+    PRIMARY KEY (issuer, subject),
+    FOREIGN KEY (user)
+        REFERENCES users (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS identities_user ON identities (user);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS identities;
+-- +goose StatementEnd
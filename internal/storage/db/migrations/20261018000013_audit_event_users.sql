@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- users are also referenced by ID, so events follow them when renamed; the
+-- names are kept, as the IDs of deleted users resolve to nothing
+ALTER TABLE audit_events
+    ADD COLUMN actor_id BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE audit_events
+    ADD COLUMN subject_id BIGINT NOT NULL DEFAULT 0;
+
+-- the fields of the subject changed by the action, comma separated
+ALTER TABLE audit_events
+    ADD COLUMN fields TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE audit_events
+    DROP COLUMN fields;
+
+ALTER TABLE audit_events
+    DROP COLUMN subject_id;
+
+ALTER TABLE audit_events
+    DROP COLUMN actor_id;
+-- +goose StatementEnd
@@ -6,8 +6,54 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
+type AccessToken struct {
+	ID           int64
+	User         uint64
+	TokenHash    []byte
+	DisplayName  string
+	Scope        int64
+	CreateTime   time.Time
+	LastUsedTime sql.NullTime
+}
+
+type AuditEvent struct {
+	ID         int64
+	CreateTime time.Time
+	Actor      string
+	Subject    string
+	Action     int64
+	Outcome    int64
+	IpAddress  string
+	UserAgent  string
+	ActorID    uint64
+	SubjectID  uint64
+	Fields     string
+}
+
+type ChapterCount struct {
+	Path         string
+	ChapterCount int64
+}
+
+type Identity struct {
+	Issuer     string
+	Subject    string
+	User       uint64
+	CreateTime time.Time
+}
+
+type Invite struct {
+	ID         int64
+	CodeHash   []byte
+	Creator    string
+	CreateTime time.Time
+	ExpireTime time.Time
+	RedeemTime sql.NullTime
+}
+
 type Resource struct {
 	User     uint64
 	Path     string
@@ -15,10 +61,40 @@ type Resource struct {
 	Starred  bool
 	ViewTime sql.NullTime
 	ReadTime sql.NullTime
+	Version  int64
+}
+
+type SavedView struct {
+	ID          int64
+	User        uint64
+	DisplayName string
+	Filter      string
+	OrderBy     string
+	Category    string
+	Version     int64
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+type Secret struct {
+	Name       string
+	Value      []byte
+	CreateTime time.Time
+}
+
+type Session struct {
+	TokenHash  []byte
+	User       uint64
+	CreateTime time.Time
+	AccessTime time.Time
+	UserAgent  string
 }
 
 type User struct {
 	ID           uint64
 	Name         string
 	PasswordHash []byte
+	Version      int64
+	Role         string
+	CreateTime   sql.NullTime
 }
@@ -5,24 +5,41 @@ package db
 import (
 	"context"
 	"database/sql"
-	"embed"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/pressly/goose/v3"
 	"modernc.org/sqlite" // sqlite sql.DB driver initialization
 )
 
-//go:embed migrations/*.sql
-var migrations embed.FS
+// ErrPendingMigrations is returned by [Open] when automatic migrations are
+// disabled and the database schema is behind the current version.
+var ErrPendingMigrations = errors.New("database has pending migrations")
 
 // Open initializes a SQLite DB connection to the specified dbPath. If the
-// database file does not exist, it attempts to create it, and then migrates the
-// database to match the current state expected of the system.
-func Open(ctx context.Context, logger *slog.Logger, dbPath string) (*sql.DB, error) {
+// database file does not exist, it attempts to create it. If autoMigrate is
+// true, the database is then migrated to match the current state expected of
+// the system; otherwise, an [ErrPendingMigrations] error is returned if the
+// schema is out of date.
+func Open(ctx context.Context, logger *slog.Logger, dbPath string, autoMigrate bool) (*sql.DB, error) {
+	handle, err := Connect(ctx, dbPath)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.With(slog.String("db", dbPath))
+	if err = checkMigrations(ctx, logger, handle, autoMigrate); err != nil {
+		return nil, errors.Join(err, handle.Close())
+	}
+	return handle, nil
+}
+
+// Connect initializes a SQLite DB connection to the specified dbPath without
+// applying any migrations. If the database file does not exist, it attempts to
+// create it.
+func Connect(ctx context.Context, dbPath string) (*sql.DB, error) {
 	if dbPath == ":memory:" { //nolint:revive // for documentation
 		// noop
 	} else if _, err := os.Stat(dbPath); err != nil {
@@ -57,13 +74,30 @@ func Open(ctx context.Context, logger *slog.Logger, dbPath string) (*sql.DB, err
 		return nil, fmt.Errorf("failed to ping DB: %w", err)
 	}
 	handle.SetMaxOpenConns(1)
+	return handle, nil
+}
 
-	logger = logger.With(slog.String("db", dbPath))
-	goose.SetLogger(slog.NewLogLogger(logger.Handler(), slog.LevelDebug))
-	goose.SetBaseFS(migrations)
-
-	if err := goose.SetDialect("sqlite3"); err != nil {
-		return nil, fmt.Errorf("failed to set migration dialect: %w", err)
+func checkMigrations(ctx context.Context, logger *slog.Logger, handle *sql.DB, autoMigrate bool) error {
+	migrator, err := NewMigrator(handle)
+	if err != nil {
+		return err
+	}
+	if autoMigrate {
+		results, err := migrator.Up(ctx)
+		for _, result := range results {
+			LogMigrationResult(ctx, logger, result)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+		return nil
+	}
+	current, target, err := migrator.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve database version: %w", err)
+	} else if current < target {
+		return fmt.Errorf("%w: schema is at version %d but %d is required; run `erato db migrate`",
+			ErrPendingMigrations, current, target)
 	}
-	return handle, goose.UpContext(ctx, handle, "migrations")
+	return nil
 }
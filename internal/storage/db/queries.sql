@@ -13,25 +13,58 @@ FROM resources
 WHERE user = ?
   AND path in (sqlc.slice('paths'));
 
--- UpsertResource upserts a resource.
+-- UpsertResource upserts a resource with its next version. Existing resources
+-- are only updated if they are at the preceding version; otherwise no rows are
+-- returned.
 -- name: UpsertResource :one
-INSERT INTO resources (user, path, hidden, starred, view_time, read_time)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+INSERT INTO resources (user, path, hidden, starred, view_time, read_time, version)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
 ON CONFLICT DO UPDATE SET hidden    = ?3,
                           starred   = ?4,
                           view_time = ?5,
-                          read_time = ?6
+                          read_time = ?6,
+                          version   = ?7
 WHERE user = ?1
   AND path = ?2
+  AND version = ?7 - 1
 RETURNING *;
 
--- UpsertUser adds a new user with the given name and password_hash.
+-- GetProgress returns the recorded number of chapters of each of the entry
+-- paths with one, along with the number of them the user has read. The
+-- chapters are matched by range rather than LIKE so the primary key index can
+-- be used ('0' is the character immediately following '/').
+-- name: GetProgress :many
+SELECT chapter_counts.path,
+       chapter_counts.chapter_count,
+       (SELECT COUNT(*)
+        FROM resources
+        WHERE resources.user = ?
+          AND resources.path > chapter_counts.path || '/chapters/'
+          AND resources.path < chapter_counts.path || '/chapters0'
+          AND resources.read_time IS NOT NULL) AS read_count
+FROM chapter_counts
+WHERE chapter_counts.path IN (sqlc.slice('paths'));
+
+-- SetChapterCount records the number of chapters of the entry path, shared by
+-- all users. It is not versioned like the user's own resource data.
+-- name: SetChapterCount :exec
+INSERT INTO chapter_counts (path, chapter_count)
+VALUES (?1, ?2)
+ON CONFLICT DO UPDATE SET chapter_count = ?2
+WHERE chapter_count != ?2;
+
+-- UpsertUser adds a new user with the given name, password_hash and role, or
+-- updates the user with the given ID if it is at the version preceding the
+-- given one.
 -- name: UpsertUser :one
-INSERT INTO users (id, name, password_hash)
-VALUES (?1, ?2, ?3)
+INSERT INTO users (id, name, password_hash, version, role, create_time)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
 ON CONFLICT DO UPDATE SET name          = ?2,
-                          password_hash = ?3
+                          password_hash = ?3,
+                          version       = ?4,
+                          role          = ?5
 WHERE id = ?1
+  AND version = ?4 - 1
 RETURNING *;
 
 -- GetUser fetches a user by ID.
@@ -56,13 +89,6 @@ WHERE name > sqlc.arg(after_name)
 ORDER BY name
 LIMIT ?1;
 
--- SetUserName updates a user's name with the given ID.
--- name: SetUserName :one
-UPDATE users
-SET name = ?2
-WHERE id = ?1
-RETURNING *;
-
 -- SetUserPasswordHash updates a user's password hash with the given ID.
 -- name: SetUserPasswordHash :one
 UPDATE users
@@ -70,8 +96,299 @@ SET password_hash = ?2
 WHERE id = ?1
 RETURNING *;
 
--- DeleteUser removes a user and their resources from the system.
+-- GetUserActivity summarizes the user's resources and when they last used
+-- the archive or authenticated. Times may be stored with different offsets,
+-- so the latest of each column is found by julianday rather than compared as
+-- text. They are returned as text, since the column type is not preserved,
+-- and each may be empty.
+-- name: GetUserActivity :one
+SELECT COUNT(*)                                   AS resources,
+       COUNT(read_time)                           AS read,
+       CAST(COALESCE(SUM(starred), 0) AS INTEGER) AS starred,
+       CAST(COALESCE(SUM(hidden), 0) AS INTEGER)  AS hidden,
+       CAST(COALESCE((SELECT view_time
+                      FROM resources AS latest
+                      WHERE latest.user = ?1
+                        AND latest.view_time IS NOT NULL
+                      ORDER BY julianday(latest.view_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_view_time,
+       CAST(COALESCE((SELECT read_time
+                      FROM resources AS latest
+                      WHERE latest.user = ?1
+                        AND latest.read_time IS NOT NULL
+                      ORDER BY julianday(latest.read_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_read_time,
+       CAST(COALESCE((SELECT access_time
+                      FROM sessions
+                      WHERE sessions.user = ?1
+                      ORDER BY julianday(access_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_session_time,
+       CAST(COALESCE((SELECT last_used_time
+                      FROM access_tokens
+                      WHERE access_tokens.user = ?1
+                        AND last_used_time IS NOT NULL
+                      ORDER BY julianday(last_used_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_token_time
+FROM resources
+WHERE resources.user = ?1;
+
+-- DeleteUser removes a user from the system. Their other rows must be removed
+-- first, as foreign keys are not enforced.
 -- name: DeleteUser :exec
 DELETE
 FROM users
 WHERE id = ?;
+
+-- DeleteAllUserSessions removes all of a user's login sessions.
+-- name: DeleteAllUserSessions :exec
+DELETE
+FROM sessions
+WHERE user = ?;
+
+-- DeleteUserAccessTokens revokes all of a user's access tokens.
+-- name: DeleteUserAccessTokens :exec
+DELETE
+FROM access_tokens
+WHERE user = ?;
+
+-- DeleteUserResources removes all of a user's stored resource data.
+-- name: DeleteUserResources :exec
+DELETE
+FROM resources
+WHERE user = ?;
+
+-- DeleteUserSavedViews removes all of a user's saved views.
+-- name: DeleteUserSavedViews :exec
+DELETE
+FROM saved_views
+WHERE user = ?;
+
+-- CreateSession stores a new login session.
+-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, user, create_time, access_time, user_agent)
+VALUES (?, ?, ?, ?, ?);
+
+-- GetSession fetches a login session by the hash of its token.
+-- name: GetSession :one
+SELECT *
+FROM sessions
+WHERE token_hash = ?
+LIMIT 1;
+
+-- TouchSession updates the last access time of a login session.
+-- name: TouchSession :exec
+UPDATE sessions
+SET access_time = ?2
+WHERE token_hash = ?1;
+
+-- DeleteSession removes a login session.
+-- name: DeleteSession :exec
+DELETE
+FROM sessions
+WHERE token_hash = ?;
+
+-- DeleteUserSessions removes all of a user's login sessions except the one
+-- with the given token hash.
+-- name: DeleteUserSessions :exec
+DELETE
+FROM sessions
+WHERE user = sqlc.arg(user)
+  AND token_hash != sqlc.arg(except_token_hash);
+
+-- DeleteStaleSessions removes all of a user's login sessions last accessed or
+-- created before the given times.
+-- name: DeleteStaleSessions :exec
+DELETE
+FROM sessions
+WHERE user = sqlc.arg(user)
+  AND (access_time < sqlc.arg(access_before) OR create_time < sqlc.arg(create_before));
+
+-- CreateAccessToken stores a new personal access token, returning its ID.
+-- name: CreateAccessToken :one
+INSERT INTO access_tokens (user, token_hash, display_name, scope, create_time)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id;
+
+-- GetAccessTokenByHash fetches a personal access token by the hash of its
+-- token.
+-- name: GetAccessTokenByHash :one
+SELECT *
+FROM access_tokens
+WHERE token_hash = ?
+LIMIT 1;
+
+-- ListAccessTokens fetches all of a user's personal access tokens in order of
+-- creation.
+-- name: ListAccessTokens :many
+SELECT *
+FROM access_tokens
+WHERE user = ?
+ORDER BY id;
+
+-- TouchAccessToken updates the last used time of a personal access token.
+-- name: TouchAccessToken :exec
+UPDATE access_tokens
+SET last_used_time = ?2
+WHERE id = ?1;
+
+-- DeleteAccessToken removes one of a user's personal access tokens.
+-- name: DeleteAccessToken :execrows
+DELETE
+FROM access_tokens
+WHERE user = ?
+  AND id = ?;
+
+-- CreateSavedView stores a new saved view, returning its ID.
+-- name: CreateSavedView :one
+INSERT INTO saved_views (user, display_name, filter, order_by, category, version, create_time, update_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- GetSavedView fetches one of a user's saved views.
+-- name: GetSavedView :one
+SELECT *
+FROM saved_views
+WHERE user = ?
+  AND id = ?
+LIMIT 1;
+
+-- ListSavedViews fetches all of a user's saved views in order of creation.
+-- name: ListSavedViews :many
+SELECT *
+FROM saved_views
+WHERE user = ?
+ORDER BY id;
+
+-- UpdateSavedView updates one of a user's saved views with its next version,
+-- if it is at the preceding version.
+-- name: UpdateSavedView :execrows
+UPDATE saved_views
+SET display_name = ?3,
+    filter       = ?4,
+    order_by     = ?5,
+    category     = ?6,
+    version      = ?7,
+    update_time  = ?8
+WHERE user = ?1
+  AND id = ?2
+  AND version = ?7 - 1;
+
+-- DeleteSavedView removes one of a user's saved views.
+-- name: DeleteSavedView :execrows
+DELETE
+FROM saved_views
+WHERE user = ?
+  AND id = ?;
+
+-- CreateAuditEvent appends an event to the audit log.
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (create_time, actor, actor_id, subject, subject_id, action, outcome, fields, ip_address,
+                          user_agent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- ListAuditEvents fetches the audit events before the given ID, newest first,
+-- along with the current names of their actor and subject. The events may be
+-- restricted to an action and outcome, which are ignored if zero.
+-- name: ListAuditEvents :many
+SELECT sqlc.embed(audit_events),
+       CAST(COALESCE(actor.name, '') AS TEXT)   AS actor_name,
+       CAST(COALESCE(subject.name, '') AS TEXT) AS subject_name
+FROM audit_events
+         LEFT JOIN users AS actor ON actor.id = audit_events.actor_id
+         LEFT JOIN users AS subject ON subject.id = audit_events.subject_id
+WHERE audit_events.id < sqlc.arg(before_id)
+  AND (CAST(sqlc.arg(action) AS INTEGER) = 0 OR audit_events.action = sqlc.arg(action))
+  AND (CAST(sqlc.arg(outcome) AS INTEGER) = 0 OR audit_events.outcome = sqlc.arg(outcome))
+ORDER BY audit_events.id DESC
+LIMIT sqlc.arg(limit);
+
+-- ListAuditEventsAfter fetches the audit events after the given ID, oldest
+-- first, along with the current names of their actor and subject.
+-- name: ListAuditEventsAfter :many
+SELECT sqlc.embed(audit_events),
+       CAST(COALESCE(actor.name, '') AS TEXT)   AS actor_name,
+       CAST(COALESCE(subject.name, '') AS TEXT) AS subject_name
+FROM audit_events
+         LEFT JOIN users AS actor ON actor.id = audit_events.actor_id
+         LEFT JOIN users AS subject ON subject.id = audit_events.subject_id
+WHERE audit_events.id > sqlc.arg(after_id)
+ORDER BY audit_events.id
+LIMIT sqlc.arg(limit);
+
+-- CountAuditEvents counts the events in the audit log.
+-- name: CountAuditEvents :one
+SELECT COUNT(*)
+FROM audit_events;
+
+-- CreateInvite stores a new invite, returning its ID.
+-- name: CreateInvite :one
+INSERT INTO invites (code_hash, creator, create_time, expire_time)
+VALUES (?, ?, ?, ?)
+RETURNING id;
+
+-- CountRedeemableInvites counts the invites with the code hash that are
+-- unexpired and not yet redeemed. Times are stored in UTC, so they can be
+-- compared as text.
+-- name: CountRedeemableInvites :one
+SELECT COUNT(*)
+FROM invites
+WHERE code_hash = sqlc.arg(code_hash)
+  AND redeem_time IS NULL
+  AND expire_time > sqlc.arg(now);
+
+-- RedeemInvite marks an unexpired invite as redeemed if it has not been
+-- already. Times are stored in UTC, so they can be compared as text.
+-- name: RedeemInvite :execrows
+UPDATE invites
+SET redeem_time = sqlc.arg(redeem_time)
+WHERE code_hash = sqlc.arg(code_hash)
+  AND redeem_time IS NULL
+  AND expire_time > sqlc.arg(redeem_time);
+
+-- CreateSecret stores a new secret, unless one with the same name exists.
+-- name: CreateSecret :exec
+INSERT INTO secrets (name, value, create_time)
+VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING;
+
+-- GetSecret fetches the value of a secret by name.
+-- name: GetSecret :one
+SELECT value
+FROM secrets
+WHERE name = ?;
+
+-- GetUserByIdentity fetches the user linked to the subject of an issuer.
+-- name: GetUserByIdentity :one
+SELECT sqlc.embed(users)
+FROM identities
+         JOIN users ON users.id = identities.user
+WHERE identities.issuer = ?
+  AND identities.subject = ?;
+
+-- CreateIdentity links the subject of an issuer to a user, unless it is
+-- already linked to one, in which case no rows are affected.
+-- name: CreateIdentity :execrows
+INSERT INTO identities (issuer, subject, user, create_time)
+VALUES (?, ?, ?, ?)
+ON CONFLICT DO NOTHING;
+
+-- ListIdentities returns the identities linked to a user in order of creation.
+-- name: ListIdentities :many
+SELECT *
+FROM identities
+WHERE user = ?
+ORDER BY create_time, issuer, subject;
+
+-- DeleteIdentity unlinks the subject of an issuer from a user.
+-- name: DeleteIdentity :execrows
+DELETE
+FROM identities
+WHERE user = ?
+  AND issuer = ?
+  AND subject = ?;
+
+-- DeleteUserIdentities unlinks all of a user's identities.
+-- name: DeleteUserIdentities :exec
+DELETE
+FROM identities
+WHERE user = ?;
@@ -9,22 +9,517 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 )
 
+const countAuditEvents = `-- name: CountAuditEvents :one
+SELECT COUNT(*)
+FROM audit_events
+`
+
+// CountAuditEvents counts the events in the audit log.
+func (q *Queries) CountAuditEvents(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuditEvents)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRedeemableInvites = `-- name: CountRedeemableInvites :one
+SELECT COUNT(*)
+FROM invites
+WHERE code_hash = ?1
+  AND redeem_time IS NULL
+  AND expire_time > ?2
+`
+
+type CountRedeemableInvitesParams struct {
+	CodeHash []byte
+	Now      time.Time
+}
+
+// CountRedeemableInvites counts the invites with the code hash that are
+// unexpired and not yet redeemed. Times are stored in UTC, so they can be
+// compared as text.
+func (q *Queries) CountRedeemableInvites(ctx context.Context, arg CountRedeemableInvitesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRedeemableInvites, arg.CodeHash, arg.Now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAccessToken = `-- name: CreateAccessToken :one
+INSERT INTO access_tokens (user, token_hash, display_name, scope, create_time)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type CreateAccessTokenParams struct {
+	User        uint64
+	TokenHash   []byte
+	DisplayName string
+	Scope       int64
+	CreateTime  time.Time
+}
+
+// CreateAccessToken stores a new personal access token, returning its ID.
+func (q *Queries) CreateAccessToken(ctx context.Context, arg CreateAccessTokenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createAccessToken,
+		arg.User,
+		arg.TokenHash,
+		arg.DisplayName,
+		arg.Scope,
+		arg.CreateTime,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (create_time, actor, actor_id, subject, subject_id, action, outcome, fields, ip_address,
+                          user_agent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateAuditEventParams struct {
+	CreateTime time.Time
+	Actor      string
+	ActorID    uint64
+	Subject    string
+	SubjectID  uint64
+	Action     int64
+	Outcome    int64
+	Fields     string
+	IpAddress  string
+	UserAgent  string
+}
+
+// CreateAuditEvent appends an event to the audit log.
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEvent,
+		arg.CreateTime,
+		arg.Actor,
+		arg.ActorID,
+		arg.Subject,
+		arg.SubjectID,
+		arg.Action,
+		arg.Outcome,
+		arg.Fields,
+		arg.IpAddress,
+		arg.UserAgent,
+	)
+	return err
+}
+
+const createIdentity = `-- name: CreateIdentity :execrows
+INSERT INTO identities (issuer, subject, user, create_time)
+VALUES (?, ?, ?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type CreateIdentityParams struct {
+	Issuer     string
+	Subject    string
+	User       uint64
+	CreateTime time.Time
+}
+
+// CreateIdentity links the subject of an issuer to a user, unless it is
+// already linked to one, in which case no rows are affected.
+func (q *Queries) CreateIdentity(ctx context.Context, arg CreateIdentityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createIdentity,
+		arg.Issuer,
+		arg.Subject,
+		arg.User,
+		arg.CreateTime,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createInvite = `-- name: CreateInvite :one
+INSERT INTO invites (code_hash, creator, create_time, expire_time)
+VALUES (?, ?, ?, ?)
+RETURNING id
+`
+
+type CreateInviteParams struct {
+	CodeHash   []byte
+	Creator    string
+	CreateTime time.Time
+	ExpireTime time.Time
+}
+
+// CreateInvite stores a new invite, returning its ID.
+func (q *Queries) CreateInvite(ctx context.Context, arg CreateInviteParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createInvite,
+		arg.CodeHash,
+		arg.Creator,
+		arg.CreateTime,
+		arg.ExpireTime,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createSavedView = `-- name: CreateSavedView :one
+INSERT INTO saved_views (user, display_name, filter, order_by, category, version, create_time, update_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
+`
+
+type CreateSavedViewParams struct {
+	User        uint64
+	DisplayName string
+	Filter      string
+	OrderBy     string
+	Category    string
+	Version     int64
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+// CreateSavedView stores a new saved view, returning its ID.
+func (q *Queries) CreateSavedView(ctx context.Context, arg CreateSavedViewParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createSavedView,
+		arg.User,
+		arg.DisplayName,
+		arg.Filter,
+		arg.OrderBy,
+		arg.Category,
+		arg.Version,
+		arg.CreateTime,
+		arg.UpdateTime,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createSecret = `-- name: CreateSecret :exec
+INSERT INTO secrets (name, value, create_time)
+VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type CreateSecretParams struct {
+	Name       string
+	Value      []byte
+	CreateTime time.Time
+}
+
+// CreateSecret stores a new secret, unless one with the same name exists.
+func (q *Queries) CreateSecret(ctx context.Context, arg CreateSecretParams) error {
+	_, err := q.db.ExecContext(ctx, createSecret, arg.Name, arg.Value, arg.CreateTime)
+	return err
+}
+
+const createSession = `-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, user, create_time, access_time, user_agent)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateSessionParams struct {
+	TokenHash  []byte
+	User       uint64
+	CreateTime time.Time
+	AccessTime time.Time
+	UserAgent  string
+}
+
+// CreateSession stores a new login session.
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createSession,
+		arg.TokenHash,
+		arg.User,
+		arg.CreateTime,
+		arg.AccessTime,
+		arg.UserAgent,
+	)
+	return err
+}
+
+const deleteAccessToken = `-- name: DeleteAccessToken :execrows
+DELETE
+FROM access_tokens
+WHERE user = ?
+  AND id = ?
+`
+
+type DeleteAccessTokenParams struct {
+	User uint64
+	ID   int64
+}
+
+// DeleteAccessToken removes one of a user's personal access tokens.
+func (q *Queries) DeleteAccessToken(ctx context.Context, arg DeleteAccessTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAccessToken, arg.User, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAllUserSessions = `-- name: DeleteAllUserSessions :exec
+DELETE
+FROM sessions
+WHERE user = ?
+`
+
+// DeleteAllUserSessions removes all of a user's login sessions.
+func (q *Queries) DeleteAllUserSessions(ctx context.Context, user uint64) error {
+	_, err := q.db.ExecContext(ctx, deleteAllUserSessions, user)
+	return err
+}
+
+const deleteIdentity = `-- name: DeleteIdentity :execrows
+DELETE
+FROM identities
+WHERE user = ?
+  AND issuer = ?
+  AND subject = ?
+`
+
+type DeleteIdentityParams struct {
+	User    uint64
+	Issuer  string
+	Subject string
+}
+
+// DeleteIdentity unlinks the subject of an issuer from a user.
+func (q *Queries) DeleteIdentity(ctx context.Context, arg DeleteIdentityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteIdentity, arg.User, arg.Issuer, arg.Subject)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSavedView = `-- name: DeleteSavedView :execrows
+DELETE
+FROM saved_views
+WHERE user = ?
+  AND id = ?
+`
+
+type DeleteSavedViewParams struct {
+	User uint64
+	ID   int64
+}
+
+// DeleteSavedView removes one of a user's saved views.
+func (q *Queries) DeleteSavedView(ctx context.Context, arg DeleteSavedViewParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSavedView, arg.User, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE
+FROM sessions
+WHERE token_hash = ?
+`
+
+// DeleteSession removes a login session.
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash []byte) error {
+	_, err := q.db.ExecContext(ctx, deleteSession, tokenHash)
+	return err
+}
+
+const deleteStaleSessions = `-- name: DeleteStaleSessions :exec
+DELETE
+FROM sessions
+WHERE user = ?1
+  AND (access_time < ?2 OR create_time < ?3)
+`
+
+type DeleteStaleSessionsParams struct {
+	User         uint64
+	AccessBefore time.Time
+	CreateBefore time.Time
+}
+
+// DeleteStaleSessions removes all of a user's login sessions last accessed or
+// created before the given times.
+func (q *Queries) DeleteStaleSessions(ctx context.Context, arg DeleteStaleSessionsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteStaleSessions, arg.User, arg.AccessBefore, arg.CreateBefore)
+	return err
+}
+
 const deleteUser = `-- name: DeleteUser :exec
 DELETE
 FROM users
 WHERE id = ?
 `
 
-// DeleteUser removes a user and their resources from the system.
+// DeleteUser removes a user from the system. Their other rows must be removed
+// first, as foreign keys are not enforced.
 func (q *Queries) DeleteUser(ctx context.Context, id uint64) error {
 	_, err := q.db.ExecContext(ctx, deleteUser, id)
 	return err
 }
 
+const deleteUserAccessTokens = `-- name: DeleteUserAccessTokens :exec
+DELETE
+FROM access_tokens
+WHERE user = ?
+`
+
+// DeleteUserAccessTokens revokes all of a user's access tokens.
+func (q *Queries) DeleteUserAccessTokens(ctx context.Context, user uint64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserAccessTokens, user)
+	return err
+}
+
+const deleteUserIdentities = `-- name: DeleteUserIdentities :exec
+DELETE
+FROM identities
+WHERE user = ?
+`
+
+// DeleteUserIdentities unlinks all of a user's identities.
+func (q *Queries) DeleteUserIdentities(ctx context.Context, user uint64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserIdentities, user)
+	return err
+}
+
+const deleteUserResources = `-- name: DeleteUserResources :exec
+DELETE
+FROM resources
+WHERE user = ?
+`
+
+// DeleteUserResources removes all of a user's stored resource data.
+func (q *Queries) DeleteUserResources(ctx context.Context, user uint64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserResources, user)
+	return err
+}
+
+const deleteUserSavedViews = `-- name: DeleteUserSavedViews :exec
+DELETE
+FROM saved_views
+WHERE user = ?
+`
+
+// DeleteUserSavedViews removes all of a user's saved views.
+func (q *Queries) DeleteUserSavedViews(ctx context.Context, user uint64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSavedViews, user)
+	return err
+}
+
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE
+FROM sessions
+WHERE user = ?1
+  AND token_hash != ?2
+`
+
+type DeleteUserSessionsParams struct {
+	User            uint64
+	ExceptTokenHash []byte
+}
+
+// DeleteUserSessions removes all of a user's login sessions except the one
+// with the given token hash.
+func (q *Queries) DeleteUserSessions(ctx context.Context, arg DeleteUserSessionsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSessions, arg.User, arg.ExceptTokenHash)
+	return err
+}
+
+const getAccessTokenByHash = `-- name: GetAccessTokenByHash :one
+SELECT id, user, token_hash, display_name, scope, create_time, last_used_time
+FROM access_tokens
+WHERE token_hash = ?
+LIMIT 1
+`
+
+// GetAccessTokenByHash fetches a personal access token by the hash of its
+// token.
+func (q *Queries) GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (AccessToken, error) {
+	row := q.db.QueryRowContext(ctx, getAccessTokenByHash, tokenHash)
+	var i AccessToken
+	err := row.Scan(
+		&i.ID,
+		&i.User,
+		&i.TokenHash,
+		&i.DisplayName,
+		&i.Scope,
+		&i.CreateTime,
+		&i.LastUsedTime,
+	)
+	return i, err
+}
+
+const getProgress = `-- name: GetProgress :many
+SELECT chapter_counts.path,
+       chapter_counts.chapter_count,
+       (SELECT COUNT(*)
+        FROM resources
+        WHERE resources.user = ?
+          AND resources.path > chapter_counts.path || '/chapters/'
+          AND resources.path < chapter_counts.path || '/chapters0'
+          AND resources.read_time IS NOT NULL) AS read_count
+FROM chapter_counts
+WHERE chapter_counts.path IN (/*SLICE:paths*/?)
+`
+
+type GetProgressParams struct {
+	User  uint64
+	Paths []string
+}
+
+type GetProgressRow struct {
+	Path         string
+	ChapterCount int64
+	ReadCount    int64
+}
+
+// GetProgress returns the recorded number of chapters of each of the entry
+// paths with one, along with the number of them the user has read. The
+// chapters are matched by range rather than LIKE so the primary key index can
+// be used ('0' is the character immediately following '/').
+func (q *Queries) GetProgress(ctx context.Context, arg GetProgressParams) ([]GetProgressRow, error) {
+	query := getProgress
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.User)
+	if len(arg.Paths) > 0 {
+		for _, v := range arg.Paths {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:paths*/?", strings.Repeat(",?", len(arg.Paths))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:paths*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProgressRow
+	for rows.Next() {
+		var i GetProgressRow
+		if err := rows.Scan(&i.Path, &i.ChapterCount, &i.ReadCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getResource = `-- name: GetResource :one
-SELECT user, path, hidden, starred, view_time, read_time
+SELECT user, path, hidden, starred, view_time, read_time, version
 FROM resources
 WHERE user = ?
   AND path = ?
@@ -47,12 +542,13 @@ func (q *Queries) GetResource(ctx context.Context, arg GetResourceParams) (Resou
 		&i.Starred,
 		&i.ViewTime,
 		&i.ReadTime,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getResources = `-- name: GetResources :many
-SELECT user, path, hidden, starred, view_time, read_time
+SELECT user, path, hidden, starred, view_time, read_time, version
 FROM resources
 WHERE user = ?
   AND path in (/*SLICE:paths*/?)
@@ -91,6 +587,7 @@ func (q *Queries) GetResources(ctx context.Context, arg GetResourcesParams) ([]R
 			&i.Starred,
 			&i.ViewTime,
 			&i.ReadTime,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -105,8 +602,74 @@ func (q *Queries) GetResources(ctx context.Context, arg GetResourcesParams) ([]R
 	return items, nil
 }
 
+const getSavedView = `-- name: GetSavedView :one
+SELECT id, user, display_name, "filter", order_by, category, version, create_time, update_time
+FROM saved_views
+WHERE user = ?
+  AND id = ?
+LIMIT 1
+`
+
+type GetSavedViewParams struct {
+	User uint64
+	ID   int64
+}
+
+// GetSavedView fetches one of a user's saved views.
+func (q *Queries) GetSavedView(ctx context.Context, arg GetSavedViewParams) (SavedView, error) {
+	row := q.db.QueryRowContext(ctx, getSavedView, arg.User, arg.ID)
+	var i SavedView
+	err := row.Scan(
+		&i.ID,
+		&i.User,
+		&i.DisplayName,
+		&i.Filter,
+		&i.OrderBy,
+		&i.Category,
+		&i.Version,
+		&i.CreateTime,
+		&i.UpdateTime,
+	)
+	return i, err
+}
+
+const getSecret = `-- name: GetSecret :one
+SELECT value
+FROM secrets
+WHERE name = ?
+`
+
+// GetSecret fetches the value of a secret by name.
+func (q *Queries) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getSecret, name)
+	var value []byte
+	err := row.Scan(&value)
+	return value, err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT token_hash, user, create_time, access_time, user_agent
+FROM sessions
+WHERE token_hash = ?
+LIMIT 1
+`
+
+// GetSession fetches a login session by the hash of its token.
+func (q *Queries) GetSession(ctx context.Context, tokenHash []byte) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, tokenHash)
+	var i Session
+	err := row.Scan(
+		&i.TokenHash,
+		&i.User,
+		&i.CreateTime,
+		&i.AccessTime,
+		&i.UserAgent,
+	)
+	return i, err
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, name, password_hash
+SELECT id, name, password_hash, version, role, create_time
 FROM users
 WHERE id = ?
 LIMIT 1
@@ -116,12 +679,115 @@ LIMIT 1
 func (q *Queries) GetUser(ctx context.Context, id uint64) (User, error) {
 	row := q.db.QueryRowContext(ctx, getUser, id)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Version,
+		&i.Role,
+		&i.CreateTime,
+	)
+	return i, err
+}
+
+const getUserActivity = `-- name: GetUserActivity :one
+SELECT COUNT(*)                                   AS resources,
+       COUNT(read_time)                           AS read,
+       CAST(COALESCE(SUM(starred), 0) AS INTEGER) AS starred,
+       CAST(COALESCE(SUM(hidden), 0) AS INTEGER)  AS hidden,
+       CAST(COALESCE((SELECT view_time
+                      FROM resources AS latest
+                      WHERE latest.user = ?1
+                        AND latest.view_time IS NOT NULL
+                      ORDER BY julianday(latest.view_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_view_time,
+       CAST(COALESCE((SELECT read_time
+                      FROM resources AS latest
+                      WHERE latest.user = ?1
+                        AND latest.read_time IS NOT NULL
+                      ORDER BY julianday(latest.read_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_read_time,
+       CAST(COALESCE((SELECT access_time
+                      FROM sessions
+                      WHERE sessions.user = ?1
+                      ORDER BY julianday(access_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_session_time,
+       CAST(COALESCE((SELECT last_used_time
+                      FROM access_tokens
+                      WHERE access_tokens.user = ?1
+                        AND last_used_time IS NOT NULL
+                      ORDER BY julianday(last_used_time) DESC
+                      LIMIT 1), '') AS TEXT) AS last_token_time
+FROM resources
+WHERE resources.user = ?1
+`
+
+type GetUserActivityRow struct {
+	Resources       int64
+	Read            int64
+	Starred         int64
+	Hidden          int64
+	LastViewTime    string
+	LastReadTime    string
+	LastSessionTime string
+	LastTokenTime   string
+}
+
+// GetUserActivity summarizes the user's resources and when they last used
+// the archive or authenticated. Times may be stored with different offsets,
+// so the latest of each column is found by julianday rather than compared as
+// text. They are returned as text, since the column type is not preserved,
+// and each may be empty.
+func (q *Queries) GetUserActivity(ctx context.Context, user uint64) (GetUserActivityRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserActivity, user)
+	var i GetUserActivityRow
+	err := row.Scan(
+		&i.Resources,
+		&i.Read,
+		&i.Starred,
+		&i.Hidden,
+		&i.LastViewTime,
+		&i.LastReadTime,
+		&i.LastSessionTime,
+		&i.LastTokenTime,
+	)
+	return i, err
+}
+
+const getUserByIdentity = `-- name: GetUserByIdentity :one
+SELECT users.id, users.name, users.password_hash, users.version, users.role, users.create_time
+FROM identities
+         JOIN users ON users.id = identities.user
+WHERE identities.issuer = ?
+  AND identities.subject = ?
+`
+
+type GetUserByIdentityParams struct {
+	Issuer  string
+	Subject string
+}
+
+type GetUserByIdentityRow struct {
+	User User
+}
+
+// GetUserByIdentity fetches the user linked to the subject of an issuer.
+func (q *Queries) GetUserByIdentity(ctx context.Context, arg GetUserByIdentityParams) (GetUserByIdentityRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByIdentity, arg.Issuer, arg.Subject)
+	var i GetUserByIdentityRow
+	err := row.Scan(
+		&i.User.ID,
+		&i.User.Name,
+		&i.User.PasswordHash,
+		&i.User.Version,
+		&i.User.Role,
+		&i.User.CreateTime,
+	)
 	return i, err
 }
 
 const getUserByName = `-- name: GetUserByName :one
-SELECT id, name, password_hash
+SELECT id, name, password_hash, version, role, create_time
 FROM users
 WHERE name = ?
 LIMIT 1
@@ -131,12 +797,19 @@ LIMIT 1
 func (q *Queries) GetUserByName(ctx context.Context, name string) (User, error) {
 	row := q.db.QueryRowContext(ctx, getUserByName, name)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Version,
+		&i.Role,
+		&i.CreateTime,
+	)
 	return i, err
 }
 
 const getUsers = `-- name: GetUsers :many
-SELECT id, name, password_hash
+SELECT id, name, password_hash, version, role, create_time
 FROM users
 WHERE name > ?2
 ORDER BY name
@@ -158,7 +831,14 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]User, err
 	var items []User
 	for rows.Next() {
 		var i User
-		if err := rows.Scan(&i.ID, &i.Name, &i.PasswordHash); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PasswordHash,
+			&i.Version,
+			&i.Role,
+			&i.CreateTime,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -172,31 +852,304 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]User, err
 	return items, nil
 }
 
-const setUserName = `-- name: SetUserName :one
-UPDATE users
-SET name = ?2
-WHERE id = ?1
-RETURNING id, name, password_hash
+const listAccessTokens = `-- name: ListAccessTokens :many
+SELECT id, user, token_hash, display_name, scope, create_time, last_used_time
+FROM access_tokens
+WHERE user = ?
+ORDER BY id
+`
+
+// ListAccessTokens fetches all of a user's personal access tokens in order of
+// creation.
+func (q *Queries) ListAccessTokens(ctx context.Context, user uint64) ([]AccessToken, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessTokens, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccessToken
+	for rows.Next() {
+		var i AccessToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.User,
+			&i.TokenHash,
+			&i.DisplayName,
+			&i.Scope,
+			&i.CreateTime,
+			&i.LastUsedTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT audit_events.id, audit_events.create_time, audit_events.actor, audit_events.subject, audit_events."action", audit_events.outcome, audit_events.ip_address, audit_events.user_agent, audit_events.actor_id, audit_events.subject_id, audit_events.fields,
+       CAST(COALESCE(actor.name, '') AS TEXT)   AS actor_name,
+       CAST(COALESCE(subject.name, '') AS TEXT) AS subject_name
+FROM audit_events
+         LEFT JOIN users AS actor ON actor.id = audit_events.actor_id
+         LEFT JOIN users AS subject ON subject.id = audit_events.subject_id
+WHERE audit_events.id < ?1
+  AND (CAST(?2 AS INTEGER) = 0 OR audit_events.action = ?2)
+  AND (CAST(?3 AS INTEGER) = 0 OR audit_events.outcome = ?3)
+ORDER BY audit_events.id DESC
+LIMIT ?4
 `
 
-type SetUserNameParams struct {
-	ID   uint64
-	Name string
+type ListAuditEventsParams struct {
+	BeforeID int64
+	Action   int64
+	Outcome  int64
+	Limit    int64
 }
 
-// SetUserName updates a user's name with the given ID.
-func (q *Queries) SetUserName(ctx context.Context, arg SetUserNameParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, setUserName, arg.ID, arg.Name)
-	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.PasswordHash)
-	return i, err
+type ListAuditEventsRow struct {
+	AuditEvent  AuditEvent
+	ActorName   string
+	SubjectName string
+}
+
+// ListAuditEvents fetches the audit events before the given ID, newest first,
+// along with the current names of their actor and subject. The events may be
+// restricted to an action and outcome, which are ignored if zero.
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]ListAuditEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents,
+		arg.BeforeID,
+		arg.Action,
+		arg.Outcome,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuditEventsRow
+	for rows.Next() {
+		var i ListAuditEventsRow
+		if err := rows.Scan(
+			&i.AuditEvent.ID,
+			&i.AuditEvent.CreateTime,
+			&i.AuditEvent.Actor,
+			&i.AuditEvent.Subject,
+			&i.AuditEvent.Action,
+			&i.AuditEvent.Outcome,
+			&i.AuditEvent.IpAddress,
+			&i.AuditEvent.UserAgent,
+			&i.AuditEvent.ActorID,
+			&i.AuditEvent.SubjectID,
+			&i.AuditEvent.Fields,
+			&i.ActorName,
+			&i.SubjectName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEventsAfter = `-- name: ListAuditEventsAfter :many
+SELECT audit_events.id, audit_events.create_time, audit_events.actor, audit_events.subject, audit_events."action", audit_events.outcome, audit_events.ip_address, audit_events.user_agent, audit_events.actor_id, audit_events.subject_id, audit_events.fields,
+       CAST(COALESCE(actor.name, '') AS TEXT)   AS actor_name,
+       CAST(COALESCE(subject.name, '') AS TEXT) AS subject_name
+FROM audit_events
+         LEFT JOIN users AS actor ON actor.id = audit_events.actor_id
+         LEFT JOIN users AS subject ON subject.id = audit_events.subject_id
+WHERE audit_events.id > ?1
+ORDER BY audit_events.id
+LIMIT ?2
+`
+
+type ListAuditEventsAfterParams struct {
+	AfterID int64
+	Limit   int64
+}
+
+type ListAuditEventsAfterRow struct {
+	AuditEvent  AuditEvent
+	ActorName   string
+	SubjectName string
+}
+
+// ListAuditEventsAfter fetches the audit events after the given ID, oldest
+// first, along with the current names of their actor and subject.
+func (q *Queries) ListAuditEventsAfter(ctx context.Context, arg ListAuditEventsAfterParams) ([]ListAuditEventsAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEventsAfter, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuditEventsAfterRow
+	for rows.Next() {
+		var i ListAuditEventsAfterRow
+		if err := rows.Scan(
+			&i.AuditEvent.ID,
+			&i.AuditEvent.CreateTime,
+			&i.AuditEvent.Actor,
+			&i.AuditEvent.Subject,
+			&i.AuditEvent.Action,
+			&i.AuditEvent.Outcome,
+			&i.AuditEvent.IpAddress,
+			&i.AuditEvent.UserAgent,
+			&i.AuditEvent.ActorID,
+			&i.AuditEvent.SubjectID,
+			&i.AuditEvent.Fields,
+			&i.ActorName,
+			&i.SubjectName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIdentities = `-- name: ListIdentities :many
+SELECT issuer, subject, user, create_time
+FROM identities
+WHERE user = ?
+ORDER BY create_time, issuer, subject
+`
+
+// ListIdentities returns the identities linked to a user in order of creation.
+func (q *Queries) ListIdentities(ctx context.Context, user uint64) ([]Identity, error) {
+	rows, err := q.db.QueryContext(ctx, listIdentities, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Identity
+	for rows.Next() {
+		var i Identity
+		if err := rows.Scan(
+			&i.Issuer,
+			&i.Subject,
+			&i.User,
+			&i.CreateTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSavedViews = `-- name: ListSavedViews :many
+SELECT id, user, display_name, "filter", order_by, category, version, create_time, update_time
+FROM saved_views
+WHERE user = ?
+ORDER BY id
+`
+
+// ListSavedViews fetches all of a user's saved views in order of creation.
+func (q *Queries) ListSavedViews(ctx context.Context, user uint64) ([]SavedView, error) {
+	rows, err := q.db.QueryContext(ctx, listSavedViews, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedView
+	for rows.Next() {
+		var i SavedView
+		if err := rows.Scan(
+			&i.ID,
+			&i.User,
+			&i.DisplayName,
+			&i.Filter,
+			&i.OrderBy,
+			&i.Category,
+			&i.Version,
+			&i.CreateTime,
+			&i.UpdateTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const redeemInvite = `-- name: RedeemInvite :execrows
+UPDATE invites
+SET redeem_time = ?1
+WHERE code_hash = ?2
+  AND redeem_time IS NULL
+  AND expire_time > ?1
+`
+
+type RedeemInviteParams struct {
+	RedeemTime sql.NullTime
+	CodeHash   []byte
+}
+
+// RedeemInvite marks an unexpired invite as redeemed if it has not been
+// already. Times are stored in UTC, so they can be compared as text.
+func (q *Queries) RedeemInvite(ctx context.Context, arg RedeemInviteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redeemInvite, arg.RedeemTime, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChapterCount = `-- name: SetChapterCount :exec
+INSERT INTO chapter_counts (path, chapter_count)
+VALUES (?1, ?2)
+ON CONFLICT DO UPDATE SET chapter_count = ?2
+WHERE chapter_count != ?2
+`
+
+type SetChapterCountParams struct {
+	Path         string
+	ChapterCount int64
+}
+
+// SetChapterCount records the number of chapters of the entry path, shared by
+// all users. It is not versioned like the user's own resource data.
+func (q *Queries) SetChapterCount(ctx context.Context, arg SetChapterCountParams) error {
+	_, err := q.db.ExecContext(ctx, setChapterCount, arg.Path, arg.ChapterCount)
+	return err
 }
 
 const setUserPasswordHash = `-- name: SetUserPasswordHash :one
 UPDATE users
 SET password_hash = ?2
 WHERE id = ?1
-RETURNING id, name, password_hash
+RETURNING id, name, password_hash, version, role, create_time
 `
 
 type SetUserPasswordHashParams struct {
@@ -208,20 +1161,106 @@ type SetUserPasswordHashParams struct {
 func (q *Queries) SetUserPasswordHash(ctx context.Context, arg SetUserPasswordHashParams) (User, error) {
 	row := q.db.QueryRowContext(ctx, setUserPasswordHash, arg.ID, arg.PasswordHash)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Version,
+		&i.Role,
+		&i.CreateTime,
+	)
 	return i, err
 }
 
+const touchAccessToken = `-- name: TouchAccessToken :exec
+UPDATE access_tokens
+SET last_used_time = ?2
+WHERE id = ?1
+`
+
+type TouchAccessTokenParams struct {
+	ID           int64
+	LastUsedTime sql.NullTime
+}
+
+// TouchAccessToken updates the last used time of a personal access token.
+func (q *Queries) TouchAccessToken(ctx context.Context, arg TouchAccessTokenParams) error {
+	_, err := q.db.ExecContext(ctx, touchAccessToken, arg.ID, arg.LastUsedTime)
+	return err
+}
+
+const touchSession = `-- name: TouchSession :exec
+UPDATE sessions
+SET access_time = ?2
+WHERE token_hash = ?1
+`
+
+type TouchSessionParams struct {
+	TokenHash  []byte
+	AccessTime time.Time
+}
+
+// TouchSession updates the last access time of a login session.
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) error {
+	_, err := q.db.ExecContext(ctx, touchSession, arg.TokenHash, arg.AccessTime)
+	return err
+}
+
+const updateSavedView = `-- name: UpdateSavedView :execrows
+UPDATE saved_views
+SET display_name = ?3,
+    filter       = ?4,
+    order_by     = ?5,
+    category     = ?6,
+    version      = ?7,
+    update_time  = ?8
+WHERE user = ?1
+  AND id = ?2
+  AND version = ?7 - 1
+`
+
+type UpdateSavedViewParams struct {
+	User        uint64
+	ID          int64
+	DisplayName string
+	Filter      string
+	OrderBy     string
+	Category    string
+	Version     int64
+	UpdateTime  time.Time
+}
+
+// UpdateSavedView updates one of a user's saved views with its next version,
+// if it is at the preceding version.
+func (q *Queries) UpdateSavedView(ctx context.Context, arg UpdateSavedViewParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateSavedView,
+		arg.User,
+		arg.ID,
+		arg.DisplayName,
+		arg.Filter,
+		arg.OrderBy,
+		arg.Category,
+		arg.Version,
+		arg.UpdateTime,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const upsertResource = `-- name: UpsertResource :one
-INSERT INTO resources (user, path, hidden, starred, view_time, read_time)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+INSERT INTO resources (user, path, hidden, starred, view_time, read_time, version)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
 ON CONFLICT DO UPDATE SET hidden    = ?3,
                           starred   = ?4,
                           view_time = ?5,
-                          read_time = ?6
+                          read_time = ?6,
+                          version   = ?7
 WHERE user = ?1
   AND path = ?2
-RETURNING user, path, hidden, starred, view_time, read_time
+  AND version = ?7 - 1
+RETURNING user, path, hidden, starred, view_time, read_time, version
 `
 
 type UpsertResourceParams struct {
@@ -231,9 +1270,12 @@ type UpsertResourceParams struct {
 	Starred  bool
 	ViewTime sql.NullTime
 	ReadTime sql.NullTime
+	Version  int64
 }
 
-// UpsertResource upserts a resource.
+// UpsertResource upserts a resource with its next version. Existing resources
+// are only updated if they are at the preceding version; otherwise no rows are
+// returned.
 func (q *Queries) UpsertResource(ctx context.Context, arg UpsertResourceParams) (Resource, error) {
 	row := q.db.QueryRowContext(ctx, upsertResource,
 		arg.User,
@@ -242,6 +1284,7 @@ func (q *Queries) UpsertResource(ctx context.Context, arg UpsertResourceParams)
 		arg.Starred,
 		arg.ViewTime,
 		arg.ReadTime,
+		arg.Version,
 	)
 	var i Resource
 	err := row.Scan(
@@ -251,29 +1294,52 @@ func (q *Queries) UpsertResource(ctx context.Context, arg UpsertResourceParams)
 		&i.Starred,
 		&i.ViewTime,
 		&i.ReadTime,
+		&i.Version,
 	)
 	return i, err
 }
 
 const upsertUser = `-- name: UpsertUser :one
-INSERT INTO users (id, name, password_hash)
-VALUES (?1, ?2, ?3)
+INSERT INTO users (id, name, password_hash, version, role, create_time)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
 ON CONFLICT DO UPDATE SET name          = ?2,
-                          password_hash = ?3
+                          password_hash = ?3,
+                          version       = ?4,
+                          role          = ?5
 WHERE id = ?1
-RETURNING id, name, password_hash
+  AND version = ?4 - 1
+RETURNING id, name, password_hash, version, role, create_time
 `
 
 type UpsertUserParams struct {
 	ID           uint64
 	Name         string
 	PasswordHash []byte
+	Version      int64
+	Role         string
+	CreateTime   sql.NullTime
 }
 
-// UpsertUser adds a new user with the given name and password_hash.
+// UpsertUser adds a new user with the given name, password_hash and role, or
+// updates the user with the given ID if it is at the version preceding the
+// given one.
 func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, upsertUser, arg.ID, arg.Name, arg.PasswordHash)
+	row := q.db.QueryRowContext(ctx, upsertUser,
+		arg.ID,
+		arg.Name,
+		arg.PasswordHash,
+		arg.Version,
+		arg.Role,
+		arg.CreateTime,
+	)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PasswordHash,
+		&i.Version,
+		&i.Role,
+		&i.CreateTime,
+	)
 	return i, err
 }
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"math"
 	"path/filepath"
 	"testing"
 	"time"
@@ -26,10 +28,12 @@ func TestDB(t *testing.T) {
 
 	const userID = 123
 	const userName = "test"
+	userCreateTime := time.Now().Round(-1) // since the monotonic part won't be equal
 	err = store.UpsertUser(t.Context(), db.User{
 		ID:           userID,
 		Name:         userName,
 		PasswordHash: []byte{},
+		CreateTime:   sql.NullTime{Time: userCreateTime, Valid: true},
 	})
 	require.NoError(t, err)
 
@@ -47,6 +51,7 @@ func TestDB(t *testing.T) {
 		err := store.UpsertResource(t.Context(), res)
 		require.NoError(t, err)
 
+		res.Version++
 		actual, err := store.GetResource(t.Context(), userID, path)
 		require.NoError(t, err)
 		assert.Equal(t, res, actual)
@@ -55,9 +60,79 @@ func TestDB(t *testing.T) {
 		err = store.UpsertResource(t.Context(), res)
 		require.NoError(t, err)
 
+		res.Version++
 		actual, err = store.GetResource(t.Context(), userID, path)
 		require.NoError(t, err)
 		assert.Equal(t, res, actual)
+
+		stale := res
+		stale.Version--
+		stale.Hidden = true
+		err = store.UpsertResource(t.Context(), stale)
+		require.ErrorIs(t, err, ErrConflict)
+
+		actual, err = store.GetResource(t.Context(), userID, path)
+		require.NoError(t, err)
+		assert.Equal(t, res, actual)
+	})
+
+	t.Run("BatchUpsertResources", func(t *testing.T) {
+		t.Parallel()
+
+		path := t.Name()
+		existing := db.Resource{
+			User: userID,
+			Path: path + "/1",
+		}
+		err := store.UpsertResource(t.Context(), existing)
+		require.NoError(t, err)
+
+		existing.Version++
+		existing.Starred = true
+		added := db.Resource{
+			User:   userID,
+			Path:   path + "/2",
+			Hidden: true,
+		}
+		err = store.BatchUpsertResources(t.Context(), existing, added)
+		require.NoError(t, err)
+
+		existing.Version++
+		added.Version++
+		res, err := store.ListResources(t.Context(), userID, existing.Path, added.Path)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []db.Resource{existing, added}, res)
+	})
+
+	t.Run("GetProgress", func(t *testing.T) {
+		t.Parallel()
+
+		entry := t.Name() + "/entries/anthology"
+		unknown := t.Name() + "/entries/unknown"
+		readTime := sql.NullTime{Valid: true, Time: time.Now()}
+		err := store.BatchUpsertResources(t.Context(),
+			db.Resource{User: userID, Path: entry, ReadTime: readTime},
+			db.Resource{User: userID, Path: entry + "/chapters/1", ReadTime: readTime},
+			db.Resource{User: userID, Path: entry + "/chapters/2", ReadTime: readTime},
+			db.Resource{User: userID, Path: entry + "/chapters/3"},
+			db.Resource{User: userID, Path: entry + "-other/chapters/1", ReadTime: readTime},
+			db.Resource{User: userID, Path: unknown + "/chapters/1", ReadTime: readTime},
+		)
+		require.NoError(t, err)
+		require.NoError(t, store.SetChapterCount(t.Context(), entry, 2))
+		require.NoError(t, store.SetChapterCount(t.Context(), entry, 3))
+
+		progress, err := store.GetProgress(t.Context(), userID, entry, unknown)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Progress{entry: {Chapters: 3, Read: 2}}, progress)
+
+		progress, err = store.GetProgress(t.Context(), userID+1, entry)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Progress{entry: {Chapters: 3}}, progress)
+
+		stored, err := store.GetResource(t.Context(), userID, entry)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, stored.Version, "recording the chapter count does not change the entry")
 	})
 
 	t.Run("GetResource", func(t *testing.T) {
@@ -79,6 +154,7 @@ func TestDB(t *testing.T) {
 		err = store.UpsertResource(t.Context(), res)
 		require.NoError(t, err)
 
+		res.Version++
 		actual, err := store.GetResource(t.Context(), userID, path)
 		require.NoError(t, err)
 		assert.Equal(t, res, actual)
@@ -109,6 +185,8 @@ func TestDB(t *testing.T) {
 		err = store.UpsertResource(t.Context(), res2)
 		require.NoError(t, err)
 
+		res1.Version++
+		res2.Version++
 		res, err = store.ListResources(t.Context(), userID, res1.Path, res2.Path, "unknown/path")
 		require.NoError(t, err)
 		assert.Len(t, res, 2)
@@ -131,8 +209,11 @@ func TestDB(t *testing.T) {
 		assert.Empty(t, res)
 
 		user := db.User{
-			ID:   userID,
-			Name: userName,
+			ID:         userID,
+			Name:       userName,
+			Version:    1,
+			Role:       db.RoleMember,
+			CreateTime: sql.NullTime{Time: userCreateTime, Valid: true},
 		}
 
 		actual, err := store.GetUser(t.Context(), userID)
@@ -170,6 +251,50 @@ func TestDB(t *testing.T) {
 
 		user, err = store.GetUserByName(t.Context(), user.Name)
 		require.NoError(t, err)
+		assert.True(t, user.CreateTime.Valid, "create time defaults to now")
+
+		stale := user
+		stale.Version--
+		err = store.UpsertUser(t.Context(), stale)
+		require.ErrorIs(t, err, ErrConflict)
+
+		user.Role = db.RoleAdmin
+		err = store.UpsertUser(t.Context(), user)
+		require.NoError(t, err)
+		user, err = store.GetUser(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.True(t, user.IsAdmin())
+
+		invalid := user
+		invalid.Role = "superuser"
+		err = store.UpsertUser(t.Context(), invalid)
+		require.Error(t, err)
+
+		renamed := user
+		renamed.Name = "user_crud_renamed"
+		err = store.UpsertUser(t.Context(), renamed)
+		require.NoError(t, err)
+		renamed, err = store.GetUserByName(t.Context(), renamed.Name)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, renamed.ID)
+		assert.Equal(t, user.CreateTime, renamed.CreateTime, "updates keep the create time")
+		user = renamed
+
+		renamed.Name = userName
+		err = store.UpsertUser(t.Context(), renamed)
+		require.ErrorIs(t, err, ErrAlreadyExists)
+
+		// users without a password are read back with a nil hash
+		passwordless := db.User{Name: "user_crud_passwordless", PasswordHash: []byte{}}
+		err = store.UpsertUser(t.Context(), passwordless)
+		require.NoError(t, err)
+		passwordless, err = store.GetUserByName(t.Context(), passwordless.Name)
+		require.NoError(t, err)
+		passwordless.Role = db.RoleAdmin
+		err = store.UpsertUser(t.Context(), passwordless)
+		require.NoError(t, err)
+		err = store.DeleteUser(t.Context(), passwordless.ID)
+		require.NoError(t, err)
 
 		err = store.DeleteUser(t.Context(), user.ID)
 		require.NoError(t, err)
@@ -179,4 +304,409 @@ func TestDB(t *testing.T) {
 		err = store.DeleteUser(t.Context(), user.ID)
 		require.NoError(t, err)
 	})
+
+	t.Run("AccessTokens", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now().Round(-1) // since the monotonic part won't be equal
+		token := db.AccessToken{
+			User:        userID,
+			TokenHash:   []byte("access token hash"),
+			DisplayName: "script",
+			Scope:       int64(eratov1.AccessToken_READ_ONLY),
+			CreateTime:  now,
+		}
+		id, err := store.CreateAccessToken(t.Context(), token)
+		require.NoError(t, err)
+		token.ID = id
+
+		_, err = store.CreateAccessToken(t.Context(), token)
+		require.Error(t, err, "token hashes must be unique")
+
+		actual, err := store.GetAccessTokenByHash(t.Context(), token.TokenHash)
+		require.NoError(t, err)
+		assert.Equal(t, token, actual)
+
+		_, err = store.GetAccessTokenByHash(t.Context(), []byte("not a real hash"))
+		require.ErrorIs(t, err, ErrNotFound)
+
+		err = store.TouchAccessToken(t.Context(), id, now.Add(time.Hour))
+		require.NoError(t, err)
+		token.LastUsedTime = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+
+		list, err := store.ListAccessTokens(t.Context(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, []db.AccessToken{token}, list)
+
+		err = store.DeleteAccessToken(t.Context(), userID+1, id)
+		require.ErrorIs(t, err, ErrNotFound, "tokens can only be deleted by their user")
+
+		err = store.DeleteAccessToken(t.Context(), userID, id)
+		require.NoError(t, err)
+		_, err = store.GetAccessTokenByHash(t.Context(), token.TokenHash)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		err = store.DeleteAccessToken(t.Context(), userID, id)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SavedViews", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now().Round(-1) // since the monotonic part won't be equal
+		view := db.SavedView{
+			User:        userID,
+			DisplayName: "unread favorites",
+			Filter:      "this.starred && !has(this.read_time)",
+			OrderBy:     "update_time",
+			CreateTime:  now,
+			UpdateTime:  now,
+		}
+		id, err := store.CreateSavedView(t.Context(), view)
+		require.NoError(t, err)
+		view.ID = id
+		view.Version = 1
+
+		actual, err := store.GetSavedView(t.Context(), userID, id)
+		require.NoError(t, err)
+		assert.Equal(t, view, actual)
+
+		_, err = store.GetSavedView(t.Context(), userID+1, id)
+		require.ErrorIs(t, err, ErrNotFound, "views can only be read by their user")
+
+		view.Category = "categories/adventure"
+		view.UpdateTime = now.Add(time.Hour)
+		require.NoError(t, store.UpdateSavedView(t.Context(), view))
+		err = store.UpdateSavedView(t.Context(), view)
+		require.ErrorIs(t, err, ErrConflict, "the version must match")
+		view.Version++
+
+		list, err := store.ListSavedViews(t.Context(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, []db.SavedView{view}, list)
+
+		err = store.DeleteSavedView(t.Context(), userID+1, id)
+		require.ErrorIs(t, err, ErrNotFound, "views can only be deleted by their user")
+
+		require.NoError(t, store.DeleteSavedView(t.Context(), userID, id))
+		err = store.UpdateSavedView(t.Context(), view)
+		require.ErrorIs(t, err, ErrNotFound)
+		err = store.DeleteSavedView(t.Context(), userID, id)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Secrets", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := store.GetOrCreateSecret(t.Context(), "key", []byte("first"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), secret)
+
+		secret, err = store.GetOrCreateSecret(t.Context(), "key", []byte("second"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), secret, "existing secrets are kept")
+	})
+
+	t.Run("AuditEvents", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now().Round(-1) // since the monotonic part won't be equal
+		var events []db.AuditEvent
+		for i, subject := range []string{"alice", "bob", "carol"} {
+			outcome := eratov1.AuditEvent_SUCCESS
+			if i == 1 {
+				outcome = eratov1.AuditEvent_FAILURE
+			}
+			event := db.AuditEvent{
+				CreateTime: now,
+				Actor:      "admin",
+				Subject:    subject,
+				SubjectID:  uint64(1000 + i), //nolint:gosec // small
+				Action:     int64(eratov1.AuditEvent_UPDATE_USER),
+				Outcome:    int64(outcome),
+				Fields:     "password",
+				IpAddress:  "192.0.2.1",
+				UserAgent:  "test",
+			}
+			require.NoError(t, store.CreateAuditEvent(t.Context(), event))
+			events = append(events, event)
+		}
+
+		newest, err := store.ListAuditEvents(t.Context(), AuditEventFilter{}, math.MaxInt64, 2)
+		require.NoError(t, err)
+		require.Len(t, newest, 2)
+		assert.Equal(t, "carol", newest[0].Subject)
+		assert.Equal(t, "bob", newest[1].Subject)
+		events[2].ID = newest[0].ID
+		assert.Equal(t, events[2], newest[0].AuditEvent)
+		assert.Empty(t, newest[0].SubjectName, "the subject does not exist")
+
+		older, err := store.ListAuditEvents(t.Context(), AuditEventFilter{}, newest[1].ID, 2)
+		require.NoError(t, err)
+		require.Len(t, older, 1)
+		assert.Equal(t, "alice", older[0].Subject)
+
+		succeeded, err := store.ListAuditEvents(t.Context(), AuditEventFilter{
+			Action:  int64(eratov1.AuditEvent_UPDATE_USER),
+			Outcome: int64(eratov1.AuditEvent_SUCCESS),
+		}, math.MaxInt64, 10)
+		require.NoError(t, err)
+		require.Len(t, succeeded, 2)
+		assert.Equal(t, "carol", succeeded[0].Subject)
+		assert.Equal(t, "alice", succeeded[1].Subject)
+
+		none, err := store.ListAuditEvents(t.Context(), AuditEventFilter{
+			Action: int64(eratov1.AuditEvent_LOGIN),
+		}, math.MaxInt64, 10)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		count, err := store.CountAuditEvents(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		after, err := store.ListAuditEventsAfter(t.Context(), older[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, after, 2)
+		assert.Equal(t, "bob", after[0].Subject, "oldest first")
+
+		_, err = store.db.ExecContext(t.Context(), "UPDATE audit_events SET subject = 'mallory'")
+		require.ErrorContains(t, err, "append-only")
+		_, err = store.db.ExecContext(t.Context(), "DELETE FROM audit_events")
+		require.ErrorContains(t, err, "append-only")
+	})
+}
+
+func TestDBIdentities(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.UpsertUser(t.Context(), db.User{ID: 123, Name: "taken", PasswordHash: []byte{}}))
+
+	const issuer = "https://idp.test"
+
+	_, err = store.GetUserByIdentity(t.Context(), issuer, "unknown")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	user, err := store.ProvisionUser(t.Context(),
+		db.User{Name: "identities_test", PasswordHash: []byte{}, Role: db.RoleMember},
+		issuer, "provisioned",
+	)
+	require.NoError(t, err)
+	assert.NotZero(t, user.ID)
+	actual, err := store.GetUserByIdentity(t.Context(), issuer, "provisioned")
+	require.NoError(t, err)
+	assert.Equal(t, user, actual)
+
+	_, err = store.ProvisionUser(t.Context(),
+		db.User{Name: "taken", PasswordHash: []byte{}, Role: db.RoleMember},
+		issuer, "taken_name",
+	)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = store.GetUserByIdentity(t.Context(), issuer, "taken_name")
+	require.ErrorIs(t, err, ErrNotFound, "nothing is linked if the user cannot be created")
+
+	_, err = store.ProvisionUser(t.Context(),
+		db.User{Name: "identities_linked", PasswordHash: []byte{}, Role: db.RoleMember},
+		issuer, "provisioned",
+	)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = store.GetUserByName(t.Context(), "identities_linked")
+	require.ErrorIs(t, err, ErrNotFound, "no user is created if the identity is linked")
+
+	require.NoError(t, store.LinkIdentity(t.Context(), user.ID, "https://other.test", "provisioned"))
+	require.ErrorIs(t, store.LinkIdentity(t.Context(), 123, issuer, "provisioned"), ErrAlreadyExists)
+	identities, err := store.ListIdentities(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Len(t, identities, 2)
+
+	require.ErrorIs(t, store.UnlinkIdentity(t.Context(), 123, issuer, "provisioned"), ErrNotFound)
+	require.NoError(t, store.UnlinkIdentity(t.Context(), user.ID, "https://other.test", "provisioned"))
+	identities, err = store.ListIdentities(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Len(t, identities, 1)
+
+	require.NoError(t, store.DeleteUser(t.Context(), user.ID))
+	_, err = store.GetUserByIdentity(t.Context(), issuer, "provisioned")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, store.LinkIdentity(t.Context(), 123, issuer, "provisioned"),
+		"deleting users unlinks their identities")
+	require.NoError(t, store.UnlinkIdentity(t.Context(), 123, issuer, "provisioned"))
+
+}
+
+func TestDBDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	user := db.User{ID: 123, Name: "deleted", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+	require.NoError(t, store.UpsertResource(t.Context(), db.Resource{User: user.ID, Path: "categories/cat", Starred: true}))
+	require.NoError(t, store.CreateSession(t.Context(), db.Session{
+		TokenHash:  []byte("session"),
+		User:       user.ID,
+		CreateTime: now,
+		AccessTime: now,
+	}))
+	_, err = store.CreateAccessToken(t.Context(), db.AccessToken{User: user.ID, TokenHash: []byte("token"), CreateTime: now})
+	require.NoError(t, err)
+	_, err = store.CreateSavedView(t.Context(), db.SavedView{User: user.ID, DisplayName: "view", CreateTime: now, UpdateTime: now})
+	require.NoError(t, err)
+	require.NoError(t, store.LinkIdentity(t.Context(), user.ID, "https://issuer.test", "subject"))
+
+	require.NoError(t, store.DeleteUser(t.Context(), user.ID))
+	for _, table := range []string{"users", "resources", "sessions", "access_tokens", "saved_views", "identities"} {
+		var count int
+		require.NoError(t, store.db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM "+table).Scan(&count)) //nolint:gosec // constant table names
+		assert.Zero(t, count, "no %s remain", table)
+	}
+}
+
+func TestDBInvites(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.UpsertUser(t.Context(), db.User{ID: 123, Name: "taken", PasswordHash: []byte{}}))
+
+	now := time.Now()
+	createInvite := func(code string, expireTime time.Time) []byte {
+		t.Helper()
+		hash := []byte(code)
+		_, err := store.CreateInvite(t.Context(), db.Invite{
+			CodeHash:   hash,
+			Creator:    "admin",
+			CreateTime: now.UTC(),
+			ExpireTime: expireTime.UTC(),
+		})
+		require.NoError(t, err)
+		return hash
+	}
+	invitee := func(id uint64, name string) db.User {
+		return db.User{ID: id, Name: name, PasswordHash: []byte("hash"), Role: db.RoleMember}
+	}
+
+	expired := createInvite("expired", now.Add(-time.Minute))
+	require.ErrorIs(t, store.CheckInvite(t.Context(), expired, now), ErrNotFound)
+	require.ErrorIs(t, store.CheckInvite(t.Context(), []byte("unknown"), now), ErrNotFound)
+	err = store.RedeemInvite(t.Context(), expired, now, invitee(9001, "expired"))
+	require.ErrorIs(t, err, ErrNotFound)
+	err = store.RedeemInvite(t.Context(), []byte("unknown"), now, invitee(9001, "unknown"))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	valid := createInvite("valid", now.Add(time.Hour))
+	require.NoError(t, store.CheckInvite(t.Context(), valid, now))
+	err = store.RedeemInvite(t.Context(), valid, now, invitee(9002, "taken"))
+	require.ErrorIs(t, err, ErrAlreadyExists, "name taken")
+
+	// the invite is not consumed by the failed redemption, and only one of
+	// concurrent redemptions succeeds
+	errs := make(chan error)
+	for i := range 2 {
+		go func() {
+			name := fmt.Sprintf("invitee_%d", i)
+			errs <- store.RedeemInvite(t.Context(), valid, now, invitee(uint64(9100+i), name))
+		}()
+	}
+	var succeeded int
+	for range 2 {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			require.ErrorIs(t, err, ErrNotFound)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	require.ErrorIs(t, store.CheckInvite(t.Context(), valid, now), ErrNotFound, "redeemed")
+
+	_, err = store.GetUserByName(t.Context(), "expired")
+	require.ErrorIs(t, err, ErrNotFound, "user not created for an expired invite")
+}
+
+func TestNewDBManualMigrations(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "db.sqlite")
+	cfg := eratov1.Config_builder{
+		DbFilepath:       dbPath,
+		ManualMigrations: true,
+	}.Build()
+
+	_, err := NewDB(t.Context(), cfg, slog.Default())
+	require.ErrorIs(t, err, db.ErrPendingMigrations)
+
+	handle, err := db.Connect(t.Context(), dbPath)
+	require.NoError(t, err)
+	migrator, err := db.NewMigrator(handle)
+	require.NoError(t, err)
+	_, err = migrator.Up(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, handle.Close())
+
+	store, err := NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+}
+
+func TestUserActivity(t *testing.T) {
+	t.Parallel()
+
+	cfg := eratov1.Config_builder{
+		DbFilepath: filepath.Join(t.TempDir(), "db.sqlite"),
+	}.Build()
+	store, err := NewDB(t.Context(), cfg, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	user := db.User{ID: 456, Name: "user_activity_test", PasswordHash: []byte{}}
+	require.NoError(t, store.UpsertUser(t.Context(), user))
+
+	activity, err := store.GetUserActivity(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, UserActivity{}, activity)
+
+	// the latest time is stored behind UTC, so it sorts first as text, both
+	// across tables and against the earlier view time stored ahead of UTC
+	now := time.Now().UTC().Round(-1)
+	behind := time.FixedZone("behind", int((-10 * time.Hour).Seconds()))
+	ahead := time.FixedZone("ahead", int((10 * time.Hour).Seconds()))
+	err = store.BatchUpsertResources(t.Context(),
+		db.Resource{User: user.ID, Path: "categories/a", Starred: true},
+		db.Resource{User: user.ID, Path: "categories/b", Hidden: true, ViewTime: sql.NullTime{Time: now.In(behind), Valid: true}},
+		db.Resource{User: user.ID, Path: "categories/c", ReadTime: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}},
+		db.Resource{User: user.ID, Path: "categories/d", ViewTime: sql.NullTime{Time: now.Add(-time.Minute).In(ahead), Valid: true}},
+	)
+	require.NoError(t, err)
+	err = store.CreateSession(t.Context(), db.Session{
+		TokenHash:  []byte("user activity session"),
+		User:       user.ID,
+		CreateTime: now.Add(-2 * time.Hour),
+		AccessTime: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	activity, err = store.GetUserActivity(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), activity.Resources)
+	assert.Equal(t, int64(1), activity.Read)
+	assert.Equal(t, int64(1), activity.Starred)
+	assert.Equal(t, int64(1), activity.Hidden)
+	assert.True(t, activity.LastActiveTime.Valid)
+	assert.True(t, now.Equal(activity.LastActiveTime.Time), "expected %v, got %v", now, activity.LastActiveTime.Time)
 }
@@ -3,6 +3,8 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/stolasapp/erato/internal/storage/db"
 )
@@ -12,6 +14,9 @@ const (
 	ErrNotFound Error = "not found"
 	// ErrAlreadyExists is returned if a unique resource or user already exists.
 	ErrAlreadyExists Error = "already exists"
+	// ErrConflict is returned when a resource or user has been modified since
+	// it was read.
+	ErrConflict Error = "modified concurrently"
 	// ErrInvalidUsername is returned when a username fails validation.
 	ErrInvalidUsername Error = "username must be 3-64 characters, alphanumeric and underscores only"
 	// ErrInternal is returned for any other type of error.
@@ -36,8 +41,28 @@ type Resources interface {
 	GetResource(ctx context.Context, userID uint64, path string) (db.Resource, error)
 	// UpsertResource creates or updates the resource. This is a full PUT-style
 	// update, so callers should do a GetResource first prior to calling this
-	// method.
+	// method. The resource's version must be the one last read (zero if it did
+	// not exist), otherwise an [ErrConflict] is returned. On success, the
+	// stored version is incremented by one.
 	UpsertResource(ctx context.Context, resource db.Resource) error
+	// BatchUpsertResources creates or updates all the resources within a
+	// single transaction, with the same versioning as UpsertResource. If any
+	// upsert fails, none of the changes are persisted.
+	BatchUpsertResources(ctx context.Context, resources ...db.Resource) error
+	// GetProgress returns the user's progress through each of the entry paths
+	// whose number of chapters has been recorded, keyed by path.
+	GetProgress(ctx context.Context, userID uint64, entryPaths ...string) (map[string]Progress, error)
+	// SetChapterCount records the number of chapters of the entry path, which
+	// is shared by all users. Unlike resources, it is not versioned.
+	SetChapterCount(ctx context.Context, entryPath string, count int64) error
+}
+
+// Progress is a user's progress through the chapters of an anthology.
+type Progress struct {
+	// Chapters is the recorded number of chapters in the anthology.
+	Chapters int64
+	// Read is the number of chapters the user has marked as read.
+	Read int64
 }
 
 // Users are the methods on a storage implementation that are responsible for
@@ -52,18 +77,195 @@ type Users interface {
 	// GetUserByName returns a single user with the specified name. An
 	// [ErrNotFound] is returned if the user name does not exist.
 	GetUserByName(ctx context.Context, name string) (db.User, error)
-	// UpsertUser creates or updates the user. This is a full PUT-style upsert.
-	// An [ErrAlreadyExists] error is returned if the username is already in use.
+	// UpsertUser creates or updates the user. This is a full PUT-style upsert,
+	// so changing the name of an existing user renames them. Users without a
+	// role are given [db.RoleMember]. An [ErrAlreadyExists] error is returned
+	// if the username is already in use.
+	// Like UpsertResource, an [ErrConflict] is returned if the user's version
+	// does not match the stored one, which is incremented on success.
 	UpsertUser(ctx context.Context, user db.User) error
-	// DeleteUser removes a user and all their associated resource data. Note
-	// that this is a hard delete; data is not recoverable.
+	// DeleteUser removes a user, their linked identities and all their
+	// associated resource data. Note that this is a hard delete; data is not
+	// recoverable.
 	DeleteUser(ctx context.Context, userID uint64) error
+	// GetUserActivity summarizes the user's resources and when they were last
+	// active.
+	GetUserActivity(ctx context.Context, userID uint64) (UserActivity, error)
+}
+
+// UserActivity summarizes a user's interactions with the archive.
+type UserActivity struct {
+	// Resources is the number of resources the user has interacted with, of
+	// which Read are marked as read, Starred are starred and Hidden are hidden.
+	Resources, Read, Starred, Hidden int64
+	// LastActiveTime is when the user last viewed or read a resource, used a
+	// session or used an access token. It is invalid if they never have.
+	LastActiveTime sql.NullTime
+}
+
+// Identities are the methods on a storage implementation that are responsible
+// for linking users to the identities they are known by to external
+// authorities, such as the subject of an OpenID Connect issuer. A user may have
+// several identities, but each identity is linked to a single user.
+type Identities interface {
+	// GetUserByIdentity returns the user linked to the subject of the issuer.
+	// An [ErrNotFound] is returned if the identity is not linked to a user.
+	GetUserByIdentity(ctx context.Context, issuer, subject string) (db.User, error)
+	// ProvisionUser creates the user, linked to the subject of the issuer, in
+	// a single transaction, returning the created user. As with UpsertUser, an
+	// [ErrAlreadyExists] error is returned if the username is in use, and
+	// likewise if the identity is already linked to a user.
+	ProvisionUser(ctx context.Context, user db.User, issuer, subject string) (db.User, error)
+	// LinkIdentity links the subject of the issuer to an existing user. An
+	// [ErrAlreadyExists] error is returned if the identity is already linked
+	// to a user.
+	LinkIdentity(ctx context.Context, userID uint64, issuer, subject string) error
+	// UnlinkIdentity removes the link between the user and the subject of the
+	// issuer. An [ErrNotFound] is returned if they are not linked.
+	UnlinkIdentity(ctx context.Context, userID uint64, issuer, subject string) error
+	// ListIdentities returns the identities linked to the user in order of
+	// creation.
+	ListIdentities(ctx context.Context, userID uint64) ([]db.Identity, error)
+}
+
+// Sessions are the methods on a storage implementation that are responsible
+// for persisting web login sessions. Sessions are identified by a hash of their
+// token so that the tokens themselves are never stored.
+type Sessions interface {
+	// CreateSession stores a new session.
+	CreateSession(ctx context.Context, session db.Session) error
+	// GetSession returns the session with the given token hash. An
+	// [ErrNotFound] is returned if the session does not exist.
+	GetSession(ctx context.Context, tokenHash []byte) (db.Session, error)
+	// TouchSession updates the last access time of the session.
+	TouchSession(ctx context.Context, tokenHash []byte, accessTime time.Time) error
+	// DeleteSession removes the session. No error is returned if the session
+	// does not exist.
+	DeleteSession(ctx context.Context, tokenHash []byte) error
+	// DeleteUserSessions removes all the user's sessions, except the one with
+	// exceptTokenHash if provided.
+	DeleteUserSessions(ctx context.Context, userID uint64, exceptTokenHash []byte) error
+	// DeleteStaleSessions removes all the user's sessions last accessed before
+	// accessBefore or created before createBefore.
+	DeleteStaleSessions(ctx context.Context, userID uint64, accessBefore, createBefore time.Time) error
+}
+
+// AccessTokens are the methods on a storage implementation that are
+// responsible for persisting personal access tokens. Like [Sessions], tokens
+// are identified by a hash so that the tokens themselves are never stored.
+type AccessTokens interface {
+	// CreateAccessToken stores a new access token, returning its ID.
+	CreateAccessToken(ctx context.Context, token db.AccessToken) (int64, error)
+	// GetAccessTokenByHash returns the access token with the given token hash.
+	// An [ErrNotFound] is returned if the token does not exist.
+	GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (db.AccessToken, error)
+	// ListAccessTokens returns all the user's access tokens in order of
+	// creation.
+	ListAccessTokens(ctx context.Context, userID uint64) ([]db.AccessToken, error)
+	// TouchAccessToken updates the last used time of the access token.
+	TouchAccessToken(ctx context.Context, tokenID int64, lastUsedTime time.Time) error
+	// DeleteAccessToken removes the user's access token. An [ErrNotFound] is
+	// returned if the user does not have a token with the given ID.
+	DeleteAccessToken(ctx context.Context, userID uint64, tokenID int64) error
+}
+
+// SavedViews are the methods on a storage implementation that are responsible
+// for persisting users' saved views, which are named filters and orders of
+// entries.
+type SavedViews interface {
+	// CreateSavedView stores a new saved view at version one, returning its
+	// ID.
+	CreateSavedView(ctx context.Context, view db.SavedView) (int64, error)
+	// GetSavedView returns the user's saved view with the given ID. An
+	// [ErrNotFound] is returned if the user does not have a view with the ID.
+	GetSavedView(ctx context.Context, userID uint64, viewID int64) (db.SavedView, error)
+	// ListSavedViews returns all the user's saved views in order of creation.
+	ListSavedViews(ctx context.Context, userID uint64) ([]db.SavedView, error)
+	// UpdateSavedView updates the user's saved view. Like UpsertResource, the
+	// view's version must be the one last read, otherwise an [ErrConflict] is
+	// returned, and the stored version is incremented on success. An
+	// [ErrNotFound] is returned if the view does not exist.
+	UpdateSavedView(ctx context.Context, view db.SavedView) error
+	// DeleteSavedView removes the user's saved view. An [ErrNotFound] is
+	// returned if the user does not have a view with the given ID.
+	DeleteSavedView(ctx context.Context, userID uint64, viewID int64) error
+}
+
+// AuditEvents are the methods on a storage implementation that are
+// responsible for persisting the audit log. Events are append-only; they cannot
+// be modified or removed once created.
+type AuditEvents interface {
+	// CreateAuditEvent appends an event to the audit log. The event's ID is
+	// assigned by the store.
+	CreateAuditEvent(ctx context.Context, event db.AuditEvent) error
+	// ListAuditEvents returns up to limit events matching filter with an ID
+	// less than beforeID, newest first.
+	ListAuditEvents(ctx context.Context, filter AuditEventFilter, beforeID int64, limit int32) ([]AuditEvent, error)
+	// ListAuditEventsAfter returns up to limit events with an ID greater than
+	// afterID, oldest first.
+	ListAuditEventsAfter(ctx context.Context, afterID int64, limit int32) ([]AuditEvent, error)
+	// CountAuditEvents returns the number of events in the audit log.
+	CountAuditEvents(ctx context.Context) (int64, error)
+}
+
+// AuditEvent is an event in the audit log, along with the current names of
+// its actor and subject, which follow them if they are renamed. The names are
+// empty if the event has no actor or subject, or they have been deleted.
+type AuditEvent struct {
+	db.AuditEvent
+
+	ActorName   string
+	SubjectName string
+}
+
+// AuditEventFilter restricts the events listed by ListAuditEvents to those with
+// the given action and outcome. Zero values match any.
+type AuditEventFilter struct {
+	Action  int64
+	Outcome int64
+}
+
+// Invites are the methods on a storage implementation that are responsible for
+// persisting invites, which allow new users to register themselves. Like
+// [AccessTokens], invites are identified by a hash of their code.
+type Invites interface {
+	// CreateInvite stores a new invite, returning its ID.
+	CreateInvite(ctx context.Context, invite db.Invite) (int64, error)
+	// CheckInvite returns an [ErrNotFound] if the invite with the given code
+	// hash does not exist, has expired by now or was already redeemed. A
+	// checked invite may still fail to redeem if it is redeemed concurrently.
+	CheckInvite(ctx context.Context, codeHash []byte, now time.Time) error
+	// RedeemInvite creates the user with the invite with the given code hash,
+	// which must not have expired by now. Invites may only be redeemed once,
+	// so an [ErrNotFound] is returned if the invite does not exist, has
+	// expired or was already redeemed. If the user cannot be created, as with
+	// UpsertUser, the invite is not redeemed.
+	RedeemInvite(ctx context.Context, codeHash []byte, now time.Time, user db.User) error
+}
+
+// Secrets are the methods on a storage implementation that are responsible
+// for persisting the server's own secrets, such as keys generated on first
+// use.
+type Secrets interface {
+	// GetOrCreateSecret returns the value of the secret with the given name,
+	// first storing value as the secret if there is none. Concurrent calls
+	// return the same value.
+	GetOrCreateSecret(ctx context.Context, name string, value []byte) ([]byte, error)
 }
 
-// Store is the combination interface for [Resources] and [Users].
+// Store is the combination interface for [Resources], [Users], [Identities],
+// [Sessions], [AccessTokens], [SavedViews], [AuditEvents], [Invites] and
+// [Secrets].
 type Store interface {
 	Resources
 	Users
+	Identities
+	Sessions
+	AccessTokens
+	SavedViews
+	AuditEvents
+	Invites
+	Secrets
 	// Close releases any resources held by the store. An error is returned if
 	// the store cannot be cleanly closed.
 	Close() error
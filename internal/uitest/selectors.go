@@ -34,6 +34,15 @@ var (
 
 	// SelectorBreadcrumbs selects the breadcrumbs container by class.
 	SelectorBreadcrumbs = "." + component.ClassBreadcrumbs
+
+	// SelectorChapterNav selects the previous and next chapter links by class.
+	SelectorChapterNav = "nav." + component.ClassChapterNav
+
+	// SelectorBatchActions selects the batch actions form by ID.
+	SelectorBatchActions = "#" + component.IDBatchActions
+
+	// SelectorSelectBox selects a list item's batch selection checkbox.
+	SelectorSelectBox = fmt.Sprintf("input[type='checkbox'][name='%s']", component.FormFieldSlug)
 )
 
 // List item selectors by kind.
@@ -91,6 +100,17 @@ func HiddenItemByKind(kind string) string {
 		component.DataAttrKind, kind, component.DataAttrHidden)
 }
 
+// BatchActionButton returns a selector for a batch action button. An empty
+// scope selects the button acting on the selected items.
+func BatchActionButton(action, scope string) string {
+	if scope == "" {
+		return fmt.Sprintf("%s button[%s='%s']:not([data-scope])",
+			SelectorBatchActions, component.DataAttrAction, action)
+	}
+	return fmt.Sprintf("%s button[%s='%s'][data-scope='%s']",
+		SelectorBatchActions, component.DataAttrAction, action, scope)
+}
+
 // ContentActionButton returns a selector for an action button in the content header.
 func ContentActionButton(action string) string {
 	return fmt.Sprintf("main > header > nav > button[%s='%s']", component.DataAttrAction, action)
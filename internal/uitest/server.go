@@ -12,11 +12,13 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/stolasapp/erato/internal/app"
 	"github.com/stolasapp/erato/internal/app/devservice"
 	"github.com/stolasapp/erato/internal/archive"
 	eratov1 "github.com/stolasapp/erato/internal/gen/stolasapp/erato/v1"
+	"github.com/stolasapp/erato/internal/sec"
 	"github.com/stolasapp/erato/internal/server"
 	"github.com/stolasapp/erato/internal/slugconv"
 	"github.com/stolasapp/erato/internal/storage"
@@ -32,6 +34,7 @@ type Server struct {
 	cancel  context.CancelFunc
 	grp     *errgroup.Group
 	store   storage.Store
+	user    db.User
 }
 
 // newTestServer creates and starts a new test server for use in TestMain.
@@ -60,15 +63,29 @@ func newTestServer() *Server {
 	cfg.SetRootUri("http://" + devAddr + "/")
 
 	// Create archive handler
-	rpcHandler, err := archive.Default(cfg, logger, store)
+	rpcHandler, err := archive.Default(ctx, cfg, logger, store, nil, nil)
 	if err != nil {
 		cancel()
 		_ = store.Close()
 		panic(fmt.Sprintf("failed to create archive handler: %v", err))
 	}
 
+	// Seed the dev user every request is authenticated as
+	dev, err := sec.NewDevAuth(ctx, cfg, store)
+	if err != nil {
+		cancel()
+		_ = store.Close()
+		panic(fmt.Sprintf("failed to seed dev user: %v", err))
+	}
+	user, err := store.GetUserByName(ctx, sec.DefaultDevUser)
+	if err != nil {
+		cancel()
+		_ = store.Close()
+		panic(fmt.Sprintf("failed to get dev user: %v", err))
+	}
+
 	// Create and start app server
-	appServer := app.New(cfg, logger, store, rpcHandler)
+	appServer := app.New(cfg, logger, store, nil, nil, nil, nil, dev, rpcHandler)
 	appAddr, err := startAppServer(ctx, grp, appServer)
 	if err != nil {
 		cancel()
@@ -81,6 +98,7 @@ func newTestServer() *Server {
 		cancel:  cancel,
 		grp:     grp,
 		store:   store,
+		user:    user,
 	}
 }
 
@@ -100,9 +118,10 @@ func (s *Server) Close() {
 
 func testConfig() *eratov1.Config {
 	return eratov1.Config_builder{
-		LogLevel:   eratov1.Config_DEBUG,
-		DbFilepath: ":memory:",
-		DevMode:    true,
+		LogLevel:     eratov1.Config_DEBUG,
+		DbFilepath:   ":memory:",
+		DevMode:      true,
+		PageTokenTtl: durationpb.New(time.Hour),
 	}.Build()
 }
 
@@ -148,7 +167,7 @@ func (s *Server) SetViewTime(ctx context.Context, slug string, viewTime time.Tim
 		return err
 	}
 	return s.store.UpsertResource(ctx, db.Resource{
-		User: 0, // Dev mode skips auth, so user ID is 0
+		User: s.user.ID,
 		Path: path,
 		ViewTime: sql.NullTime{
 			Time:  viewTime,
@@ -2,6 +2,7 @@ package uitest
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stolasapp/erato/internal/app/component"
+	"github.com/stolasapp/erato/internal/slugconv"
 )
 
 const (
@@ -171,6 +173,9 @@ func TestUI(t *testing.T) {
 	t.Run("ContentPageToggle", func(t *testing.T) {
 		testContentPageToggle(t, newPage)
 	})
+	t.Run("BatchActions", func(t *testing.T) {
+		testBatchActions(t, newPage)
+	})
 }
 
 // navigateToCategory clicks the first visible category and waits for navigation.
@@ -310,10 +315,23 @@ func testAnthologyPage(t *testing.T, newPage func(*testing.T) *testPage) {
 		t.Fatal("no anthologies found")
 	}
 
-	// Verify chapter list loads with no filter bar
+	// Verify chapter list loads with only the chapter filters
 	assert.NotEmpty(t, p.els(SelectorListItem), "anthology should have chapters")
-	assert.NotEmpty(t, p.els(SelectorChapterItem), "chapters should have chapter data-kind")
-	assert.Empty(t, p.els(SelectorFilters), "anthology page should not have filter bar")
+	chapters := p.els(SelectorChapterItem)
+	assert.NotEmpty(t, chapters, "chapters should have chapter data-kind")
+	assert.NotEmpty(t, p.els(SelectorFilters), "anthology page should have filter bar")
+	assert.NotNil(t, findFilterButton(p, "Unread"), "expected Unread filter")
+	assert.Nil(t, findFilterButton(p, "Hidden"), "chapters cannot be hidden")
+
+	// Verify numbered chapters are in reading order by default, wherever the
+	// unnumbered chapters fall between them
+	var numbers []int32
+	for _, chapter := range chapters {
+		if number := slugconv.ToChapterNumber(*chapter.MustAttribute("id")); number > 0 {
+			numbers = append(numbers, number)
+		}
+	}
+	assert.True(t, slices.IsSorted(numbers), "chapters should be in reading order")
 }
 
 // testStoryPage tests navigating to a story and viewing content.
@@ -689,6 +707,16 @@ func testChapterPage(t *testing.T, newPage func(*testing.T) *testPage) {
 	// Should have at least 3 links: Archive > Category > Anthology
 	breadcrumbLinks := p.els(SelectorBreadcrumbs + " a")
 	assert.GreaterOrEqual(t, len(breadcrumbLinks), 3, "chapter should have at least 3 breadcrumb links")
+
+	// The first chapter in reading order only links to the next
+	assert.Empty(t, p.els(SelectorChapterNav+" a[rel='prev']"), "first chapter should have no previous chapter")
+	next := p.els(SelectorChapterNav + " a[rel='next']")
+	if len(next) == 0 {
+		return // single chapter anthology
+	}
+	next[0].MustClick()
+	p.waitStable()
+	assert.NotEmpty(t, p.els(SelectorChapterNav+" a[rel='prev']"), "next chapter should link back")
 }
 
 // testFilterCombination tests that multiple filters work together correctly.
@@ -1065,3 +1093,47 @@ func testContentPageToggle(t *testing.T, newPage func(*testing.T) *testPage) {
 		})
 	}
 }
+
+// testBatchActions tests marking selected entries and all of an anthology's
+// chapters as read via the batch actions form.
+func testBatchActions(t *testing.T, newPage func(*testing.T) *testPage) {
+	t.Run("SelectedEntries", func(t *testing.T) {
+		p := newPage(t)
+		navigateToCategory(p)
+
+		items := p.els(VisibleItemByKind(component.KindStory) + ":not([" + component.DataAttrRead + "])")
+		if len(items) < 2 {
+			t.Skip("not enough unread stories")
+		}
+
+		ids := make([]string, 0, 2)
+		for _, item := range items[:2] {
+			id := item.MustAttribute("id")
+			require.NotNil(t, id)
+			ids = append(ids, *id)
+			item.Timeout(defaultTimeout).MustElement(SelectorSelectBox).MustClick()
+		}
+
+		p.clickAndWaitIdle(p.el(BatchActionButton(string(component.ActionRead), "")))
+
+		for _, id := range ids {
+			item := p.el(fmt.Sprintf("article[id='%s']", id))
+			assert.NotNil(t, item.MustAttribute(component.DataAttrRead), "selected entry %s should be read", id)
+		}
+	})
+
+	t.Run("AllChapters", func(t *testing.T) {
+		p := newPage(t)
+		if !navigateToAnthology(p) {
+			t.Skip("no anthologies found")
+		}
+
+		p.clickAndWaitIdle(p.el(BatchActionButton(string(component.ActionRead), component.BatchScopeAll)))
+
+		chapters := p.els(SelectorChapterItem)
+		require.NotEmpty(t, chapters)
+		for _, chapter := range chapters {
+			assert.NotNil(t, chapter.MustAttribute(component.DataAttrRead), "all chapters should be read")
+		}
+	})
+}
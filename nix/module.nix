@@ -78,7 +78,7 @@ in
             dev_mode = lib.mkOption {
               type = lib.types.bool;
               default = false;
-              description = "Enable development mode with fake upstream service.";
+              description = "Enable development mode with fake upstream service and seeded users instead of logins.";
             };
           };
         }